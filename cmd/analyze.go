@@ -7,6 +7,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/generator"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +15,9 @@ import (
 var (
 	analyzeTarget string
 	analyzeCI     bool
+	analyzeStdin  bool
+
+	analyzeOnlyChanged bool
 )
 
 var analyzeCmd = &cobra.Command{
@@ -31,63 +35,95 @@ Supported targets:
   traefik              Traefik v3.x (lowest migration friction)
   gateway-api          Kubernetes Gateway API via Envoy Gateway
   gateway-api-traefik  Kubernetes Gateway API with Traefik as the provider
+  all                  traefik and gateway-api, one analysis after the other
 
 CI mode (--ci):
   Exits with code 1 if any ingress has unsupported annotations (breaking).
   Exits with code 2 if any ingress needs workarounds (partial).
   Exits with code 0 if all ingresses are fully compatible.
-  Useful for CI/CD pipelines to gate deployments on migration readiness.`,
+  Useful for CI/CD pipelines to gate deployments on migration readiness.
+
+Stdin mode (--stdin):
+  Reads a multi-document YAML stream instead of scanning the cluster, so
+  charts can be checked before they are installed:
+    helm template my-release ./chart | ing-switch analyze --stdin --target traefik
+  Non-Ingress documents are skipped. Ingresses without a namespace use
+  --namespace (or "default").
+
+Only changed (--only-changed):
+  Lists only partial and unsupported annotations per ingress, hiding the
+  ones that carry over unchanged. Summary counts still include every annotation.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runAnalyze(cmd)
 	},
 }
 
 func init() {
-	analyzeCmd.Flags().StringVar(&analyzeTarget, "target", "", "Target controller: traefik|gateway-api|gateway-api-traefik (required)")
+	analyzeCmd.Flags().StringVar(&analyzeTarget, "target", "", "Target controller: traefik|gateway-api|gateway-api-traefik|all (required)")
 	analyzeCmd.MarkFlagRequired("target")
 	analyzeCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table|json")
 	analyzeCmd.Flags().BoolVar(&analyzeCI, "ci", false, "CI mode: exit 1 on unsupported, exit 2 on partial annotations")
+	analyzeCmd.Flags().BoolVar(&analyzeStdin, "stdin", false, "Read Ingress manifests (e.g. helm template output) from stdin instead of the cluster")
+	analyzeCmd.Flags().BoolVar(&analyzeOnlyChanged, "only-changed", false, "List only partial and unsupported annotations per ingress")
 	rootCmd.AddCommand(analyzeCmd)
 }
 
 func runAnalyze(_ *cobra.Command) error {
 	switch analyzeTarget {
-	case "traefik", "gateway-api", "gateway-api-traefik":
+	case "traefik", "gateway-api", "gateway-api-traefik", generator.TargetAll:
 	default:
-		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", analyzeTarget)
+		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', 'gateway-api-traefik', or 'all'", analyzeTarget)
 	}
 
-	s, err := scanner.NewScanner(kubeconfig, kubecontext)
+	scanResult, err := loadAnalyzeInput()
 	if err != nil {
-		return fmt.Errorf("connecting to cluster: %w", err)
+		return err
 	}
 
-	scanResult, err := s.Scan(namespace)
-	if err != nil {
-		return fmt.Errorf("scanning cluster: %w", err)
+	targets := []string{analyzeTarget}
+	if analyzeTarget == generator.TargetAll {
+		targets = generator.AllTargets
+	}
+	var reports []*analyzer.AnalysisReport
+	for _, t := range targets {
+		report := analyzer.NewAnalyzer(t).Analyze(scanResult)
+		if analyzeOnlyChanged {
+			report = report.OnlyChanged()
+		}
+		reports = append(reports, report)
 	}
-
-	a := analyzer.NewAnalyzer(analyzeTarget)
-	report := a.Analyze(scanResult)
 
 	switch outputFormat {
 	case "json":
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
-		if err := enc.Encode(report); err != nil {
+		// --target all prints an array with one report per target
+		var v any = reports
+		if len(reports) == 1 {
+			v = reports[0]
+		}
+		if err := enc.Encode(v); err != nil {
 			return err
 		}
 	default:
-		printAnalysisReport(report)
+		for _, report := range reports {
+			printAnalysisReport(report)
+		}
 	}
 
 	if analyzeCI {
-		if report.Summary.HasUnsupported > 0 {
-			fmt.Fprintf(os.Stderr, "\nCI: %d ingress(es) have unsupported annotations — exiting with code 1\n", report.Summary.HasUnsupported)
+		// With --target all, the worst target decides the exit code
+		unsupported, workaround := 0, 0
+		for _, report := range reports {
+			unsupported = max(unsupported, report.Summary.HasUnsupported)
+			workaround = max(workaround, report.Summary.NeedsWorkaround)
+		}
+		if unsupported > 0 {
+			fmt.Fprintf(os.Stderr, "\nCI: %d ingress(es) have unsupported annotations — exiting with code 1\n", unsupported)
 			os.Exit(1)
 		}
-		if report.Summary.NeedsWorkaround > 0 {
-			fmt.Fprintf(os.Stderr, "\nCI: %d ingress(es) need workarounds — exiting with code 2\n", report.Summary.NeedsWorkaround)
+		if workaround > 0 {
+			fmt.Fprintf(os.Stderr, "\nCI: %d ingress(es) need workarounds — exiting with code 2\n", workaround)
 			os.Exit(2)
 		}
 	}
@@ -95,10 +131,32 @@ func runAnalyze(_ *cobra.Command) error {
 	return nil
 }
 
+// loadAnalyzeInput returns the ingresses to analyze, either from stdin
+// (--stdin) or from a live cluster scan.
+func loadAnalyzeInput() (*scanner.ScanResult, error) {
+	if analyzeStdin {
+		scanResult, err := scanner.ScanManifests(os.Stdin, namespace, annotationSource)
+		if err != nil {
+			return nil, fmt.Errorf("reading manifests from stdin: %w", err)
+		}
+		return scanResult, nil
+	}
+
+	s, err := newScanner()
+	if err != nil {
+		return nil, fmt.Errorf("connecting to cluster: %w", err)
+	}
+
+	scanResult, err := scanCluster(s)
+	if err != nil {
+		return nil, fmt.Errorf("scanning cluster: %w", err)
+	}
+	return scanResult, nil
+}
+
 func printAnalysisReport(report *analyzer.AnalysisReport) {
-	fmt.Printf("\n  ing-switch — Compatibility Analysis\n")
-	fmt.Printf("  Target: %s\n", report.Target)
-	fmt.Println()
+	bannerf("\n  ing-switch — Compatibility Analysis\n")
+	bannerf("  Target: %s\n\n", report.Target)
 
 	fmt.Printf("  Summary\n")
 	fmt.Printf("  -------\n")
@@ -106,20 +164,50 @@ func printAnalysisReport(report *analyzer.AnalysisReport) {
 	fmt.Printf("  Fully compatible:     %d\n", report.Summary.FullyCompatible)
 	fmt.Printf("  Needs workarounds:    %d\n", report.Summary.NeedsWorkaround)
 	fmt.Printf("  Has unsupported:      %d\n", report.Summary.HasUnsupported)
+	if report.Summary.MigrationInProgress > 0 || report.Summary.AlreadyMigrated > 0 {
+		fmt.Printf("  Migration in progress: %d\n", report.Summary.MigrationInProgress)
+		fmt.Printf("  Already migrated:     %d\n", report.Summary.AlreadyMigrated)
+	}
+	fmt.Println()
+
+	stats := report.Summary.AnnotationStats
+	fmt.Printf("  Annotations\n")
+	fmt.Printf("  -----------\n")
+	fmt.Printf("  Total seen:           %d\n", stats.Total)
+	fmt.Printf("  Supported:            %d\n", stats.Supported)
+	fmt.Printf("  Partial:              %d\n", stats.Partial)
+	fmt.Printf("  Unsupported:          %d\n", stats.Unsupported)
+	if len(stats.TopUnsupported) > 0 {
+		fmt.Printf("\n  Most common unsupported (ingresses affected):\n")
+		for _, ac := range stats.TopUnsupported {
+			fmt.Printf("    %-40s %d\n", ac.Key, ac.Count)
+		}
+	}
 	fmt.Println()
 
 	for _, ir := range report.IngressReports {
-		fmt.Printf("  %s/%s\n", ir.Namespace, ir.Name)
+		fmt.Printf("  %s/%s", ir.Namespace, ir.Name)
+		if ir.MigrationState != "" && ir.MigrationState != analyzer.MigrationNotStarted {
+			fmt.Printf("  (migration %s)", ir.MigrationState)
+		}
+		fmt.Println()
 		fmt.Printf("  %s\n", repeatChar("-", len(ir.Namespace)+len(ir.Name)+1))
+		for _, warn := range ir.Warnings {
+			fmt.Printf("  ⚠ %s\n", warn)
+		}
 
 		if len(ir.Mappings) == 0 {
-			fmt.Printf("  No nginx annotations — ready to migrate as-is\n\n")
+			if analyzeOnlyChanged {
+				fmt.Printf("  No partial or unsupported annotations — ready to migrate as-is\n\n")
+			} else {
+				fmt.Printf("  No nginx annotations — ready to migrate as-is\n\n")
+			}
 			continue
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		fmt.Fprintf(w, "  ANNOTATION\tSTATUS\tTARGET RESOURCE\tNOTES\n")
-		fmt.Fprintf(w, "  ----------\t------\t---------------\t-----\n")
+		fmt.Fprintf(w, "  ANNOTATION\t%s\tTARGET RESOURCE\tNOTES\n", statusToIcon("STATUS"))
+		fmt.Fprintf(w, "  ----------\t%s\t---------------\t-----\n", statusToIcon("------"))
 		for _, m := range ir.Mappings {
 			statusIcon := statusToIcon(string(m.Status))
 			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", m.OriginalKey, statusIcon, m.TargetResource, m.Note)
@@ -128,19 +216,21 @@ func printAnalysisReport(report *analyzer.AnalysisReport) {
 		fmt.Println()
 	}
 
-	fmt.Printf("  Run 'ing-switch migrate --target %s' to generate migration files\n\n", report.Target)
+	printStreamServices(report.StreamServices)
+
+	bannerf("  Run 'ing-switch migrate --target %s' to generate migration files\n\n", report.Target)
 }
 
 func statusToIcon(s string) string {
 	switch s {
 	case "supported":
-		return "[supported]"
+		return colorGreen("[supported]")
 	case "partial":
-		return "[partial]  "
+		return colorYellow("[partial]  ")
 	case "unsupported":
-		return "[UNSUPPORTED]"
+		return colorRed("[UNSUPPORTED]")
 	default:
-		return s
+		return colorPlain(s)
 	}
 }
 
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	annotateTarget string
+	annotateDryRun bool
+)
+
+var annotateStatusCmd = &cobra.Command{
+	Use:   "annotate-status",
+	Short: "Write migration readiness onto each Ingress as an annotation",
+	Long: `Analyzes every Ingress for the target controller and writes the result
+onto the Ingress itself:
+
+  ing-switch.io/migration-status: ready | workaround | breaking
+
+Dashboards and 'kubectl describe ingress' can then show readiness without
+running ing-switch. Only networking.k8s.io Ingress objects are annotated;
+Traefik IngressRoutes and Istio VirtualServices are skipped.
+
+Use --dry-run to validate the patches server-side without persisting them.
+
+Examples:
+  ing-switch annotate-status --target traefik --dry-run
+  ing-switch annotate-status --target gateway-api -n production`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAnnotateStatus()
+	},
+}
+
+func init() {
+	annotateStatusCmd.Flags().StringVar(&annotateTarget, "target", "", "Target controller: traefik|gateway-api|gateway-api-traefik (required)")
+	annotateStatusCmd.MarkFlagRequired("target")
+	annotateStatusCmd.Flags().BoolVar(&annotateDryRun, "dry-run", false, "Validate patches with server-side dry-run without persisting")
+	rootCmd.AddCommand(annotateStatusCmd)
+}
+
+func runAnnotateStatus() error {
+	switch annotateTarget {
+	case "traefik", "gateway-api", "gateway-api-traefik":
+	default:
+		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", annotateTarget)
+	}
+
+	mode := "APPLY"
+	if annotateDryRun {
+		mode = "DRY-RUN"
+	}
+	fmt.Printf("\n  ing-switch annotate-status [%s]\n", mode)
+	fmt.Printf("  Target: %s\n\n", annotateTarget)
+
+	s, err := newScanner()
+	if err != nil {
+		return fmt.Errorf("connecting to cluster: %w", err)
+	}
+
+	scanResult, err := s.Scan(namespace)
+	if err != nil {
+		return fmt.Errorf("scanning cluster: %w", err)
+	}
+
+	report := analyzer.NewAnalyzer(annotateTarget).Analyze(scanResult)
+
+	sources := make(map[string]scanner.IngressInfo, len(scanResult.Ingresses))
+	for _, ing := range scanResult.Ingresses {
+		sources[ing.Namespace+"/"+ing.Name] = ing
+	}
+
+	var annotated, skipped, failed int
+	for _, ir := range report.IngressReports {
+		key := ir.Namespace + "/" + ir.Name
+		if ing, ok := sources[key]; !ok || !ing.IsIngressResource() {
+			skipped++
+			continue
+		}
+		slog.Info("annotating ingress", "ingress", key, "status", ir.OverallStatus, "dryRun", annotateDryRun)
+		if err := s.AnnotateIngress(ir.Namespace, ir.Name, scanner.MigrationStatusAnnotation, ir.OverallStatus, annotateDryRun); err != nil {
+			slog.Error("annotating ingress failed", "ingress", key, "error", err)
+			fmt.Printf("  ✗ %-50s %v\n", key, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  ✓ %-50s %s\n", key, ir.OverallStatus)
+		annotated++
+	}
+
+	fmt.Printf("\n  Annotated: %d  Skipped (not an Ingress): %d  Failed: %d\n\n", annotated, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d ingress(es) could not be annotated", failed)
+	}
+	return nil
+}
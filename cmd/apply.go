@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -21,15 +22,6 @@ var (
 	applyDryRun   bool
 )
 
-// applyableCategories are file categories whose YAML can be kubectl-applied directly.
-var applyableCategories = map[string]bool{
-	"middleware": true,
-	"ingress":    true,
-	"gateway":    true,
-	"httproute":  true,
-	"policy":     true,
-}
-
 var applyCmd = &cobra.Command{
 	Use:   "apply",
 	Short: "Apply generated migration manifests to the cluster",
@@ -76,9 +68,9 @@ func runApply() error {
 		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", applyTarget)
 	}
 
-	if applyCategory != "" && !applyableCategories[applyCategory] {
+	if applyCategory != "" && !generator.ApplyableCategories[applyCategory] {
 		valid := []string{}
-		for k := range applyableCategories {
+		for k := range generator.ApplyableCategories {
 			valid = append(valid, k)
 		}
 		return fmt.Errorf("category %q is not kubectl-applyable — use one of: %s\nFor install/verify/cleanup, download the files via 'ing-switch migrate' and run manually",
@@ -99,7 +91,7 @@ func runApply() error {
 	fmt.Println()
 
 	// Scan
-	s, err := scanner.NewScanner(kubeconfig, kubecontext)
+	s, err := newScanner()
 	if err != nil {
 		return fmt.Errorf("connecting to cluster: %w", err)
 	}
@@ -146,7 +138,7 @@ func runApply() error {
 				yamlFiles = append(yamlFiles, f)
 			}
 		} else {
-			if applyableCategories[f.Category] {
+			if generator.ApplyableCategories[f.Category] {
 				yamlFiles = append(yamlFiles, f)
 			}
 		}
@@ -188,6 +180,7 @@ func runApply() error {
 	if kubecontext != "" {
 		args = append(args, "--context", kubecontext)
 	}
+	args = append(args, scanner.KubectlConfigArgs()...)
 	args = append(args, "apply", "-f", tmpDir)
 	if applyDryRun {
 		args = append(args, "--dry-run=server")
@@ -203,9 +196,12 @@ func runApply() error {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
+	slog.Info("running kubectl apply", "target", applyTarget, "category", applyCategory, "dryRun", applyDryRun, "files", len(applied), "args", args)
 	if err := cmd.Run(); err != nil {
+		slog.Error("kubectl apply failed", "target", applyTarget, "category", applyCategory, "error", err)
 		return fmt.Errorf("kubectl apply failed: %w", err)
 	}
+	slog.Info("kubectl apply succeeded", "target", applyTarget, "category", applyCategory, "dryRun", applyDryRun)
 
 	fmt.Println()
 	if applyDryRun {
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+	"github.com/spf13/cobra"
+)
+
+var auditAnnotations string
+
+// defaultAuditAnnotations are the annotations that inject raw controller
+// config or code — the usual suspects in a security review.
+const defaultAuditAnnotations = "server-snippet,configuration-snippet,auth-snippet,modsecurity-snippet,stream-snippet,lua-resty-waf"
+
+// auditFinding is one audited annotation found on one resource.
+type auditFinding struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	SourceType string `json:"sourceType"`
+	Annotation string `json:"annotation"` // full annotation key as set on the resource
+	Value      string `json:"value"`
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "List every ingress that uses any of the named annotations",
+	Long: `Reports every scanned resource that sets one of the named annotations,
+together with the annotation value. Use it for a compliance sweep of risky
+annotations (raw NGINX snippets, Lua, ModSecurity rules) before a migration.
+
+A name matches the bare key after the vendor prefix, so 'server-snippet'
+matches nginx.ingress.kubernetes.io/server-snippet. A full key matches only
+itself. With no --annotations, the snippet/Lua/ModSecurity family is audited.
+
+Examples:
+  ing-switch audit
+  ing-switch audit --annotations server-snippet,configuration-snippet,modsecurity-snippet
+  ing-switch audit --annotations nginx.ingress.kubernetes.io/auth-snippet -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAudit()
+	},
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditAnnotations, "annotations", defaultAuditAnnotations, "Comma-separated annotation names or full keys to audit")
+	auditCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table|json")
+	rootCmd.AddCommand(auditCmd)
+}
+
+func runAudit() error {
+	names := scanner.SplitList(auditAnnotations)
+	if len(names) == 0 {
+		return fmt.Errorf("--annotations must name at least one annotation")
+	}
+
+	s, err := newScanner()
+	if err != nil {
+		return fmt.Errorf("connecting to cluster: %w", err)
+	}
+
+	result, err := s.Scan(namespace)
+	if err != nil {
+		return fmt.Errorf("scanning cluster: %w", err)
+	}
+
+	findings := auditIngresses(result.Ingresses, names)
+
+	switch outputFormat {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(findings)
+	default:
+		printAuditFindings(findings, names, len(result.Ingresses))
+	}
+	return nil
+}
+
+// auditIngresses returns one finding per (resource, matching annotation),
+// sorted by namespace, name, then annotation key.
+func auditIngresses(ingresses []scanner.IngressInfo, names []string) []auditFinding {
+	findings := []auditFinding{}
+	for _, ing := range ingresses {
+		for key, value := range ing.Annotations {
+			if !auditMatches(key, names) {
+				continue
+			}
+			findings = append(findings, auditFinding{
+				Namespace:  ing.Namespace,
+				Name:       ing.Name,
+				SourceType: string(ing.SourceType),
+				Annotation: key,
+				Value:      value,
+			})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Annotation < b.Annotation
+	})
+	return findings
+}
+
+// auditMatches reports whether key is one of the audited names, either as the
+// exact key or as the part after the vendor prefix.
+func auditMatches(key string, names []string) bool {
+	bare := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		bare = key[i+1:]
+	}
+	for _, n := range names {
+		if n == key || n == bare {
+			return true
+		}
+	}
+	return false
+}
+
+func printAuditFindings(findings []auditFinding, names []string, scanned int) {
+	fmt.Printf("\n  ing-switch — Annotation Audit\n")
+	fmt.Printf("  Audited:  %s\n", strings.Join(names, ", "))
+	fmt.Printf("  Scanned:  %d resource(s)\n\n", scanned)
+
+	if len(findings) == 0 {
+		fmt.Printf("  No resources use the audited annotations.\n\n")
+		return
+	}
+
+	resources := make(map[string]bool)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  NAMESPACE\tNAME\tANNOTATION\tVALUE\n")
+	fmt.Fprintf(w, "  ---------\t----\t----------\t-----\n")
+	for _, f := range findings {
+		resources[f.Namespace+"/"+f.Name] = true
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", f.Namespace, f.Name, f.Annotation, auditValuePreview(f.Value))
+	}
+	w.Flush()
+	fmt.Printf("\n  %d finding(s) across %d resource(s). Use -o json for full values.\n\n", len(findings), len(resources))
+}
+
+// auditValuePreview collapses multi-line snippets onto one line and truncates
+// them, on a rune boundary, so the table stays readable.
+func auditValuePreview(v string) string {
+	v = strings.Join(strings.Fields(v), " ")
+	if r := []rune(v); len(r) > 60 {
+		v = string(r[:57]) + "..."
+	}
+	return v
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	catalogTarget string
+	catalogVerify bool
+)
+
+// catalogEntry joins KnownAnnotations, the target mapping table, and the fix
+// guide for a single annotation key.
+type catalogEntry struct {
+	Key            string                 `json:"key"`
+	Category       string                 `json:"category,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	Known          bool                   `json:"known"`  // listed in KnownAnnotations
+	Mapped         bool                   `json:"mapped"` // has an explicit mapping for the target
+	Status         analyzer.MappingStatus `json:"status"`
+	TargetResource string                 `json:"targetResource,omitempty"`
+	Note           string                 `json:"note,omitempty"`
+	What           string                 `json:"what,omitempty"`
+	Fix            string                 `json:"fix,omitempty"`
+	Example        string                 `json:"example,omitempty"`
+	DocsLink       string                 `json:"docsLink,omitempty"`
+	Consequence    string                 `json:"consequence,omitempty"`
+	IssueUrl       string                 `json:"issueUrl,omitempty"`
+}
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Print every annotation ing-switch knows how to translate for a target",
+	Long: `Dumps the full annotation mapping catalog for a target controller: every
+known nginx.ingress.kubernetes.io/* annotation joined with its mapping status,
+target resource, note, and fix guide (what / fix / example / docs link).
+
+No cluster access is required — this is the tool's built-in knowledge.
+
+Examples:
+  # Human-readable table
+  ing-switch catalog --target traefik
+
+  # Machine-readable catalog for downstream tooling
+  ing-switch catalog --target gateway-api -o json
+
+  # Check KnownAnnotations and the mapping tables are in sync (exits 1 on drift)
+  ing-switch catalog --verify`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCatalog()
+	},
+}
+
+func init() {
+	catalogCmd.Flags().StringVar(&catalogTarget, "target", "", "Target controller: traefik|gateway-api|gateway-api-traefik (required unless --verify)")
+	catalogCmd.Flags().BoolVar(&catalogVerify, "verify", false, "Report annotations missing from KnownAnnotations or a target mapping table; exit 1 on drift")
+	catalogCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table|json")
+	rootCmd.AddCommand(catalogCmd)
+}
+
+func runCatalog() error {
+	if catalogVerify {
+		return runCatalogVerify()
+	}
+	if catalogTarget == "" {
+		return fmt.Errorf("--target is required (traefik, gateway-api, or gateway-api-traefik)")
+	}
+
+	switch catalogTarget {
+	case "traefik", "gateway-api", "gateway-api-traefik":
+	default:
+		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", catalogTarget)
+	}
+
+	entries := buildCatalog(catalogTarget)
+
+	switch outputFormat {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	default:
+		printCatalog(entries)
+	}
+	return nil
+}
+
+// buildCatalog returns one entry per annotation key found in KnownAnnotations,
+// the target mapping table, or the target guides, sorted by key.
+func buildCatalog(target string) []catalogEntry {
+	keySet := make(map[string]bool)
+	for _, def := range analyzer.KnownAnnotations {
+		keySet[def.Key] = true
+	}
+	for _, k := range analyzer.MappedAnnotations(target) {
+		keySet[k] = true
+	}
+	for _, k := range analyzer.GuidedAnnotations(target) {
+		keySet[k] = true
+	}
+
+	keys := make([]string, 0, len(keySet))
+	for k := range keySet {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]catalogEntry, 0, len(keys))
+	for _, k := range keys {
+		def, known := analyzer.AnnotationsByKey[k]
+		m := analyzer.MapAnnotation(k, "", target)
+		guide := analyzer.GetAnnotationGuide(target, k)
+		entries = append(entries, catalogEntry{
+			Key:            k,
+			Category:       def.Category,
+			Description:    def.Description,
+			Known:          known,
+			Mapped:         analyzer.HasMapping(k, target),
+			Status:         m.Status,
+			TargetResource: m.TargetResource,
+			Note:           m.Note,
+			What:           guide.What,
+			Fix:            guide.Fix,
+			Example:        guide.Example,
+			DocsLink:       guide.DocsLink,
+			Consequence:    guide.Consequence,
+			IssueUrl:       guide.IssueUrl,
+		})
+	}
+	return entries
+}
+
+func printCatalog(entries []catalogEntry) {
+	fmt.Printf("\n  ing-switch — Annotation Catalog\n")
+	fmt.Printf("  Target: %s\n\n", catalogTarget)
+
+	counts := map[analyzer.MappingStatus]int{}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  ANNOTATION\t%s\tTARGET RESOURCE\tGUIDE\n", statusToIcon("STATUS"))
+	fmt.Fprintf(w, "  ----------\t%s\t---------------\t-----\n", statusToIcon("------"))
+	for _, e := range entries {
+		counts[e.Status]++
+		guide := "-"
+		if e.Fix != "" || e.Example != "" {
+			guide = "yes"
+		}
+		target := e.TargetResource
+		if target == "" {
+			target = "-"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", e.Key, statusToIcon(string(e.Status)), target, guide)
+	}
+	w.Flush()
+
+	fmt.Printf("\n  %d annotations: %d supported, %d partial, %d unsupported\n",
+		len(entries), counts[analyzer.StatusSupported], counts[analyzer.StatusPartial], counts[analyzer.StatusUnsupported])
+	fmt.Printf("  Use -o json for notes, fix guides, and examples\n\n")
+}
+
+// runCatalogVerify checks the catalog for drift. With no --target it checks
+// every target mapping table.
+func runCatalogVerify() error {
+	targets := []string{"traefik", "gateway-api"}
+	if catalogTarget != "" {
+		targets = []string{catalogTarget}
+	}
+
+	var drifts []analyzer.CatalogDrift
+	drifted := false
+	for _, t := range targets {
+		d := analyzer.CheckCatalog(t)
+		drifts = append(drifts, d)
+		if d.HasDrift() {
+			drifted = true
+		}
+	}
+
+	if outputFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(drifts); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\n  ing-switch — Catalog Consistency Check\n\n")
+		for _, d := range drifts {
+			if !d.HasDrift() {
+				fmt.Printf("  [OK]   %s: every known annotation is mapped\n", d.Target)
+				continue
+			}
+			fmt.Printf("  [FAIL] %s\n", d.Target)
+			for _, k := range d.Unmapped {
+				fmt.Printf("         unmapped: %s (in KnownAnnotations, no %s mapping)\n", k, d.Target)
+			}
+			for _, k := range d.Unknown {
+				fmt.Printf("         unknown:  %s (mapped for %s, missing from KnownAnnotations)\n", k, d.Target)
+			}
+		}
+		fmt.Println()
+	}
+
+	if drifted {
+		return fmt.Errorf("annotation catalog drift detected")
+	}
+	return nil
+}
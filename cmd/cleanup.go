@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanupTarget      string
+	cleanupManagedOnly bool
+	cleanupDryRun      bool
+)
+
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Delete resources created by ing-switch from the cluster",
+	Long: `Deletes the resources a migration attempt created, selected by the
+app.kubernetes.io/managed-by=ing-switch label, using the Kubernetes API.
+
+Only generated resources are touched:
+  traefik              Middlewares
+  gateway-api          HTTPRoutes, Gateways, GatewayClasses, BackendTrafficPolicies, SecurityPolicies
+  gateway-api-traefik  HTTPRoutes, Gateways, GatewayClasses, Middlewares
+
+Hand-written Middlewares/HTTPRoutes and your Ingresses are never deleted
+(rewritten Ingresses carry only the source-ingress label). This does NOT
+uninstall NGINX — use the generated cleanup scripts for that.
+
+Examples:
+  # See what would be deleted
+  ing-switch cleanup --target traefik --managed-only --dry-run
+
+  # Roll back a Gateway API attempt in one namespace
+  ing-switch cleanup --target gateway-api --managed-only -n shop`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCleanup()
+	},
+}
+
+func init() {
+	cleanupCmd.Flags().StringVar(&cleanupTarget, "target", "", "Target controller: traefik|gateway-api|gateway-api-traefik (required)")
+	cleanupCmd.MarkFlagRequired("target")
+	cleanupCmd.Flags().BoolVar(&cleanupManagedOnly, "managed-only", false, "Delete only resources labelled app.kubernetes.io/managed-by=ing-switch (required)")
+	cleanupCmd.Flags().BoolVar(&cleanupDryRun, "dry-run", false, "List what would be deleted and validate with server-side dry-run")
+	cleanupCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table|json")
+	rootCmd.AddCommand(cleanupCmd)
+}
+
+func runCleanup() error {
+	switch cleanupTarget {
+	case "traefik", "gateway-api", "gateway-api-traefik":
+	default:
+		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", cleanupTarget)
+	}
+	if !cleanupManagedOnly {
+		return fmt.Errorf("--managed-only is required — cleanup only deletes ing-switch-managed resources; to remove NGINX run the generated cleanup scripts from 'ing-switch migrate'")
+	}
+
+	s, err := newScanner()
+	if err != nil {
+		return fmt.Errorf("connecting to cluster: %w", err)
+	}
+
+	resources, err := s.ListManaged(scanner.ManagedKindsForTarget(cleanupTarget), namespace)
+	if err != nil {
+		return fmt.Errorf("listing managed resources: %w", err)
+	}
+
+	var failed int
+	deleted := make([]bool, len(resources))
+	for i, r := range resources {
+		slog.Info("deleting managed resource", "kind", r.Kind, "namespace", r.Namespace, "name", r.Name, "dryRun", cleanupDryRun)
+		if err := s.DeleteManaged(r, cleanupDryRun); err != nil {
+			slog.Error("deleting managed resource failed", "kind", r.Kind, "namespace", r.Namespace, "name", r.Name, "error", err)
+			failed++
+			continue
+		}
+		deleted[i] = true
+	}
+
+	if outputFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(resources); err != nil {
+			return err
+		}
+	} else {
+		printCleanup(resources, deleted)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d resource(s) could not be deleted", failed)
+	}
+	return nil
+}
+
+func printCleanup(resources []scanner.ManagedResource, deleted []bool) {
+	mode := "DELETE"
+	if cleanupDryRun {
+		mode = "DRY-RUN"
+	}
+	fmt.Printf("\n  ing-switch cleanup [%s]\n", mode)
+	fmt.Printf("  Target: %s\n\n", cleanupTarget)
+
+	if len(resources) == 0 {
+		fmt.Printf("  No ing-switch-managed resources found.\n\n")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  KIND\tNAMESPACE\tNAME\tSOURCE INGRESS\tRESULT\n")
+	fmt.Fprintf(w, "  ----\t---------\t----\t--------------\t------\n")
+	for i, r := range resources {
+		result := "failed"
+		if deleted[i] {
+			result = "deleted"
+			if cleanupDryRun {
+				result = "would delete"
+			}
+		}
+		ns := r.Namespace
+		if ns == "" {
+			ns = "(cluster)"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", r.Kind, ns, r.Name, r.Source, result)
+	}
+	w.Flush()
+	fmt.Println()
+
+	if cleanupDryRun {
+		fmt.Printf("  Dry-run complete. Run without --dry-run to delete.\n\n")
+	}
+}
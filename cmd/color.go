@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// noColor is set by --no-color. Color is also off when stdout is not a
+// terminal or NO_COLOR (https://no-color.org) is set.
+var noColor bool
+
+func colorEnabled() bool {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// colorize wraps s in an ANSI SGR code when color output is enabled.
+func colorize(code, s string) string {
+	if !colorEnabled() {
+		return s
+	}
+	return "\033[" + code + "m" + s + "\033[0m"
+}
+
+func colorRed(s string) string    { return colorize("31", s) }
+func colorGreen(s string) string  { return colorize("32", s) }
+func colorYellow(s string) string { return colorize("33", s) }
+func colorDim(s string) string    { return colorize("2", s) }
+
+// colorPlain adds the same number of invisible bytes as the two-digit colors
+// without changing the color. tabwriter counts escape codes as width, so every
+// cell in a colored column — header and separator rows too — must carry them.
+func colorPlain(s string) string { return colorize("39", s) }
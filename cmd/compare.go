@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/spf13/cobra"
+)
+
+// compareTargets are the targets compare analyzes, in column order.
+var compareTargets = []string{"traefik", "gateway-api", "gateway-api-traefik"}
+
+var compareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Compare annotation support across every target controller",
+	Long: `Analyzes every Ingress against each supported target and prints a matrix:
+one row per annotation in use, with its status for each target (the worst
+across the ingresses that set it), followed by the per-target ingress counts
+and a recommendation — the target with the fewest breaking ingresses.
+
+Examples:
+  ing-switch compare
+  helm template my-release ./chart | ing-switch compare --stdin
+  ing-switch compare -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCompare()
+	},
+}
+
+func init() {
+	compareCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table|json")
+	// Shares analyze's --stdin handling (loadAnalyzeInput)
+	compareCmd.Flags().BoolVar(&analyzeStdin, "stdin", false, "Read Ingress manifests (e.g. helm template output) from stdin instead of the cluster")
+	rootCmd.AddCommand(compareCmd)
+}
+
+func runCompare() error {
+	scanResult, err := loadAnalyzeInput()
+	if err != nil {
+		return err
+	}
+
+	var reports []*analyzer.AnalysisReport
+	for _, t := range compareTargets {
+		reports = append(reports, analyzer.NewAnalyzer(t).Analyze(scanResult))
+	}
+	comparison := analyzer.Compare(reports)
+
+	if outputFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(comparison)
+	}
+	printComparison(comparison)
+	return nil
+}
+
+func printComparison(c *analyzer.Comparison) {
+	bannerf("\n  ing-switch — Target Comparison\n\n")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if len(c.Annotations) == 0 {
+		fmt.Printf("  No annotations in use — every target migrates these ingresses as-is.\n\n")
+	} else {
+		fmt.Fprintf(w, "  ANNOTATION\tINGRESSES")
+		for _, t := range c.Targets {
+			fmt.Fprintf(w, "\t%s", statusToIcon(t))
+		}
+		fmt.Fprintf(w, "\n  ----------\t---------")
+		for _, t := range c.Targets {
+			fmt.Fprintf(w, "\t%s", statusToIcon(repeatChar("-", len(t))))
+		}
+		fmt.Fprintln(w)
+		for _, ac := range c.Annotations {
+			fmt.Fprintf(w, "  %s\t%d", ac.Key, ac.Ingresses)
+			for _, t := range c.Targets {
+				fmt.Fprintf(w, "\t%s", statusToIcon(string(ac.Status[t])))
+			}
+			fmt.Fprintln(w)
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  INGRESSES")
+	for _, t := range c.Targets {
+		fmt.Fprintf(w, "\t%s", t)
+	}
+	fmt.Fprintf(w, "\n  ---------")
+	for _, t := range c.Targets {
+		fmt.Fprintf(w, "\t%s", repeatChar("-", len(t)))
+	}
+	fmt.Fprintln(w)
+	rows := []struct {
+		label string
+		count func(analyzer.Summary) int
+	}{
+		{"Ready", func(s analyzer.Summary) int { return s.FullyCompatible }},
+		{"Needs workarounds", func(s analyzer.Summary) int { return s.NeedsWorkaround }},
+		{"Breaking", func(s analyzer.Summary) int { return s.HasUnsupported }},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(w, "  %s", row.label)
+		for _, t := range c.Targets {
+			fmt.Fprintf(w, "\t%d", row.count(c.Summaries[t]))
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	fmt.Println()
+
+	if c.Recommended != "" {
+		fmt.Printf("  Recommended target: %s\n", c.Recommended)
+		fmt.Printf("  %s\n\n", c.Reason)
+		bannerf("  Run 'ing-switch analyze --target %s' for the per-ingress details\n\n", c.Recommended)
+	}
+}
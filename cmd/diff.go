@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/saiyam1814/ing-switch/pkg/analyzer"
@@ -52,7 +51,7 @@ func runDiff() error {
 		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", diffTarget)
 	}
 
-	s, err := scanner.NewScanner(kubeconfig, kubecontext)
+	s, err := newScanner()
 	if err != nil {
 		return fmt.Errorf("connecting to cluster: %w", err)
 	}
@@ -211,25 +210,3 @@ func runDiff() error {
 
 	return nil
 }
-
-// Color helpers — use ANSI codes, with NO_COLOR support
-func colorRed(s string) string {
-	if os.Getenv("NO_COLOR") != "" {
-		return s
-	}
-	return "\033[31m" + s + "\033[0m"
-}
-
-func colorGreen(s string) string {
-	if os.Getenv("NO_COLOR") != "" {
-		return s
-	}
-	return "\033[32m" + s + "\033[0m"
-}
-
-func colorDim(s string) string {
-	if os.Getenv("NO_COLOR") != "" {
-		return s
-	}
-	return "\033[2m" + s + "\033[0m"
-}
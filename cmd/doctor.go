@@ -34,7 +34,7 @@ func runDoctor() error {
 	fmt.Printf("  =================\n\n")
 
 	// Connect
-	s, err := scanner.NewScanner(kubeconfig, kubecontext)
+	s, err := newScanner()
 	if err != nil {
 		fmt.Printf("  [FAIL] Cannot connect to cluster: %v\n", err)
 		fmt.Printf("\n  Tip: Use --kubeconfig or set KUBECONFIG\n\n")
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate testdata/golden after an intended generator change")
+
+// goldenDir holds the committed migrate output for examples/, one directory
+// per target.
+const goldenDir = "../testdata/golden"
+
+var goldenTargets = []string{"traefik", "gateway-api", "gateway-api-traefik"}
+
+// TestGolden migrates the examples/ manifests (ssl-redirect, CORS, canary,
+// rate limiting, auth, multi-host TLS, ...) offline for each target and
+// compares the output with testdata/golden/<target>/, so generator changes
+// show up as a reviewable diff. Run with -update after an intended change.
+func TestGolden(t *testing.T) {
+	var manifests []byte
+	examples, err := filepath.Glob("../examples/*.yaml")
+	if err != nil || len(examples) == 0 {
+		t.Fatalf("no examples: %v", err)
+	}
+	for _, path := range examples {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests = append(manifests, b...)
+	}
+
+	for _, target := range goldenTargets {
+		t.Run(target, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), target)
+			if msg, err := migrateStdinManifests(t, manifests, "--target", target, "--output-dir", out); err != nil {
+				t.Fatalf("migrate --target %s: %v\n%s", target, err, msg)
+			}
+			golden := filepath.Join(goldenDir, target)
+			if *update {
+				if err := os.RemoveAll(golden); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.CopyFS(golden, os.DirFS(out)); err != nil {
+					t.Fatal(err)
+				}
+				t.Logf("updated %s", golden)
+				return
+			}
+
+			want, got := readTree(t, golden), readTree(t, out)
+			for path, content := range want {
+				g, ok := got[path]
+				switch {
+				case !ok:
+					t.Errorf("%s is no longer generated", path)
+				case !bytes.Equal(g, content):
+					t.Errorf("%s differs from the golden file%s", path, firstDifference(content, g))
+				}
+			}
+			for path := range got {
+				if _, ok := want[path]; !ok {
+					t.Errorf("%s is generated but has no golden file", path)
+				}
+			}
+			if t.Failed() {
+				t.Log("if the change is intended, run: make golden-update")
+			}
+		})
+	}
+}
+
+// migrateStdinManifests runs ing-switch migrate --stdin with args on
+// manifests and returns what it printed.
+func migrateStdinManifests(t *testing.T, manifests []byte, args ...string) (string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	in := filepath.Join(dir, "stdin.yaml")
+	if err := os.WriteFile(in, manifests, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stdin, err := os.Open(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+
+	oldStdin, oldStdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = stdin, stdout
+	defer func() { os.Stdin, os.Stdout = oldStdin, oldStdout }()
+
+	rootCmd.SetArgs(append([]string{"migrate", "--stdin"}, args...))
+	err = rootCmd.Execute()
+	msg, _ := os.ReadFile(stdout.Name())
+	return string(msg), err
+}
+
+// readTree returns the files under root by slash-separated relative path.
+func readTree(t *testing.T, root string) map[string][]byte {
+	t.Helper()
+	files := map[string][]byte{}
+	err := fs.WalkDir(os.DirFS(root), ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		files[path], err = os.ReadFile(filepath.Join(root, path))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("reading %s: %v", root, err)
+	}
+	return files
+}
+
+// firstDifference describes the first line where got departs from want.
+func firstDifference(want, got []byte) string {
+	w, g := strings.Split(string(want), "\n"), strings.Split(string(got), "\n")
+	for i := 0; i < len(w) || i < len(g); i++ {
+		var wl, gl string
+		if i < len(w) {
+			wl = w[i]
+		}
+		if i < len(g) {
+			gl = g[i]
+		}
+		if wl != gl {
+			return fmt.Sprintf("\n  line %d:\n  - %s\n  + %s", i+1, wl, gl)
+		}
+	}
+	return ""
+}
@@ -3,9 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/saiyam1814/ing-switch/pkg/analyzer"
 	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
 	"github.com/saiyam1814/ing-switch/pkg/migrator/gatewayapi"
 	"github.com/saiyam1814/ing-switch/pkg/migrator/traefik"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
@@ -15,6 +18,24 @@ import (
 var (
 	migrateTarget    string
 	migrateOutputDir string
+	migrateStrict    bool
+	migrateForce     bool
+	migrateDiffLive  bool
+	migrateByHost    bool
+	migrateStdin     bool
+	migrateMerge     bool
+	migrateAllowed   string
+	migratePlan      bool
+	migrateLayout    string
+	migrateNetpol    bool
+	migrateWebEP     string
+	migrateSecureEP  string
+	migrateResNs     string
+	migrateInclSupp  bool
+	migrateAPIVer    string
+	migrateSetAnn    []string
+	migrateReconcile bool
+	migrateResOnly   bool
 )
 
 var migrateCmd = &cobra.Command{
@@ -48,97 +69,515 @@ For Gateway API (Traefik provider):
   - Verification and cleanup scripts
   - Ideal for Rancher / k3s where Traefik is already the default
 
-All generated files are valid YAML you can review before applying.`,
+All generated files are valid YAML you can review before applying.
+
+Use --strict to abort (no files written, non-zero exit) when any Ingress
+has breaking annotations. Pass --force alongside --strict to generate
+anyway after reviewing the list.
+
+Use --diff-against-applied when re-running after resources were applied
+(and possibly hand-edited): before writing, each generated resource is
+compared with the live cluster and a summary of adds, changes, and deletes
+is printed.
+
+Use --reconcile when some target resources already exist, written by hand
+or applied by an earlier run: each generated Middleware, Gateway, HTTPRoute,
+or policy found in the cluster is left out of its file, and the fields it
+lacks are patched in by a <file>-reconcile.sh script next to it (a merge
+patch). Fields it sets differently are kept and listed as manual steps;
+resources that lack nothing are skipped.
+
+Use --consolidate-by-host (Gateway API targets) to merge ingresses in the
+same namespace that serve the same single host into one HTTPRoute. Each
+Ingress keeps its own rules and filters; ingresses that need policies
+(rate limit, auth, IP filtering) keep their own HTTPRoute. A host whose
+ingresses add up to more than 16 rules gets several HTTPRoutes.
+
+Use --web-entrypoint and --websecure-entrypoint (Traefik targets) when an
+existing Traefik serves HTTP and HTTPS on entrypoints not called web and
+websecure: values.yaml configures those, and the generated routers are
+pinned to them.
+
+Use --resource-namespace to create the Middlewares, HTTPRoutes, and policies
+in one namespace instead of next to each Ingress. They are named
+<namespace>-<name> there; backends, Secrets, and mirror targets stay in
+their namespaces and are referenced across namespaces (with ReferenceGrants
+for Gateway API routes).
+
+Use --api-version v1beta1 (Gateway API targets) when the cluster's Gateway
+API CRDs predate v1.0 and only serve v1beta1: the GatewayClass, Gateway, and
+HTTPRoutes are generated with that version, without the HTTPRoute timeouts
+it lacks. Both versions are in the Standard channel that the install step
+installs.
+
+Use --set-annotation key=value (repeatable) to add an annotation to every
+generated Traefik Ingress and HTTPRoute, e.g. a router priority that no
+NGINX annotation maps to. Annotations under set_annotations in
+.ing-switch.yaml are added the same way; the flag overrides them.
+
+Use --resources-only when the controller is installed and retired outside
+ing-switch, e.g. from a GitOps repo: only the routing resources are written
+(Middlewares, Ingresses, Gateways, HTTPRoutes, policies, NetworkPolicies,
+and the scripts that patch their Services), without the install, verify,
+and cleanup scripts, the guides, or 00-migration-report.md. Manual steps are
+printed instead.
+
+Use --include-supported to list every annotation in 00-migration-report.md,
+including those that map cleanly, with their target resource: a complete
+record of the migration, e.g. for an audit. By default the report lists only
+the partial and unsupported ones.
+
+Use --target all to compare targets: the traefik and gateway-api output is
+written to traefik/ and gateway-api/ under the output dir, with a top-level
+00-migration-report.md comparing both analyses.
+
+Use --stdin to generate from manifests instead of a cluster, e.g.
+  helm template my-release ./chart | ing-switch migrate --stdin --target gateway-api`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runMigrate(cmd)
 	},
 }
 
 func init() {
-	migrateCmd.Flags().StringVar(&migrateTarget, "target", "", "Target controller: traefik|gateway-api|gateway-api-traefik (required)")
+	migrateCmd.Flags().StringVar(&migrateTarget, "target", "", "Target controller: traefik|gateway-api|gateway-api-traefik|all (required)")
 	migrateCmd.MarkFlagRequired("target")
 	migrateCmd.Flags().StringVar(&migrateOutputDir, "output-dir", "./migration", "Directory to write generated files")
+	migrateCmd.Flags().BoolVar(&migrateStrict, "strict", false, "Fail without writing files if any Ingress is breaking")
+	migrateCmd.Flags().BoolVar(&migrateForce, "force", false, "With --strict, report breaking Ingresses but generate files anyway")
+	migrateCmd.Flags().BoolVar(&migrateByHost, "consolidate-by-host", false, "Gateway API: merge same-host ingresses into one HTTPRoute per host")
+	migrateCmd.Flags().StringVar(&migrateAllowed, "allowed-routes", gatewayapi.AllowedRoutesAll, "Gateway API: namespaces routes may attach from: All|Same|Selector (Selector = the migrated namespaces)")
+	migrateCmd.Flags().StringVar(&migrateLayout, "layout", generator.LayoutNumbered, "Output directory layout: numbered|flat|by-kind (scripts assume numbered)")
+	migrateCmd.Flags().BoolVar(&migrateNetpol, "emit-networkpolicy", false, "Generate a NetworkPolicy per backend namespace admitting the new controller to the backend pods")
+	migrateCmd.Flags().BoolVar(&migrateStdin, "stdin", false, "Read Ingress manifests from stdin instead of the cluster")
+	migrateCmd.Flags().BoolVar(&migrateMerge, "merge", false, "Re-run into an existing output dir: keep edited files, write changed output as <file>.new")
+	migrateCmd.Flags().BoolVar(&migratePlan, "plan", false, "Print how many files each step would generate, and the apply order, without writing anything")
+	migrateCmd.Flags().BoolVar(&migrateReconcile, "reconcile", false, "Patch target resources that already exist with the generated fields they lack instead of replacing them")
+	migrateCmd.Flags().BoolVar(&migrateDiffLive, "diff-against-applied", false, "Before writing, summarize adds/changes/deletes versus the live cluster")
+	migrateCmd.Flags().StringVar(&migrateWebEP, "web-entrypoint", migrator.DefaultWebEntryPoint, "Traefik: name of the entrypoint serving HTTP (port 80)")
+	migrateCmd.Flags().StringVar(&migrateSecureEP, "websecure-entrypoint", migrator.DefaultWebSecureEntryPoint, "Traefik: name of the entrypoint serving HTTPS (port 443)")
+	migrateCmd.Flags().StringVar(&migrateAPIVer, "api-version", gatewayapi.APIVersionV1, "Gateway API: version of the generated GatewayClass, Gateway, and HTTPRoutes: v1|v1beta1 (v1beta1 drops HTTPRoute timeouts)")
+	migrateCmd.Flags().StringArrayVar(&migrateSetAnn, "set-annotation", nil, "Add key=value to the annotations of every generated Traefik Ingress and HTTPRoute (repeatable)")
+	migrateCmd.Flags().BoolVar(&migrateResOnly, "resources-only", false, "Write only the routing resources: no install, verify, or cleanup scripts, guides, or migration report")
+	migrateCmd.Flags().BoolVar(&migrateInclSupp, "include-supported", false, "List cleanly mapped annotations in the migration report too, not only partial and unsupported ones")
+	migrateCmd.Flags().StringVar(&migrateResNs, "resource-namespace", "", "Create generated Middlewares, HTTPRoutes, and policies in this namespace instead of the Ingress's")
 	rootCmd.AddCommand(migrateCmd)
 }
 
 func runMigrate(cmd *cobra.Command) error {
 	switch migrateTarget {
-	case "traefik", "gateway-api", "gateway-api-traefik":
+	case "traefik", "gateway-api", "gateway-api-traefik", generator.TargetAll:
+	default:
+		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', 'gateway-api-traefik', or 'all'", migrateTarget)
+	}
+
+	if migrateByHost && migrateTarget == "traefik" {
+		return fmt.Errorf("--consolidate-by-host only applies to the gateway-api and gateway-api-traefik targets")
+	}
+	switch migrateAllowed {
+	case gatewayapi.AllowedRoutesAll, gatewayapi.AllowedRoutesSame, gatewayapi.AllowedRoutesSelector:
+	default:
+		return fmt.Errorf("unknown --allowed-routes %q — use 'All', 'Same', or 'Selector'", migrateAllowed)
+	}
+	if migrateAllowed != gatewayapi.AllowedRoutesAll && migrateTarget == "traefik" {
+		return fmt.Errorf("--allowed-routes only applies to the gateway-api and gateway-api-traefik targets")
+	}
+	if !gatewayapi.ValidAPIVersion(migrateAPIVer) {
+		return fmt.Errorf("unknown --api-version %q — use 'v1' or 'v1beta1', the versions the Gateway API Standard channel serves for GatewayClass, Gateway and HTTPRoute", migrateAPIVer)
+	}
+	if migrateAPIVer != gatewayapi.APIVersionV1 && migrateTarget == "traefik" {
+		return fmt.Errorf("--api-version only applies to the gateway-api and gateway-api-traefik targets")
+	}
+	if err := migrateEntryPoints().Validate(); err != nil {
+		return err
+	}
+	if migrateEntryPoints().Custom() && migrateTarget == "gateway-api" {
+		return fmt.Errorf("--web-entrypoint and --websecure-entrypoint only apply to the traefik and gateway-api-traefik targets")
+	}
+	if _, err := migrateAnnotations(); err != nil {
+		return err
+	}
+	if migrateResNs != "" && !migrator.ValidateNamespace(migrateResNs) {
+		return fmt.Errorf("invalid --resource-namespace %q — use a DNS-1123 label (lowercase letters, digits, '-')", migrateResNs)
+	}
+	switch migrateLayout {
+	case generator.LayoutNumbered, generator.LayoutFlat, generator.LayoutByKind:
 	default:
-		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", migrateTarget)
+		return fmt.Errorf("unknown layout %q — use 'numbered', 'flat', or 'by-kind'", migrateLayout)
+	}
+	if migrateTarget == generator.TargetAll && migrateDiffLive {
+		return fmt.Errorf("--diff-against-applied compares one target with the cluster and cannot be combined with --target all")
+	}
+	if migrateStdin && migrateDiffLive {
+		return fmt.Errorf("--diff-against-applied needs a cluster and cannot be combined with --stdin")
+	}
+	if migrateTarget == generator.TargetAll && migrateReconcile {
+		return fmt.Errorf("--reconcile compares one target with the cluster and cannot be combined with --target all")
+	}
+	if migrateStdin && migrateReconcile {
+		return fmt.Errorf("--reconcile needs a cluster and cannot be combined with --stdin")
 	}
 
-	fmt.Printf("\n  ing-switch — Generating Migration Files\n")
-	fmt.Printf("  Target:     %s\n", migrateTarget)
-	fmt.Printf("  Output dir: %s\n\n", migrateOutputDir)
+	bannerf("\n  ing-switch — Generating Migration Files\n")
+	bannerf("  Target:     %s\n", migrateTarget)
+	bannerf("  Output dir: %s\n\n", migrateOutputDir)
 
-	s, err := scanner.NewScanner(kubeconfig, kubecontext)
-	if err != nil {
-		return fmt.Errorf("connecting to cluster: %w", err)
+	var s *scanner.Scanner
+	var scanResult *scanner.ScanResult
+	var err error
+	if migrateStdin {
+		scanResult, err = scanner.ScanManifests(os.Stdin, namespace, annotationSource)
+		if err != nil {
+			return fmt.Errorf("reading manifests from stdin: %w", err)
+		}
+	} else {
+		s, err = newScanner()
+		if err != nil {
+			return fmt.Errorf("connecting to cluster: %w", err)
+		}
+		scanResult, err = scanCluster(s)
+		if err != nil {
+			return fmt.Errorf("scanning cluster: %w", err)
+		}
+	}
+
+	// Install scripts and a Gateway with no routes are no use on their own.
+	// TCP/UDP services still need their stream routes without any Ingress.
+	if len(scanResult.Ingresses) == 0 && len(scanResult.StreamServices) == 0 {
+		where := "any namespace"
+		if namespace != "" {
+			where = "namespace " + namespace
+		}
+		fmt.Printf("  No Ingress resources found in %s — nothing to migrate.\n\n", where)
+		return nil
+	}
+
+	// --target all runs each migrator on the same scan
+	targets := []string{migrateTarget}
+	if migrateTarget == generator.TargetAll {
+		targets = generator.AllTargets
+	}
+	outputs := make([]generator.TargetOutput, len(targets))
+	for i, t := range targets {
+		outputs[i] = generator.TargetOutput{Target: t, Report: analyzer.NewAnalyzer(t).Analyze(scanResult)}
+	}
+	report := outputs[0].Report
+
+	if migrateStrict {
+		breaking := 0
+		for _, o := range outputs {
+			b := breakingIngresses(o.Report)
+			if len(b) == 0 {
+				continue
+			}
+			if len(outputs) > 1 {
+				fmt.Printf("  %s:\n", o.Target)
+			}
+			printBreakingIngresses(b)
+			breaking = max(breaking, len(b))
+		}
+		if breaking > 0 {
+			if !migrateForce {
+				return fmt.Errorf("%d ingress(es) have unsupported annotations — aborting (--strict); pass --force to generate anyway", breaking)
+			}
+			fmt.Printf("  --force set: generating files despite breaking ingresses\n\n")
+		}
+	}
+
+	for _, o := range outputs {
+		if n := o.Report.Summary.MigrationInProgress + o.Report.Summary.AlreadyMigrated; n > 0 {
+			fmt.Printf("  Note: %d ingress(es) already have %s resources in the cluster:\n", n, o.Target)
+			for _, ir := range o.Report.IngressReports {
+				if ir.MigrationState != analyzer.MigrationNotStarted {
+					fmt.Printf("    %s/%s (%s)\n", ir.Namespace, ir.Name, ir.MigrationState)
+				}
+			}
+			fmt.Printf("  Their manifests are regenerated — diff against the cluster before re-applying.\n\n")
+		}
+	}
+
+	for i := range outputs {
+		outputs[i].Files, err = migrateFiles(outputs[i].Target, scanResult, outputs[i].Report)
+		if err != nil {
+			return fmt.Errorf("generating %s migration files: %w", outputs[i].Target, err)
+		}
+		if migrateResOnly {
+			outputs[i].Files = generator.ResourcesOnly(outputs[i].Files)
+		}
+		for _, f := range outputs[i].Files {
+			verbosef("  %-10s %s — %s\n", f.Category, f.RelPath, f.Description)
+		}
+	}
+	if migrateReconcile {
+		r, err := s.NewReconciler(migrateTarget)
+		if err != nil {
+			return fmt.Errorf("reconciling with the cluster: %w", err)
+		}
+		var recs []scanner.Reconciled
+		outputs[0].Files, recs, err = reconcileFiles(r, outputs[0].Files)
+		if err != nil {
+			return fmt.Errorf("reconciling with the cluster: %w", err)
+		}
+		printReconciled(recs)
+	}
+	if migratePlan {
+		for _, o := range outputs {
+			printMigrationPlan(o.Target, o.Files, len(scanResult.Ingresses))
+		}
+		return nil
+	}
+	files := outputs[0].Files
+
+	if migrateDiffLive {
+		var docs []string
+		for _, f := range files {
+			if strings.HasSuffix(f.RelPath, ".yaml") || strings.HasSuffix(f.RelPath, ".yml") {
+				docs = append(docs, f.Content)
+			}
+		}
+		changes, err := s.DiffAgainstLive(docs, migrateTarget, namespace)
+		if err != nil {
+			return fmt.Errorf("diffing against live cluster: %w", err)
+		}
+		printLiveChanges(changes)
 	}
 
-	scanResult, err := s.Scan(namespace)
+	gen := generator.NewOutputGenerator(migrateOutputDir)
+	gen.SetMerge(migrateMerge)
+	gen.SetLayout(migrateLayout)
+	reportOpts := generator.ReportOptions{IncludeSupported: migrateInclSupp}
+	gen.SetReportOptions(reportOpts)
+	gen.SetResourcesOnly(migrateResOnly)
+	if migrateTarget == generator.TargetAll {
+		files = generator.CombineTargets(outputs, migrateLayout, reportOpts)
+		if migrateResOnly {
+			files = generator.ResourcesOnly(files)
+		}
+		err = gen.WriteTargets(outputs)
+	} else {
+		err = gen.Write(files, report)
+	}
 	if err != nil {
-		return fmt.Errorf("scanning cluster: %w", err)
+		return fmt.Errorf("writing output: %w", err)
 	}
 
-	a := analyzer.NewAnalyzer(migrateTarget)
-	report := a.Analyze(scanResult)
+	fmt.Printf("  Generated %d files in %s/\n\n", len(files), migrateOutputDir)
+	if warnings := generator.AllWarnings(files); len(warnings) > 0 && migrateResOnly {
+		// No report to list them in
+		fmt.Printf("  %s %d manual step(s) required:\n", colorYellow("⚠"), len(warnings))
+		for _, w := range warnings {
+			fmt.Printf("    - %s\n", w)
+		}
+		fmt.Println()
+	} else if len(warnings) > 0 {
+		fmt.Printf("  %s %d manual step(s) required — listed under Manual Steps in the migration report\n\n", colorYellow("⚠"), len(warnings))
+	}
+	if changed := gen.Changed(); len(changed) > 0 {
+		fmt.Printf("  %d existing file(s) differ from the new output and were kept.\n", len(changed))
+		fmt.Printf("  Review and merge each <file>.new by hand:\n")
+		for _, f := range changed {
+			fmt.Printf("    ~ %s\n", f)
+		}
+		fmt.Println()
+	}
 
-	var files []generator.GeneratedFile
+	printStreamServices(report.StreamServices)
+	printNetworkPolicies(scanResult.NetworkPolicies)
+	printDefaultCertificate(scanResult.Controller)
+	printClientIPConfig(scanResult)
+	printInstalledTarget(scanResult, migrateTarget)
 
-	switch migrateTarget {
+	bannerf("  Next steps:\n")
+	switch {
+	case migrateResOnly:
+		bannerf("  1. Review the generated resources in %s/\n", migrateOutputDir)
+		bannerf("  2. Commit them to your GitOps repo, or apply them in directory order, once the controller is installed\n")
+	case migrateTarget == generator.TargetAll:
+		bannerf("  1. Compare the targets in %s/00-migration-report.md\n", migrateOutputDir)
+		bannerf("  2. Pick one and follow %s/<target>/00-migration-report.md\n", migrateOutputDir)
+		bannerf("  3. Re-run migrate with that --target to get its step-by-step instructions\n")
+	case migrateTarget == "traefik":
+		bannerf("  1. Review %s/00-migration-report.md\n", migrateOutputDir)
+		bannerf("  2. Run %s/01-install-traefik/helm-install.sh\n", migrateOutputDir)
+		bannerf("  3. Apply %s/02-middlewares/ (run auth-secret-convert.sh there first, if generated)\n", migrateOutputDir)
+		bannerf("  4. Apply %s/03-ingresses/\n", migrateOutputDir)
+		bannerf("  5. Run %s/04-verify.sh to test both controllers\n", migrateOutputDir)
+		bannerf("  6. Follow %s/05-dns-migration.md\n", migrateOutputDir)
+		bannerf("  7. Run %s/06-cleanup/ when ready\n", migrateOutputDir)
+	case migrateTarget == "gateway-api":
+		bannerf("  1. Review %s/00-migration-report.md\n", migrateOutputDir)
+		bannerf("  2. Run %s/01-install-gateway-api-crds/install.sh\n", migrateOutputDir)
+		bannerf("  3. Run %s/02-install-envoy-gateway/helm-install.sh\n", migrateOutputDir)
+		bannerf("  4. Apply %s/03-gateway/\n", migrateOutputDir)
+		bannerf("  5. Apply %s/04-httproutes/\n", migrateOutputDir)
+		bannerf("  6. Apply %s/05-policies/ (if applicable)\n", migrateOutputDir)
+		bannerf("  7. Run %s/06-verify.sh\n", migrateOutputDir)
+	case migrateTarget == "gateway-api-traefik":
+		bannerf("  1. Review %s/00-migration-report.md\n", migrateOutputDir)
+		bannerf("  2. Run %s/01-install-gateway-api-crds/install.sh\n", migrateOutputDir)
+		bannerf("  3. Run %s/02-install-traefik-gateway/helm-install.sh\n", migrateOutputDir)
+		bannerf("  4. Apply %s/03-gateway/\n", migrateOutputDir)
+		bannerf("  5. Apply %s/04-httproutes/\n", migrateOutputDir)
+		bannerf("  6. Apply %s/05-policies/ (Traefik Middlewares, if applicable)\n", migrateOutputDir)
+		bannerf("  7. Run %s/06-verify.sh\n", migrateOutputDir)
+	}
+
+	bannerf("\n  Run 'ing-switch ui' to open the visual migration dashboard\n\n")
+
+	_ = os.Stdout
+	return nil
+}
+
+// migrateFiles runs the migrator for target with the migrate flags applied.
+func migrateFiles(target string, scanResult *scanner.ScanResult, report *analyzer.AnalysisReport) ([]generator.GeneratedFile, error) {
+	extra, err := migrateAnnotations()
+	if err != nil {
+		return nil, err
+	}
+	switch target {
 	case "traefik":
 		m := traefik.NewMigrator()
-		files, err = m.Migrate(scanResult, report)
+		m.SetEmitNetworkPolicy(migrateNetpol)
+		m.SetEntryPoints(migrateEntryPoints())
+		m.SetResourceNamespace(migrateResNs)
+		m.SetExtraAnnotations(extra)
+		return m.Migrate(scanResult, report)
 	case "gateway-api":
 		m := gatewayapi.NewMigrator()
-		files, err = m.Migrate(scanResult, report)
+		m.SetConsolidateByHost(migrateByHost)
+		m.SetAllowedRoutes(migrateAllowed)
+		m.SetEmitNetworkPolicy(migrateNetpol)
+		m.SetResourceNamespace(migrateResNs)
+		m.SetAPIVersion(migrateAPIVer)
+		m.SetExtraAnnotations(extra)
+		return m.Migrate(scanResult, report)
 	case "gateway-api-traefik":
 		m := gatewayapi.NewTraefikGatewayMigrator()
-		files, err = m.Migrate(scanResult, report)
+		m.SetConsolidateByHost(migrateByHost)
+		m.SetAllowedRoutes(migrateAllowed)
+		m.SetEmitNetworkPolicy(migrateNetpol)
+		m.SetEntryPoints(migrateEntryPoints())
+		m.SetResourceNamespace(migrateResNs)
+		m.SetAPIVersion(migrateAPIVer)
+		m.SetExtraAnnotations(extra)
+		return m.Migrate(scanResult, report)
 	}
-	if err != nil {
-		return fmt.Errorf("generating migration files: %w", err)
+	return nil, fmt.Errorf("unknown target %q", target)
+}
+
+// printInstalledTarget says when the target controller is already running,
+// in which case the install step upgrades or reuses it instead.
+func printInstalledTarget(result *scanner.ScanResult, target string) {
+	if target == generator.TargetAll {
+		return
+	}
+	tc, ok := result.InstalledTarget(scanner.TargetControllerType(target))
+	if !ok {
+		return
+	}
+	action := "the install step upgrades its Helm release instead of installing a second one"
+	if tc.Type == scanner.TargetEnvoyGateway {
+		action = "the install step only checks it is ready"
 	}
+	fmt.Printf("  %s %s is already running in %s — %s.\n\n", tc.Type, tc.Version, tc.Namespace, action)
+}
 
-	gen := generator.NewOutputGenerator(migrateOutputDir)
-	if err := gen.Write(files, report); err != nil {
-		return fmt.Errorf("writing output: %w", err)
+// migrateEntryPoints returns the Traefik entrypoints named by the flags.
+func migrateEntryPoints() migrator.EntryPoints {
+	return migrator.EntryPoints{Web: migrateWebEP, WebSecure: migrateSecureEP}
+}
+
+// migrateAnnotations returns the annotations to add to the generated
+// Ingresses and HTTPRoutes: .ing-switch.yaml's set_annotations, overridden
+// by --set-annotation.
+func migrateAnnotations() (map[string]string, error) {
+	return migrator.ExtraAnnotations(scanner.LoadConfig().SetAnnotations, migrateSetAnn)
+}
+
+// printMigrationPlan summarizes what migrate would write, one row per file
+// category in the order the migrator emits them, which is the apply order.
+func printMigrationPlan(target string, files []generator.GeneratedFile, ingresses int) {
+	var order []string
+	counts := make(map[string]int)
+	for _, f := range files {
+		if counts[f.Category] == 0 {
+			order = append(order, f.Category)
+		}
+		counts[f.Category]++
 	}
 
-	fmt.Printf("  Generated %d files in %s/\n\n", len(files), migrateOutputDir)
+	report := ", plus 00-migration-report.md"
+	if migrateResOnly {
+		report = ""
+	}
+	fmt.Printf("  Plan (%s): %d file(s) for %d ingress(es)%s — nothing written\n\n", target, len(files), ingresses, report)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  STEP\tCATEGORY\tFILES\tHOW\n")
+	fmt.Fprintf(w, "  ----\t--------\t-----\t---\n")
+	for i, cat := range order {
+		how := "review / run by hand"
+		if generator.ApplyableCategories[cat] {
+			how = fmt.Sprintf("ing-switch apply --target %s --category %s", target, cat)
+		}
+		fmt.Fprintf(w, "  %d\t%s\t%d\t%s\n", i+1, cat, counts[cat], how)
+	}
+	w.Flush()
+	bannerf("\n  Run without --plan to write the files to %s/\n\n", migrateOutputDir)
+}
 
-	fmt.Printf("  Next steps:\n")
-	switch migrateTarget {
-	case "traefik":
-		fmt.Printf("  1. Review %s/00-migration-report.md\n", migrateOutputDir)
-		fmt.Printf("  2. Run %s/01-install-traefik/helm-install.sh\n", migrateOutputDir)
-		fmt.Printf("  3. Apply %s/02-middlewares/\n", migrateOutputDir)
-		fmt.Printf("  4. Apply %s/03-ingresses/\n", migrateOutputDir)
-		fmt.Printf("  5. Run %s/04-verify.sh to test both controllers\n", migrateOutputDir)
-		fmt.Printf("  6. Follow %s/05-dns-migration.md\n", migrateOutputDir)
-		fmt.Printf("  7. Run %s/06-cleanup/ when ready\n", migrateOutputDir)
-	case "gateway-api":
-		fmt.Printf("  1. Review %s/00-migration-report.md\n", migrateOutputDir)
-		fmt.Printf("  2. Run %s/01-install-gateway-api-crds/install.sh\n", migrateOutputDir)
-		fmt.Printf("  3. Run %s/02-install-envoy-gateway/helm-install.sh\n", migrateOutputDir)
-		fmt.Printf("  4. Apply %s/03-gateway/\n", migrateOutputDir)
-		fmt.Printf("  5. Apply %s/04-httproutes/\n", migrateOutputDir)
-		fmt.Printf("  6. Apply %s/05-policies/ (if applicable)\n", migrateOutputDir)
-		fmt.Printf("  7. Run %s/06-verify.sh\n", migrateOutputDir)
-	case "gateway-api-traefik":
-		fmt.Printf("  1. Review %s/00-migration-report.md\n", migrateOutputDir)
-		fmt.Printf("  2. Run %s/01-install-gateway-api-crds/install.sh\n", migrateOutputDir)
-		fmt.Printf("  3. Run %s/02-install-traefik-gateway/helm-install.sh\n", migrateOutputDir)
-		fmt.Printf("  4. Apply %s/03-gateway/\n", migrateOutputDir)
-		fmt.Printf("  5. Apply %s/04-httproutes/\n", migrateOutputDir)
-		fmt.Printf("  6. Apply %s/05-policies/ (Traefik Middlewares, if applicable)\n", migrateOutputDir)
-		fmt.Printf("  7. Run %s/06-verify.sh\n", migrateOutputDir)
+// breakingIngresses returns the ingress reports whose overall status is breaking.
+func breakingIngresses(report *analyzer.AnalysisReport) []analyzer.IngressReport {
+	var breaking []analyzer.IngressReport
+	for _, ir := range report.IngressReports {
+		if ir.OverallStatus == "breaking" {
+			breaking = append(breaking, ir)
+		}
 	}
+	return breaking
+}
 
-	fmt.Printf("\n  Run 'ing-switch ui' to open the visual migration dashboard\n\n")
+// printBreakingIngresses lists each breaking ingress with the unsupported
+// annotations that caused it.
+func printBreakingIngresses(breaking []analyzer.IngressReport) {
+	fmt.Printf("  ✗ Strict mode: %d ingress(es) would lose functionality\n\n", len(breaking))
+	for _, ir := range breaking {
+		fmt.Printf("  %s/%s\n", ir.Namespace, ir.Name)
+		for _, m := range ir.Mappings {
+			if m.Status == analyzer.StatusUnsupported {
+				fmt.Printf("    - %s: %s\n", m.OriginalKey, m.Note)
+			}
+		}
+	}
+	fmt.Println()
+}
 
-	_ = os.Stdout
-	return nil
+// printLiveChanges prints the --diff-against-applied summary. Unchanged
+// resources are counted but not listed.
+func printLiveChanges(changes []scanner.LiveChange) {
+	counts := map[string]int{}
+	fmt.Printf("  Changes versus the live cluster:\n")
+	for _, c := range changes {
+		counts[c.Change]++
+		var mark string
+		switch c.Change {
+		case scanner.ChangeAdd:
+			mark = "+"
+		case scanner.ChangeUpdate:
+			mark = "~"
+		case scanner.ChangeDelete:
+			mark = "-"
+		default:
+			continue
+		}
+		ref := c.Name
+		if c.Namespace != "" {
+			ref = c.Namespace + "/" + c.Name
+		}
+		fmt.Printf("    %s %s %s\n", mark, c.Kind, ref)
+	}
+	fmt.Printf("\n  %d to add, %d to change, %d no longer generated, %d unchanged\n",
+		counts[scanner.ChangeAdd], counts[scanner.ChangeUpdate], counts[scanner.ChangeDelete], counts[scanner.ChangeUnchanged])
+	if counts[scanner.ChangeDelete] > 0 {
+		fmt.Printf("  Resources no longer generated stay in the cluster — remove them with 'ing-switch cleanup' if intended.\n")
+	}
+	fmt.Println()
 }
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+)
+
+// TestMigrateEmptyScan checks that a scan without Ingresses is not an error
+// and writes nothing: no install scripts, no Gateway without routes, and no
+// migration report.
+func TestMigrateEmptyScan(t *testing.T) {
+	for _, target := range append(goldenTargets, generator.TargetAll) {
+		t.Run(target, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "migration")
+			msg, err := migrateStdinManifests(t, nil, "--target", target, "--output-dir", out)
+			if err != nil {
+				t.Fatalf("migrate --target %s: %v\n%s", target, err, msg)
+			}
+			if !strings.Contains(msg, "No Ingress resources found in any namespace — nothing to migrate.") {
+				t.Errorf("migrate --target %s did not stop with \"nothing to migrate\":\n%s", target, msg)
+			}
+			if strings.Contains(msg, "Generated") {
+				t.Errorf("migrate --target %s reports generated files:\n%s", target, msg)
+			}
+			if _, err := os.Stat(out); !os.IsNotExist(err) {
+				t.Errorf("migrate --target %s created %s (stat: %v)", target, out, err)
+			}
+		})
+	}
+}
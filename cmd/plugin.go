@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// kubectlPluginName is the executable kubectl runs for "kubectl ing-switch":
+// dashes in a plugin command become underscores in its file name.
+const kubectlPluginName = "kubectl-ing_switch"
+
+// kubectlPluginEnv maps global flags to the variables kubectl's original
+// plugin mechanism (before 1.12) passed its own global flags in. Current
+// kubectl passes the flags through as arguments instead.
+var kubectlPluginEnv = map[string]string{
+	"kubeconfig": "KUBECTL_PLUGINS_GLOBAL_FLAG_KUBECONFIG",
+	"context":    "KUBECTL_PLUGINS_GLOBAL_FLAG_CONTEXT",
+	"namespace":  "KUBECTL_PLUGINS_GLOBAL_FLAG_NAMESPACE",
+	"cluster":    "KUBECTL_PLUGINS_GLOBAL_FLAG_CLUSTER",
+	"user":       "KUBECTL_PLUGINS_GLOBAL_FLAG_USER",
+}
+
+// runningAsKubectlPlugin reports whether the binary was invoked through
+// kubectl, i.e. installed under a kubectl-* name (krew does this).
+func runningAsKubectlPlugin() bool {
+	return strings.HasPrefix(filepath.Base(os.Args[0]), "kubectl-")
+}
+
+// applyKubectlPluginEnv fills the global flags not given on the command line
+// from KUBECTL_PLUGINS_GLOBAL_FLAG_*. Empty variables are ignored, as kubectl
+// sets them all, to "" for flags the user did not pass.
+func applyKubectlPluginEnv(cmd *cobra.Command) error {
+	for flag, env := range kubectlPluginEnv {
+		v := os.Getenv(env)
+		f := cmd.Flags().Lookup(flag)
+		if v == "" || f == nil || f.Changed {
+			continue
+		}
+		if err := cmd.Flags().Set(flag, v); err != nil {
+			return fmt.Errorf("%s: %w", env, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+var docSeparator = regexp.MustCompile(`(?m)^---[ \t]*\n`)
+
+// reconcileFiles rewrites the generated YAML files for migrate --reconcile.
+// Resources the cluster already has are taken out of their file: skipped
+// when they carry every generated field, and otherwise completed by a
+// <file>-reconcile.sh script that patches in only the fields they lack. A
+// file left without resources is dropped.
+func reconcileFiles(r *scanner.Reconciler, files []generator.GeneratedFile) ([]generator.GeneratedFile, []scanner.Reconciled, error) {
+	var out []generator.GeneratedFile
+	var all []scanner.Reconciled
+	for _, f := range files {
+		if !strings.HasSuffix(f.RelPath, ".yaml") {
+			out = append(out, f)
+			continue
+		}
+		var docs []string
+		var existing []scanner.Reconciled
+		objects := 0
+		for _, doc := range docSeparator.Split(f.Content, -1) {
+			rec, ok, err := r.Reconcile(doc)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", f.RelPath, err)
+			}
+			if !ok || rec.Action == scanner.ReconcileCreate {
+				if strings.TrimSpace(commentLines(doc)) != strings.TrimSpace(doc) {
+					objects++
+				}
+				docs = append(docs, doc)
+				continue
+			}
+			all = append(all, rec)
+			existing = append(existing, rec)
+			note := "skipped"
+			if rec.Action == scanner.ReconcilePatch {
+				note = "the fields it lacks are patched in by " + reconcileScriptName(f.RelPath)
+			}
+			docs = append(docs, commentLines(doc)+fmt.Sprintf("# %s %s exists in the cluster (--reconcile): %s\n", rec.Kind, reconciledRef(rec), note))
+		}
+		if len(existing) == 0 {
+			out = append(out, f)
+			continue
+		}
+		if objects > 0 {
+			f.Content = strings.Join(docs, "---\n")
+			out = append(out, f)
+		}
+		if script, ok := reconcileScript(f.RelPath, existing); ok {
+			out = append(out, script)
+		}
+	}
+	return out, all, nil
+}
+
+// reconcileScript renders the kubectl patches completing the existing
+// resources of the file at relPath; ok is false when none needs one. The
+// resources are custom resources, which take JSON merge patches only.
+func reconcileScript(relPath string, existing []scanner.Reconciled) (generator.GeneratedFile, bool) {
+	var b strings.Builder
+	var notes []string
+	patches := 0
+	for _, rec := range existing {
+		for _, field := range rec.Kept {
+			notes = append(notes, fmt.Sprintf("%s %s keeps its %s, which differs from the generated one in %s", rec.Kind, reconciledRef(rec), field, relPath))
+		}
+		if rec.Action != scanner.ReconcilePatch {
+			continue
+		}
+		patch, err := json.Marshal(rec.Patch)
+		if err != nil {
+			continue
+		}
+		namespace := ""
+		if rec.Namespace != "" {
+			namespace = " -n " + rec.Namespace
+		}
+		fmt.Fprintf(&b, "\nkubectl patch %s %s%s --type merge \\\n  -p '%s'\n", rec.Resource, rec.Name, namespace, strings.ReplaceAll(string(patch), "'", `'\''`))
+		patches++
+	}
+	if patches == 0 && len(notes) == 0 {
+		return generator.GeneratedFile{}, false
+	}
+	if patches == 0 {
+		b.WriteString("\n# Nothing to patch: every generated field is set.\n")
+	}
+	content := "#!/bin/bash\n" +
+		"# Completes resources of " + path.Base(relPath) + " that already exist in the cluster\n" +
+		"# (migrate --reconcile): only the fields they lack are patched in, so\n" +
+		"# values set by hand are kept.\n" +
+		migrator.NoteComments(notes) +
+		"set -e\n" + b.String()
+	return generator.GeneratedFile{
+		RelPath:     reconcileScriptName(relPath),
+		Content:     content,
+		Description: "Patches for existing resources of " + path.Base(relPath),
+		Category:    "patch",
+		Warnings:    notes,
+	}, true
+}
+
+// reconcileScriptName is the patch script for the YAML file at relPath.
+func reconcileScriptName(relPath string) string {
+	return strings.TrimSuffix(relPath, ".yaml") + "-reconcile.sh"
+}
+
+// commentLines returns the leading comment lines of doc, e.g. its NOTEs.
+func commentLines(doc string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(doc, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+func reconciledRef(rec scanner.Reconciled) string {
+	if rec.Namespace == "" {
+		return rec.Name
+	}
+	return rec.Namespace + "/" + rec.Name
+}
+
+// printReconciled summarizes what --reconcile did with the resources already
+// in the cluster.
+func printReconciled(recs []scanner.Reconciled) {
+	counts := map[string]int{}
+	fmt.Printf("  Resources already in the cluster (--reconcile):\n")
+	for _, rec := range recs {
+		counts[rec.Action]++
+		mark := "="
+		if rec.Action == scanner.ReconcilePatch {
+			mark = "~"
+		}
+		fmt.Printf("    %s %s %s\n", mark, rec.Kind, reconciledRef(rec))
+	}
+	if len(recs) == 0 {
+		fmt.Printf("    none — every resource is applied as generated\n")
+	}
+	fmt.Printf("\n  %d patched with the fields they lack, %d skipped as complete\n\n", counts[scanner.ReconcilePatch], counts[scanner.ReconcileSkip])
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/saiyam1814/ing-switch/pkg/analyzer"
 	"github.com/saiyam1814/ing-switch/pkg/generator"
-	"github.com/saiyam1814/ing-switch/pkg/scanner"
 	"github.com/spf13/cobra"
 )
 
@@ -52,7 +51,7 @@ func runReport() error {
 	fmt.Printf("\n  ing-switch — Generating HTML Report\n")
 	fmt.Printf("  Target: %s\n\n", reportTarget)
 
-	s, err := scanner.NewScanner(kubeconfig, kubecontext)
+	s, err := newScanner()
 	if err != nil {
 		return fmt.Errorf("connecting to cluster: %w", err)
 	}
@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +16,15 @@ var (
 	kubecontext string
 	namespace   string
 	outputFormat string
+	logLevel     string
+	annotationSource string
+	quiet            bool
+	verbose          bool
+
+	// kubectl's --cluster, --user and --all-namespaces, for use as a kubectl plugin
+	kubecluster   string
+	kubeuser      string
+	allNamespaces bool
 )
 
 var rootCmd = &cobra.Command{
@@ -43,6 +56,22 @@ Examples:
 
   # Open local UI
   ing-switch ui`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyKubectlPluginEnv(cmd); err != nil {
+			return err
+		}
+		if allNamespaces {
+			namespace = ""
+		}
+		scanner.SetConfigOverrides(kubecluster, kubeuser)
+		if quiet && verbose {
+			return fmt.Errorf("--quiet and --verbose cannot be combined")
+		}
+		if err := configureLogging(logLevel); err != nil {
+			return err
+		}
+		return scanner.ValidateAnnotationSource(annotationSource)
+	},
 }
 
 func Execute() {
@@ -53,7 +82,76 @@ func Execute() {
 }
 
 func init() {
+	if runningAsKubectlPlugin() {
+		rootCmd.Annotations = map[string]string{cobra.CommandDisplayNameAnnotation: "kubectl ing-switch"}
+	}
 	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG or ~/.kube/config)")
 	rootCmd.PersistentFlags().StringVar(&kubecontext, "context", "", "Kubernetes context to use")
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "Namespace to scan (default: all namespaces)")
+	rootCmd.PersistentFlags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Scan all namespaces, overriding --namespace (the default)")
+	rootCmd.PersistentFlags().StringVar(&kubecluster, "cluster", "", "Name of the kubeconfig cluster to use")
+	rootCmd.PersistentFlags().StringVar(&kubeuser, "user", "", "Name of the kubeconfig user to use")
+	rootCmd.PersistentFlags().StringVar(&annotationSource, "source", scanner.AnnotationSourceCommunity, "Ingress annotation family: community (nginx.ingress.kubernetes.io/) or f5 (nginx.org/, nginx.com/)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level for stderr logs: debug|info|warn|error")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Print only results and errors (no banner or next steps)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also off when stdout is not a terminal or NO_COLOR is set)")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Print per-resource generation detail and API call timing to stderr")
+}
+
+// bannerf prints the decorative parts of table output — headers, legends,
+// next steps — which --quiet suppresses.
+func bannerf(format string, args ...any) {
+	if !quiet {
+		fmt.Printf(format, args...)
+	}
+}
+
+// verbosef prints --verbose detail. It goes to stderr so stdout, table or
+// JSON, is the same with and without it.
+func verbosef(format string, args ...any) {
+	if verbose {
+		fmt.Fprintf(os.Stderr, format, args...)
+	}
+}
+
+// newScanner connects to the cluster the global flags select, reading
+// Ingresses with the --source annotation family.
+func newScanner() (*scanner.Scanner, error) {
+	s, err := scanner.NewScanner(kubeconfig, kubecontext)
+	if err != nil {
+		return nil, err
+	}
+	s.SetAnnotationSource(annotationSource)
+	return s, nil
+}
+
+// scanCluster runs a cluster scan, reporting how long it took with --verbose.
+func scanCluster(s *scanner.Scanner) (*scanner.ScanResult, error) {
+	start := time.Now()
+	result, err := s.Scan(namespace)
+	if err != nil {
+		return nil, err
+	}
+	verbosef("  scanned %d resource(s) in %s\n", len(result.Ingresses), time.Since(start).Round(time.Millisecond))
+	return result, nil
+}
+
+// configureLogging installs the default slog logger. Logs always go to stderr
+// so they never mix with table/JSON output on stdout.
+func configureLogging(level string) error {
+	var l slog.Level
+	switch strings.ToLower(level) {
+	case "debug":
+		l = slog.LevelDebug
+	case "info", "":
+		l = slog.LevelInfo
+	case "warn", "warning":
+		l = slog.LevelWarn
+	case "error":
+		l = slog.LevelError
+	default:
+		return fmt.Errorf("unknown log level %q — use 'debug', 'info', 'warn', or 'error'", level)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: l})))
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
 	"github.com/spf13/cobra"
 )
@@ -43,14 +44,14 @@ func init() {
 }
 
 func runScan(_ *cobra.Command) bool {
-	s, err := scanner.NewScanner(kubeconfig, kubecontext)
+	s, err := newScanner()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error connecting to cluster: %v\n", err)
 		fmt.Fprintln(os.Stderr, "\nTip: Use --kubeconfig or set KUBECONFIG environment variable")
 		return true
 	}
 
-	result, err := s.Scan(namespace)
+	result, err := scanCluster(s)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error scanning cluster: %v\n", err)
 		return true
@@ -68,9 +69,8 @@ func runScan(_ *cobra.Command) bool {
 }
 
 func printScanResult(result *scanner.ScanResult) {
-	fmt.Printf("\n  ing-switch — Cluster Scan Results\n")
-	fmt.Printf("  Cluster: %s\n", result.ClusterName)
-	fmt.Println()
+	bannerf("\n  ing-switch — Cluster Scan Results\n")
+	bannerf("  Cluster: %s\n\n", result.ClusterName)
 
 	// Controller info
 	if result.Controller.Detected {
@@ -78,6 +78,12 @@ func printScanResult(result *scanner.ScanResult) {
 		fmt.Printf("  Type:      %s\n", result.Controller.Type)
 		fmt.Printf("  Version:   %s\n", result.Controller.Version)
 		fmt.Printf("  Namespace: %s\n", result.Controller.Namespace)
+		if len(result.Controller.IngressClasses) > 0 {
+			fmt.Printf("  Classes:   %s\n", strings.Join(result.Controller.IngressClasses, ", "))
+		}
+		if result.Controller.DefaultSSLCertificate != "" {
+			fmt.Printf("  Default certificate: %s\n", result.Controller.DefaultSSLCertificate)
+		}
 	} else {
 		fmt.Printf("  No ingress controller detected (or insufficient permissions)\n")
 	}
@@ -85,6 +91,11 @@ func printScanResult(result *scanner.ScanResult) {
 
 	if len(result.Ingresses) == 0 {
 		fmt.Println("  No Ingress resources found.")
+		fmt.Println()
+		printStreamServices(result.StreamServices)
+		printNetworkPolicies(result.NetworkPolicies)
+		printDefaultCertificate(result.Controller)
+		printClientIPConfig(result)
 		return
 	}
 
@@ -132,8 +143,8 @@ func printScanResult(result *scanner.ScanResult) {
 	fmt.Printf("\n\n")
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintf(w, "  NAMESPACE\tNAME\tTYPE\tHOSTS\tANNOTATIONS\tTLS\tCOMPLEXITY\n")
-	fmt.Fprintf(w, "  ---------\t----\t----\t-----\t-----------\t---\t----------\n")
+	fmt.Fprintf(w, "  NAMESPACE\tNAME\tTYPE\tHOSTS\tADDRESS\tANNOTATIONS\tTLS\tCOMPLEXITY\n")
+	fmt.Fprintf(w, "  ---------\t----\t----\t-----\t-------\t-----------\t---\t----------\n")
 
 	for _, ing := range result.Ingresses {
 		hosts := ""
@@ -159,24 +170,201 @@ func printScanResult(result *scanner.ScanResult) {
 		case scanner.SourceIstioVirtualService:
 			sourceLabel = "Istio VS"
 		}
-		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%d\t%s\t%s\n",
-			ing.Namespace, ing.Name, sourceLabel, hosts, len(ing.NginxAnnotations), tls, complexity)
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			ing.Namespace, ing.Name, sourceLabel, hosts, ingressAddress(ing, result.Controller), len(ing.NginxAnnotations), tls, complexity)
 	}
 	w.Flush()
+	fmt.Println()
+
+	printUnservedIngresses(result)
+	printStreamServices(result.StreamServices)
+	printNetworkPolicies(result.NetworkPolicies)
+	printDefaultCertificate(result.Controller)
+	printTLSSecretIssues(result.Ingresses)
+	printClientIPConfig(result)
+	printGlobalAuth(result)
+
+	bannerf("  Complexity: [simple] [complex] [unsupported]\n")
+	bannerf("  Run 'ing-switch analyze --target traefik' for detailed annotation mapping\n\n")
+}
+
+// ingressAddress renders the first status address of an ingress, "-" when
+// no controller published one. The controller that owns the address is named
+// when it is not the detected one, e.g. a Traefik already serving the class.
+func ingressAddress(ing scanner.IngressInfo, controller scanner.ControllerInfo) string {
+	if len(ing.Addresses) == 0 {
+		return "-"
+	}
+	addr := ing.Addresses[0]
+	if len(ing.Addresses) > 1 {
+		addr += fmt.Sprintf(" +%d", len(ing.Addresses)-1)
+	}
+	if ing.AddressController != "" && ing.AddressController != controller.Type {
+		addr += " (" + ing.AddressController + ")"
+	}
+	return addr
+}
+
+// printUnservedIngresses lists ingresses whose class the detected controller
+// does not serve (a wrong ingressClassName, or a controller that is gone).
+// They carry no traffic, so they can be cleaned up instead of migrated.
+func printUnservedIngresses(result *scanner.ScanResult) {
+	var unserved []scanner.IngressInfo
+	for _, ing := range result.Ingresses {
+		if !ing.Served {
+			unserved = append(unserved, ing)
+		}
+	}
+	if len(unserved) == 0 {
+		return
+	}
+	fmt.Printf("  ⚠ %d ingress(es) not served by the detected %s controller\n", len(unserved), result.Controller.Type)
+	fmt.Printf("  Their class matches none it serves (%s), so they carry no traffic.\n", strings.Join(result.Controller.IngressClasses, ", "))
+	fmt.Printf("  Skip them when migrating, or delete them.\n\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  NAMESPACE\tNAME\tCLASS\n")
+	fmt.Fprintf(w, "  ---------\t----\t-----\n")
+	for _, ing := range unserved {
+		class := ing.IngressClass
+		if class == "" {
+			class = "(none)"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", ing.Namespace, ing.Name, class)
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+// printStreamServices warns about TCP/UDP ports exposed through the
+// ingress-nginx tcp-services/udp-services ConfigMaps. They are not Ingress
+// resources, so they are the part of a migration most often forgotten.
+func printStreamServices(streams []scanner.StreamService) {
+	if len(streams) == 0 {
+		return
+	}
+	fmt.Printf("  ⚠ %d TCP/UDP service(s) exposed via ingress-nginx ConfigMaps\n", len(streams))
+	fmt.Printf("  These are NOT Ingress resources — they stop working when NGINX is removed\n")
+	fmt.Printf("  unless migrated (migrate generates stream routes for them).\n\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  PROTOCOL\tPORT\tBACKEND\tCONFIGMAP\n")
+	fmt.Fprintf(w, "  --------\t----\t-------\t---------\n")
+	for _, st := range streams {
+		backend := fmt.Sprintf("%s/%s:%s", st.Namespace, st.Service, st.ServicePort)
+		if st.DecodeProxy || st.EncodeProxy {
+			backend += " (PROXY protocol)"
+		}
+		fmt.Fprintf(w, "  %s\t%d\t%s\t%s\n", st.Protocol, st.Port, backend, st.ConfigMap)
+	}
+	w.Flush()
+	fmt.Println()
+}
 
+// printDefaultCertificate warns that ingress-nginx serves a default TLS
+// certificate. Hosts relying on it break after cutover unless the new
+// controller is given the same certificate.
+func printDefaultCertificate(controller scanner.ControllerInfo) {
+	if controller.DefaultSSLCertificate == "" {
+		return
+	}
+	fmt.Printf("  ⚠ ingress-nginx serves default certificate %s (--default-ssl-certificate)\n", controller.DefaultSSLCertificate)
+	fmt.Printf("  Hosts without their own TLS secret use it. migrate configures it as the\n")
+	fmt.Printf("  Traefik default TLSStore or a catch-all Gateway HTTPS listener.\n\n")
+}
+
+// printTLSSecretIssues lists TLS secrets that ingresses reference but that
+// are missing or not of type kubernetes.io/tls. The generated TLS config
+// names the same secrets, so they fail after cutover unless fixed first.
+func printTLSSecretIssues(ingresses []scanner.IngressInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	count := 0
+	for _, ing := range ingresses {
+		for _, issue := range ing.TLSSecretIssues {
+			if count == 0 {
+				fmt.Fprintf(w, "  NAMESPACE\tINGRESS\tSECRET\tPROBLEM\n")
+				fmt.Fprintf(w, "  ---------\t-------\t------\t-------\n")
+			}
+			count++
+			problem := "missing"
+			if !issue.Missing() {
+				problem = "type " + issue.Type
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", ing.Namespace, ing.Name, issue.Name, problem)
+		}
+	}
+	if count == 0 {
+		return
+	}
+	fmt.Printf("  ⚠ %d TLS secret reference(s) are missing or not of type kubernetes.io/tls\n", count)
+	fmt.Printf("  The migrated listeners reference the same secrets, so TLS fails after cutover\n")
+	fmt.Printf("  unless they are created or fixed first.\n\n")
+	w.Flush()
+	fmt.Println()
+}
+
+// printClientIPConfig warns when ingresses filter on source ranges but the
+// ingress-nginx ConfigMap could not be read: whether nginx matched the
+// X-Forwarded-For address or the connection's is unknown, so the generated
+// IP filters may match the load balancer instead of the client.
+func printClientIPConfig(result *scanner.ScanResult) {
+	if result.Controller.Type != "ingress-nginx" || result.Controller.ForwardedHeaders != nil ||
+		!migrator.HasSourceRanges(result.Ingresses) {
+		return
+	}
+	fmt.Printf("  ⚠ the ingress-nginx ConfigMap could not be read\n")
+	fmt.Printf("  whitelist-source-range / denylist-source-range depend on use-forwarded-headers and\n")
+	fmt.Printf("  proxy-real-ip-cidr there; check them and configure the new controller's trusted proxies.\n\n")
+}
+
+// printGlobalAuth reports the ingress-nginx global external auth and how
+// many ingresses it was carried over to as per-ingress auth.
+func printGlobalAuth(result *scanner.ScanResult) {
+	auth := result.Controller.GlobalAuth
+	if auth == nil {
+		return
+	}
+	covered := 0
+	for _, ing := range result.Ingresses {
+		if ing.NginxAnnotations["global-auth"] != "" {
+			covered++
+		}
+	}
+	fmt.Printf("  Global auth: %s (from %s)\n", auth.URL, auth.ConfigMap)
+	fmt.Printf("  Applied to %d ingress(es) as per-ingress auth — the others set their own auth-url\n", covered)
+	fmt.Printf("  or enable-global-auth: \"false\". Migrated routes get ForwardAuth / ext-auth for it.\n\n")
+}
+
+// printNetworkPolicies flags backend namespaces whose NetworkPolicies restrict
+// ingress traffic. Policies that only admit ingress-nginx silently block the
+// new controller, which runs in its own namespace.
+func printNetworkPolicies(policies []scanner.NetworkPolicyInfo) {
+	if len(policies) == 0 {
+		return
+	}
+	fmt.Printf("  ⚠ %d NetworkPolicy(ies) restrict ingress to Ingress backends\n", len(policies))
+	fmt.Printf("  The new controller's namespace must be an allowed source, or its traffic is dropped.\n")
+	fmt.Printf("  migrate writes networkpolicy-review/ with a guide and allow policies.\n\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  NAMESPACE\tPOLICY\tALLOWED NAMESPACES\tSERVICES\n")
+	fmt.Fprintf(w, "  ---------\t------\t------------------\t--------\n")
+	for _, np := range policies {
+		allowed := strings.Join(np.AllowedNamespaces, ",")
+		if allowed == "" {
+			allowed = "-"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", np.Namespace, np.Name, allowed, strings.Join(np.Services, ","))
+	}
+	w.Flush()
 	fmt.Println()
-	fmt.Printf("  Complexity: [simple] [complex] [unsupported]\n")
-	fmt.Printf("  Run 'ing-switch analyze --target traefik' for detailed annotation mapping\n\n")
 }
 
 func complexityIcon(c string) string {
 	switch c {
 	case "simple":
-		return "simple"
+		return colorGreen("simple")
 	case "complex":
-		return "complex"
+		return colorYellow("complex")
 	case "unsupported":
-		return "unsupported"
+		return colorRed("unsupported")
 	default:
 		return c
 	}
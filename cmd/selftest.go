@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	selftestTarget     string
+	selftestTimeout    time.Duration
+	selftestAnyCluster bool
+)
+
+// selftestPollInterval is how often the resource statuses are re-read while
+// some are still pending.
+const selftestPollInterval = 2 * time.Second
+
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Generate, apply, and check that the target controller accepts the output",
+	Long: `Development harness: generates the migration files for the Ingresses in
+the current context, applies the kubectl-applyable ones (as 'ing-switch apply'
+does), then waits for the target controller to report on each resource:
+
+  GatewayClass           Accepted
+  Gateway                Programmed
+  HTTPRoute/TCPRoute/UDPRoute, Envoy Gateway policies
+                         Accepted and ResolvedRefs on every parent
+  Ingress                programmed with a load balancer address in its
+                         status, otherwise stored
+  Middleware, IngressRouteTCP/UDP
+                         stored (these kinds have no status)
+
+It fails when any resource is rejected, missing, or still pending after
+--timeout, so generated manifests are checked against a real controller,
+not just parsed.
+
+The target controller and its CRDs must already be installed (run the
+install step of 'ing-switch migrate' first). Applying rewrites the source
+Ingresses for the traefik target, so selftest only runs against kind
+clusters (context kind-*) unless --any-cluster is given. Nothing is removed
+afterwards; use 'ing-switch cleanup --managed-only' to roll back.
+
+Example:
+  kind create cluster
+  kubectl apply -f examples/
+  ing-switch selftest --target gateway-api`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSelftest()
+	},
+}
+
+func init() {
+	selftestCmd.Flags().StringVar(&selftestTarget, "target", "", "Target controller: traefik|gateway-api|gateway-api-traefik (required)")
+	selftestCmd.MarkFlagRequired("target")
+	selftestCmd.Flags().DurationVar(&selftestTimeout, "timeout", 2*time.Minute, "How long to wait for the controller to report on every resource")
+	selftestCmd.Flags().BoolVar(&selftestAnyCluster, "any-cluster", false, "Run against a context that is not a kind cluster")
+	rootCmd.AddCommand(selftestCmd)
+}
+
+func runSelftest() error {
+	switch selftestTarget {
+	case "traefik", "gateway-api", "gateway-api-traefik":
+	default:
+		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", selftestTarget)
+	}
+
+	s, err := newScanner()
+	if err != nil {
+		return fmt.Errorf("connecting to cluster: %w", err)
+	}
+	scanResult, err := s.Scan(namespace)
+	if err != nil {
+		return fmt.Errorf("scanning cluster: %w", err)
+	}
+	if !strings.HasPrefix(scanResult.ClusterName, "kind-") && !selftestAnyCluster {
+		return fmt.Errorf("context %q is not a kind cluster — selftest applies generated resources, pass --any-cluster to run it anyway", scanResult.ClusterName)
+	}
+
+	fmt.Printf("\n  ing-switch selftest\n")
+	fmt.Printf("  Target:  %s\n", selftestTarget)
+	fmt.Printf("  Context: %s\n\n", scanResult.ClusterName)
+
+	if len(scanResult.Ingresses) == 0 {
+		return fmt.Errorf("%w — apply some first, e.g. kubectl apply -f examples/", scanner.ErrNoIngresses)
+	}
+	if _, ok := scanResult.InstalledTarget(scanner.TargetControllerType(selftestTarget)); !ok {
+		return fmt.Errorf("no running %s found — install it with the generated install step first", scanner.TargetControllerType(selftestTarget))
+	}
+
+	// 1. Generate
+	report := analyzer.NewAnalyzer(selftestTarget).Analyze(scanResult)
+	files, err := migrateFiles(selftestTarget, scanResult, report)
+	if err != nil {
+		return fmt.Errorf("generating migration files: %w", err)
+	}
+	var docs []string
+	for _, f := range files {
+		if generator.ApplyableCategories[f.Category] && strings.HasSuffix(f.RelPath, ".yaml") {
+			docs = append(docs, f.Content)
+		}
+	}
+	fmt.Printf("  [1/3] Generated %d file(s), %d to apply\n", len(files), len(docs))
+
+	// 2. Apply
+	if err := selftestApply(files); err != nil {
+		return err
+	}
+	fmt.Printf("  [2/3] Applied\n")
+
+	// 3. Verify
+	statuses, err := selftestWait(s, docs)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  [3/3] Read the status of %d resource(s)\n\n", len(statuses))
+	failed := printSelftest(statuses)
+
+	fmt.Printf("  Roll back with: ing-switch cleanup --target %s --managed-only\n\n", selftestTarget)
+	if failed > 0 {
+		return fmt.Errorf("selftest failed: %d of %d resource(s) not accepted by %s", failed, len(statuses), selftestTarget)
+	}
+	return nil
+}
+
+// selftestApply kubectl-applies the applyable YAML files.
+func selftestApply(files []generator.GeneratedFile) error {
+	tmpDir, err := os.MkdirTemp("", "ing-switch-selftest-*")
+	if err != nil {
+		return fmt.Errorf("creating temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, f := range files {
+		if !generator.ApplyableCategories[f.Category] || !strings.HasSuffix(f.RelPath, ".yaml") {
+			continue
+		}
+		// Flatten the step directories without name clashes
+		dest := filepath.Join(tmpDir, strings.ReplaceAll(f.RelPath, "/", "-"))
+		if err := os.WriteFile(dest, []byte(f.Content), 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", f.RelPath, err)
+		}
+	}
+
+	args := []string{}
+	if kubeconfig != "" {
+		args = append(args, "--kubeconfig", kubeconfig)
+	}
+	if kubecontext != "" {
+		args = append(args, "--context", kubecontext)
+	}
+	args = append(args, scanner.KubectlConfigArgs()...)
+	args = append(args, "apply", "-f", tmpDir)
+
+	out, err := exec.Command("kubectl", args...).CombinedOutput()
+	slog.Info("selftest kubectl apply", "target", selftestTarget, "args", args, "error", err)
+	if err != nil {
+		return fmt.Errorf("kubectl apply failed: %w\n%s", err, out)
+	}
+	return nil
+}
+
+// selftestWait re-reads the statuses until none is pending or the timeout
+// passes, and returns the last reading.
+func selftestWait(s *scanner.Scanner, docs []string) ([]scanner.ResourceStatus, error) {
+	deadline := time.Now().Add(selftestTimeout)
+	for {
+		statuses, err := s.ResourceStatuses(docs, selftestTarget)
+		if err != nil {
+			return nil, fmt.Errorf("reading resource status: %w", err)
+		}
+		pending := 0
+		for _, st := range statuses {
+			if !st.Settled() {
+				pending++
+			}
+		}
+		if pending == 0 || time.Now().After(deadline) {
+			return statuses, nil
+		}
+		slog.Info("selftest waiting for controller", "pending", pending)
+		time.Sleep(selftestPollInterval)
+	}
+}
+
+// printSelftest prints one row per resource and returns how many failed.
+func printSelftest(statuses []scanner.ResourceStatus) int {
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  %s\tKIND\tNAMESPACE\tNAME\tREASON\n", colorPlain("STATE"))
+	fmt.Fprintf(w, "  %s\t----\t---------\t----\t------\n", colorPlain("-----"))
+	for _, st := range statuses {
+		state := colorGreen(st.State)
+		switch st.State {
+		case scanner.StateStored:
+			state = colorPlain(st.State)
+		case scanner.StatePending:
+			state = colorYellow(st.State)
+			failed++
+		case scanner.StateRejected, scanner.StateMissing:
+			state = colorRed(st.State)
+			failed++
+		}
+		ns := st.Namespace
+		if ns == "" {
+			ns = "(cluster)"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", state, st.Kind, ns, st.Name, st.Reason)
+	}
+	w.Flush()
+	fmt.Println()
+	return failed
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/saiyam1814/ing-switch/pkg/tui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tuiTarget       string
+	tuiOutputDir    string
+	tuiApplyTimeout time.Duration
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Interactive terminal UI: browse ingresses, pick a target, generate and apply",
+	Long: `Starts an interactive terminal UI on top of the same scan, analyze, and
+migrate logic as the other commands — no web server involved.
+
+Screens:
+  Ingresses   — scanned ingresses with their readiness for the chosen target
+  Target      — switch between traefik, gateway-api, and gateway-api-traefik
+  Annotations — per-ingress annotation matrix (supported / partial / unsupported)
+
+Keys on the ingress list:
+  enter  annotation matrix     t  change target
+  g      generate files        d  kubectl apply --dry-run=server
+  a      apply (asks first)    r  rescan
+
+Examples:
+  # Pick the target interactively
+  ing-switch tui
+
+  # Start on Gateway API, writing generated files to ./out
+  ing-switch tui --target gateway-api --output-dir ./out`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTUI()
+	},
+}
+
+func init() {
+	tuiCmd.Flags().StringVar(&tuiTarget, "target", "", "Initial target: traefik|gateway-api|gateway-api-traefik (default: ask)")
+	tuiCmd.Flags().StringVar(&tuiOutputDir, "output-dir", "./migration", "Directory the generate action writes to")
+	tuiCmd.Flags().DurationVar(&tuiApplyTimeout, "apply-timeout", 60*time.Second, "Maximum time a single kubectl apply from the TUI may run")
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func runTUI() error {
+	switch tuiTarget {
+	case "", "traefik", "gateway-api", "gateway-api-traefik":
+	default:
+		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", tuiTarget)
+	}
+
+	cfg := tui.Config{
+		Kubeconfig:   kubeconfig,
+		Kubecontext:  kubecontext,
+		Namespace:    namespace,
+		OutputDir:    tuiOutputDir,
+		Source:       annotationSource,
+		ApplyTimeout: tuiApplyTimeout,
+	}
+	return tui.Run(cfg, tuiTarget)
+}
@@ -5,12 +5,16 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"time"
 
 	"github.com/saiyam1814/ing-switch/pkg/server"
 	"github.com/spf13/cobra"
 )
 
-var uiPort int
+var (
+	uiPort         int
+	uiApplyTimeout time.Duration
+)
 
 var uiCmd = &cobra.Command{
 	Use:   "ui",
@@ -29,6 +33,7 @@ The UI provides:
 
 func init() {
 	uiCmd.Flags().IntVar(&uiPort, "port", 8080, "Port for the local web UI")
+	uiCmd.Flags().DurationVar(&uiApplyTimeout, "apply-timeout", 60*time.Second, "Maximum time a single kubectl apply from the UI may run")
 	rootCmd.AddCommand(uiCmd)
 }
 
@@ -44,6 +49,8 @@ func runUI() error {
 	go openBrowser(url)
 
 	srv := server.NewServer(addr, kubeconfig, kubecontext)
+	srv.SetApplyTimeout(uiApplyTimeout)
+	srv.SetAnnotationSource(annotationSource)
 	return srv.Start()
 }
 
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/saiyam1814/ing-switch/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the ing-switch version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVersion()
+	},
+}
+
+func init() {
+	versionCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table|json")
+	rootCmd.AddCommand(versionCmd)
+}
+
+func runVersion() error {
+	if outputFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(version.Get())
+	}
+
+	fmt.Printf("ing-switch %s\n", version.Version)
+	fmt.Printf("  commit: %s\n", version.Commit)
+	fmt.Printf("  built:  %s\n", version.Date)
+	return nil
+}
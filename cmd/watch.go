@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/spf13/cobra"
+)
+
+// watchDebounce coalesces bursts of Ingress changes (e.g. kubectl apply of a
+// whole directory) into a single re-analysis.
+const watchDebounce = 2 * time.Second
+
+var watchTarget string
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Continuously report migration readiness as Ingresses change",
+	Long: `Analyzes every Ingress for the target, prints a readiness summary, then
+watches Ingresses and re-runs the analysis on every change, printing an
+updated summary line and the ingresses whose status changed. Runs until
+interrupted (Ctrl-C).
+
+Only Ingress changes trigger a refresh; other sources (IngressRoutes, Kong
+and Istio resources) are re-read with each refresh.
+
+Examples:
+  ing-switch watch --target traefik
+  ing-switch watch --target gateway-api -n production`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWatch()
+	},
+}
+
+func init() {
+	watchCmd.Flags().StringVar(&watchTarget, "target", "", "Target controller: traefik|gateway-api|gateway-api-traefik (required)")
+	watchCmd.MarkFlagRequired("target")
+	rootCmd.AddCommand(watchCmd)
+}
+
+func runWatch() error {
+	switch watchTarget {
+	case "traefik", "gateway-api", "gateway-api-traefik":
+	default:
+		return fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", watchTarget)
+	}
+
+	s, err := newScanner()
+	if err != nil {
+		return fmt.Errorf("connecting to cluster: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	changed := make(chan struct{}, 1)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- s.WatchIngresses(ctx, namespace, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+	}()
+
+	bannerf("\n  ing-switch — Migration Readiness (target: %s)\n", watchTarget)
+	bannerf("  Watching Ingresses, Ctrl-C to stop\n\n")
+
+	var previous *analyzer.AnalysisReport
+	refresh := func() {
+		result, err := scanCluster(s)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %s  scan failed: %v\n", time.Now().Format("15:04:05"), err)
+			return
+		}
+		report := analyzer.NewAnalyzer(watchTarget).Analyze(result)
+		printReadinessLine(report, previous)
+		previous = report
+	}
+	refresh()
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			bannerf("\n")
+			return nil
+		case err := <-watchErr:
+			return err
+		case <-changed:
+			if debounce == nil {
+				debounce = time.After(watchDebounce)
+			}
+		case <-debounce:
+			debounce = nil
+			refresh()
+		}
+	}
+}
+
+// printReadinessLine prints one timestamped summary of report, then the
+// ingresses added, removed, or with a different status than in previous.
+func printReadinessLine(report, previous *analyzer.AnalysisReport) {
+	sum := report.Summary
+	fmt.Printf("  %s  %d ingress(es)   %s   %s   %s\n",
+		time.Now().Format("15:04:05"), sum.Total,
+		colorGreen(fmt.Sprintf("ready %d", sum.FullyCompatible)),
+		colorYellow(fmt.Sprintf("workaround %d", sum.NeedsWorkaround)),
+		colorRed(fmt.Sprintf("breaking %d", sum.HasUnsupported)))
+	if previous == nil {
+		return
+	}
+
+	before := readinessByIngress(previous)
+	after := readinessByIngress(report)
+	var changes []string
+	for key, status := range after {
+		switch old, ok := before[key]; {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("+ %s  %s", key, status))
+		case old != status:
+			changes = append(changes, fmt.Sprintf("~ %s  %s → %s", key, old, status))
+		}
+	}
+	for key, status := range before {
+		if _, ok := after[key]; !ok {
+			changes = append(changes, fmt.Sprintf("- %s  %s", key, status))
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i][2:] < changes[j][2:] })
+	for _, c := range changes {
+		fmt.Printf("              %s\n", c)
+	}
+}
+
+// readinessByIngress maps "namespace/name" to each ingress's overall status.
+func readinessByIngress(report *analyzer.AnalysisReport) map[string]string {
+	m := make(map[string]string, len(report.IngressReports))
+	for _, ir := range report.IngressReports {
+		m[ir.Namespace+"/"+ir.Name] = ir.OverallStatus
+	}
+	return m
+}
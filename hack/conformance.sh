@@ -0,0 +1,15 @@
+#!/usr/bin/env bash
+# Validates every generator's output against the vendored CRD schemas: a
+# wrapper around the test/conformance Go test (see hack/update-crds.sh for
+# the schemas).
+#
+# Usage: hack/conformance.sh [target...]   (default: all targets)
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+run='^TestGeneratedManifests$'
+if [ $# -gt 0 ]; then
+  run="^TestGeneratedManifests\$/^($(IFS='|'; echo "$*"))\$"
+fi
+exec go test ./test/conformance -run "$run" -count=1 -v
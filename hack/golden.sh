@@ -0,0 +1,18 @@
+#!/usr/bin/env bash
+# Golden-file check for every generator: runs TestGolden, which migrates the
+# examples/ manifests offline for each target and compares the output with
+# testdata/golden/<target>/, and TestMigrateEmptyScan, which checks that an
+# empty input ends with "nothing to migrate" and no output directory.
+# go test ./... runs both as well.
+#
+# Usage:
+#   hack/golden.sh            compare against testdata/golden
+#   hack/golden.sh -update    regenerate testdata/golden after an intended change
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+if [ "${1:-}" = "-update" ]; then
+  exec go test ./cmd -run '^TestGolden$' -count=1 -update
+fi
+exec go test ./cmd -run '^(TestGolden|TestMigrateEmptyScan)$' -count=1
@@ -0,0 +1,48 @@
+#!/usr/bin/env bash
+# Vendors the upstream CRDs the conformance test (test/conformance) validates
+# generated manifests against, from the Go module proxy. Keep the versions in
+# step with the ones the install scripts deploy.
+#
+# Usage: hack/update-crds.sh
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+GATEWAY_API_VERSION="${GATEWAY_API_VERSION:-v1.5.0}"
+ENVOY_GATEWAY_VERSION="${ENVOY_GATEWAY_VERSION:-v1.7.1}"
+TRAEFIK_VERSION="${TRAEFIK_VERSION:-v3.7.13}"
+GOPROXY_URL="${GOPROXY_URL:-https://proxy.golang.org}"
+
+DEST=test/conformance/testdata/crds
+WORK="$(mktemp -d)"
+trap 'rm -rf "$WORK"' EXIT
+
+# fetch <dir> <module> <version> <path in module>... copies each file to $DEST/<dir>/
+fetch() {
+  local dir="$DEST/$1" module="$2" version="$3"
+  shift 3
+  curl -sfL -o "$WORK/module.zip" "$GOPROXY_URL/$module/@v/$version.zip"
+  mkdir -p "$dir"
+  for path in "$@"; do
+    unzip -p "$WORK/module.zip" "$module@$version/$path" >"$dir/$(basename "$path")"
+  done
+  echo "$module $version" >"$dir/VERSION"
+}
+
+rm -rf "$DEST"
+fetch gateway-api sigs.k8s.io/gateway-api "$GATEWAY_API_VERSION" \
+  config/crd/standard/gateway.networking.k8s.io_gatewayclasses.yaml \
+  config/crd/standard/gateway.networking.k8s.io_gateways.yaml \
+  config/crd/standard/gateway.networking.k8s.io_httproutes.yaml \
+  config/crd/standard/gateway.networking.k8s.io_grpcroutes.yaml \
+  config/crd/standard/gateway.networking.k8s.io_referencegrants.yaml \
+  config/crd/experimental/gateway.networking.k8s.io_tcproutes.yaml \
+  config/crd/experimental/gateway.networking.k8s.io_udproutes.yaml
+fetch envoy-gateway github.com/envoyproxy/gateway "$ENVOY_GATEWAY_VERSION" \
+  charts/gateway-helm/crds/generated/gateway.envoyproxy.io_backends.yaml \
+  charts/gateway-helm/crds/generated/gateway.envoyproxy.io_backendtrafficpolicies.yaml \
+  charts/gateway-helm/crds/generated/gateway.envoyproxy.io_clienttrafficpolicies.yaml \
+  charts/gateway-helm/crds/generated/gateway.envoyproxy.io_securitypolicies.yaml
+fetch traefik github.com/traefik/traefik/v3 "$TRAEFIK_VERSION" \
+  docs/content/reference/dynamic-configuration/kubernetes-crd-definition-v1.yml
+echo "updated $DEST"
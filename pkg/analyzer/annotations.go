@@ -12,6 +12,7 @@ var KnownAnnotations = []AnnotationDef{
 	// TLS / Redirect
 	{Key: "ssl-redirect", Category: "tls", Description: "Redirect HTTP to HTTPS"},
 	{Key: "force-ssl-redirect", Category: "tls", Description: "Force HTTPS redirect (ignore x-forwarded-proto)"},
+	{Key: "use-port-in-redirects", Category: "tls", Description: "HTTPS port named in SSL redirects (use-port-in-redirects and --https-port of the ingress-nginx controller)"},
 	{Key: "ssl-passthrough", Category: "tls", Description: "Pass SSL directly to backend"},
 	{Key: "ssl-ciphers", Category: "tls", Description: "Custom SSL cipher list"},
 	{Key: "auth-tls-secret", Category: "tls", Description: "Client certificate authentication"},
@@ -38,10 +39,12 @@ var KnownAnnotations = []AnnotationDef{
 	{Key: "auth-keepalive-timeout", Category: "auth", Description: "Idle timeout for keepalive to auth service"},
 	{Key: "auth-proxy-set-headers", Category: "auth", Description: "ConfigMap of extra headers to send to auth service"},
 	{Key: "auth-snippet", Category: "auth", Description: "Custom NGINX snippet for auth location block"},
+	{Key: "auth-request-headers", Category: "auth", Description: "Only request headers sent to external auth, from an auth-snippet (proxy_pass_request_headers off)"},
 	{Key: "auth-always-set-cookie", Category: "auth", Description: "Always set cookies from auth service, even on deny"},
 	{Key: "auth-signin", Category: "auth", Description: "URL to redirect to on 401 from auth service"},
 	{Key: "auth-signin-redirect-param", Category: "auth", Description: "URL param name for signin redirect"},
 	{Key: "enable-global-auth", Category: "auth", Description: "Enable/disable global external auth for this ingress"},
+	{Key: "global-auth", Category: "auth", Description: "Global external auth (global-auth-url in the ingress-nginx ConfigMap) applied to this ingress"},
 
 	// Routing
 	{Key: "rewrite-target", Category: "routing", Description: "Rewrite the request URL"},
@@ -52,6 +55,7 @@ var KnownAnnotations = []AnnotationDef{
 	{Key: "temporal-redirect", Category: "routing", Description: "Temporary redirect URL"},
 	{Key: "temporal-redirect-code", Category: "routing", Description: "Custom status code for temporal redirect (default 302)"},
 	{Key: "preserve-trailing-slash", Category: "routing", Description: "Preserve trailing slash on SSL redirect"},
+	{Key: "from-to-www-redirect", Category: "routing", Description: "Redirect between www and non-www hostnames"},
 	{Key: "server-snippet", Category: "routing", Description: "NGINX server block snippet (UNSUPPORTED)"},
 	{Key: "configuration-snippet", Category: "routing", Description: "NGINX configuration snippet (UNSUPPORTED)"},
 
@@ -67,6 +71,7 @@ var KnownAnnotations = []AnnotationDef{
 	{Key: "session-cookie-secure", Category: "affinity", Description: "Secure flag for session cookie"},
 	{Key: "session-cookie-domain", Category: "affinity", Description: "Domain attribute for session cookie"},
 	{Key: "affinity-canary-behavior", Category: "affinity", Description: "Canary behavior with session affinity (sticky/legacy)"},
+	{Key: "session-cookie-change-on-failure", Category: "affinity", Description: "Re-issue session cookie when the upstream fails"},
 
 	// Rate Limiting
 	{Key: "limit-rps", Category: "ratelimit", Description: "Rate limit: requests per second"},
@@ -83,6 +88,7 @@ var KnownAnnotations = []AnnotationDef{
 	{Key: "proxy-send-timeout", Category: "proxy", Description: "Backend send timeout"},
 	{Key: "proxy-connect-timeout", Category: "proxy", Description: "Backend connection timeout"},
 	{Key: "proxy-buffering", Category: "proxy", Description: "Enable/disable proxy buffering"},
+	{Key: "proxy-request-buffering", Category: "proxy", Description: "Enable/disable request body buffering"},
 	{Key: "proxy-buffer-size", Category: "proxy", Description: "Proxy buffer size"},
 	{Key: "proxy-next-upstream", Category: "proxy", Description: "Next upstream retry conditions"},
 	{Key: "proxy-next-upstream-timeout", Category: "proxy", Description: "Timeout for trying next upstream (0=disabled)"},
@@ -95,6 +101,8 @@ var KnownAnnotations = []AnnotationDef{
 	{Key: "proxy-ssl-verify", Category: "proxy", Description: "Enable backend TLS certificate verification"},
 	{Key: "proxy-ssl-verify-depth", Category: "proxy", Description: "Backend certificate chain verification depth"},
 	{Key: "proxy-ssl-server-name", Category: "proxy", Description: "Send SNI server name to backend via TLS"},
+	{Key: "secure-verify-ca-secret", Category: "proxy", Description: "CA secret used to verify HTTPS backend certificates"},
+	{Key: "upstream-vhost", Category: "proxy", Description: "Override the Host header sent to the upstream"},
 	{Key: "proxy-cookie-domain", Category: "proxy", Description: "Rewrite Domain in upstream Set-Cookie headers"},
 	{Key: "proxy-cookie-path", Category: "proxy", Description: "Rewrite Path in upstream Set-Cookie headers"},
 	{Key: "proxy-redirect-from", Category: "proxy", Description: "Source for proxy_redirect (rewrite Location headers)"},
@@ -115,6 +123,9 @@ var KnownAnnotations = []AnnotationDef{
 	// Headers
 	{Key: "configuration-snippet", Category: "headers", Description: "Custom NGINX config (UNSUPPORTED)"},
 	{Key: "custom-headers", Category: "headers", Description: "Custom response headers from ConfigMap"},
+	{Key: "custom-headers-remove", Category: "headers", Description: "Response headers removed by the configuration-snippet (more_clear_headers, proxy_hide_header)"},
+	{Key: "custom-request-headers-remove", Category: "headers", Description: "Request headers removed by the configuration-snippet (more_clear_input_headers, empty proxy_set_header)"},
+	{Key: "custom-request-headers", Category: "headers", Description: "Request headers set by the configuration-snippet (proxy_set_header)"},
 	{Key: "whitelist-source-range", Category: "access", Description: "Allowed IP/CIDR ranges"},
 	{Key: "denylist-source-range", Category: "access", Description: "Blocked IP/CIDR ranges"},
 
@@ -144,13 +155,16 @@ var KnownAnnotations = []AnnotationDef{
 	{Key: "x-forwarded-prefix", Category: "misc", Description: "Override X-Forwarded-Prefix header value"},
 	{Key: "connection-proxy-header", Category: "misc", Description: "Custom Connection header for proxy"},
 	{Key: "enable-owasp-modsecurity-crs", Category: "misc", Description: "Enable OWASP Core Rule Set for ModSecurity"},
+	{Key: "enable-owasp-core-rules", Category: "misc", Description: "Load the OWASP ModSecurity Core Rule Set for this ingress"},
+	{Key: "lua-resty-waf", Category: "misc", Description: "Enable the deprecated lua-resty-waf WAF (active/simulate)"},
 
 	// WebSocket / gRPC
 	{Key: "websocket-services", Category: "protocol", Description: "Services that use WebSocket"},
 	{Key: "grpc-backend", Category: "protocol", Description: "Enable gRPC passthrough"},
-	{Key: "backend-protocol", Category: "protocol", Description: "Backend protocol (GRPC/GRPCS/HTTPS/AJP)"},
+	{Key: "backend-protocol", Category: "protocol", Description: "Backend protocol (HTTP/HTTPS/GRPC/GRPCS/AJP/FCGI)"},
 	{Key: "upstream-hash-by", Category: "lb", Description: "Hash-based load balancing key"},
 	{Key: "load-balance", Category: "lb", Description: "Load balancing algorithm"},
+	{Key: "service-upstream", Category: "lb", Description: "Route to the Service ClusterIP instead of pod endpoints"},
 }
 
 // AnnotationsByKey provides O(1) lookup.
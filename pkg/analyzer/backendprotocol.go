@@ -0,0 +1,47 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// adapterFailures describes how requests fail when the new controller sends
+// plain HTTP to a backend that only speaks the backend-protocol value.
+var adapterFailures = map[string]string{
+	"AJP": "the controller sends HTTP to Tomcat's AJP connector (port 8009 by default), which reads it as a malformed AJP packet " +
+		"and closes the connection: every request fails with a connection reset and clients get 502 Bad Gateway",
+	"FCGI": "the controller sends HTTP to the FastCGI server (e.g. PHP-FPM on port 9000), which reads it as a malformed FastCGI record " +
+		"and drops the connection: every request fails with a connection reset and clients get 502 Bad Gateway",
+}
+
+// IsAdapterBackendProtocol reports whether a backend-protocol value is FCGI
+// (FastCGI) or AJP, which ingress-nginx speaks but Traefik and Gateway API
+// implementations do not.
+func IsAdapterBackendProtocol(value string) bool {
+	_, ok := adapterFailures[strings.ToUpper(strings.TrimSpace(value))]
+	return ok
+}
+
+// BackendProtocolWarnings flags the backends of an Ingress whose
+// backend-protocol neither target speaks. The generated routes apply
+// cleanly and point at the same Service port, so nothing fails until
+// traffic arrives.
+func BackendProtocolWarnings(ing scanner.IngressInfo, target string) []string {
+	value := ing.NginxAnnotations["backend-protocol"]
+	if !IsAdapterBackendProtocol(value) {
+		return nil
+	}
+	protocol := strings.ToUpper(strings.TrimSpace(value))
+	controller := "Gateway API implementations"
+	if target == "traefik" {
+		controller = "Traefik"
+	}
+	var backends []string
+	for _, svc := range ing.Services {
+		backends = append(backends, fmt.Sprintf("%s/%s:%d", svc.Namespace, svc.Name, svc.Port))
+	}
+	return []string{fmt.Sprintf("backend-protocol %s: %s do not speak %s, so for %s %s — serve HTTP from the backend or put an HTTP→%s adapter in front of it before cutover",
+		protocol, controller, protocol, strings.Join(backends, ", "), adapterFailures[protocol], protocol)}
+}
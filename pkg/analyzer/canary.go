@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// CanaryWarnings checks canary-weight settings that ingress-nginx tolerates
+// but that turn into surprising weighted backends after migration: weights
+// that are not integers or fall outside 0..canary-weight-total, and several
+// canary Ingresses on one host and path whose weights add up to more than
+// the whole. Results are keyed by "namespace/name".
+func CanaryWarnings(ingresses []scanner.IngressInfo) map[string][]string {
+	warnings := make(map[string][]string)
+
+	type canaryShare struct {
+		key   string
+		share float64 // weight / total
+	}
+	siblings := make(map[string][]canaryShare) // host+path → canaries
+	var routeOrder []string
+
+	for _, ing := range ingresses {
+		a := ing.NginxAnnotations
+		if a["canary"] != "true" || a["canary-weight"] == "" {
+			continue
+		}
+		key := ing.Namespace + "/" + ing.Name
+
+		total := 100
+		if v := strings.TrimSpace(a["canary-weight-total"]); v != "" {
+			t, err := strconv.Atoi(v)
+			if err != nil || t <= 0 {
+				warnings[key] = append(warnings[key], fmt.Sprintf("canary-weight-total %q is not a positive integer; the default of 100 is used", v))
+			} else {
+				total = t
+			}
+		}
+
+		weight, err := strconv.Atoi(strings.TrimSpace(a["canary-weight"]))
+		switch {
+		case err != nil:
+			warnings[key] = append(warnings[key], fmt.Sprintf("canary-weight %q is not an integer; no weighted backends are generated", a["canary-weight"]))
+			continue
+		case weight < 0:
+			warnings[key] = append(warnings[key], fmt.Sprintf("canary-weight %d is negative; no weighted backends are generated", weight))
+			continue
+		case weight > total:
+			warnings[key] = append(warnings[key], fmt.Sprintf("canary-weight %d exceeds canary-weight-total %d; it is capped, sending all traffic to the canary", weight, total))
+			weight = total
+		}
+
+		for _, p := range ing.Paths {
+			route := p.Host + p.Path
+			if _, seen := siblings[route]; !seen {
+				routeOrder = append(routeOrder, route)
+			}
+			siblings[route] = append(siblings[route], canaryShare{key: key, share: float64(weight) / float64(total)})
+		}
+	}
+
+	for _, route := range routeOrder {
+		canaries := siblings[route]
+		if len(canaries) < 2 {
+			continue
+		}
+		sum := 0.0
+		var keys []string
+		for _, c := range canaries {
+			sum += c.share
+			keys = append(keys, c.key)
+		}
+		if sum <= 1 {
+			continue
+		}
+		sort.Strings(keys)
+		msg := fmt.Sprintf("canary Ingresses %s share %s and their weights add up to %s of traffic; the stable backend would get none",
+			strings.Join(keys, ", "), route, strconv.FormatFloat(sum*100, 'f', -1, 64)+"%")
+		for _, k := range keys {
+			if !slices.Contains(warnings[k], msg) {
+				warnings[k] = append(warnings[k], msg)
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Comparison is the analysis of one scan against several targets, for
+// choosing which controller to migrate to.
+type Comparison struct {
+	Targets     []string               `json:"targets"`
+	Summaries   map[string]Summary     `json:"summaries"` // by target
+	Annotations []AnnotationComparison `json:"annotations"`
+
+	// Recommended is the target with the fewest breaking ingresses (then
+	// the fewest needing workarounds); Reason says why.
+	Recommended string `json:"recommended"`
+	Reason      string `json:"reason"`
+}
+
+// AnnotationComparison is how each target handles one annotation in use.
+type AnnotationComparison struct {
+	Key       string                   `json:"key"`
+	Ingresses int                      `json:"ingresses"` // ingresses that set it
+	Status    map[string]MappingStatus `json:"status"`    // by target, the worst across those ingresses
+}
+
+// statusRank orders statuses from best to worst.
+var statusRank = map[MappingStatus]int{StatusSupported: 0, StatusPartial: 1, StatusUnsupported: 2}
+
+// Compare combines reports for the same scan, one per target, in the order
+// given. Annotations are sorted by how many targets fail to support them
+// fully, then by how many ingresses use them.
+func Compare(reports []*AnalysisReport) *Comparison {
+	c := &Comparison{Summaries: make(map[string]Summary)}
+	byKey := make(map[string]*AnnotationComparison)
+	for _, report := range reports {
+		c.Targets = append(c.Targets, report.Target)
+		c.Summaries[report.Target] = report.Summary
+		for _, ir := range report.IngressReports {
+			seen := make(map[string]bool)
+			for _, m := range ir.Mappings {
+				ac, ok := byKey[m.OriginalKey]
+				if !ok {
+					ac = &AnnotationComparison{Key: m.OriginalKey, Status: make(map[string]MappingStatus)}
+					byKey[m.OriginalKey] = ac
+				}
+				if prev, ok := ac.Status[report.Target]; !ok || statusRank[m.Status] > statusRank[prev] {
+					ac.Status[report.Target] = m.Status
+				}
+				// Ingresses are counted once, from the first report
+				if report == reports[0] && !seen[m.OriginalKey] {
+					seen[m.OriginalKey] = true
+					ac.Ingresses++
+				}
+			}
+		}
+	}
+
+	for _, ac := range byKey {
+		c.Annotations = append(c.Annotations, *ac)
+	}
+	gaps := func(ac AnnotationComparison) int {
+		n := 0
+		for _, st := range ac.Status {
+			n += statusRank[st]
+		}
+		return n
+	}
+	sort.Slice(c.Annotations, func(i, j int) bool {
+		a, b := c.Annotations[i], c.Annotations[j]
+		if gaps(a) != gaps(b) {
+			return gaps(a) > gaps(b)
+		}
+		if a.Ingresses != b.Ingresses {
+			return a.Ingresses > b.Ingresses
+		}
+		return a.Key < b.Key
+	})
+
+	for _, t := range c.Targets {
+		best, s := c.Summaries[c.Recommended], c.Summaries[t]
+		if c.Recommended == "" || s.HasUnsupported < best.HasUnsupported ||
+			(s.HasUnsupported == best.HasUnsupported && s.NeedsWorkaround < best.NeedsWorkaround) {
+			c.Recommended = t
+		}
+	}
+	if c.Recommended != "" {
+		s := c.Summaries[c.Recommended]
+		c.Reason = fmt.Sprintf("%d of %d ingress(es) breaking and %d needing workarounds, the fewest of the compared targets",
+			s.HasUnsupported, s.Total, s.NeedsWorkaround)
+	}
+	return c
+}
@@ -1,5 +1,13 @@
 package analyzer
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
 // MappingStatus represents how well an annotation maps to the target controller.
 type MappingStatus string
 
@@ -27,6 +35,7 @@ var traefikMappings = map[string]struct {
 }{
 	"ssl-redirect":             {StatusSupported, "Middleware (RedirectScheme)", "Generates RedirectScheme middleware"},
 	"force-ssl-redirect":       {StatusSupported, "Middleware (RedirectScheme)", "Permanent redirect to HTTPS"},
+	"use-port-in-redirects":    {StatusSupported, "Middleware (RedirectScheme)", "The redirect keeps ingress-nginx's HTTPS port (port)"},
 	"enable-cors":              {StatusSupported, "Middleware (Headers)", "Generates CORS Headers middleware"},
 	"cors-allow-origin":        {StatusSupported, "Middleware (Headers)", "Part of Headers CORS middleware"},
 	"cors-allow-methods":       {StatusSupported, "Middleware (Headers)", "Part of Headers CORS middleware"},
@@ -49,12 +58,12 @@ var traefikMappings = map[string]struct {
 	"auth-response-headers":    {StatusSupported, "Middleware (ForwardAuth)", "Headers passed after auth"},
 	"auth-request-redirect":    {StatusPartial, "Middleware (ForwardAuth)", "Redirect URL for auth failure"},
 	"auth-type":                {StatusPartial, "Middleware (BasicAuth)", "Basic auth only; digest not supported"},
-	"auth-secret":              {StatusPartial, "Middleware (BasicAuth)", "Secret format differs from NGINX"},
+	"auth-secret":              {StatusPartial, "Middleware (BasicAuth)", "Secret format differs from NGINX — 02-middlewares/auth-secret-convert.sh builds the Traefik secret, prompting for passwords whose hashes Traefik cannot verify"},
 	"auth-realm":               {StatusSupported, "Middleware (BasicAuth)", "Auth realm"},
 	"whitelist-source-range":   {StatusSupported, "Middleware (IPAllowList)", "Generates IPAllowList middleware"},
-	"denylist-source-range":    {StatusSupported, "Middleware (IPDenyList)", "Generates IPDenyList middleware"},
+	"denylist-source-range":    {StatusUnsupported, "", traefikDenyListNote},
 	"custom-headers":           {StatusPartial, "Middleware (Headers)", "ConfigMap ref not supported; inline headers needed"},
-	"rewrite-target":           {StatusSupported, "Middleware (ReplacePath/AddPrefix)", "URL rewrite middleware"},
+	"rewrite-target":           {StatusSupported, "Middleware (ReplacePath/ReplacePathRegex)", "URL rewrite middleware; $N captures use the Ingress path regex"},
 	"use-regex":                {StatusSupported, "Router (native)", "Traefik supports regex routing natively"},
 	"app-root":                 {StatusSupported, "Router + Middleware", "Redirect root path"},
 	"permanent-redirect":       {StatusSupported, "Middleware (RedirectRegex)", "Permanent redirect"},
@@ -72,6 +81,9 @@ var traefikMappings = map[string]struct {
 	"proxy-request-buffering":                  {StatusPartial, "Native (off by default)", "Off is default behavior; enabling request buffering requires Buffering middleware"},
 	"client-body-buffer-size":                  {StatusUnsupported, "", "Impact: NONE. NGINX-internal buffer tuning for request body — Traefik handles request body buffering automatically"},
 	"configuration-snippet":                    {StatusUnsupported, "", "Impact: VARIES. Raw NGINX config injection — inherently non-portable. Review snippet content to find Traefik equivalents per feature"},
+	"custom-headers-remove":                    {StatusSupported, "Middleware (Headers)", "customResponseHeaders set to \"\" removes the header"},
+	"custom-request-headers-remove":            {StatusSupported, "Middleware (Headers)", "customRequestHeaders set to \"\" removes the header"},
+	"custom-request-headers":                   {StatusSupported, "Middleware (Headers)", "customRequestHeaders; values from $remote_addr, $scheme or $host are already forwarded and get a NOTE"},
 	"server-snippet":                           {StatusUnsupported, "", "Impact: VARIES. Raw NGINX server block injection — inherently non-portable. Review snippet content to find Traefik equivalents per feature"},
 	"ssl-passthrough":                          {StatusPartial, "Traefik TCP router", "Requires TCP entrypoint config"},
 	"backend-protocol":                         {StatusPartial, "Service annotation", "HTTPS/GRPC backends need ServersTransport"},
@@ -101,19 +113,21 @@ var traefikMappings = map[string]struct {
 	"auth-tls-match-cn":                        {StatusUnsupported, "", "Impact: MEDIUM. Traefik has no CN regex matching — use cert-manager to issue certs with the right CN or validate in your app"},
 
 	// External auth extras
-	"auth-secret-type":                         {StatusPartial, "Middleware (BasicAuth)", "BasicAuth uses htpasswd format; auth-map format requires manual conversion"},
-	"auth-cache-key":                           {StatusUnsupported, "", "Impact: LOW. Traefik ForwardAuth does not cache responses — every request hits the auth service. Use auth service-side caching instead"},
-	"auth-cache-duration":                      {StatusUnsupported, "", "Impact: LOW. No auth caching in Traefik — adds latency per request but auth behavior is correct"},
+	"auth-secret-type":                         {StatusSupported, "Middleware (BasicAuth)", "auth-file and auth-map secrets are both converted to htpasswd by 02-middlewares/auth-secret-convert.sh"},
+	"auth-cache-key":                           {StatusUnsupported, "", "Impact: LOW. Traefik ForwardAuth does not cache responses — every request hits the auth service. Cache in the auth service, or use a caching ForwardAuth plugin"},
+	"auth-cache-duration":                      {StatusUnsupported, "", "Impact: LOW. No auth caching in Traefik — adds latency per request but auth behavior is correct. Cache in the auth service, or use a caching ForwardAuth plugin"},
 	"auth-keepalive":                           {StatusUnsupported, "", "Impact: NONE. NGINX-internal optimization — Traefik manages its own connection pooling automatically"},
 	"auth-keepalive-share-vars":                {StatusUnsupported, "", "Impact: NONE. NGINX-internal variable sharing — not applicable to Traefik architecture"},
 	"auth-keepalive-requests":                  {StatusUnsupported, "", "Impact: NONE. NGINX-internal optimization — Traefik handles connection reuse automatically"},
 	"auth-keepalive-timeout":                   {StatusUnsupported, "", "Impact: NONE. NGINX-internal timeout — Traefik manages connection lifecycle automatically"},
 	"auth-proxy-set-headers":                   {StatusPartial, "Middleware (ForwardAuth)", "ForwardAuth supports authRequestHeaders but reads from middleware config, not ConfigMap"},
-	"auth-snippet":                             {StatusUnsupported, "", "Impact: VARIES. NGINX-specific config snippet — review the snippet content to determine if Traefik has an equivalent feature"},
+	"auth-snippet":                             {StatusUnsupported, "", "Impact: VARIES. Raw NGINX in the auth subrequest — may add credentials or headers the auth service relies on. Header pass-through with proxy_pass_request_headers off is translated to ForwardAuth authRequestHeaders; review the rest"},
+	"auth-request-headers":                     {StatusSupported, "Middleware (ForwardAuth)", "authRequestHeaders"},
 	"auth-always-set-cookie":                   {StatusUnsupported, "", "Impact: LOW. Traefik ForwardAuth always forwards response headers including Set-Cookie — this is default behavior"},
 	"auth-signin":                              {StatusPartial, "Middleware (ForwardAuth)", "ForwardAuth can handle redirects but auth-signin-specific behavior requires custom auth service logic"},
 	"auth-signin-redirect-param":               {StatusUnsupported, "", "Impact: LOW. Controls the query param name for redirect URL — configure this in your auth service instead"},
-	"enable-global-auth":                       {StatusPartial, "Middleware (ForwardAuth)", "Traefik uses middleware chains — add/remove ForwardAuth from the chain to enable/disable per-ingress"},
+	"enable-global-auth":                       {StatusSupported, "Middleware (ForwardAuth)", "\"false\" opts out of global auth: no ForwardAuth middleware is generated for it"},
+	"global-auth":                              {StatusSupported, "Middleware (ForwardAuth)", "global-auth-url from this ingress-nginx ConfigMap — generated as this ingress's ForwardAuth middleware"},
 
 	// Redirect code customization
 	"permanent-redirect-code":                  {StatusPartial, "Middleware (RedirectRegex)", "RedirectRegex supports custom status codes; default 301"},
@@ -125,8 +139,8 @@ var traefikMappings = map[string]struct {
 	"affinity-canary-behavior":                 {StatusUnsupported, "", "Impact: LOW. Controls NGINX canary+affinity interaction — Traefik weighted services handle this differently but produce similar results"},
 
 	// Rate limiting extras
-	"limit-rate":                               {StatusUnsupported, "", "Impact: LOW. Response-rate limiting (KB/s) — Traefik rate limiting is request-based, not response-bandwidth based. Rarely needed outside file downloads"},
-	"limit-rate-after":                         {StatusUnsupported, "", "Impact: LOW. Threshold before response rate limiting — same as limit-rate, not applicable to Traefik's request-based model"},
+	"limit-rate":                               {StatusUnsupported, "", "Impact: LOW. Response bandwidth per connection (KB/s) — Traefik has no bandwidth throttling (RateLimit counts requests), so responses are sent at full speed and large downloads no longer share bandwidth fairly. Throttle in the application or at the load balancer"},
+	"limit-rate-after":                         {StatusUnsupported, "", "Impact: LOW. KB sent at full speed before limit-rate applies — dropped with limit-rate, since Traefik has no bandwidth throttling"},
 
 	// Proxy SSL / Backend TLS
 	"proxy-ssl-ciphers":                        {StatusPartial, "ServersTransport CRD", "ServersTransport supports TLS cipher config for backend connections"},
@@ -135,6 +149,7 @@ var traefikMappings = map[string]struct {
 	"proxy-ssl-verify":                         {StatusPartial, "ServersTransport CRD", "ServersTransport insecureSkipVerify=false enables backend cert verification"},
 	"proxy-ssl-verify-depth":                   {StatusUnsupported, "", "Impact: LOW. Traefik uses full chain verification — no depth limit needed in most setups"},
 	"proxy-ssl-server-name":                    {StatusPartial, "ServersTransport CRD", "SNI is sent automatically when serverName is configured in ServersTransport"},
+	"proxy-ssl-secret":                         {StatusSupported, "ServersTransport CRD", "Generated ServersTransport certificatesSecrets presents the client certificate to the backend (mTLS); a script points the backend Services at it"},
 
 	// Proxy cookie rewriting
 	"proxy-cookie-domain":                      {StatusUnsupported, "", "Impact: MEDIUM. No Traefik equivalent for Set-Cookie domain rewriting — handle in your application or use Headers middleware to strip/replace"},
@@ -152,7 +167,7 @@ var traefikMappings = map[string]struct {
 	// Proxy buffer extras
 	"proxy-buffers-number":                     {StatusUnsupported, "", "Impact: NONE. NGINX-internal buffer tuning — Traefik handles buffering automatically with no user-facing knobs"},
 	"proxy-busy-buffers-size":                  {StatusUnsupported, "", "Impact: NONE. NGINX-internal buffer tuning — not applicable to Traefik architecture"},
-	"proxy-buffer-size":                        {StatusUnsupported, "", "Impact: NONE. NGINX-internal buffer tuning — Traefik handles response buffering automatically"},
+	"proxy-buffer-size":                        {StatusUnsupported, "", "Impact: NONE. Usually raised to fix NGINX's 502 'upstream sent too big header' — Traefik accepts response headers up to 10 MB (Go default), so large cookies/JWTs keep working with no setting"},
 
 	// Canary extras
 	"canary-by-header-pattern":                 {StatusPartial, "Router rules (regex)", "Traefik router rules support HeaderRegexp matcher for regex header matching"},
@@ -166,11 +181,11 @@ var traefikMappings = map[string]struct {
 	"enable-opentelemetry":                     {StatusPartial, "Traefik static config", "OpenTelemetry tracing is global in Traefik static config; cannot toggle per-ingress"},
 
 	// Request mirroring
-	"mirror-target":                            {StatusPartial, "Middleware (Mirroring)", "Traefik Mirroring middleware supports traffic mirroring to additional services"},
-	"mirror-request-body":                      {StatusPartial, "Middleware (Mirroring)", "Mirroring middleware includes body by default; no toggle"},
+	"mirror-target":                            {StatusPartial, "TraefikService (Mirroring) + IngressRoute", "Mirroring TraefikService per backend, served by an IngressRoute that takes precedence over the Ingress; the mirror must be a cluster Service"},
+	"mirror-request-body":                      {StatusSupported, "TraefikService (Mirroring)", "off → mirrorBody: false"},
 
 	// Misc
-	"server-alias":                             {StatusPartial, "Ingress spec.rules", "Add additional hosts in Ingress spec.rules[].host — not an annotation concept in Traefik"},
+	"server-alias":                             {StatusSupported, "Ingress spec.rules", "Each alias becomes an Ingress rule host with the paths of the first rule host"},
 	"satisfy":                                  {StatusUnsupported, "", "Impact: LOW. NGINX any/all auth satisfaction — Traefik middleware chains always require all to pass (AND logic). Rarely used with 'any'"},
 	"enable-modsecurity":                       {StatusUnsupported, "", "Impact: MEDIUM. No built-in WAF in Traefik — use Traefik plugin ecosystem (e.g., traefik-modsecurity-plugin) or external WAF"},
 	"modsecurity-snippet":                      {StatusUnsupported, "", "Impact: MEDIUM. Requires WAF plugin — see enable-modsecurity note"},
@@ -178,6 +193,8 @@ var traefikMappings = map[string]struct {
 	"x-forwarded-prefix":                       {StatusSupported, "Middleware (Headers)", "Headers middleware can set X-Forwarded-Prefix; Traefik also auto-sets this with StripPrefix"},
 	"connection-proxy-header":                  {StatusUnsupported, "", "Impact: NONE. NGINX-internal Connection header override — Traefik handles WebSocket upgrade and connection headers automatically"},
 	"enable-owasp-modsecurity-crs":             {StatusUnsupported, "", "Impact: MEDIUM. Requires WAF plugin — see enable-modsecurity note"},
+	"enable-owasp-core-rules":                  {StatusUnsupported, "", "Impact: HIGH. OWASP CRS protection is lost — install the Coraza WAF plugin (bundles CRS) or an external WAF before cutover"},
+	"lua-resty-waf":                            {StatusUnsupported, "", "Impact: HIGH. Deprecated Lua WAF with no Traefik equivalent — replace with the Coraza WAF plugin or an external WAF before cutover"},
 	"ssl-ciphers":                              {StatusPartial, "TLSOption CRD", "TLSOption CRD supports cipher suite configuration"},
 }
 
@@ -189,7 +206,8 @@ var gatewayAPIMappings = map[string]struct {
 }{
 	"ssl-redirect":           {StatusSupported, "HTTPRoute (RequestRedirect filter)", "RequestRedirect filter with scheme=https"},
 	"force-ssl-redirect":     {StatusSupported, "HTTPRoute (RequestRedirect filter)", "301 redirect to HTTPS"},
-	"rewrite-target":         {StatusSupported, "HTTPRoute (URLRewrite filter)", "Path rewrite via URLRewrite filter"},
+	"use-port-in-redirects":  {StatusSupported, "HTTPRoute (RequestRedirect filter)", "The redirect keeps ingress-nginx's HTTPS port (requestRedirect.port)"},
+	"rewrite-target":         {StatusSupported, "HTTPRoute (URLRewrite filter)", "Path rewrite via URLRewrite filter; prefix + $N capture idioms become ReplacePrefixMatch"},
 	"custom-headers":         {StatusSupported, "HTTPRoute (ResponseHeaderModifier)", "Response header manipulation filter"},
 	"canary":                 {StatusSupported, "HTTPRoute (weighted backendRefs)", "Traffic split via backendRefs weights"},
 	"canary-weight":          {StatusSupported, "HTTPRoute (weighted backendRefs)", "Weight value in backendRefs"},
@@ -216,10 +234,13 @@ var gatewayAPIMappings = map[string]struct {
 	"temporal-redirect":      {StatusSupported, "HTTPRoute (RequestRedirect)", "302 redirect filter"},
 	"backend-protocol":       {StatusPartial, "Gateway TLS config", "TLS backend via Gateway listener config"},
 	"websocket-services":     {StatusSupported, "Native", "Gateway API supports WebSocket natively"},
-	"grpc-backend":           {StatusSupported, "GRPCRoute", "Dedicated GRPCRoute resource"},
+	"grpc-backend":           {StatusSupported, "GRPCRoute", "GRPCRoute with a method match per /package.Service/Method path"},
 	"proxy-body-size":                          {StatusPartial, "BackendTrafficPolicy (requestBuffer)", "Envoy Gateway BackendTrafficPolicy with requestBuffer.limit"},
 	"proxy-request-buffering":                  {StatusSupported, "Native", "Envoy Gateway streams requests by default (off is the default)"},
 	"configuration-snippet":                    {StatusUnsupported, "", "Impact: VARIES. Raw NGINX config injection — inherently non-portable. Review snippet content to find Gateway API equivalents per feature"},
+	"custom-headers-remove":                    {StatusSupported, "HTTPRoute (ResponseHeaderModifier)", "Listed in the filter's remove"},
+	"custom-request-headers-remove":            {StatusSupported, "HTTPRoute (RequestHeaderModifier)", "Listed in the filter's remove"},
+	"custom-request-headers":                   {StatusSupported, "HTTPRoute (RequestHeaderModifier)", "Listed in the filter's set; values from $remote_addr, $scheme or $host are already forwarded and get a NOTE"},
 	"server-snippet":                           {StatusUnsupported, "", "Impact: VARIES. Raw NGINX server block injection — inherently non-portable. Review snippet content to find Gateway API equivalents per feature"},
 	"auth-type":                                {StatusUnsupported, "", "Impact: MEDIUM. Basic/digest auth — not in core Gateway API. Use externalAuth filter (experimental v1.4) pointing to an auth service that handles basic auth"},
 	"auth-secret":                              {StatusUnsupported, "", "Impact: MEDIUM. Credential secret for basic auth — not in core Gateway API. Move credentials to an external auth service"},
@@ -227,7 +248,7 @@ var gatewayAPIMappings = map[string]struct {
 	"load-balance":                             {StatusUnsupported, "", "Impact: LOW. Gateway API uses implementation-default LB (usually round-robin) — only matters if you specifically need least-connections or random. Round-robin works well for most workloads"},
 	"upstream-hash-by":                         {StatusUnsupported, "", "Impact: MEDIUM. Consistent hash-based LB — not in core Gateway API. Use BackendLBPolicy SessionPersistence as an alternative for session-pinning"},
 	"affinity-mode":                             {StatusPartial, "BackendLBPolicy (SessionPersistence)", "Cookie persistence in BackendLBPolicy; balanced re-balancing unavailable in spec"},
-	"canary-weight-total":                       {StatusSupported, "HTTPRoute (weighted backendRefs)", "Gateway API backendRefs weights are relative; total is implicit"},
+	"canary-weight-total":                       {StatusSupported, "HTTPRoute (weighted backendRefs)", "Stable backendRef weight is set to total - canary-weight so the ratio is preserved"},
 	"proxy-http-version":                        {StatusSupported, "Native", "Envoy Gateway handles HTTP/2 and HTTP/1.1 natively"},
 	"session-cookie-expires":                   {StatusPartial, "BackendLBPolicy (absoluteTimeout)", "BackendLBPolicy cookieConfig.lifetimeType: Permanent + absoluteTimeout"},
 	"session-cookie-max-age":                   {StatusPartial, "BackendLBPolicy (absoluteTimeout)", "BackendLBPolicy cookieConfig.absoluteTimeout field"},
@@ -253,7 +274,7 @@ var gatewayAPIMappings = map[string]struct {
 	// Canary cookie routing
 	"canary-by-cookie": {StatusUnsupported, "", "Impact: MEDIUM. Cookie-based canary routing not in core Gateway API — use header-based canary (canary-by-header) or implementation-specific ExtensionRef"},
 	// App root redirect
-	"app-root": {StatusPartial, "HTTPRoute (URLRewrite)", "URLRewrite on root path can redirect / to the app root; limited to exact path"},
+	"app-root": {StatusSupported, "HTTPRoute (RequestRedirect)", "Exact / match redirected to the app root (302, ReplaceFullPath)"},
 
 	// ── New annotations ──────────────────────────────────────────────────
 	// Client certificate auth
@@ -268,18 +289,20 @@ var gatewayAPIMappings = map[string]struct {
 	"auth-secret-type":                         {StatusUnsupported, "", "Impact: NONE. No basic auth in core Gateway API — auth-secret-type is irrelevant since auth-secret is also unsupported"},
 	"auth-method":                              {StatusPartial, "SecurityPolicy / HTTPRoute externalAuth", "Auth method configurable in SecurityPolicy or externalAuth filter"},
 	"auth-request-redirect":                    {StatusPartial, "SecurityPolicy / HTTPRoute externalAuth", "Redirect on auth failure configurable in externalAuth filter (experimental v1.4)"},
-	"auth-cache-key":                           {StatusUnsupported, "", "Impact: LOW. No auth response caching in Gateway API — add caching in your auth service instead"},
-	"auth-cache-duration":                      {StatusUnsupported, "", "Impact: LOW. No auth caching — every request hits auth service. Adds latency but auth is correct"},
+	"auth-cache-key":                           {StatusUnsupported, "", "Impact: LOW. SecurityPolicy extAuth does not cache auth responses — add caching in your auth service instead"},
+	"auth-cache-duration":                      {StatusUnsupported, "", "Impact: LOW. No auth caching — every request hits auth service. Adds latency but auth is correct; cache in the auth service"},
 	"auth-keepalive":                           {StatusUnsupported, "", "Impact: NONE. NGINX-internal connection pooling — Gateway API implementations manage connections automatically"},
 	"auth-keepalive-share-vars":                {StatusUnsupported, "", "Impact: NONE. NGINX-internal variable sharing — not applicable to Gateway API architecture"},
 	"auth-keepalive-requests":                  {StatusUnsupported, "", "Impact: NONE. NGINX-internal optimization — not applicable"},
 	"auth-keepalive-timeout":                   {StatusUnsupported, "", "Impact: NONE. NGINX-internal timeout — not applicable"},
 	"auth-proxy-set-headers":                   {StatusPartial, "SecurityPolicy / HTTPRoute externalAuth", "externalAuth filter supports headersToBackend for forwarding custom headers"},
-	"auth-snippet":                             {StatusUnsupported, "", "Impact: VARIES. NGINX-specific snippet — review content to determine if Gateway API has equivalent features"},
+	"auth-snippet":                             {StatusUnsupported, "", "Impact: VARIES. Raw NGINX in the auth subrequest — may add credentials or headers the auth service relies on. Header pass-through with proxy_pass_request_headers off is translated to extAuth headersToExtAuth; review the rest"},
+	"auth-request-headers":                     {StatusSupported, "SecurityPolicy (extAuth)", "headersToExtAuth (authRequestHeaders on the Traefik provider)"},
 	"auth-always-set-cookie":                   {StatusUnsupported, "", "Impact: LOW. Behavior depends on implementation — most Gateway API implementations forward auth response headers including Set-Cookie"},
 	"auth-signin":                              {StatusPartial, "SecurityPolicy / HTTPRoute externalAuth", "Auth redirect configurable via externalAuth filter redirectURL (experimental)"},
 	"auth-signin-redirect-param":               {StatusUnsupported, "", "Impact: LOW. Configure redirect param name in your auth service instead"},
-	"enable-global-auth":                       {StatusUnsupported, "", "Impact: LOW. Gateway API has no global auth concept — apply SecurityPolicy per-route or use a mesh-level policy"},
+	"enable-global-auth":                       {StatusSupported, "SecurityPolicy / HTTPRoute externalAuth", "\"false\" opts out of global auth: no ext-auth is generated for this route"},
+	"global-auth":                              {StatusSupported, "SecurityPolicy / HTTPRoute externalAuth", "global-auth-url from this ingress-nginx ConfigMap — generated as this route's ext-auth, since Gateway API has no global auth"},
 	"auth-realm":                               {StatusUnsupported, "", "Impact: NONE. No basic auth in core Gateway API — realm is irrelevant"},
 
 	// Redirect code customization
@@ -292,11 +315,11 @@ var gatewayAPIMappings = map[string]struct {
 	"affinity-canary-behavior":                 {StatusUnsupported, "", "Impact: LOW. Gateway API weighted backendRefs don't have affinity interaction — traffic splitting is stateless by default"},
 
 	// Rate limiting extras
-	"limit-rate":                               {StatusUnsupported, "", "Impact: LOW. Response bandwidth limiting (KB/s) — Gateway API rate limiting is request-count based. Rarely needed outside large file downloads"},
-	"limit-rate-after":                         {StatusUnsupported, "", "Impact: LOW. Threshold before response rate limiting — same as limit-rate, not applicable"},
+	"limit-rate":                               {StatusUnsupported, "", "Impact: LOW. Response bandwidth per connection (KB/s) — neither Gateway API nor Envoy Gateway's BackendTrafficPolicy exposes Envoy's bandwidth limit filter (rateLimit counts requests), so responses are sent at full speed. Throttle in the application, or patch the filter in with an EnvoyPatchPolicy"},
+	"limit-rate-after":                         {StatusUnsupported, "", "Impact: LOW. KB sent at full speed before limit-rate applies — dropped with limit-rate; Envoy's bandwidth limit filter has no initial burst"},
 
 	// Proxy SSL / Backend TLS
-	"proxy-ssl-secret":                         {StatusSupported, "BackendTLSPolicy", "BackendTLSPolicy with client certificate for mTLS to backend (GA in v1.4)"},
+	"proxy-ssl-secret":                         {StatusPartial, "Gateway spec.tls.backend", "BackendTLSPolicy only validates the backend — it carries no client certificate. Set Gateway spec.tls.backend.clientCertificateRef (Gateway API v1.4 experimental), which presents one certificate to every backend of the Gateway"},
 	"proxy-ssl-ciphers":                        {StatusUnsupported, "", "Impact: LOW. Gateway API does not expose per-backend cipher selection — implementation uses default TLS stack which covers standard ciphers"},
 	"proxy-ssl-name":                           {StatusSupported, "BackendTLSPolicy", "BackendTLSPolicy hostname field for backend SNI verification"},
 	"proxy-ssl-protocols":                      {StatusUnsupported, "", "Impact: LOW. No per-backend protocol version selection in Gateway API — TLS 1.2+ is default and covers all standard use cases"},
@@ -320,8 +343,8 @@ var gatewayAPIMappings = map[string]struct {
 	// Proxy buffer extras
 	"proxy-buffers-number":                     {StatusUnsupported, "", "Impact: NONE. Implementation-internal buffer tuning — Gateway API abstracts this away. No user impact"},
 	"proxy-busy-buffers-size":                  {StatusUnsupported, "", "Impact: NONE. Implementation-internal buffer tuning — not applicable"},
-	"proxy-buffer-size":                        {StatusUnsupported, "", "Impact: NONE. Implementation-internal buffer tuning — not applicable"},
-	"client-body-buffer-size":                  {StatusUnsupported, "", "Impact: NONE. Implementation-internal buffer tuning — not applicable"},
+	"proxy-buffer-size":                        {StatusPartial, "BackendTrafficPolicy (connection.bufferLimit)", "Envoy Gateway: set as the per-connection buffer limit (not a header buffer). Envoy accepts 60Ki response headers by default, so the NGINX 'too big header' case rarely applies"},
+	"client-body-buffer-size":                  {StatusUnsupported, "", "Impact: NONE. NGINX-internal buffer tuning for request body — Gateway API implementations stream request bodies and buffer automatically"},
 
 	// Canary extras
 	"canary-by-header-pattern":                 {StatusUnsupported, "", "Impact: MEDIUM. Gateway API HTTPRouteMatch only supports exact header matching — regex header matching requires implementation-specific extensions"},
@@ -335,11 +358,11 @@ var gatewayAPIMappings = map[string]struct {
 	"enable-opentelemetry":                     {StatusPartial, "Envoy Gateway ObservabilityPolicy", "Envoy Gateway supports OTLP via BackendTrafficPolicy; not in core Gateway API spec"},
 
 	// Request mirroring
-	"mirror-target":                            {StatusSupported, "HTTPRoute (RequestMirror filter)", "RequestMirror filter is Standard in Gateway API v1.3+ — mirrors traffic to a backendRef"},
-	"mirror-request-body":                      {StatusSupported, "HTTPRoute (RequestMirror filter)", "Request body is included in mirror by default; percentage-based mirroring also available (v1.3)"},
+	"mirror-target":                            {StatusSupported, "HTTPRoute (RequestMirror filter)", "RequestMirror filter to the mirror Service (ReferenceGrant when cross-namespace); external hosts need a Service first"},
+	"mirror-request-body":                      {StatusPartial, "HTTPRoute (RequestMirror filter)", "Request bodies are always mirrored; off has no equivalent"},
 
 	// Misc
-	"server-alias":                             {StatusPartial, "HTTPRoute hostnames", "Add additional hostnames in HTTPRoute spec.hostnames array"},
+	"server-alias":                             {StatusSupported, "HTTPRoute hostnames", "Each alias is added to the HTTPRoute hostnames and the HTTPS listener of the Ingress's TLS secret"},
 	"satisfy":                                  {StatusUnsupported, "", "Impact: LOW. NGINX any/all auth satisfaction logic — Gateway API has no equivalent; all policies apply (AND logic). 'any' mode is rarely used"},
 	"enable-modsecurity":                       {StatusUnsupported, "", "Impact: MEDIUM. No built-in WAF in Gateway API — use implementation-specific extensions or an external WAF"},
 	"modsecurity-snippet":                      {StatusUnsupported, "", "Impact: MEDIUM. Requires WAF support — see enable-modsecurity note"},
@@ -347,23 +370,34 @@ var gatewayAPIMappings = map[string]struct {
 	"x-forwarded-prefix":                       {StatusSupported, "HTTPRoute (RequestHeaderModifier)", "RequestHeaderModifier filter can set X-Forwarded-Prefix header"},
 	"connection-proxy-header":                  {StatusUnsupported, "", "Impact: NONE. NGINX-internal Connection header override — Gateway API implementations handle WebSocket upgrade and connection headers automatically"},
 	"enable-owasp-modsecurity-crs":             {StatusUnsupported, "", "Impact: MEDIUM. Requires WAF support — see enable-modsecurity note"},
+	"enable-owasp-core-rules":                  {StatusUnsupported, "", "Impact: HIGH. OWASP CRS protection is lost — use the Coraza WAF via Envoy Gateway EnvoyExtensionPolicy (Wasm) or an external WAF before cutover"},
+	"lua-resty-waf":                            {StatusUnsupported, "", "Impact: HIGH. Deprecated Lua WAF with no Gateway API equivalent — use the Coraza WAF via EnvoyExtensionPolicy or an external WAF before cutover"},
 	"ssl-ciphers":                              {StatusUnsupported, "", "Impact: LOW. Gateway API does not expose listener cipher configuration — implementations use secure defaults. Only matters for legacy client compatibility"},
 }
 
-// MapAnnotation returns the mapping for a given annotation key and target.
-func MapAnnotation(key, value, target string) AnnotationMapping {
-	var mappings map[string]struct {
-		Status         MappingStatus
-		TargetResource string
-		Note           string
-	}
+// mappingTable is the shape of the per-target annotation mapping tables.
+type mappingTable = map[string]struct {
+	Status         MappingStatus
+	TargetResource string
+	Note           string
+}
 
+// mappingsForTarget returns the mapping table for a target, or false if the
+// target is unknown.
+func mappingsForTarget(target string) (mappingTable, bool) {
 	switch target {
 	case "traefik":
-		mappings = traefikMappings
+		return traefikMappings, true
 	case "gateway-api", "gateway-api-traefik":
-		mappings = gatewayAPIMappings
-	default:
+		return gatewayAPIMappings, true
+	}
+	return nil, false
+}
+
+// MapAnnotation returns the mapping for a given annotation key and target.
+func MapAnnotation(key, value, target string) AnnotationMapping {
+	mappings, ok := mappingsForTarget(target)
+	if !ok {
 		return AnnotationMapping{
 			OriginalKey:   key,
 			OriginalValue: value,
@@ -373,13 +407,18 @@ func MapAnnotation(key, value, target string) AnnotationMapping {
 	}
 
 	if m, ok := mappings[key]; ok {
-		return AnnotationMapping{
+		mapping := AnnotationMapping{
 			OriginalKey:    key,
 			OriginalValue:  value,
 			Status:         m.Status,
 			TargetResource: m.TargetResource,
 			Note:           m.Note,
 		}
+		applyValueOverrides(&mapping, target)
+		return mapping
+	}
+	if mapping, ok := mapF5Annotation(key, value); ok {
+		return mapping
 	}
 
 	return AnnotationMapping{
@@ -389,3 +428,105 @@ func MapAnnotation(key, value, target string) AnnotationMapping {
 		Note:          "Unknown annotation — manual review required",
 	}
 }
+
+// applyValueOverrides adjusts a table mapping when the annotation value itself
+// (or the Gateway API provider sharing the table) changes how well it translates.
+func applyValueOverrides(m *AnnotationMapping, target string) {
+	switch {
+	case m.OriginalKey == "proxy-buffer-size" && target == "gateway-api-traefik":
+		// The table entry describes Envoy Gateway; Traefik has no buffer knob.
+		m.Status = StatusUnsupported
+		m.TargetResource = ""
+		m.Note = "Impact: NONE. Traefik has no buffer size setting and accepts response headers up to 10 MB (Go default), so responses NGINX needed a larger buffer for keep working"
+	case m.OriginalKey == "denylist-source-range" && target == "gateway-api-traefik":
+		// The table entry describes Envoy Gateway's ipFilter policy.
+		m.Status = StatusUnsupported
+		m.TargetResource = ""
+		m.Note = traefikDenyListNote
+	case m.OriginalKey == "backend-protocol" && IsAdapterBackendProtocol(m.OriginalValue):
+		// Listed as a backend-protocol value, but neither target speaks it;
+		// requests reach the backend as plain HTTP.
+		controller := "Gateway API"
+		if target == "traefik" {
+			controller = "Traefik"
+		}
+		protocol := strings.ToUpper(strings.TrimSpace(m.OriginalValue))
+		m.Status = StatusUnsupported
+		m.TargetResource = ""
+		m.Note = fmt.Sprintf("Impact: HIGH. %s backends are not supported by %s: %s. "+
+			"Serve HTTP from the backend, or put a protocol adapter sidecar in front of it", protocol, controller, adapterFailures[protocol])
+	case m.OriginalKey == "use-port-in-redirects" && m.OriginalValue == scanner.RedirectPortUnknown:
+		m.Status = StatusPartial
+		m.Note = "The controller's --https-port could not be read, so the redirect goes to port 443 — set the port by hand if HTTPS is served on another one"
+	case m.OriginalKey == "server-alias":
+		// nginx server_name regexes have no host equivalent on either target
+		if _, regexes := scanner.ServerAliases(m.OriginalValue); len(regexes) > 0 {
+			m.Status = StatusPartial
+			m.Note = fmt.Sprintf("Regular expression aliases (%s) are left out: hosts can only be names or wildcards (*.example.com), "+
+				"so list the hostnames they match as aliases or rule hosts", strings.Join(regexes, ", "))
+		}
+	case (m.OriginalKey == "permanent-redirect" || m.OriginalKey == "temporal-redirect") && redirectHasVariables(m.OriginalValue):
+		m.Status = StatusUnsupported
+		m.TargetResource = ""
+		m.Note = "Impact: HIGH. Only a trailing $request_uri or $uri can be translated; with other nginx variables no redirect is generated " +
+			"and requests are proxied to the backends — write the redirect by hand"
+	case m.OriginalKey == "session-cookie-samesite" && target == "traefik" && !validSameSite(m.OriginalValue):
+		m.Status = StatusPartial
+		m.Note = fmt.Sprintf("%q is not None, Lax or Strict, so the sticky cookie's SameSite is left unset", m.OriginalValue)
+	case m.OriginalKey == "cors-allow-origin" && target != "traefik" && len(scanner.SplitList(m.OriginalValue)) > 1:
+		// A static ResponseHeaderModifier can only send one origin; only the
+		// native CORS filter (or an Envoy Gateway policy) reflects the request Origin.
+		m.Status = StatusPartial
+		m.Note = "Impact: HIGH if your Gateway lacks the CORS filter. Multiple origins need per-request Origin reflection — the generated CORS filter lists all of them, " +
+			"but a ResponseHeaderModifier fallback can only send one static origin and browsers will reject the rest. On Envoy Gateway use a SecurityPolicy cors block (or EnvoyPatchPolicy) instead."
+	}
+}
+
+// traefikDenyListNote explains why denylist-source-range is not migrated to
+// either Traefik target.
+const traefikDenyListNote = "Impact: HIGH. Traefik has no deny-list middleware, so the listed ranges are not blocked — " +
+	"block them at the load balancer or firewall, or add !ClientIP(...) to an IngressRoute match"
+
+// validSameSite reports whether v is a SameSite value Traefik's sticky cookie
+// takes, in any case.
+func validSameSite(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "none", "lax", "strict":
+		return true
+	}
+	return false
+}
+
+// HasMapping reports whether the target's mapping table has an explicit entry for key.
+func HasMapping(key, target string) bool {
+	mappings, ok := mappingsForTarget(target)
+	if !ok {
+		return false
+	}
+	_, ok = mappings[key]
+	return ok
+}
+
+// MappedAnnotations returns the sorted keys of the target's mapping table.
+func MappedAnnotations(target string) []string {
+	mappings, _ := mappingsForTarget(target)
+	keys := make([]string, 0, len(mappings))
+	for k := range mappings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// redirectHasVariables reports whether a redirect target uses nginx variables
+// other than a trailing $request_uri or $uri, which the migrators keep the
+// request path for.
+func redirectHasVariables(target string) bool {
+	for _, v := range []string{"$request_uri", "$uri"} {
+		if base, ok := strings.CutSuffix(target, v); ok {
+			target = base
+			break
+		}
+	}
+	return strings.Contains(target, "$")
+}
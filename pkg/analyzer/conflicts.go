@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// RouteConflicts finds host+path pairs claimed by more than one non-canary
+// Ingress. ingress-nginx quietly serves one of them (the oldest Ingress);
+// the targets pick by their own rules, so traffic can move after migration.
+// Each involved Ingress gets one warning per conflict naming the others and
+// the winner under target. Results are keyed by "namespace/name".
+func RouteConflicts(ingresses []scanner.IngressInfo, target string) map[string][]string {
+	claims := make(map[string][]string) // host + path match → "namespace/name"
+	var routeOrder []string
+	for _, ing := range ingresses {
+		if ing.NginxAnnotations["canary"] == "true" {
+			continue
+		}
+		key := ing.Namespace + "/" + ing.Name
+		for _, p := range ing.Paths {
+			route := routeKey(p)
+			owners := claims[route]
+			if len(owners) > 0 && owners[len(owners)-1] == key {
+				continue // several backends of one Ingress, not a conflict
+			}
+			if len(owners) == 0 {
+				routeOrder = append(routeOrder, route)
+			}
+			claims[route] = append(owners, key)
+		}
+	}
+
+	warnings := make(map[string][]string)
+	for _, route := range routeOrder {
+		owners := claims[route]
+		if len(owners) < 2 {
+			continue
+		}
+		sort.Strings(owners)
+		winner := conflictWinner(owners, target)
+		for _, k := range owners {
+			var others []string
+			for _, o := range owners {
+				if o != k {
+					others = append(others, o)
+				}
+			}
+			warnings[k] = append(warnings[k], fmt.Sprintf("%s is also claimed by %s; %s", route, strings.Join(others, ", "), winner))
+		}
+	}
+	return warnings
+}
+
+// routeKey identifies a path match. Exact and prefix matches on the same
+// path are different routes on every target.
+func routeKey(p scanner.PathInfo) string {
+	host := p.Host
+	if host == "" {
+		host = "*"
+	}
+	if p.PathType == "Exact" {
+		return fmt.Sprintf("host %s exact path %s", host, p.Path)
+	}
+	return fmt.Sprintf("host %s path %s", host, p.Path)
+}
+
+// conflictWinner explains which Ingress serves a duplicated route after
+// migration. Generated resources are applied in file (namespace/name) order,
+// so on Gateway API the first of owners is the oldest HTTPRoute.
+func conflictWinner(owners []string, target string) string {
+	if target == "traefik" {
+		return "ingress-nginx serves the oldest Ingress, while Traefik gives identical rules the same priority and either may win — keep one"
+	}
+	return fmt.Sprintf("ingress-nginx serves the oldest Ingress, while Gateway API picks the oldest HTTPRoute (%s when applied in file order) — keep one", owners[0])
+}
@@ -0,0 +1,37 @@
+package analyzer
+
+import "sort"
+
+// CatalogDrift lists annotation keys that are out of sync between
+// KnownAnnotations and a target's mapping table. Any key reported here would
+// otherwise fall through to "Unknown annotation" at analysis time.
+type CatalogDrift struct {
+	Target   string   `json:"target"`
+	Unmapped []string `json:"unmapped"` // in KnownAnnotations but no mapping for the target
+	Unknown  []string `json:"unknown"`  // mapped for the target but missing from KnownAnnotations
+}
+
+// HasDrift reports whether any inconsistency was found.
+func (d CatalogDrift) HasDrift() bool {
+	return len(d.Unmapped) > 0 || len(d.Unknown) > 0
+}
+
+// CheckCatalog compares KnownAnnotations against the mapping table for target.
+func CheckCatalog(target string) CatalogDrift {
+	drift := CatalogDrift{Target: target, Unmapped: []string{}, Unknown: []string{}}
+
+	for key := range AnnotationsByKey {
+		if !HasMapping(key, target) {
+			drift.Unmapped = append(drift.Unmapped, key)
+		}
+	}
+	for _, key := range MappedAnnotations(target) {
+		if _, ok := AnnotationsByKey[key]; !ok {
+			drift.Unknown = append(drift.Unknown, key)
+		}
+	}
+
+	sort.Strings(drift.Unmapped)
+	sort.Strings(drift.Unknown)
+	return drift
+}
@@ -0,0 +1,34 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// ExternalNameWarnings flags backends that are ExternalName Services.
+// ingress-nginx proxies to them by resolving the external host; the targets
+// either refuse them by default or leave them implementation-specific, so
+// routes that worked can start returning errors after cutover.
+func ExternalNameWarnings(ing scanner.IngressInfo, target string) []string {
+	var warnings []string
+	for _, svc := range ing.Services {
+		if svc.ExternalName == "" {
+			continue
+		}
+		var fix string
+		switch target {
+		case "traefik":
+			fix = "Traefik ignores ExternalName Services unless the provider sets allowExternalNameServices: true"
+		case "gateway-api":
+			fix = "Envoy Gateway does not route to ExternalName Services; point the backendRef at the generated Backend " +
+				"(gateway.envoyproxy.io, needs config.envoyGateway.extensionApis.enableBackend: true)"
+		default:
+			fix = "Gateway API leaves ExternalName backends implementation-specific; verify the target resolves it " +
+				"before cutover, or front the external host with an in-cluster proxy Service"
+		}
+		warnings = append(warnings, fmt.Sprintf("backend %s/%s is an ExternalName Service (→ %s); %s",
+			svc.Namespace, svc.Name, svc.ExternalName, fix))
+	}
+	return warnings
+}
@@ -0,0 +1,36 @@
+package analyzer
+
+import "strings"
+
+// f5Mappings describes the F5 NGINX annotations the scanner keeps under their
+// full key (see scanner.mapF5Annotation) because they have no community
+// equivalent to translate to. Keys are without the nginx.org/ or nginx.com/
+// prefix; the notes hold for every target.
+var f5Mappings = map[string]struct {
+	Status MappingStatus
+	Note   string
+}{
+	"rewrites":      {StatusUnsupported, "Impact: HIGH. Per-service prefix rewrites (serviceName=… rewrite=…) are not migrated, so the listed Services receive the original path — add a prefix rewrite for their paths by hand"},
+	"ssl-services":  {StatusPartial, "Lists only some of the ingress's backends: HTTPS to the listed Services is not migrated, so they are proxied over plain HTTP — set HTTPS per backend by hand, or split them into their own Ingress with backend-protocol: HTTPS"},
+	"grpc-services": {StatusPartial, "Lists only some of the ingress's backends: gRPC to the listed Services is not migrated — split them into their own Ingress with backend-protocol: GRPC"},
+	"lb-method":     {StatusUnsupported, "Impact: LOW. least_conn, least_time and the random methods have no equivalent; the target balances round-robin"},
+}
+
+// mapF5Annotation returns the mapping of an F5 NGINX annotation kept under
+// its full key, or false when key is not one.
+func mapF5Annotation(key, value string) (AnnotationMapping, bool) {
+	name, ok := strings.CutPrefix(key, "nginx.org/")
+	if !ok {
+		name, ok = strings.CutPrefix(key, "nginx.com/")
+	}
+	m, known := f5Mappings[name]
+	if !ok || !known {
+		return AnnotationMapping{}, false
+	}
+	return AnnotationMapping{
+		OriginalKey:   key,
+		OriginalValue: value,
+		Status:        m.Status,
+		Note:          m.Note,
+	}, true
+}
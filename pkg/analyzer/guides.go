@@ -1,4 +1,9 @@
-package server
+package analyzer
+
+import (
+	"sort"
+	"strings"
+)
 
 // AnnotationGuide holds actionable fix information for a single annotation mapping issue.
 type AnnotationGuide struct {
@@ -31,11 +36,23 @@ var traefikGuides = map[string]AnnotationGuide{
 		Fix:     "Traefik sticky cookies support limited path config. Set the cookie path via the service annotation traefik.ingress.kubernetes.io/affinity-cookie-path on your Service resource.",
 		Example: "# On the Service:\nannotations:\n  traefik.ingress.kubernetes.io/affinity-cookie-path: \"/app\"",
 	},
+	"mirror-target": {
+		What:        "Sends a copy of every request to another service (shadow testing); the mirror's responses are discarded.",
+		Fix:         "ing-switch generates a Mirroring TraefikService per backend and an IngressRoute with the Ingress's hosts, paths and middlewares (03-ingresses/<ns>-<name>-mirroring.yaml). Its routes have a higher priority than the Ingress, so they take over those paths. The mirror must be a cluster Service; for an external host create an ExternalName Service first.",
+		Example:     "apiVersion: traefik.io/v1alpha1\nkind: TraefikService\nmetadata:\n  name: web-mirror-web\nspec:\n  mirroring:\n    name: web\n    port: 80\n    mirrors:\n    - name: shadow\n      port: 8080\n      percent: 100",
+		DocsLink:    "https://doc.traefik.io/traefik/routing/providers/kubernetes-crd/#mirroring",
+		Consequence: "Without it the shadow service stops receiving traffic after cutover; production traffic is unaffected.",
+	},
 	"auth-method": {
 		What:    "Sets the HTTP method ForwardAuth should use when calling the auth URL.",
 		Fix:     "Traefik ForwardAuth always uses GET. If your auth server requires POST, add a proxy adapter in front of it, or switch to a GET-compatible auth endpoint.",
 		Example: "# ForwardAuth always calls auth-url with GET — no config needed",
 	},
+	"limit-rate": {
+		What:    "Throttles each response to a number of KB per second per connection (after limit-rate-after KB), usually to share bandwidth fairly between large downloads.",
+		Fix:     "Traefik has no bandwidth throttling — the RateLimit middleware limits requests per second, not bytes. Without a replacement, responses are sent at full speed. Throttle downloads in the application, or keep them on a proxy that supports it.",
+		Example: "# No Traefik equivalent. A RateLimit middleware caps request rate only:\napiVersion: traefik.io/v1alpha1\nkind: Middleware\nmetadata:\n  name: downloads-ratelimit\nspec:\n  rateLimit:\n    average: 10\n    burst: 20",
+	},
 	"auth-request-redirect": {
 		What:    "Sets the redirect URL passed to the auth service on failure.",
 		Fix:     "Add redirectUntrusted: true and configure the redirect in your auth service instead. The ForwardAuth middleware passes the original URL via X-Forwarded-Uri header.",
@@ -43,14 +60,14 @@ var traefikGuides = map[string]AnnotationGuide{
 	},
 	"auth-type": {
 		What:    "Enables HTTP Basic authentication using an htpasswd secret.",
-		Fix:     "Create a Kubernetes secret with htpasswd users, then use BasicAuth Middleware. The secret format differs from NGINX — values must be bcrypt hashed.",
-		Example: "# 1. Create secret:\nkubectl create secret generic basic-auth \\\n  --from-literal=users=$(htpasswd -nb user 'mypassword') \\\n  -n <namespace>\n\n# 2. Create Middleware:\napiVersion: traefik.io/v1alpha1\nkind: Middleware\nmetadata:\n  name: basic-auth-mw\nspec:\n  basicAuth:\n    secret: basic-auth",
+		Fix:     "The generated BasicAuth Middleware references a per-ingress secret in Traefik's htpasswd format. Run 02-middlewares/auth-secret-convert.sh to build it from the NGINX secret before applying the middlewares.",
+		Example: "# 1. Build <ingress>-basicauth from the NGINX secret:\n./02-middlewares/auth-secret-convert.sh\n\n# 2. Apply the generated Middleware:\napiVersion: traefik.io/v1alpha1\nkind: Middleware\nmetadata:\n  name: <ingress>-basicauth\nspec:\n  basicAuth:\n    secret: <ingress>-basicauth",
 		DocsLink: "https://doc.traefik.io/traefik/middlewares/http/basicauth/",
 	},
 	"auth-secret": {
 		What:    "References the Kubernetes secret containing htpasswd credentials.",
-		Fix:     "Traefik BasicAuth Middleware references a secret directly but expects bcrypt-hashed values (not MD5). Regenerate the secret with: htpasswd -nB user password",
-		Example: "# Regenerate with bcrypt:\nhtpasswd -nB admin mypassword\n# Output: admin:$2y$...\nkubectl create secret generic basic-auth --from-literal=users='admin:$2y$...' -n <ns>",
+		Fix:     "Traefik BasicAuth reads a single htpasswd key from a secret in the middleware's namespace and only verifies bcrypt, apr1 MD5 and SHA1 hashes. 02-middlewares/auth-secret-convert.sh copies auth-file or auth-map entries into a new secret, re-hashing any others with bcrypt after prompting for the password.",
+		Example: "# Build the Traefik secrets (nginx secrets are left untouched):\n./02-middlewares/auth-secret-convert.sh\n\n# Or by hand, with bcrypt:\nhtpasswd -nB admin mypassword\n# Output: admin:$2y$...\nkubectl create secret generic <ingress>-basicauth --from-literal=users='admin:$2y$...' -n <ns>",
 	},
 	"custom-headers": {
 		What:    "Adds custom request/response headers via a ConfigMap reference.",
@@ -99,6 +116,13 @@ var traefikGuides = map[string]AnnotationGuide{
 		Fix:     "For HTTPS backends: add ServersTransport with rootCAs. For gRPC: configure h2c in ServersTransport. See example below.",
 		Example: "# For gRPC (h2c):\napiVersion: traefik.io/v1alpha1\nkind: ServersTransport\nmetadata:\n  name: grpc-transport\nspec:\n  disableHTTP2: false\n# Then add on Service:\nannotations:\n  traefik.ingress.kubernetes.io/service.serversscheme: h2c",
 	},
+	"proxy-ssl-secret": {
+		What:        "Client certificate (tls.crt/tls.key, optionally ca.crt) that NGINX presents to HTTPS backends requiring mutual TLS.",
+		Fix:         "Apply the generated 02-middlewares/<ns>-<name>-serverstransport.yaml, then run the matching -serverstransport-services.sh so the backend Services use it over HTTPS. With proxy-ssl-verify: on, the secret's ca.crt verifies the backend.",
+		Example:     "apiVersion: traefik.io/v1alpha1\nkind: ServersTransport\nmetadata:\n  name: myapp-backend-tls\nspec:\n  serverName: \"backend.internal\"\n  rootCAs:\n    - secret: backend-client-cert\n  certificatesSecrets:\n    - backend-client-cert\n# Then on each backend Service:\nannotations:\n  traefik.ingress.kubernetes.io/service.serversscheme: https\n  traefik.ingress.kubernetes.io/service.serverstransport: default-myapp-backend-tls@kubernetescrd",
+		DocsLink:    "https://doc.traefik.io/traefik/routing/providers/kubernetes-crd/#kind-serverstransport",
+		Consequence: "Backends that require a client certificate reject Traefik's TLS handshake and every request returns 502.",
+	},
 	"grpc-backend": {
 		What:    "Marks a backend as gRPC, enabling HTTP/2 and gRPC-specific routing.",
 		Fix:     "Configure h2c (HTTP/2 cleartext) via ServersTransport and set the service scheme annotation.",
@@ -192,6 +216,24 @@ var traefikGuides = map[string]AnnotationGuide{
 		Example:     "# Convert per-feature snippets to typed Middleware CRDs.\n# Use IngressRoute CRD for full control over routing.",
 		Consequence: "Server-level NGINX directives will NOT be applied. Review the snippet and replace each directive with its Traefik equivalent.",
 	},
+	"auth-snippet": {
+		What:        "Injects raw NGINX into the location that makes the external auth subrequest — typically to set or pass headers (API keys, the original host) the auth service relies on.",
+		Fix:         "Passing client headers through is migrated: with proxy_pass_request_headers off, the passed headers become the ForwardAuth authRequestHeaders. For anything else, make the auth service read what ForwardAuth already sends (X-Forwarded-Method/Proto/Host/Uri/For), or add the header before the ForwardAuth middleware with a Headers middleware.",
+		Example:     "# NGINX auth-snippet:\n#   proxy_pass_request_headers off;\n#   proxy_set_header Authorization $http_authorization;\n# Traefik equivalent:\nspec:\n  forwardAuth:\n    address: \"http://auth.default.svc/verify\"\n    authRequestHeaders:\n      - Authorization",
+		DocsLink:    "https://doc.traefik.io/traefik/middlewares/http/forwardauth/",
+		Consequence: "The auth service stops receiving what the snippet added and may reject every request, or allow requests it should have denied.",
+	},
+	"auth-cache-key": {
+		What:        "Caches external auth responses per key (with auth-cache-duration), so repeated requests skip the auth subrequest.",
+		Fix:         "ForwardAuth has no response cache. Cache decisions in the auth service (e.g. oauth2-proxy session cookies), or use a ForwardAuth plugin with caching.",
+		Consequence: "Every request reaches the auth service: more latency and load on it, but the same auth decisions.",
+	},
+	"proxy-buffer-size": {
+		What:        "Sets the NGINX buffer for the first part of the backend response (the headers). Usually raised to fix 502 'upstream sent too big header' caused by large Set-Cookie or JWT headers.",
+		Fix:         "No Traefik setting and none needed — Traefik accepts backend response headers up to 10 MB (Go default). Remove the annotation.",
+		Example:     "# No Traefik configuration needed.",
+		Consequence: "None. Responses NGINX needed a larger buffer for are accepted by Traefik as-is.",
+	},
 	"proxy-buffering": {
 		What:        "Enables/disables NGINX proxy response buffering.",
 		Fix:         "Traefik doesn't expose proxy response buffering via Ingress annotations. For streaming APIs (SSE, chunked transfer), buffering is automatically disabled. For large responses, configure at the application level.",
@@ -210,6 +252,33 @@ var traefikGuides = map[string]AnnotationGuide{
 		Example:     "# For weighted backends via IngressRoute:\nspec:\n  routes:\n    - kind: Rule\n      match: Host(`example.com`)\n      services:\n        - name: app-v1\n          port: 80\n          weight: 80\n        - name: app-v2\n          port: 80\n          weight: 20",
 		Consequence: "Custom load balancing algorithm will be replaced with round-robin. Impact depends on your use case.",
 	},
+	"enable-modsecurity": {
+		What:        "Turns on the ModSecurity web application firewall for this ingress, inspecting requests against the configured rule set.",
+		Fix:         "Traefik has no built-in WAF. Options:\n1. Install a WAF plugin (e.g. the Coraza WAF plugin, which runs ModSecurity-compatible SecLang rules) and attach it as a Middleware\n2. Put an external WAF (cloud WAF, dedicated ModSecurity proxy) in front of Traefik\nDo NOT cut DNS over until one of these is in place.",
+		Example:     "# Traefik static config (Helm values) — enable the Coraza plugin:\nexperimental:\n  plugins:\n    coraza:\n      moduleName: github.com/jcchavezs/coraza-http-wasm-traefik\n      version: v0.2.2\n---\napiVersion: traefik.io/v1alpha1\nkind: Middleware\nmetadata:\n  name: waf\nspec:\n  plugin:\n    coraza:\n      directives:\n        - SecRuleEngine On\n        - Include @owasp_crs/*.conf",
+		DocsLink:    "https://plugins.traefik.io/plugins/65f2aea146079255c9ffd1ec/coraza-waf",
+		Consequence: "Requests are no longer inspected by a WAF. Attacks your ModSecurity rules were blocking (SQLi, XSS, scanners) will reach the backend.",
+	},
+	"modsecurity-snippet": {
+		What:        "Injects custom ModSecurity (SecLang) rules for this ingress.",
+		Fix:         "Copy the SecLang directives from the annotation into the directives list of a Coraza WAF plugin Middleware. Coraza is ModSecurity-compatible, so most rules carry over unchanged — test each rule in DetectionOnly mode first.",
+		Example:     "apiVersion: traefik.io/v1alpha1\nkind: Middleware\nmetadata:\n  name: waf-custom-rules\nspec:\n  plugin:\n    coraza:\n      directives:\n        - SecRuleEngine DetectionOnly\n        # paste the rules from modsecurity-snippet here",
+		DocsLink:    "https://coraza.io/docs/seclang/",
+		Consequence: "Your custom WAF rules are silently dropped — only the controller default (none, on Traefik) applies.",
+	},
+	"enable-owasp-core-rules": {
+		What:        "Loads the OWASP ModSecurity Core Rule Set (CRS) for this ingress.",
+		Fix:         "Use the Coraza WAF plugin, which bundles the OWASP CRS — include @owasp_crs/*.conf in its directives. Alternatively enable CRS on an external WAF in front of Traefik.",
+		Example:     "apiVersion: traefik.io/v1alpha1\nkind: Middleware\nmetadata:\n  name: waf-crs\nspec:\n  plugin:\n    coraza:\n      directives:\n        - Include @coraza.conf-recommended\n        - Include @crs-setup.conf.example\n        - Include @owasp_crs/*.conf\n        - SecRuleEngine On",
+		DocsLink:    "https://coreruleset.org/",
+		Consequence: "Generic attack protection from the OWASP CRS (SQLi, XSS, RCE, protocol violations) is lost.",
+	},
+	"lua-resty-waf": {
+		What:        "Enables the lua-resty-waf Lua-based WAF (deprecated in ingress-nginx) in the given mode.",
+		Fix:         "lua-resty-waf has no successor in Traefik. Move to the Coraza WAF plugin with the OWASP CRS (see enable-owasp-core-rules) or an external WAF.",
+		Example:     "# See enable-owasp-core-rules for a Coraza + CRS Middleware example",
+		Consequence: "WAF inspection performed by lua-resty-waf is lost.",
+	},
 }
 
 // gatewayAPIGuides maps annotation key → actionable fix guide for Gateway API target.
@@ -221,9 +290,16 @@ var gatewayAPIGuides = map[string]AnnotationGuide{
 		Example: "spec:\n  rules:\n    - matches:\n        - method: OPTIONS\n      filters:\n        - type: ResponseHeaderModifier\n          responseHeaderModifier:\n            set:\n              - name: Access-Control-Allow-Origin\n                value: \"https://app.example.com\"\n              - name: Access-Control-Allow-Methods\n                value: \"GET,POST,PUT,DELETE,OPTIONS\"\n              - name: Access-Control-Allow-Headers\n                value: \"Authorization,Content-Type\"\n              - name: Access-Control-Max-Age\n                value: \"86400\"",
 	},
 	"cors-allow-origin": {
-		What:    "Sets the Access-Control-Allow-Origin CORS header.",
-		Fix:     "Add ResponseHeaderModifier filter to HTTPRoute with the Access-Control-Allow-Origin header.",
-		Example: "filters:\n  - type: ResponseHeaderModifier\n    responseHeaderModifier:\n      set:\n        - name: Access-Control-Allow-Origin\n          value: \"https://app.example.com\"",
+		What:        "Sets the Access-Control-Allow-Origin CORS header. nginx accepts a comma-separated list and echoes back whichever origin matches the request.",
+		Fix:         "Use the native CORS filter (generated for you) — it lists every origin and reflects the matching one per request. A ResponseHeaderModifier can only set ONE static origin, so it is only safe for a single origin. For multiple origins on Envoy Gateway without CORS filter support, use a SecurityPolicy cors block (or an EnvoyPatchPolicy) targeting the HTTPRoute.",
+		Example:     "apiVersion: gateway.envoyproxy.io/v1alpha1\nkind: SecurityPolicy\nmetadata:\n  name: myapp-cors\nspec:\n  targetRefs:\n  - group: gateway.networking.k8s.io\n    kind: HTTPRoute\n    name: myapp\n  cors:\n    allowOrigins:\n    - \"https://app.example.com\"\n    - \"https://admin.example.com\"\n    allowMethods: [GET, POST, OPTIONS]",
+		DocsLink:    "https://gateway.envoyproxy.io/docs/tasks/security/cors/",
+		Consequence: "With a static header only the first origin works; browsers block cross-origin requests from every other configured origin.",
+	},
+	"mirror-request-body": {
+		What:     "Controls whether the request body is sent to the mirror (on by default).",
+		Fix:      "RequestMirror always mirrors the body. If the mirror must not receive bodies (e.g. large uploads), filter them in the shadow service, or on Envoy Gateway use an EnvoyPatchPolicy on the route's request_mirror_policies.",
+		DocsLink: "https://gateway-api.sigs.k8s.io/guides/http-request-mirroring/",
 	},
 	"cors-allow-methods": {
 		What:    "Sets the Access-Control-Allow-Methods CORS header.",
@@ -262,6 +338,12 @@ var gatewayAPIGuides = map[string]AnnotationGuide{
 		Fix:     "Same as limit-rps but set unit: Minute in BackendTrafficPolicy.",
 		Example: "limit:\n  requests: 300\n  unit: Minute",
 	},
+	"limit-rate": {
+		What:    "Throttles each response to a number of KB per second per connection (after limit-rate-after KB), usually to share bandwidth fairly between large downloads.",
+		Fix:     "Envoy Gateway's BackendTrafficPolicy rateLimit counts requests, not bytes, and Gateway API has no bandwidth limit. Without a replacement, responses are sent at full speed. Throttle in the application, or add Envoy's bandwidth_limit HTTP filter with an EnvoyPatchPolicy (requires enablePatchPolicy in the Envoy Gateway config).",
+		Example: "apiVersion: gateway.envoyproxy.io/v1alpha1\nkind: EnvoyPatchPolicy\nmetadata:\n  name: bandwidth-limit\nspec:\n  targetRef:\n    group: gateway.networking.k8s.io\n    kind: Gateway\n    name: ing-switch-gateway\n  type: JSONPatch\n  jsonPatches:\n  # Insert envoy.filters.http.bandwidth_limit with\n  # enable_mode: RESPONSE and limit_kbps: <limit-rate>\n  # into the listener's HTTP filter chain",
+		DocsLink: "https://gateway.envoyproxy.io/docs/tasks/extensibility/envoy-patch-policy/",
+	},
 	"limit-connections": {
 		What:    "Limits the number of concurrent connections from a single IP.",
 		Fix:     "Envoy Gateway doesn't have per-IP connection limits directly. Use circuit breaker via BackendTrafficPolicy or configure at the load balancer level.",
@@ -311,6 +393,14 @@ var gatewayAPIGuides = map[string]AnnotationGuide{
 		Example: "# For HTTPS backend, set parentRef with TLS listener and use httpsRoute\n# For gRPC backend, switch to GRPCRoute instead of HTTPRoute",
 	},
 
+	"proxy-ssl-secret": {
+		What:        "Client certificate (tls.crt/tls.key, optionally ca.crt) that NGINX presents to HTTPS backends requiring mutual TLS.",
+		Fix:         "BackendTLSPolicy only verifies the backend's certificate. The client certificate is set once per Gateway with spec.tls.backend.clientCertificateRef (experimental channel, Gateway API v1.4+), so every backend of that Gateway receives the same certificate — use a dedicated Gateway if backends need different ones.",
+		Example:     "apiVersion: gateway.networking.k8s.io/v1\nkind: Gateway\nmetadata:\n  name: ing-switch-gateway\nspec:\n  tls:\n    backend:\n      clientCertificateRef:\n        kind: Secret\n        name: backend-client-cert\n        namespace: default\n---\napiVersion: gateway.networking.k8s.io/v1\nkind: BackendTLSPolicy\nmetadata:\n  name: myapp-backend-tls\nspec:\n  targetRefs:\n    - group: \"\"\n      kind: Service\n      name: myapp\n  validation:\n    caCertificateRefs:\n      - group: \"\"\n        kind: ConfigMap\n        name: backend-ca\n    hostname: backend.internal",
+		DocsLink:    "https://gateway-api.sigs.k8s.io/api-types/backendtlspolicy/",
+		Consequence: "Backends that require a client certificate reject the Gateway's TLS handshake and every request returns 503.",
+	},
+
 	// --- PARTIAL ---
 	"affinity-mode": {
 		What:        "Controls how sticky sessions are re-balanced when pod replicas change. 'balanced' re-distributes sessions on scaling; 'persistent' keeps cookies mapped to the same backend.",
@@ -326,6 +416,19 @@ var gatewayAPIGuides = map[string]AnnotationGuide{
 		DocsLink:    "https://gateway.envoyproxy.io/docs/api/extension_types/#backendtrafficpolicy",
 		Consequence: "Without this limit, Envoy Gateway will accept request bodies of any size to your backend.",
 	},
+	"proxy-buffer-size": {
+		What:        "Sets the NGINX buffer for the first part of the backend response (the headers). Usually raised to fix 502 'upstream sent too big header' caused by large Set-Cookie or JWT headers.",
+		Fix:         "Envoy Gateway: the generated BackendTrafficPolicy sets connection.bufferLimit from this value. Envoy already accepts response headers up to 60Ki, so most apps need nothing more.\nTraefik (gateway-api-traefik): no equivalent and none needed — response headers up to 10 MB are accepted.",
+		Example:     "apiVersion: gateway.envoyproxy.io/v1alpha1\nkind: BackendTrafficPolicy\nmetadata:\n  name: myapp-buffer\n  namespace: default\nspec:\n  targetRef:\n    group: gateway.networking.k8s.io\n    kind: HTTPRoute\n    name: myapp\n  connection:\n    bufferLimit: 16Ki  # from proxy-buffer-size: 16k",
+		DocsLink:    "https://gateway.envoyproxy.io/docs/api/extension_types/#backendconnection",
+		Consequence: "None for typical header sizes. Responses with headers above 60Ki are rejected by Envoy with 502, as NGINX did above its buffer size.",
+	},
+	"client-body-buffer-size": {
+		What:        "Sets the size of the buffer for reading the client request body before proxying.",
+		Fix:         "No Gateway API equivalent. Envoy Gateway and Traefik stream request bodies to the backend. To cap the body size use proxy-body-size (Envoy: BackendTrafficPolicy requestBuffer.limit); to fully buffer bodies, do it at the application level.",
+		Example:     "# Not configurable via Gateway API.\n# Limit total body size instead:\nspec:\n  requestBuffer:\n    limit: 10Mi",
+		Consequence: "Requests may be forwarded to the backend without being fully buffered first (streaming behavior).",
+	},
 	"proxy-request-buffering": {
 		What:    "Controls whether the request body is fully buffered before forwarding. 'off' enables streaming.",
 		Fix:     "Envoy Gateway streams requests by default (equivalent to off). No configuration needed.",
@@ -401,6 +504,18 @@ var gatewayAPIGuides = map[string]AnnotationGuide{
 		DocsLink:    "https://gateway.envoyproxy.io/docs/api/extension_types/#envoypatchpolicy",
 		Consequence: "Server-level NGINX directives will NOT be applied. Review the snippet and replace each directive.",
 	},
+	"auth-snippet": {
+		What:        "Injects raw NGINX into the location that makes the external auth subrequest — typically to set or pass headers (API keys, the original host) the auth service relies on.",
+		Fix:         "Passing client headers through is migrated: with proxy_pass_request_headers off, the passed headers become the SecurityPolicy extAuth headersToExtAuth. Envoy's HTTP ext auth otherwise sends only Host, Method, Path, Content-Length and Authorization, so list any other header the auth service needs there.",
+		Example:     "# NGINX auth-snippet:\n#   proxy_pass_request_headers off;\n#   proxy_set_header X-Api-Key $http_x_api_key;\n# Envoy Gateway equivalent:\nspec:\n  extAuth:\n    headersToExtAuth:\n    - X-Api-Key\n    http:\n      backendRef:\n        name: auth-service\n        port: 9001",
+		DocsLink:    "https://gateway.envoyproxy.io/docs/tasks/security/ext-auth/",
+		Consequence: "The auth service stops receiving what the snippet added and may reject every request, or allow requests it should have denied.",
+	},
+	"auth-cache-key": {
+		What:        "Caches external auth responses per key (with auth-cache-duration), so repeated requests skip the auth subrequest.",
+		Fix:         "SecurityPolicy extAuth has no response cache. Cache decisions in the auth service (e.g. oauth2-proxy session cookies) instead.",
+		Consequence: "Every request reaches the auth service: more latency and load on it, but the same auth decisions.",
+	},
 	"auth-type": {
 		What:        "Enables HTTP Basic authentication (type: basic).",
 		Fix:         "Core Gateway API has no basic auth. Use SecurityPolicy with BasicAuth (Envoy Gateway v1.1+) or deploy oauth2-proxy as an auth sidecar with ext-auth.",
@@ -426,6 +541,61 @@ var gatewayAPIGuides = map[string]AnnotationGuide{
 		Example:     "# Via EnvoyPatchPolicy for consistent hashing:\n# Patch the cluster config to use RING_HASH or MAGLEV",
 		Consequence: "Consistent hash-based routing will be replaced with round-robin. If your application relies on hash affinity, configure BackendLBPolicy.",
 	},
+	"enable-modsecurity": {
+		What:        "Turns on the ModSecurity web application firewall for this ingress, inspecting requests against the configured rule set.",
+		Fix:         "Core Gateway API has no WAF. With Envoy Gateway, attach the Coraza WAF (ModSecurity-compatible) as a Wasm extension via EnvoyExtensionPolicy targeting the HTTPRoute. With Traefik as the provider, use the Coraza WAF plugin Middleware via an ExtensionRef filter. Otherwise put an external WAF in front of the Gateway.\nDo NOT cut DNS over until one of these is in place.",
+		Example:     "apiVersion: gateway.envoyproxy.io/v1alpha1\nkind: EnvoyExtensionPolicy\nmetadata:\n  name: waf\nspec:\n  targetRef:\n    group: gateway.networking.k8s.io\n    kind: HTTPRoute\n    name: myapp\n  wasm:\n  - name: coraza\n    rootID: \"\"\n    code:\n      type: Image\n      image:\n        url: ghcr.io/corazawaf/coraza-proxy-wasm:latest\n    config:\n      directives_map:\n        default:\n          - Include @recommended-conf\n          - SecRuleEngine On\n      default_directives: default",
+		DocsLink:    "https://gateway.envoyproxy.io/docs/tasks/extensibility/wasm/",
+		Consequence: "Requests are no longer inspected by a WAF. Attacks your ModSecurity rules were blocking (SQLi, XSS, scanners) will reach the backend.",
+	},
+	"modsecurity-snippet": {
+		What:        "Injects custom ModSecurity (SecLang) rules for this ingress.",
+		Fix:         "Copy the SecLang directives into the Coraza Wasm extension config (directives_map). Coraza is ModSecurity-compatible, so most rules carry over unchanged — test each rule in DetectionOnly mode first.",
+		Example:     "config:\n  directives_map:\n    default:\n      - SecRuleEngine DetectionOnly\n      # paste the rules from modsecurity-snippet here\n  default_directives: default",
+		DocsLink:    "https://coraza.io/docs/seclang/",
+		Consequence: "Your custom WAF rules are silently dropped.",
+	},
+	"enable-owasp-core-rules": {
+		What:        "Loads the OWASP ModSecurity Core Rule Set (CRS) for this ingress.",
+		Fix:         "The Coraza Wasm extension bundles the OWASP CRS — include @owasp_crs/*.conf in its directives. Alternatively enable CRS on an external WAF in front of the Gateway.",
+		Example:     "config:\n  directives_map:\n    default:\n      - Include @recommended-conf\n      - Include @crs-setup-conf\n      - Include @owasp_crs/*.conf\n      - SecRuleEngine On\n  default_directives: default",
+		DocsLink:    "https://coreruleset.org/",
+		Consequence: "Generic attack protection from the OWASP CRS (SQLi, XSS, RCE, protocol violations) is lost.",
+	},
+	"lua-resty-waf": {
+		What:        "Enables the lua-resty-waf Lua-based WAF (deprecated in ingress-nginx) in the given mode.",
+		Fix:         "lua-resty-waf has no Gateway API successor. Move to the Coraza Wasm extension with the OWASP CRS (see enable-owasp-core-rules) or an external WAF.",
+		Example:     "# See enable-owasp-core-rules for a Coraza + CRS configuration",
+		Consequence: "WAF inspection performed by lua-resty-waf is lost.",
+	},
+}
+
+// valueGuides are fix guides for annotation values that change the fix
+// entirely, keyed by "<annotation>=<VALUE>". They apply to every target.
+var valueGuides = map[string]AnnotationGuide{
+	"backend-protocol=FCGI": {
+		What:        "Makes NGINX talk FastCGI to the backend (typically PHP-FPM), passing SCRIPT_FILENAME and the other fastcgi-params itself.",
+		Fix:         "Neither Traefik nor Gateway API implementations speak FastCGI. Add a sidecar that serves HTTP and talks FastCGI to the app (e.g. nginx or Caddy next to PHP-FPM), point the Service at the sidecar port, and remove backend-protocol.",
+		Example:     "# Caddy sidecar next to PHP-FPM (Caddyfile in a ConfigMap):\n:8080 {\n  root * /var/www/html\n  php_fastcgi 127.0.0.1:9000\n}\n# Service targetPort: 8080",
+		DocsLink:    "https://kubernetes.github.io/ingress-nginx/user-guide/fcgi-services/",
+		Consequence: "PHP-FPM reads the plain HTTP request as a malformed FastCGI record and drops the connection: every request to the backend fails with 502 Bad Gateway.",
+	},
+	"backend-protocol=AJP": {
+		What:        "Makes NGINX talk AJP to the backend (typically Tomcat's AJP connector).",
+		Fix:         "Neither Traefik nor Gateway API implementations speak AJP. Enable Tomcat's HTTP connector and point the Service at it, or, when the app cannot change, add an HTTP → AJP adapter sidecar (e.g. Apache httpd with mod_proxy_ajp) and point the Service at that; then remove backend-protocol.",
+		Example:     "<!-- server.xml: serve HTTP alongside (or instead of) AJP -->\n<Connector port=\"8080\" protocol=\"HTTP/1.1\" connectionTimeout=\"20000\" />\n\n# or, in an httpd sidecar listening on 8080:\nProxyPass / ajp://127.0.0.1:8009/",
+		DocsLink:    "https://tomcat.apache.org/tomcat-10.1-doc/config/http.html",
+		Consequence: "Tomcat's AJP connector reads the plain HTTP request as a malformed AJP packet and closes the connection: the new controller logs a connection reset and every request to the backend fails with 502 Bad Gateway.",
+	},
+}
+
+// GetValueGuide returns the value-specific guide for an annotation when one
+// exists (e.g. backend-protocol: FCGI), otherwise GetAnnotationGuide.
+func GetValueGuide(target, annotationKey, value string) AnnotationGuide {
+	if g, ok := valueGuides[annotationKey+"="+strings.ToUpper(strings.TrimSpace(value))]; ok {
+		return g
+	}
+	return GetAnnotationGuide(target, annotationKey)
 }
 
 // GetAnnotationGuide returns the fix guide for a given target and annotation key.
@@ -442,3 +612,23 @@ func GetAnnotationGuide(target, annotationKey string) AnnotationGuide {
 	}
 	return guides[annotationKey]
 }
+
+// GuidedAnnotations returns the sorted annotation keys that have a dedicated
+// fix guide for the given target.
+func GuidedAnnotations(target string) []string {
+	var guides map[string]AnnotationGuide
+	switch target {
+	case "traefik":
+		guides = traefikGuides
+	case "gateway-api", "gateway-api-traefik":
+		guides = gatewayAPIGuides
+	default:
+		return nil
+	}
+	keys := make([]string, 0, len(guides))
+	for k := range guides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,48 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// PathPrefixWarnings flags paths whose matching narrows on Gateway API.
+// ingress-nginx serves an ImplementationSpecific path as a plain nginx
+// location, a string prefix: /foo also matches /foobar. Gateway API
+// PathPrefix matches whole path segments, so /foo no longer matches /foobar.
+// Prefix paths are segment-aligned on ingress-nginx already, and paths that
+// end in "/" or become regex matches are unaffected. Traefik's NGINX
+// provider keeps nginx's matching, so only Gateway API targets are checked.
+func PathPrefixWarnings(ing scanner.IngressInfo, target string) []string {
+	if target == "traefik" {
+		return nil
+	}
+	if _, useRegex := ing.NginxAnnotations["use-regex"]; useRegex {
+		return nil
+	}
+	var warnings []string
+	seen := make(map[string]bool)
+	for _, p := range ing.Paths {
+		if !stringPrefixPath(p) || seen[p.Path] {
+			continue
+		}
+		seen[p.Path] = true
+		warnings = append(warnings, fmt.Sprintf("path %[1]s matches as a string prefix on ingress-nginx (%[1]sx too) but by whole "+
+			"segments as a Gateway API PathPrefix (only %[1]s and %[1]s/...); if string-prefix matching is required, "+
+			"change the HTTPRoute match to type RegularExpression with value %[1]s.*", p.Path))
+	}
+	return warnings
+}
+
+// stringPrefixPath reports whether ingress-nginx matches p as a string
+// prefix that does not end on a segment boundary.
+func stringPrefixPath(p scanner.PathInfo) bool {
+	if p.PathType != "" && p.PathType != "ImplementationSpecific" {
+		return false
+	}
+	if p.Path == "" || strings.HasSuffix(p.Path, "/") {
+		return false
+	}
+	return !strings.ContainsAny(p.Path, "()|[]{}")
+}
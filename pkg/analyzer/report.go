@@ -1,6 +1,15 @@
 package analyzer
 
-import "github.com/saiyam1814/ing-switch/pkg/scanner"
+import (
+	"slices"
+	"sort"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// topUnsupportedLimit caps how many unsupported annotations are listed in
+// AnnotationStats.TopUnsupported.
+const topUnsupportedLimit = 5
 
 // Analyzer performs compatibility analysis for a given target controller.
 type Analyzer struct {
@@ -14,37 +23,109 @@ func NewAnalyzer(target string) *Analyzer {
 
 // AnalysisReport is the full output of an analysis run.
 type AnalysisReport struct {
-	Target        string          `json:"target"`
+	Target         string          `json:"target"`
 	IngressReports []IngressReport `json:"ingressReports"`
-	Summary       Summary         `json:"summary"`
+	Summary        Summary         `json:"summary"`
+
+	// StreamServices are TCP/UDP ports from the ingress-nginx ConfigMaps.
+	// They carry no annotations but must be migrated to stream routes.
+	StreamServices []scanner.StreamService `json:"streamServices,omitempty"`
 }
 
 // IngressReport is the analysis of a single Ingress resource.
 type IngressReport struct {
-	Namespace string              `json:"namespace"`
-	Name      string              `json:"name"`
-	Mappings  []AnnotationMapping `json:"mappings"`
-	OverallStatus string          `json:"overallStatus"` // "ready" | "workaround" | "breaking"
+	Namespace      string              `json:"namespace"`
+	Name           string              `json:"name"`
+	Mappings       []AnnotationMapping `json:"mappings"`
+	OverallStatus  string              `json:"overallStatus"`      // "ready" | "workaround" | "breaking"
+	MigrationState string              `json:"migrationState"`     // "not-started" | "in-progress" | "done"
+	Warnings       []string            `json:"warnings,omitempty"` // misconfigurations carried over verbatim (e.g. canary weights)
 }
 
+// Migration states for IngressReport.MigrationState.
+const (
+	MigrationNotStarted = "not-started"
+	MigrationInProgress = "in-progress"
+	MigrationDone       = "done"
+)
+
 // Summary aggregates across all ingresses.
 type Summary struct {
 	Total           int `json:"total"`
 	FullyCompatible int `json:"fullyCompatible"`
 	NeedsWorkaround int `json:"needsWorkaround"`
 	HasUnsupported  int `json:"hasUnsupported"`
+
+	MigrationInProgress int `json:"migrationInProgress"` // target resources exist but cutover is incomplete
+	AlreadyMigrated     int `json:"alreadyMigrated"`     // target resources exist and are active
+
+	AnnotationStats AnnotationStats `json:"annotationStats"`
+}
+
+// AnnotationStats aggregates annotation-level counts across all ingresses.
+type AnnotationStats struct {
+	Total          int               `json:"total"`
+	Supported      int               `json:"supported"`
+	Partial        int               `json:"partial"`
+	Unsupported    int               `json:"unsupported"`
+	TopUnsupported []AnnotationCount `json:"topUnsupported"` // most common unsupported annotations, highest first
+}
+
+// AnnotationCount is the number of ingresses that use a given annotation.
+type AnnotationCount struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
 }
 
 // Analyze performs compatibility analysis on all ingresses in the scan result.
 func (a *Analyzer) Analyze(scan *scanner.ScanResult) *AnalysisReport {
 	report := &AnalysisReport{
-		Target: a.target,
+		Target:         a.target,
+		StreamServices: scan.StreamServices,
 	}
 
+	unsupportedCounts := make(map[string]int)
+	routes := indexRoutes(scan.HTTPRoutes)
+	canary := CanaryWarnings(scan.Ingresses)
+	conflicts := RouteConflicts(scan.Ingresses, a.target)
+
 	for _, ing := range scan.Ingresses {
 		ir := a.analyzeIngress(ing)
+		ir.MigrationState = a.migrationState(ing, routes)
+		key := ing.Namespace + "/" + ing.Name
+		ir.Warnings = slices.Concat(canary[key], conflicts[key], ExternalNameWarnings(ing, a.target))
+		if prefix := PathPrefixWarnings(ing, a.target); len(prefix) > 0 {
+			ir.Warnings = append(ir.Warnings, prefix...)
+			if ir.OverallStatus == "ready" {
+				ir.OverallStatus = "workaround"
+			}
+		}
+		secrets, missing := TLSSecretWarnings(ing, a.target)
+		ir.Warnings = append(ir.Warnings, secrets...)
+		if missing && ir.OverallStatus == "ready" {
+			ir.OverallStatus = "workaround"
+		}
+		switch ir.MigrationState {
+		case MigrationInProgress:
+			report.Summary.MigrationInProgress++
+		case MigrationDone:
+			report.Summary.AlreadyMigrated++
+		}
 		report.IngressReports = append(report.IngressReports, ir)
 
+		for _, m := range ir.Mappings {
+			report.Summary.AnnotationStats.Total++
+			switch m.Status {
+			case StatusSupported:
+				report.Summary.AnnotationStats.Supported++
+			case StatusPartial:
+				report.Summary.AnnotationStats.Partial++
+			case StatusUnsupported:
+				report.Summary.AnnotationStats.Unsupported++
+				unsupportedCounts[m.OriginalKey]++
+			}
+		}
+
 		report.Summary.Total++
 		switch ir.OverallStatus {
 		case "ready":
@@ -56,9 +137,48 @@ func (a *Analyzer) Analyze(scan *scanner.ScanResult) *AnalysisReport {
 		}
 	}
 
+	report.Summary.AnnotationStats.TopUnsupported = topAnnotations(unsupportedCounts, topUnsupportedLimit)
+
 	return report
 }
 
+// OnlyChanged returns a copy of the report whose ingress mappings are limited
+// to partial and unsupported annotations, the ones that need attention.
+// Summary counts are unchanged and still cover every annotation.
+func (r *AnalysisReport) OnlyChanged() *AnalysisReport {
+	filtered := *r
+	filtered.IngressReports = make([]IngressReport, len(r.IngressReports))
+	for i, ir := range r.IngressReports {
+		var mappings []AnnotationMapping
+		for _, m := range ir.Mappings {
+			if m.Status != StatusSupported {
+				mappings = append(mappings, m)
+			}
+		}
+		ir.Mappings = mappings
+		filtered.IngressReports[i] = ir
+	}
+	return &filtered
+}
+
+// topAnnotations returns up to n annotation counts sorted by count (desc), then key.
+func topAnnotations(counts map[string]int, n int) []AnnotationCount {
+	result := make([]AnnotationCount, 0, len(counts))
+	for k, c := range counts {
+		result = append(result, AnnotationCount{Key: k, Count: c})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
 func (a *Analyzer) analyzeIngress(ing scanner.IngressInfo) IngressReport {
 	ir := IngressReport{
 		Namespace: ing.Namespace,
@@ -69,9 +189,14 @@ func (a *Analyzer) analyzeIngress(ing scanner.IngressInfo) IngressReport {
 	hasPartial := false
 
 	for key, value := range ing.NginxAnnotations {
-		mapping := MapAnnotation(key, value, a.target)
-		ir.Mappings = append(ir.Mappings, mapping)
-
+		ir.Mappings = append(ir.Mappings, MapAnnotation(key, value, a.target))
+	}
+	if a.target != "traefik" {
+		for key, value := range ing.TraefikAnnotations {
+			ir.Mappings = append(ir.Mappings, MapTraefikAnnotation(key, value, a.target))
+		}
+	}
+	for _, mapping := range ir.Mappings {
 		switch mapping.Status {
 		case StatusUnsupported:
 			hasUnsupported = true
@@ -80,6 +205,11 @@ func (a *Analyzer) analyzeIngress(ing scanner.IngressInfo) IngressReport {
 		}
 	}
 
+	// Map iteration order is random; sort so reports and generated files are stable.
+	sort.Slice(ir.Mappings, func(i, j int) bool {
+		return ir.Mappings[i].OriginalKey < ir.Mappings[j].OriginalKey
+	})
+
 	switch {
 	case hasUnsupported:
 		ir.OverallStatus = "breaking"
@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+const traefikIngressAnnotationPrefix = "traefik.ingress.kubernetes.io/"
+
+// indexRoutes keys existing HTTPRoutes by "namespace/name".
+func indexRoutes(routes []scanner.RouteInfo) map[string]scanner.RouteInfo {
+	idx := make(map[string]scanner.RouteInfo, len(routes))
+	for _, r := range routes {
+		idx[r.Namespace+"/"+r.Name] = r
+	}
+	return idx
+}
+
+// migrationState detects whether an Ingress has already been (partially)
+// migrated to the target, so re-runs can flag it instead of treating it as
+// untouched nginx config.
+//
+// Traefik: the Ingress carries traefik.ingress.kubernetes.io/* annotations
+// (in-progress) or has already been switched to the traefik IngressClass (done).
+//
+// Gateway API: an HTTPRoute with the name ing-switch generates for this
+// Ingress exists (in-progress) and has been Accepted by its Gateway (done).
+func (a *Analyzer) migrationState(ing scanner.IngressInfo, routes map[string]scanner.RouteInfo) string {
+	switch a.target {
+	case "traefik":
+		if ing.SourceType == scanner.SourceTraefikIngressRoute {
+			return MigrationNotStarted
+		}
+		if ing.IngressClass == "traefik" {
+			return MigrationDone
+		}
+		for k := range ing.Annotations {
+			if strings.HasPrefix(k, traefikIngressAnnotationPrefix) {
+				return MigrationInProgress
+			}
+		}
+	case "gateway-api", "gateway-api-traefik":
+		key := ing.Namespace + "/" + ing.Name
+		route, ok := routes[key]
+		if !ok {
+			route, ok = routes[key+"-redirect"]
+		}
+		if ok {
+			if route.Accepted {
+				return MigrationDone
+			}
+			return MigrationInProgress
+		}
+	}
+	return MigrationNotStarted
+}
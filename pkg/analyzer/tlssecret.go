@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// TLSSecretWarnings flags the TLS secrets of an ingress that the scan found
+// missing or not of type kubernetes.io/tls. The generated listeners and TLS
+// blocks reference them by name, so a broken secret today is a TLS failure
+// after cutover. missing reports whether any secret does not exist.
+func TLSSecretWarnings(ing scanner.IngressInfo, target string) (warnings []string, missing bool) {
+	for _, issue := range ing.TLSSecretIssues {
+		if issue.Missing() {
+			missing = true
+			var fails string
+			switch target {
+			case "traefik":
+				fails = "Traefik serves its default self-signed certificate for these hosts"
+			default:
+				fails = "the Gateway HTTPS listener's certificateRef does not resolve and its hosts get no certificate"
+			}
+			warnings = append(warnings, fmt.Sprintf("TLS secret %s/%s does not exist: ingress-nginx is serving its fake default certificate, and %s; create the secret (or fix spec.tls.secretName) before cutover",
+				ing.Namespace, issue.Name, fails))
+			continue
+		}
+		var fix string
+		switch target {
+		case "traefik":
+			fix = "Traefik reads its tls.crt and tls.key whatever the type, but recreate it with kubectl create secret tls to be safe"
+		default:
+			fix = "Gateway API implementations need only accept kubernetes.io/tls Secrets in certificateRefs; recreate it with kubectl create secret tls"
+		}
+		warnings = append(warnings, fmt.Sprintf("TLS secret %s/%s is of type %s, not kubernetes.io/tls: %s",
+			ing.Namespace, issue.Name, issue.Type, fix))
+	}
+	return warnings, missing
+}
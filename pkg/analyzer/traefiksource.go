@@ -0,0 +1,59 @@
+package analyzer
+
+import "strings"
+
+// traefikSourceMappings defines how each traefik.ingress.kubernetes.io
+// annotation of an Ingress served by Traefik maps to Gateway API. Routers are
+// what the generated HTTPRoutes replace; service.* annotations are only read
+// from Services by Traefik (see MapTraefikAnnotation).
+var traefikSourceMappings = mappingTable{
+	"router.entrypoints":      {StatusPartial, "Gateway listener (sectionName)", "Routes attach to the generated Gateway's HTTP and HTTPS listeners; an entrypoint on another port needs its own listener, referenced by sectionName from the HTTPRoute parentRef"},
+	"router.middlewares":      {StatusPartial, "HTTPRoute (ExtensionRef filter)", "Reference each Middleware from an ExtensionRef filter (group traefik.io, kind Middleware) on the HTTPRoute rules; it must live in the route's namespace"},
+	"router.priority":         {StatusPartial, "HTTPRoute match precedence", "Gateway API has no route priority: matches are ordered by its precedence rules (exact path, longest prefix, method, headers) — check routes that overlap"},
+	"router.pathmatcher":      {StatusPartial, "HTTPRoute path match type", "Paths are matched by their pathType; change the HTTPRoute match type by hand (Exact or RegularExpression) to keep this matcher"},
+	"router.tls":              {StatusPartial, "Gateway HTTPS listener", "Routes are served over HTTPS only for hosts listed under the Ingress spec.tls; add the others, with their certificates, to the Gateway's HTTPS listener"},
+	"router.tls.certresolver": {StatusUnsupported, "Gateway listener certificateRefs", "Gateway API has no ACME resolver: issue the certificate with cert-manager and reference its Secret from the HTTPS listener"},
+	"router.tls.options":      {StatusPartial, "Gateway listener TLS options", "TLS versions and ciphers are implementation-specific: set them in the listener's tls.options"},
+}
+
+// MapTraefikAnnotation returns the mapping for a traefik.ingress.kubernetes.io
+// annotation, with key stripped of that prefix, on a Gateway API target. On
+// the traefik target these annotations are the target's own config and are
+// not mapped.
+func MapTraefikAnnotation(key, value, target string) AnnotationMapping {
+	mapping := AnnotationMapping{
+		OriginalKey:   traefikIngressAnnotationPrefix + key,
+		OriginalValue: value,
+	}
+	m, ok := traefikSourceMappings[key]
+	if !ok && strings.HasPrefix(key, "router.tls.domains.") {
+		// main and sans name the certificate the resolver requests
+		m, ok = traefikSourceMappings["router.tls.certresolver"]
+	}
+	switch {
+	case strings.HasPrefix(key, "service."):
+		mapping.Status = StatusSupported
+		mapping.Note = "No effect on an Ingress: Traefik reads service.* annotations from the backend Service, so nothing needs migrating"
+		return mapping
+	case !ok:
+		mapping.Status = StatusUnsupported
+		mapping.Note = "Unknown Traefik annotation — manual review required"
+		return mapping
+	}
+	mapping.Status, mapping.TargetResource, mapping.Note = m.Status, m.TargetResource, m.Note
+
+	switch {
+	case key == "router.middlewares" && target == "gateway-api":
+		mapping.Status = StatusUnsupported
+		mapping.TargetResource = ""
+		mapping.Note = "Envoy Gateway does not read Traefik Middlewares: re-express each with HTTPRoute filters or Envoy Gateway policies"
+	case key == "router.tls.options" && target == "gateway-api":
+		mapping.TargetResource = "ClientTrafficPolicy (tls)"
+		mapping.Note = "Set the TLS versions and ciphers of the TLSOption in a ClientTrafficPolicy targeting the Gateway"
+	case key == "router.pathmatcher" && value == "PathPrefix":
+		// Traefik's default, and what a Prefix pathType becomes anyway
+		mapping.Status = StatusSupported
+		mapping.Note = "Prefix paths become PathPrefix matches"
+	}
+	return mapping
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/saiyam1814/ing-switch/pkg/analyzer"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
+	"github.com/saiyam1814/ing-switch/pkg/version"
 )
 
 // GenerateHTMLReport produces a self-contained HTML report of the migration analysis.
@@ -65,8 +66,8 @@ func GenerateHTMLReport(scan *scanner.ScanResult, report *analyzer.AnalysisRepor
 `)
 
 	sb.WriteString(`<h1>ing-switch Migration Report</h1>`)
-	sb.WriteString(fmt.Sprintf(`<p class="meta">Target: <strong>%s</strong> &middot; Generated: %s</p>`,
-		html.EscapeString(report.Target), time.Now().Format("2006-01-02 15:04 MST")))
+	sb.WriteString(fmt.Sprintf(`<p class="meta">Target: <strong>%s</strong> &middot; Generated: %s &middot; ing-switch %s</p>`,
+		html.EscapeString(report.Target), time.Now().Format("2006-01-02 15:04 MST"), html.EscapeString(version.Version)))
 
 	// Cluster info
 	if scan != nil {
@@ -156,7 +157,7 @@ func GenerateHTMLReport(scan *scanner.ScanResult, report *analyzer.AnalysisRepor
 		sb.WriteString(fmt.Sprintf(`<div class="cards"><div class="card"><div class="label">Readiness Score</div><div class="value green">%d%%</div></div></div>`, score))
 	}
 
-	sb.WriteString(fmt.Sprintf(`<footer>Generated by <strong>ing-switch</strong> &middot; %s</footer>`, time.Now().Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf(`<footer>Generated by <strong>ing-switch</strong> %s &middot; %s</footer>`, html.EscapeString(version.String()), time.Now().Format("2006-01-02")))
 	sb.WriteString(`</div></body></html>`)
 
 	return sb.String()
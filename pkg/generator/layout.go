@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"path"
+	"strings"
+)
+
+// Output directory layouts selectable with --layout. Numbered keeps the
+// migrators' "NN-step/" directories, which the generated scripts and the
+// migration report's next steps refer to; the other two only rename files.
+const (
+	LayoutNumbered = "numbered"
+	LayoutFlat     = "flat"
+	LayoutByKind   = "by-kind"
+)
+
+// applyLayout rewrites RelPath for the given layout. Flat puts every file in
+// the output root; by-kind puts each YAML file in a directory named after the
+// kind of its first resource (scripts and guides go to scripts/ and docs/).
+// When files from different directories would end up with the same path,
+// each is prefixed with its step directory minus the number, e.g.
+// "install-traefik-values.yaml".
+func applyLayout(files []GeneratedFile, layout string) []GeneratedFile {
+	if layout == "" || layout == LayoutNumbered {
+		return files
+	}
+
+	paths := make([]string, len(files))
+	sources := make(map[string]map[string]bool) // new path → original paths
+	for i, f := range files {
+		paths[i] = path.Base(f.RelPath)
+		if layout == LayoutByKind {
+			paths[i] = path.Join(kindDir(f), paths[i])
+		}
+		if sources[paths[i]] == nil {
+			sources[paths[i]] = make(map[string]bool)
+		}
+		sources[paths[i]][f.RelPath] = true
+	}
+
+	laid := make([]GeneratedFile, len(files))
+	for i, f := range files {
+		if len(sources[paths[i]]) > 1 {
+			if step := stepName(path.Dir(f.RelPath)); step != "" {
+				dir, base := path.Split(paths[i])
+				paths[i] = dir + step + "-" + base
+			}
+		}
+		f.RelPath = paths[i]
+		laid[i] = f
+	}
+	return laid
+}
+
+// kindDir is the by-kind directory for f: the lowercased kind of its first
+// resource, or scripts/, docs/ or other/ for files without one.
+func kindDir(f GeneratedFile) string {
+	switch path.Ext(f.RelPath) {
+	case ".sh":
+		return "scripts"
+	case ".md":
+		return "docs"
+	}
+	for _, line := range strings.Split(f.Content, "\n") {
+		if kind, ok := strings.CutPrefix(line, "kind:"); ok {
+			if kind = strings.TrimSpace(kind); kind != "" {
+				return strings.ToLower(kind)
+			}
+		}
+	}
+	return "other"
+}
+
+// stepName strips the "NN-" prefix from a step directory; "" for the root.
+func stepName(dir string) string {
+	if dir == "." {
+		return ""
+	}
+	dir = path.Base(dir)
+	if n, rest, ok := strings.Cut(dir, "-"); ok && strings.Trim(n, "0123456789") == "" {
+		return rest
+	}
+	return dir
+}
@@ -4,44 +4,88 @@ import (
 	"archive/zip"
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/version"
 )
 
 // OutputGenerator writes generated files to disk.
 type OutputGenerator struct {
 	outputDir string
+	progress  io.Writer
+	merge     bool
+	layout    string
+	report    ReportOptions
+	resources bool
+	changed   []string
 }
 
 // NewOutputGenerator creates an OutputGenerator for the given directory.
 func NewOutputGenerator(outputDir string) *OutputGenerator {
-	return &OutputGenerator{outputDir: outputDir}
+	return &OutputGenerator{outputDir: outputDir, progress: os.Stdout}
+}
+
+// SetProgressOutput redirects the "+ file" progress lines printed while
+// writing (default: stdout). Pass io.Discard to silence them.
+func (g *OutputGenerator) SetProgressOutput(w io.Writer) {
+	g.progress = w
+}
+
+// SetMerge lets Write reuse a non-empty output directory from a previous
+// run. Existing files that differ from the new output are left untouched
+// and the new content is written next to them as "<file>.new", so
+// hand-edits survive a re-run.
+func (g *OutputGenerator) SetMerge(enabled bool) {
+	g.merge = enabled
+}
+
+// SetLayout selects how generated files are arranged in the output
+// directory: LayoutNumbered (default), LayoutFlat or LayoutByKind.
+func (g *OutputGenerator) SetLayout(layout string) {
+	g.layout = layout
+}
+
+// SetReportOptions sets what 00-migration-report.md includes.
+func (g *OutputGenerator) SetReportOptions(opts ReportOptions) {
+	g.report = opts
+}
+
+// SetResourcesOnly makes Write and WriteTargets leave out the scaffolding
+// (see ResourcesOnly), migration reports included.
+func (g *OutputGenerator) SetResourcesOnly(enabled bool) {
+	g.resources = enabled
+}
+
+// Changed returns the files (relative paths) that already existed with
+// different content during the last merge Write; each has a ".new" sibling.
+func (g *OutputGenerator) Changed() []string {
+	return g.changed
 }
 
 // Write creates the output directory structure and writes all files.
 func (g *OutputGenerator) Write(files []GeneratedFile, report *analyzer.AnalysisReport) error {
 	// Warn if the output directory already exists and contains files — the
 	// generator does not overwrite, so stale files from a previous run would
-	// be mixed in with the new output.
-	if entries, err := os.ReadDir(g.outputDir); err == nil && len(entries) > 0 {
-		return fmt.Errorf(
-			"output directory %q already exists and is not empty.\n"+
-				"Delete it (rm -rf %s) or choose a different --output-dir before regenerating.",
-			g.outputDir, g.outputDir,
-		)
+	// be mixed in with the new output. Merge mode opts in to exactly that.
+	g.changed = nil
+	if err := g.prepareOutputDir(); err != nil {
+		return err
 	}
 
-	if err := os.MkdirAll(g.outputDir, 0755); err != nil {
-		return fmt.Errorf("creating output directory: %w", err)
-	}
+	files = StampVersion(applyLayout(files, g.layout))
 
-	// Write migration report first
-	reportContent := generateMigrationReport(files, report)
-	if err := g.writeFile("00-migration-report.md", reportContent); err != nil {
-		return err
+	if g.resources {
+		files = ResourcesOnly(files)
+	} else {
+		// Write migration report first
+		reportContent := generateMigrationReport(files, report, g.report)
+		if err := g.writeFile("00-migration-report.md", reportContent); err != nil {
+			return err
+		}
 	}
 
 	// Write all generated files
@@ -51,28 +95,39 @@ func (g *OutputGenerator) Write(files []GeneratedFile, report *analyzer.Analysis
 		}
 	}
 
-	// Make shell scripts executable
-	for _, f := range files {
-		if strings.HasSuffix(f.RelPath, ".sh") {
-			fullPath := filepath.Join(g.outputDir, f.RelPath)
-			_ = os.Chmod(fullPath, 0755)
-		}
+	return nil
+}
+
+// prepareOutputDir creates the output directory, refusing a non-empty one
+// unless merging.
+func (g *OutputGenerator) prepareOutputDir() error {
+	if entries, err := os.ReadDir(g.outputDir); err == nil && len(entries) > 0 && !g.merge {
+		return fmt.Errorf(
+			"output directory %q already exists and is not empty.\n"+
+				"Delete it (rm -rf %s), choose a different --output-dir, or pass --merge to keep edited files.",
+			g.outputDir, g.outputDir,
+		)
 	}
 
+	if err := os.MkdirAll(g.outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
 	return nil
 }
 
 // CreateZip creates an in-memory ZIP of all generated files.
-func CreateZip(files []GeneratedFile, report *analyzer.AnalysisReport) ([]byte, error) {
-	var buf bytes.Buffer
-	w := zip.NewWriter(&buf)
+func CreateZip(files []GeneratedFile, report *analyzer.AnalysisReport, opts ReportOptions) ([]byte, error) {
+	files = StampVersion(files)
 
 	// Add migration report
-	reportContent := generateMigrationReport(files, report)
-	if err := addToZip(w, "00-migration-report.md", reportContent); err != nil {
-		return nil, err
-	}
+	reportFile := GeneratedFile{RelPath: "00-migration-report.md", Content: generateMigrationReport(files, report, opts)}
+	return zipFiles(append([]GeneratedFile{reportFile}, files...))
+}
 
+// zipFiles archives files in order, as they are.
+func zipFiles(files []GeneratedFile) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
 	for _, f := range files {
 		if err := addToZip(w, f.RelPath, f.Content); err != nil {
 			return nil, err
@@ -86,16 +141,37 @@ func CreateZip(files []GeneratedFile, report *analyzer.AnalysisReport) ([]byte,
 	return buf.Bytes(), nil
 }
 
+// writeFile writes one file, making shell scripts executable. In merge mode
+// an identical existing file is skipped and a differing one gets a ".new"
+// sibling instead of being overwritten.
 func (g *OutputGenerator) writeFile(relPath, content string) error {
 	fullPath := filepath.Join(g.outputDir, relPath)
 	dir := filepath.Dir(fullPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("creating directory %s: %w", dir, err)
 	}
+
+	marker := "+"
+	if g.merge {
+		if existing, err := os.ReadFile(fullPath); err == nil {
+			if string(existing) == content {
+				fmt.Fprintf(g.progress, "  = %s\n", relPath)
+				return nil
+			}
+			g.changed = append(g.changed, relPath)
+			fullPath += ".new"
+			relPath += ".new"
+			marker = "~"
+		}
+	}
+
 	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
 		return fmt.Errorf("writing file %s: %w", fullPath, err)
 	}
-	fmt.Printf("  + %s\n", relPath)
+	if strings.HasSuffix(relPath, ".sh") {
+		_ = os.Chmod(fullPath, 0755)
+	}
+	fmt.Fprintf(g.progress, "  %s %s\n", marker, relPath)
 	return nil
 }
 
@@ -108,17 +184,27 @@ func addToZip(w *zip.Writer, name, content string) error {
 	return err
 }
 
+// ReportOptions controls what the migration report includes.
+type ReportOptions struct {
+	// IncludeSupported also lists the annotations that map cleanly, with
+	// their target resource, for a complete record of the migration. By
+	// default only partial and unsupported mappings are listed, and the
+	// supported ones are counted.
+	IncludeSupported bool
+}
+
 // GenerateMigrationReport produces the markdown migration report content.
 // It is exported so the API layer can include it in the response files list.
-func GenerateMigrationReport(files []GeneratedFile, report *analyzer.AnalysisReport) string {
-	return generateMigrationReport(files, report)
+func GenerateMigrationReport(files []GeneratedFile, report *analyzer.AnalysisReport, opts ReportOptions) string {
+	return generateMigrationReport(files, report, opts)
 }
 
-func generateMigrationReport(files []GeneratedFile, report *analyzer.AnalysisReport) string {
+func generateMigrationReport(files []GeneratedFile, report *analyzer.AnalysisReport, opts ReportOptions) string {
 	var sb strings.Builder
 
 	sb.WriteString("# ing-switch Migration Report\n\n")
 	sb.WriteString(fmt.Sprintf("**Target Controller:** %s\n\n", report.Target))
+	sb.WriteString(fmt.Sprintf("**ing-switch Version:** %s\n\n", version.String()))
 
 	sb.WriteString("## Summary\n\n")
 	sb.WriteString(fmt.Sprintf("| Metric | Count |\n|--------|-------|\n"))
@@ -127,6 +213,17 @@ func generateMigrationReport(files []GeneratedFile, report *analyzer.AnalysisRep
 	sb.WriteString(fmt.Sprintf("| Needs Workarounds | %d |\n", report.Summary.NeedsWorkaround))
 	sb.WriteString(fmt.Sprintf("| Has Unsupported Annotations | %d |\n\n", report.Summary.HasUnsupported))
 
+	if len(report.StreamServices) > 0 {
+		sb.WriteString("## ⚠️ TCP/UDP Services\n\n")
+		sb.WriteString("These ports are exposed through the ingress-nginx `tcp-services` / `udp-services` ConfigMaps, ")
+		sb.WriteString("not Ingress resources. They stop working when NGINX is removed unless the generated stream routes are applied.\n\n")
+		sb.WriteString("| Protocol | Port | Backend | ConfigMap |\n|----------|------|---------|-----------|\n")
+		for _, st := range report.StreamServices {
+			sb.WriteString(fmt.Sprintf("| %s | %d | `%s/%s:%s` | `%s` |\n", st.Protocol, st.Port, st.Namespace, st.Service, st.ServicePort, st.ConfigMap))
+		}
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString("## Ingress Analysis\n\n")
 	for _, ir := range report.IngressReports {
 		status := map[string]string{
@@ -136,11 +233,25 @@ func generateMigrationReport(files []GeneratedFile, report *analyzer.AnalysisRep
 		}[ir.OverallStatus]
 
 		sb.WriteString(fmt.Sprintf("### %s/%s\n\n**Status:** %s\n\n", ir.Namespace, ir.Name, status))
+		for _, warn := range ir.Warnings {
+			sb.WriteString(fmt.Sprintf("> ⚠️ %s\n\n", warn))
+		}
 
-		if len(ir.Mappings) > 0 {
+		var listed []analyzer.AnnotationMapping
+		supported := 0
+		for _, m := range ir.Mappings {
+			if m.Status == analyzer.StatusSupported {
+				supported++
+				if !opts.IncludeSupported {
+					continue
+				}
+			}
+			listed = append(listed, m)
+		}
+		if len(listed) > 0 {
 			sb.WriteString("| Annotation | Status | Target Resource | Notes |\n")
 			sb.WriteString("|-----------|--------|-----------------|-------|\n")
-			for _, m := range ir.Mappings {
+			for _, m := range listed {
 				statusIcon := map[analyzer.MappingStatus]string{
 					analyzer.StatusSupported:   "✅",
 					analyzer.StatusPartial:     "⚠️",
@@ -151,23 +262,40 @@ func generateMigrationReport(files []GeneratedFile, report *analyzer.AnalysisRep
 			}
 			sb.WriteString("\n")
 		}
+		if supported > 0 && !opts.IncludeSupported {
+			sb.WriteString(fmt.Sprintf("%d annotation(s) map cleanly (`--include-supported` lists them).\n\n", supported))
+		}
+	}
+
+	if warnings := AllWarnings(files); len(warnings) > 0 {
+		sb.WriteString(fmt.Sprintf("## ⚠️ Manual Steps (%d)\n\n", len(warnings)))
+		sb.WriteString("The generated files could not fully express these; each is also a `# NOTE:` comment in its file.\n\n")
+		for _, entry := range warnings {
+			relPath, w, _ := strings.Cut(entry, ": ")
+			sb.WriteString(fmt.Sprintf("- `%s` — %s\n", relPath, w))
+		}
+		sb.WriteString("\n")
 	}
 
 	sb.WriteString("## Generated Files\n\n")
 	categories := map[string][]GeneratedFile{}
+	var categoryOrder []string
 	for _, f := range files {
+		if _, seen := categories[f.Category]; !seen {
+			categoryOrder = append(categoryOrder, f.Category)
+		}
 		categories[f.Category] = append(categories[f.Category], f)
 	}
-	for cat, catFiles := range categories {
+	for _, cat := range categoryOrder {
 		sb.WriteString(fmt.Sprintf("### %s\n\n", cat))
-		for _, f := range catFiles {
+		for _, f := range categories[cat] {
 			sb.WriteString(fmt.Sprintf("- `%s` — %s\n", f.RelPath, f.Description))
 		}
 		sb.WriteString("\n")
 	}
 
 	sb.WriteString("---\n")
-	sb.WriteString("*Generated by ing-switch — https://github.com/saiyam1814/ing-switch*\n")
+	sb.WriteString(fmt.Sprintf("*Generated by ing-switch %s — https://github.com/saiyam1814/ing-switch*\n", version.Version))
 
 	return sb.String()
 }
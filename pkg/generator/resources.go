@@ -0,0 +1,35 @@
+package generator
+
+// scaffoldingCategories are the categories of files that install, verify,
+// or remove controllers and guide the cutover, rather than route traffic.
+var scaffoldingCategories = map[string]bool{
+	"install": true,
+	"verify":  true,
+	"guide":   true,
+	"cleanup": true,
+}
+
+// ApplyableCategories are the categories of files whose YAML can be
+// kubectl-applied directly: what 'ing-switch apply', the TUI and the UI's
+// apply action send to the cluster.
+var ApplyableCategories = map[string]bool{
+	"middleware": true,
+	"ingress":    true,
+	"gateway":    true,
+	"httproute":  true,
+	"policy":     true,
+}
+
+// ResourcesOnly returns the files that are not scaffolding: the Middlewares,
+// Ingresses, Gateways, HTTPRoutes, policies, and NetworkPolicies, with the
+// scripts that patch the resources they depend on. It is the output for
+// teams that install controllers separately, e.g. from a GitOps repo.
+func ResourcesOnly(files []GeneratedFile) []GeneratedFile {
+	var kept []GeneratedFile
+	for _, f := range files {
+		if !scaffoldingCategories[f.Category] {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
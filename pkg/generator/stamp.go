@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/version"
+)
+
+// stampPrefix marks the version header so StampVersion never adds it twice.
+const stampPrefix = "# Generated by ing-switch "
+
+// StampVersion prepends a "# Generated by ing-switch <version>" comment to
+// every YAML file and shell script, keeping a leading shebang on the first
+// line. Other files (markdown guides) are returned unchanged.
+func StampVersion(files []GeneratedFile) []GeneratedFile {
+	stamped := make([]GeneratedFile, len(files))
+	for i, f := range files {
+		f.Content = stampContent(f.RelPath, f.Content)
+		stamped[i] = f
+	}
+	return stamped
+}
+
+func stampContent(relPath, content string) string {
+	switch filepath.Ext(relPath) {
+	case ".yaml", ".yml", ".sh":
+	default:
+		return content
+	}
+	if strings.Contains(content, stampPrefix) {
+		return content
+	}
+
+	header := stampPrefix + version.Version + " (commit " + version.Commit + ")\n"
+	if strings.HasPrefix(content, "#!") {
+		shebang, rest, _ := strings.Cut(content, "\n")
+		return shebang + "\n" + header + rest
+	}
+	return header + content
+}
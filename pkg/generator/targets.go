@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/version"
+)
+
+// TargetAll generates every target in AllTargets side by side, each in a
+// subdirectory named after it, for teams still choosing between them.
+const TargetAll = "all"
+
+// AllTargets are the targets TargetAll generates.
+var AllTargets = []string{"traefik", "gateway-api"}
+
+// TargetOutput is one target's generated files and analysis.
+type TargetOutput struct {
+	Target string
+	Files  []GeneratedFile
+	Report *analyzer.AnalysisReport
+}
+
+// CombineTargets lays out several targets' output in one tree: each target's
+// files and migration report under "<target>/", and a root
+// 00-migration-report.md comparing the analyses. layout is applied within
+// each target directory, opts to each target's report.
+func CombineTargets(outputs []TargetOutput, layout string, opts ReportOptions) []GeneratedFile {
+	files := []GeneratedFile{{
+		RelPath:     "00-migration-report.md",
+		Content:     generateComparisonReport(outputs),
+		Description: "Side-by-side comparison of the target analyses",
+		Category:    "guide",
+	}}
+	for _, o := range outputs {
+		targetFiles := StampVersion(applyLayout(o.Files, layout))
+		files = append(files, GeneratedFile{
+			RelPath:     path.Join(o.Target, "00-migration-report.md"),
+			Content:     generateMigrationReport(targetFiles, o.Report, opts),
+			Description: fmt.Sprintf("Migration summary and annotation analysis for %s", o.Target),
+			Category:    "guide",
+		})
+		for _, f := range targetFiles {
+			f.RelPath = path.Join(o.Target, f.RelPath)
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// WriteTargets writes CombineTargets output, with the same output directory
+// checks as Write.
+func (g *OutputGenerator) WriteTargets(outputs []TargetOutput) error {
+	g.changed = nil
+	if err := g.prepareOutputDir(); err != nil {
+		return err
+	}
+	files := CombineTargets(outputs, g.layout, g.report)
+	if g.resources {
+		files = ResourcesOnly(files)
+	}
+	for _, f := range files {
+		if err := g.writeFile(f.RelPath, f.Content); err != nil {
+			return fmt.Errorf("writing %s: %w", f.RelPath, err)
+		}
+	}
+	return nil
+}
+
+// CreateTargetsZip is CreateZip for CombineTargets output.
+func CreateTargetsZip(outputs []TargetOutput, opts ReportOptions) ([]byte, error) {
+	return zipFiles(CombineTargets(outputs, LayoutNumbered, opts))
+}
+
+// generateComparisonReport summarizes each target's analysis in one table,
+// then shows every ingress's status per target.
+func generateComparisonReport(outputs []TargetOutput) string {
+	var sb strings.Builder
+
+	sb.WriteString("# ing-switch Target Comparison\n\n")
+	sb.WriteString(fmt.Sprintf("**ing-switch Version:** %s\n\n", version.String()))
+	sb.WriteString("Each target's manifests and full migration report are in its own directory.\n\n")
+
+	header := "| Metric |"
+	sep := "|--------|"
+	for _, o := range outputs {
+		header += fmt.Sprintf(" %s |", o.Target)
+		sep += strings.Repeat("-", len(o.Target)+2) + "|"
+	}
+	sb.WriteString("## Summary\n\n" + header + "\n" + sep + "\n")
+	rows := []struct {
+		label string
+		count func(analyzer.Summary) int
+	}{
+		{"Total Ingresses", func(s analyzer.Summary) int { return s.Total }},
+		{"Fully Compatible", func(s analyzer.Summary) int { return s.FullyCompatible }},
+		{"Needs Workarounds", func(s analyzer.Summary) int { return s.NeedsWorkaround }},
+		{"Has Unsupported Annotations", func(s analyzer.Summary) int { return s.HasUnsupported }},
+	}
+	for _, row := range rows {
+		sb.WriteString("| " + row.label + " |")
+		for _, o := range outputs {
+			sb.WriteString(fmt.Sprintf(" %d |", row.count(o.Report.Summary)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("| Generated Files |")
+	for _, o := range outputs {
+		sb.WriteString(fmt.Sprintf(" %d |", len(o.Files)))
+	}
+	sb.WriteString("\n\n")
+
+	if len(outputs) > 0 {
+		status := map[string]string{"ready": "✅ ready", "workaround": "⚠️ workaround", "breaking": "❌ breaking"}
+		sb.WriteString("## Ingresses\n\n| Ingress |")
+		for _, o := range outputs {
+			sb.WriteString(fmt.Sprintf(" %s |", o.Target))
+		}
+		sb.WriteString("\n|---------|" + strings.Repeat("------|", len(outputs)) + "\n")
+		for i, ir := range outputs[0].Report.IngressReports {
+			sb.WriteString(fmt.Sprintf("| `%s/%s` |", ir.Namespace, ir.Name))
+			for _, o := range outputs {
+				cell := "-"
+				if i < len(o.Report.IngressReports) {
+					cell = status[o.Report.IngressReports[i].OverallStatus]
+				}
+				sb.WriteString(" " + cell + " |")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("---\n")
+	sb.WriteString(fmt.Sprintf("*Generated by ing-switch %s — https://github.com/saiyam1814/ing-switch*\n", version.Version))
+
+	return sb.String()
+}
@@ -10,4 +10,24 @@ type GeneratedFile struct {
 	Description string `json:"description"`
 	// Category groups files for UI display (e.g., "middleware", "ingress", "install")
 	Category string `json:"category"`
+	// Warnings are the manual steps the file's "# NOTE:" comments describe,
+	// so they can be listed without reading every file
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// AllWarnings lists the warnings of every file, each prefixed with the
+// file's RelPath, in file order. A warning repeated for the same path (two
+// generators writing one file) is listed once.
+func AllWarnings(files []GeneratedFile) []string {
+	var all []string
+	seen := map[string]bool{}
+	for _, f := range files {
+		for _, w := range f.Warnings {
+			if entry := f.RelPath + ": " + w; !seen[entry] {
+				seen[entry] = true
+				all = append(all, entry)
+			}
+		}
+	}
+	return all
 }
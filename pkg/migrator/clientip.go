@@ -0,0 +1,77 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// HasSourceRanges reports whether any ingress filters on the client IP with
+// whitelist-source-range or denylist-source-range.
+func HasSourceRanges(ingresses []scanner.IngressInfo) bool {
+	for _, ing := range ingresses {
+		if ing.NginxAnnotations["whitelist-source-range"] != "" || ing.NginxAnnotations["denylist-source-range"] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIPNotes explains what a migrated source-range filter cannot take
+// over from the ingress-nginx client-IP settings. fh is nil when the
+// ConfigMap could not be read, which is itself worth a warning: the filter
+// may end up matching the load balancer's address instead of the client's.
+func ClientIPNotes(fh *scanner.ForwardedHeaders) []string {
+	if fh == nil {
+		return []string{"the ingress-nginx ConfigMap could not be read: if it sets use-forwarded-headers, configure trusted proxies so this filter matches the client IP, not the load balancer's"}
+	}
+	var notes []string
+	if fh.UseForwardedHeaders && !strings.EqualFold(fh.ForwardedForHeader, "X-Forwarded-For") {
+		notes = append(notes, fmt.Sprintf("ingress-nginx reads the client IP from %s (forwarded-for-header); the generated configuration uses X-Forwarded-For", fh.ForwardedForHeader))
+	}
+	return notes
+}
+
+// TraefikIPStrategy returns the ipStrategy block, indented by indent, that
+// makes ipAllowList find the client as ingress-nginx did, or ""
+// when nginx used the connection address (use-forwarded-headers off), which
+// is Traefik's default. nginx's real_ip_recursive takes the rightmost
+// untrusted X-Forwarded-For address, which is excludedIPs; with every proxy
+// trusted (proxy-real-ip-cidr 0.0.0.0/0) depth 1 is the closest equivalent.
+func TraefikIPStrategy(fh *scanner.ForwardedHeaders, indent string) (yaml string, notes []string) {
+	if fh == nil || !fh.UseForwardedHeaders {
+		return "", nil
+	}
+	if fh.TrustsAllProxies() {
+		return fmt.Sprintf("%sipStrategy:\n%s  depth: 1\n", indent, indent),
+			[]string{"ingress-nginx trusts every proxy (proxy-real-ip-cidr 0.0.0.0/0) and uses the leftmost X-Forwarded-For address; depth 1 uses the one added by the nearest proxy, raise it for each extra proxy hop"}
+	}
+	return fmt.Sprintf("%sipStrategy:\n%s  excludedIPs:\n%s\n", indent, indent, YAMLList(fh.ProxyRealIPCIDR, indent+"  ")), nil
+}
+
+// TraefikEntryPointTrust returns the Helm values, indented by indent, for
+// one Traefik entrypoint under "ports" that trust X-Forwarded-* from the
+// proxies ingress-nginx trusted, and accept the PROXY protocol when nginx
+// did. Without them Traefik overwrites X-Forwarded-For and an ipStrategy only
+// ever sees the load balancer. It returns "" when nginx trusted neither.
+func TraefikEntryPointTrust(fh *scanner.ForwardedHeaders, indent string) string {
+	if fh == nil || (!fh.UseForwardedHeaders && !fh.UseProxyProtocol) {
+		return ""
+	}
+	trust := func(field string) string {
+		if fh.TrustsAllProxies() {
+			return fmt.Sprintf("%s%s:\n%s  insecure: true\n", indent, field, indent)
+		}
+		return fmt.Sprintf("%s%s:\n%s  trustedIPs:\n%s\n", indent, field, indent, YAMLList(fh.ProxyRealIPCIDR, indent+"    "))
+	}
+
+	out := fmt.Sprintf("%s# Client IP settings from the ingress-nginx ConfigMap %s\n", indent, fh.ConfigMap)
+	if fh.UseForwardedHeaders {
+		out += trust("forwardedHeaders")
+	}
+	if fh.UseProxyProtocol {
+		out += trust("proxyProtocol")
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package migrator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The Traefik chart's entrypoints for HTTP (port 8000, exposed on 80) and
+// HTTPS (8443, exposed on 443).
+const (
+	DefaultWebEntryPoint       = "web"
+	DefaultWebSecureEntryPoint = "websecure"
+)
+
+var entryPointNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// EntryPoints names the Traefik entrypoints that serve HTTP and HTTPS, so
+// the generated values and routers fit an existing Traefik install whose
+// entrypoints are not called web and websecure.
+type EntryPoints struct {
+	Web       string
+	WebSecure string
+}
+
+// DefaultEntryPoints returns the Traefik chart's entrypoint names.
+func DefaultEntryPoints() EntryPoints {
+	return EntryPoints{Web: DefaultWebEntryPoint, WebSecure: DefaultWebSecureEntryPoint}
+}
+
+// Validate checks that both names are usable as a Helm values key and in a
+// comma-separated router.entrypoints annotation, and that they differ.
+func (e EntryPoints) Validate() error {
+	for _, name := range []string{e.Web, e.WebSecure} {
+		if !entryPointNameRe.MatchString(name) {
+			return fmt.Errorf("invalid entrypoint name %q — use letters, digits, '-' and '_'", name)
+		}
+	}
+	if e.Web == e.WebSecure {
+		return fmt.Errorf("the web and websecure entrypoints must differ, both are %q", e.Web)
+	}
+	return nil
+}
+
+// Custom reports whether either name differs from the chart's. Only then do
+// generated routers name their entrypoints: Traefik attaches routers without
+// any to every entrypoint.
+func (e EntryPoints) Custom() bool {
+	return e != DefaultEntryPoints()
+}
+
+// Annotation is the traefik.ingress.kubernetes.io/router.entrypoints value
+// for routers served on both entrypoints.
+func (e EntryPoints) Annotation() string {
+	return strings.Join([]string{e.Web, e.WebSecure}, ",")
+}
+
+// UnusedDefaults lists the chart entrypoints neither name takes over. The
+// chart defines them whatever the values say, on the ports the renamed
+// entrypoints need, so the generated values must drop them.
+func (e EntryPoints) UnusedDefaults() []string {
+	var unused []string
+	for _, name := range []string{DefaultWebEntryPoint, DefaultWebSecureEntryPoint} {
+		if name != e.Web && name != e.WebSecure {
+			unused = append(unused, name)
+		}
+	}
+	return unused
+}
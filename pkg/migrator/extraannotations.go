@@ -0,0 +1,39 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// reservedAnnotationPrefix is the prefix of the annotations ing-switch
+// writes itself (content hash, rewrite paths), which users may not set.
+const reservedAnnotationPrefix = "ing-switch.io/"
+
+// ExtraAnnotations merges the key=value pairs of --set-annotation over base,
+// the set_annotations of .ing-switch.yaml: a later pair overrides an earlier
+// one, and a pair overrides the config. Every key must be a valid annotation
+// name outside ing-switch.io/.
+func ExtraAnnotations(base map[string]string, pairs []string) (map[string]string, error) {
+	extra := make(map[string]string, len(base)+len(pairs))
+	for k, v := range base {
+		extra[k] = v
+	}
+	for _, pair := range pairs {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --set-annotation %q — use key=value", pair)
+		}
+		extra[strings.TrimSpace(k)] = v
+	}
+	for k := range extra {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid annotation key %q: %s", k, strings.Join(errs, "; "))
+		}
+		if strings.HasPrefix(k, reservedAnnotationPrefix) {
+			return nil, fmt.Errorf("annotation key %q is reserved — ing-switch sets the %s annotations itself", k, reservedAnnotationPrefix)
+		}
+	}
+	return extra, nil
+}
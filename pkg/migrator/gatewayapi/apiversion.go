@@ -0,0 +1,80 @@
+package gatewayapi
+
+import (
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+)
+
+// Gateway API versions of the generated GatewayClass, Gateway and HTTPRoute
+// resources, selectable with --api-version. The Standard channel serves
+// both; v1beta1 is for clusters whose CRDs predate Gateway API v1.0 and do
+// not serve v1 yet.
+const (
+	APIVersionV1      = "v1"
+	APIVersionV1Beta1 = "v1beta1"
+)
+
+// ValidAPIVersion reports whether v is one of the APIVersion* versions.
+func ValidAPIVersion(v string) bool {
+	return v == APIVersionV1 || v == APIVersionV1Beta1
+}
+
+const v1APIVersionLine = "apiVersion: gateway.networking.k8s.io/v1\n"
+
+// timeoutsNote explains the HTTPRoute timeouts left out for v1beta1.
+const timeoutsNote = "HTTPRoute rule timeouts (from proxy-read-timeout) are left out: the CRDs of Gateway API releases " +
+	"before v1.0, which only serve v1beta1, do not have the field — set the timeout with the controller's own policy"
+
+// grpcRouteVersionNote explains why a GRPCRoute stays v1 under v1beta1.
+const grpcRouteVersionNote = "GRPCRoute stays gateway.networking.k8s.io/v1: it has no v1beta1 version, and needs the CRDs of Gateway API v1.1 or later"
+
+// withAPIVersion rewrites the gateway.networking.k8s.io/v1 resources in the
+// generated files to version. v1beta1 HTTPRoutes lose their rule timeouts,
+// which came with v1.0, and say so in a NOTE. Other Gateway API kinds
+// (ReferenceGrant, TCPRoute, ...) keep the version they are generated with,
+// and so do GRPCRoutes, which only have v1 (each is in a file of its own).
+func withAPIVersion(files []generator.GeneratedFile, version string) []generator.GeneratedFile {
+	if version == "" || version == APIVersionV1 {
+		return files
+	}
+	for i, f := range files {
+		if !strings.HasSuffix(f.RelPath, ".yaml") || !strings.Contains(f.Content, v1APIVersionLine) {
+			continue
+		}
+		if strings.Contains(f.Content, "\nkind: GRPCRoute\n") {
+			f.Content = migrator.NoteComments([]string{grpcRouteVersionNote}) + f.Content
+			f.Warnings = migrator.AppendNotes(f.Warnings, grpcRouteVersionNote)
+			files[i] = f
+			continue
+		}
+		content := strings.ReplaceAll(f.Content, v1APIVersionLine, "apiVersion: gateway.networking.k8s.io/"+version+"\n")
+		if stripped, ok := stripTimeouts(content); ok {
+			content = migrator.NoteComments([]string{timeoutsNote}) + stripped
+			f.Warnings = migrator.AppendNotes(f.Warnings, timeoutsNote)
+		}
+		f.Content = content
+		files[i] = f
+	}
+	return files
+}
+
+// stripTimeouts removes the rule timeouts blocks (see buildTimeouts) from
+// HTTPRoute YAML. ok is false when there were none.
+func stripTimeouts(yaml string) (string, bool) {
+	lines := strings.SplitAfter(yaml, "\n")
+	var out strings.Builder
+	stripped := false
+	for i := 0; i < len(lines); i++ {
+		if lines[i] != "    timeouts:\n" {
+			out.WriteString(lines[i])
+			continue
+		}
+		stripped = true
+		for i+1 < len(lines) && strings.HasPrefix(lines[i+1], "      ") {
+			i++
+		}
+	}
+	return out.String(), stripped
+}
@@ -0,0 +1,213 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// maxRouteRules is the most rules an HTTPRoute may have (spec.rules
+// maxItems in the Gateway API CRDs).
+const maxRouteRules = 16
+
+// hostGroup is a set of same-namespace ingresses that serve exactly one
+// (shared) host and can be merged into a single HTTPRoute.
+type hostGroup struct {
+	namespace    string
+	host         string
+	redirectCode int
+	ingresses    []scanner.IngressInfo
+	namePrefix   string // set by relocate
+	part         int    // 1-based; parts after the first get a name suffix
+}
+
+// consolidationGroups groups ingresses by namespace, host, and SSL redirect
+// mode. Only groups with two or more ingresses are returned, split into parts
+// of at most maxRouteRules rules. Ingresses are left out (and keep their own
+// HTTPRoute) when they:
+//   - serve more than one host — merging would widen their hostnames
+//   - generate policies — those target a whole HTTPRoute and would leak onto
+//     the other ingresses' rules
+//   - become a GRPCRoute (see usesGRPCRoute)
+//   - have more than maxRouteRules rules on their own
+func consolidationGroups(scan *scanner.ScanResult, p Provider) []hostGroup {
+	idx := map[string]int{}
+	var groups []hostGroup
+	for _, ing := range scan.Ingresses {
+		if len(ing.Hosts) != 1 || hasRoutePolicies(ing, p) || usesGRPCRoute(ing) || routeRuleCount(ing) > maxRouteRules {
+			continue
+		}
+		code := sslRedirectCode(ing.NginxAnnotations)
+		key := fmt.Sprintf("%s/%s/%d", ing.Namespace, ing.Hosts[0], code)
+		i, ok := idx[key]
+		if !ok {
+			i = len(groups)
+			idx[key] = i
+			groups = append(groups, hostGroup{namespace: ing.Namespace, host: ing.Hosts[0], redirectCode: code})
+		}
+		groups[i].ingresses = append(groups[i].ingresses, ing)
+	}
+
+	var merged []hostGroup
+	for _, g := range groups {
+		if len(g.ingresses) > 1 {
+			merged = append(merged, g)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].namespace != merged[j].namespace {
+			return merged[i].namespace < merged[j].namespace
+		}
+		return merged[i].host < merged[j].host
+	})
+
+	var parts []hostGroup
+	for _, g := range merged {
+		parts = append(parts, g.split()...)
+	}
+	return parts
+}
+
+// split cuts the group into parts whose HTTPRoutes stay within
+// maxRouteRules, keeping each ingress's rules together and in order.
+func (g hostGroup) split() []hostGroup {
+	var parts []hostGroup
+	rules := 0
+	for _, ing := range g.ingresses {
+		n := routeRuleCount(ing)
+		if len(parts) == 0 || rules+n > maxRouteRules {
+			part := g
+			part.ingresses = nil
+			part.part = len(parts) + 1
+			parts = append(parts, part)
+			rules = 0
+		}
+		last := &parts[len(parts)-1]
+		last.ingresses = append(last.ingresses, ing)
+		rules += n
+	}
+	return parts
+}
+
+// routeRuleCount is the number of rules the ingress contributes to an
+// HTTPRoute: one per path, plus the app-root redirect rule. The redirect
+// route has one rule per path, never more.
+func routeRuleCount(ing scanner.IngressInfo) int {
+	n := len(routePaths(ing))
+	if _, hasRedirect := migrator.ParseRedirect(ing.NginxAnnotations); ing.NginxAnnotations["app-root"] != "" && !hasRedirect {
+		n++
+	}
+	return n
+}
+
+func hasRoutePolicies(ing scanner.IngressInfo, p Provider) bool {
+	if p.Name == "traefik" {
+		return len(generateTraefikGatewayPolicies(ing, nil)) > 0
+	}
+	return len(generateEnvoyPolicies(ing, nil)) > 0
+}
+
+// name derives a DNS-1123 HTTPRoute name from the host, e.g.
+// "api.example.com" → "api-example-com", "*.example.com" → "wildcard-example-com",
+// and "api-example-com-2" for the second part of a split group.
+func (g hostGroup) name() string {
+	name := strings.ReplaceAll(g.host, "*", "wildcard")
+	name = strings.ReplaceAll(name, ".", "-")
+	if g.part > 1 {
+		name += fmt.Sprintf("-%d", g.part)
+	}
+	return g.namePrefix + strings.ToLower(name)
+}
+
+// relocate moves the group's HTTPRoute to namespace ns, as migrator.Relocate
+// does for a single Ingress: the name is prefixed with the group's namespace
+// and the backends point back at it. The ingresses keep their own namespace
+// and name, which only label the merged rules.
+func (g hostGroup) relocate(ns string) hostGroup {
+	if ns == "" || ns == g.namespace {
+		return g
+	}
+	ingresses := make([]scanner.IngressInfo, len(g.ingresses))
+	for i, ing := range g.ingresses {
+		ingresses[i] = migrator.Relocate(ing, ns)
+		ingresses[i].Namespace, ingresses[i].Name = ing.Namespace, ing.Name
+	}
+	g.ingresses = ingresses
+	g.namePrefix = g.namespace + "-"
+	g.namespace = ns
+	return g
+}
+
+// sources lists the merged ingresses as "namespace/name".
+func (g hostGroup) sources() []string {
+	var refs []string
+	for _, ing := range g.ingresses {
+		refs = append(refs, ing.Namespace+"/"+ing.Name)
+	}
+	return refs
+}
+
+// generateConsolidatedHTTPRoute renders one HTTPRoute for every ingress in
+// the group. Each ingress contributes its own rules, built from its own
+// annotations, so filters (rewrite, CORS, headers, canary weights) stay
+// scoped to the paths they came from. With SSL redirect the same
+// redirect/backend split as generateSplitHTTPRoutes is used.
+func generateConsolidatedHTTPRoute(g hostGroup, gatewayName, gatewayNamespace string, hostnameToSection map[string]string) (string, []string) {
+	name := g.name()
+	hostnameSection := buildHostnameSection([]string{g.host})
+	header := fmt.Sprintf("# Consolidated from %d ingresses: %s\n", len(g.ingresses), strings.Join(g.sources(), ", "))
+
+	var backendRules, redirectRules, redirectNotes, notes []string
+	for _, ing := range g.ingresses {
+		comment := fmt.Sprintf("  # from %s/%s\n", ing.Namespace, ing.Name)
+		rules, ruleNotes := buildBackendOnlyRules(ing, ing.NginxAnnotations)
+		backendRules = append(backendRules, comment+rules)
+		notes = migrator.AppendNotes(notes, ruleNotes...)
+		if g.redirectCode != 0 {
+			rules, ruleNotes := buildRedirectOnlyRules(ing, g.redirectCode)
+			redirectRules = append(redirectRules, comment+rules)
+			redirectNotes = migrator.AppendNotes(redirectNotes, ruleNotes...)
+		}
+	}
+
+	parentRef := fmt.Sprintf("  - name: %s\n    namespace: %s", gatewayName, gatewayNamespace)
+	if g.redirectCode != 0 {
+		if section := hostnameToSection[g.host]; section != "" {
+			parentRef += fmt.Sprintf("\n    sectionName: %s", section)
+		}
+	}
+
+	backendRoute := fmt.Sprintf(`%sapiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  parentRefs:
+%s
+%s  rules:
+%s`, header, name, g.namespace, parentRef, hostnameSection, strings.Join(backendRules, ""))
+
+	if g.redirectCode == 0 {
+		return backendRoute, notes
+	}
+
+	redirectRoute := fmt.Sprintf(`# HTTP→HTTPS redirect route (attached to HTTP listener only)
+%sapiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: %s-redirect
+  namespace: %s
+spec:
+  parentRefs:
+  - name: %s
+    namespace: %s
+    sectionName: http
+%s  rules:
+%s`, migrator.NoteComments(redirectNotes), name, g.namespace, gatewayName, gatewayNamespace, hostnameSection, strings.Join(redirectRules, ""))
+
+	return redirectRoute + "---\n" + backendRoute, migrator.AppendNotes(redirectNotes, notes...)
+}
@@ -0,0 +1,73 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// shopIngress serves paths /<name>/0 … /<name>/<paths-1> on shop.example.com.
+func shopIngress(name string, paths int) scanner.IngressInfo {
+	ing := scanner.IngressInfo{
+		Name:             name,
+		Namespace:        "shop",
+		Hosts:            []string{"shop.example.com"},
+		NginxAnnotations: map[string]string{},
+	}
+	for i := 0; i < paths; i++ {
+		ing.Paths = append(ing.Paths, scanner.PathInfo{
+			Host: "shop.example.com", Path: fmt.Sprintf("/%s/%d", name, i), PathType: "Prefix", ServiceName: name, ServicePort: 80,
+		})
+	}
+	return ing
+}
+
+func TestConsolidationGroupsSplitAtMaxRules(t *testing.T) {
+	scan := &scanner.ScanResult{Ingresses: []scanner.IngressInfo{
+		shopIngress("cart", 6),
+		shopIngress("catalog", 6),
+		shopIngress("checkout", 6),
+		shopIngress("search", maxRouteRules+1),
+	}}
+
+	var got []string
+	for _, g := range consolidationGroups(scan, EnvoyProvider) {
+		got = append(got, fmt.Sprintf("%s=%s", g.name(), strings.Join(g.sources(), ",")))
+	}
+	want := []string{
+		"shop-example-com=shop/cart,shop/catalog",
+		"shop-example-com-2=shop/checkout",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("groups = %q, want %q", got, want)
+	}
+}
+
+func TestConsolidatedHTTPRouteListsSources(t *testing.T) {
+	scan := &scanner.ScanResult{Ingresses: []scanner.IngressInfo{shopIngress("cart", 1), shopIngress("catalog", 1)}}
+	m := NewMigrator()
+	m.SetConsolidateByHost(true)
+	files, err := m.Migrate(scan, analyzer.NewAnalyzer("gateway-api").Analyze(scan))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	for _, f := range files {
+		if f.RelPath != "04-httproutes/shop-shop-example-com.yaml" {
+			continue
+		}
+		want := fmt.Sprintf("%s: %q", migrator.SourceIngressesAnnotation, "shop/cart,shop/catalog")
+		if !strings.Contains(f.Content, want) {
+			t.Errorf("consolidated HTTPRoute lacks %s:\n%s", want, f.Content)
+		}
+		if strings.Contains(f.Content, migrator.SourceIngressLabel+":") {
+			t.Errorf("consolidated HTTPRoute is labelled with a single source ingress:\n%s", f.Content)
+		}
+		return
+	}
+	t.Fatal("no consolidated HTTPRoute generated")
+}
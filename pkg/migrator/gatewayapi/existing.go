@@ -0,0 +1,79 @@
+package gatewayapi
+
+import (
+	"fmt"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// generateEnvoyGatewaySkipInstall replaces helm-install.sh when the scan
+// found Envoy Gateway already running. It needs no new settings, so nothing
+// is installed or upgraded and no values.yaml is generated: the script only
+// waits for the running controller.
+func generateEnvoyGatewaySkipInstall(tc scanner.TargetController, p Provider) generator.GeneratedFile {
+	deployment := tc.Deployment
+	if deployment == "" {
+		deployment = "envoy-gateway"
+	}
+	return generator.GeneratedFile{
+		RelPath: "02-install-envoy-gateway/helm-install.sh",
+		Content: fmt.Sprintf(`#!/bin/bash
+# Envoy Gateway %[1]s was already running in namespace %[2]s when these files
+# were generated. Installing it again would conflict with that install, so
+# this step only checks it is ready.
+#
+# 03-gateway/gatewayclass.yaml uses controllerName %[3]s;
+# change it if this install watches another one.
+set -e
+
+echo "Envoy Gateway is already installed in %[2]s — skipping install."
+echo "Waiting for Envoy Gateway to be ready..."
+kubectl rollout status deployment/%[4]s -n %[2]s --timeout=120s
+
+echo ""
+echo "Next: Apply the GatewayClass and Gateway resources"
+echo "  kubectl apply -f ../03-gateway/"
+`, tc.Version, tc.Namespace, p.ControllerName, deployment),
+		Description: fmt.Sprintf("Readiness check for the Envoy Gateway already running in %s (install skipped)", tc.Namespace),
+		Category:    "install",
+	}
+}
+
+// generateTraefikGatewayUpgrade replaces helm-install.sh when the scan found
+// Traefik already running: its release is upgraded to enable the Gateway API
+// provider rather than a second Traefik installed next to it.
+func generateTraefikGatewayUpgrade(tc scanner.TargetController) generator.GeneratedFile {
+	return generator.GeneratedFile{
+		RelPath:     "02-install-traefik-gateway/helm-install.sh",
+		Content:     migrator.TraefikHelmUpgradeScript(tc, "Enabling the Gateway API provider", false),
+		Description: fmt.Sprintf("Helm upgrade script for the Traefik already running in %s", tc.Namespace),
+		Category:    "install",
+	}
+}
+
+// generateTraefikGatewayUpgradeValues is values.yaml for that upgrade: only
+// the providers and the client IP trust of the entrypoints (named by eps),
+// so the rest of the existing install is left alone.
+func generateTraefikGatewayUpgradeValues(fh *scanner.ForwardedHeaders, eps migrator.EntryPoints) generator.GeneratedFile {
+	ports := ""
+	if trust := migrator.TraefikEntryPointTrust(fh, "    "); trust != "" {
+		ports = "\n# Entrypoints\nports:\n  " + eps.Web + ":\n" + trust + "  " + eps.WebSecure + ":\n" + trust
+	}
+	return generator.GeneratedFile{
+		RelPath: "02-install-traefik-gateway/values.yaml",
+		Content: `# Traefik Helm values merged into the running Traefik release
+# (helm upgrade --reuse-values): only what Gateway API mode needs.
+
+# Keep serving the Ingresses until cutover, and serve the Gateway API
+providers:
+  kubernetesIngress:
+    enabled: true
+  kubernetesGateway:
+    enabled: true
+` + ports,
+		Description: "Traefik Helm values merged into the existing release",
+		Category:    "install",
+	}
+}
@@ -0,0 +1,52 @@
+package gatewayapi
+
+import (
+	"fmt"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// generateExternalNameBackends emits an Envoy Gateway Backend with an FQDN
+// endpoint for every ExternalName Service used as an Ingress backend. The
+// Backend keeps the Service's name, so switching a backendRef over only needs
+// group: gateway.envoyproxy.io and kind: Backend added.
+func generateExternalNameBackends(ingresses []scanner.IngressInfo) []generator.GeneratedFile {
+	var files []generator.GeneratedFile
+	seen := make(map[string]bool)
+	for _, ing := range ingresses {
+		for _, svc := range ing.Services {
+			key := svc.Namespace + "/" + svc.Name
+			if svc.ExternalName == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			port := svc.Port
+			if port == 0 {
+				port = 80
+			}
+			doc := fmt.Sprintf(`# Replaces ExternalName Service %s (→ %s). Enable the Backend API with
+# config.envoyGateway.extensionApis.enableBackend: true, then reference it from the
+# HTTPRoute as: group: gateway.envoyproxy.io, kind: Backend, name: %s
+apiVersion: gateway.envoyproxy.io/v1alpha1
+kind: Backend
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  endpoints:
+  - fqdn:
+      hostname: %s
+      port: %d
+`, key, svc.ExternalName, svc.Name, svc.Name, svc.Namespace, svc.ExternalName, port)
+			files = append(files, generator.GeneratedFile{
+				RelPath:     fmt.Sprintf("05-policies/%s-%s-backend.yaml", svc.Namespace, svc.Name),
+				Content:     migrator.AddLabels(doc, migrator.ManagedLabels("", "")),
+				Description: fmt.Sprintf("Envoy Gateway Backend for ExternalName Service %s", key),
+				Category:    "policy",
+			})
+		}
+	}
+	return files
+}
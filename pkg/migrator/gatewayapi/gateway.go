@@ -2,8 +2,10 @@ package gatewayapi
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
 )
 
@@ -17,6 +19,8 @@ type Provider struct {
 	Name              string // "envoy" or "traefik"
 	GatewayClassName  string // "eg" or "traefik"
 	ControllerName    string // controller name for GatewayClass
+	ControllerNamespace string // namespace the data plane runs in (Helm install target)
+	Target           string // analyzer target: "gateway-api" or "gateway-api-traefik"
 }
 
 var (
@@ -24,11 +28,15 @@ var (
 		Name:             "envoy",
 		GatewayClassName: "eg",
 		ControllerName:   "gateway.envoyproxy.io/gatewayclass-controller",
+		ControllerNamespace: "envoy-gateway-system",
+		Target:           "gateway-api",
 	}
 	TraefikProvider = Provider{
 		Name:             "traefik",
 		GatewayClassName: "traefik",
 		ControllerName:   "traefik.io/gateway-controller",
+		ControllerNamespace: "traefik",
+		Target:           "gateway-api-traefik",
 	}
 )
 
@@ -43,8 +51,33 @@ spec:
 `, p.GatewayClassName, p.ControllerName)
 }
 
+// Listener allowedRoutes.namespaces.from modes selectable with --allowed-routes.
+const (
+	AllowedRoutesAll      = "All"
+	AllowedRoutesSame     = "Same"
+	AllowedRoutesSelector = "Selector"
+)
+
 // generateGateway creates the Gateway resource with HTTP and HTTPS listeners.
-func generateGateway(scan *scanner.ScanResult, p Provider) string {
+// allowedRoutes is one of the AllowedRoutes* modes ("" means All), and
+// resourceNamespace the namespace the routes are moved to ("" for none).
+// notes are the NOTE comments in the Gateway.
+func generateGateway(scan *scanner.ScanResult, p Provider, allowedRoutes, resourceNamespace string) (yaml string, notes []string) {
+	namespaces := routeNamespaces(scan, resourceNamespace)
+	allowed := buildAllowedRoutes(allowedRoutes, namespaces)
+	header := ""
+	if allowedRoutes == AllowedRoutesSame {
+		for _, ns := range namespaces {
+			if ns != defaultGatewayNamespace {
+				header = fmt.Sprintf("# NOTE: allowedRoutes from: Same — only routes in %q can attach; generated routes in\n", defaultGatewayNamespace) +
+					"# other namespaces (" + strings.Join(namespaces, ", ") + ") will not be accepted.\n"
+				notes = append(notes, fmt.Sprintf("allowedRoutes from: Same — only routes in %q can attach; generated routes in other namespaces (%s) will not be accepted",
+					defaultGatewayNamespace, strings.Join(namespaces, ", ")))
+				break
+			}
+		}
+	}
+
 	// Collect all TLS secrets referenced by Ingresses
 	type tlsEntry struct {
 		hosts      []string
@@ -53,7 +86,7 @@ func generateGateway(scan *scanner.ScanResult, p Provider) string {
 	}
 	var tlsEntries []tlsEntry
 
-	for _, ing := range scan.Ingresses {
+	for _, ing := range scan.ListenerIngresses() {
 		if ing.TLSEnabled && len(ing.TLSSecrets) > 0 {
 			for _, secret := range ing.TLSSecrets {
 				tlsEntries = append(tlsEntries, tlsEntry{
@@ -77,10 +110,15 @@ func generateGateway(scan *scanner.ScanResult, p Provider) string {
       certificateRefs:
       - name: %s
         namespace: %s
-    allowedRoutes:
-      namespaces:
-        from: All
-`, i, hosts, tls.secretName, tls.namespace)
+%s`, i, hosts, tls.secretName, tls.namespace, allowed)
+	}
+
+	// Hosts without a TLS secret of their own were served the ingress-nginx
+	// --default-ssl-certificate; a listener without a hostname keeps that.
+	if ns, name, ok := scan.Controller.DefaultCertificate(); ok {
+		listener, certNotes := buildDefaultCertListener(ns, name, scan.Controller.Namespace, allowed)
+		tlsListeners += listener
+		notes = append(notes, certNotes...)
 	}
 
 	if tlsListeners == "" {
@@ -97,7 +135,7 @@ func generateGateway(scan *scanner.ScanResult, p Provider) string {
 `
 	}
 
-	return fmt.Sprintf(`apiVersion: gateway.networking.k8s.io/v1
+	return fmt.Sprintf(`%sapiVersion: gateway.networking.k8s.io/v1
 kind: Gateway
 metadata:
   name: %s
@@ -108,10 +146,85 @@ spec:
   - name: http
     protocol: HTTP
     port: 80
-    allowedRoutes:
+%s%s%s`, header, defaultGatewayName, defaultGatewayNamespace, p.GatewayClassName, allowed, tlsListeners, buildStreamListeners(scan.StreamServices, allowed)), notes
+}
+
+// buildDefaultCertListener returns a catch-all HTTPS listener serving the
+// ingress-nginx default certificate. controllerNamespace is where
+// ingress-nginx runs; a Secret there is deleted along with NGINX.
+func buildDefaultCertListener(namespace, name, controllerNamespace, allowed string) (string, []string) {
+	comments := ""
+	var notes []string
+	if namespace != defaultGatewayNamespace {
+		comments += fmt.Sprintf("    # NOTE: cross-namespace certificateRef — create a ReferenceGrant in %q\n", namespace)
+		comments += fmt.Sprintf("    # allowing Gateways in %q to reference Secrets.\n", defaultGatewayNamespace)
+		notes = append(notes, fmt.Sprintf("default certificate %s/%s is in another namespace — create a ReferenceGrant in %q allowing Gateways in %q to reference Secrets",
+			namespace, name, namespace, defaultGatewayNamespace))
+	}
+	if namespace == controllerNamespace {
+		comments += "    # NOTE: this Secret lives in the ingress-nginx namespace — copy it elsewhere\n"
+		comments += "    # before running cleanup, or the listener loses its certificate.\n"
+		notes = append(notes, fmt.Sprintf("default certificate %s/%s lives in the ingress-nginx namespace — copy it elsewhere before running cleanup, or the listener loses its certificate", namespace, name))
+	}
+	return fmt.Sprintf(`  - name: https-default
+    protocol: HTTPS
+    port: 443
+    # Default certificate (ingress-nginx --default-ssl-certificate), served for
+    # hosts that have no listener of their own.
+%s    tls:
+      mode: Terminate
+      certificateRefs:
+      - name: %s
+        namespace: %s
+%s`, comments, name, namespace, allowed), notes
+}
+
+// buildAllowedRoutes renders a listener's allowedRoutes block. Selector
+// admits exactly the namespaces the migrated routes live in, by the
+// kubernetes.io/metadata.name label every namespace carries.
+func buildAllowedRoutes(mode string, namespaces []string) string {
+	switch mode {
+	case AllowedRoutesSame:
+		return "    allowedRoutes:\n      namespaces:\n        from: Same\n"
+	case AllowedRoutesSelector:
+		if len(namespaces) == 0 {
+			// An empty In list is invalid; nothing would attach anyway.
+			return "    allowedRoutes:\n      namespaces:\n        from: Same\n"
+		}
+		return fmt.Sprintf(`    allowedRoutes:
       namespaces:
-        from: All
-%s`, defaultGatewayName, defaultGatewayNamespace, p.GatewayClassName, tlsListeners)
+        from: Selector
+        selector:
+          matchExpressions:
+          - key: kubernetes.io/metadata.name
+            operator: In
+            values:
+%s
+`, migrator.YAMLList(namespaces, "            "))
+	}
+	return "    allowedRoutes:\n      namespaces:\n        from: All\n"
+}
+
+// routeNamespaces returns the sorted namespaces generated routes are created
+// in: every Ingress namespace, or resourceNamespace when set, plus the
+// TCP/UDP backend namespaces.
+func routeNamespaces(scan *scanner.ScanResult, resourceNamespace string) []string {
+	seen := make(map[string]bool)
+	var namespaces []string
+	add := func(ns string) {
+		if ns != "" && !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	for _, ing := range scan.ListenerIngresses() {
+		add(migrator.Relocate(ing, resourceNamespace).Namespace)
+	}
+	for _, st := range scan.StreamServices {
+		add(st.Namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
 }
 
 func buildHostnameList(hosts []string) string {
@@ -122,34 +235,48 @@ func buildHostnameList(hosts []string) string {
 	return fmt.Sprintf("    hostname: \"%s\"\n", hosts[0])
 }
 
-// generatePolicies creates provider-specific policies for advanced features.
-func generatePolicies(scan *scanner.ScanResult, p Provider) []policyFile {
-	if p.Name == "traefik" {
-		return generateTraefikGatewayPolicies(scan)
+// generatePolicies creates provider-specific policies for advanced features,
+// next to the HTTPRoutes in resourceNamespace when set. Each policy is
+// labelled with the Ingress it was generated from.
+func generatePolicies(scan *scanner.ScanResult, p Provider, resourceNamespace string) []policyFile {
+	var policies []policyFile
+	for _, ing := range scan.Ingresses {
+		var ingPolicies []policyFile
+		route := migrator.Relocate(ing, resourceNamespace)
+		if p.Name == "traefik" {
+			ingPolicies = generateTraefikGatewayPolicies(route, scan.Controller.ForwardedHeaders)
+		} else {
+			ingPolicies = generateEnvoyPolicies(route, scan.Controller.ForwardedHeaders)
+		}
+		for _, pol := range ingPolicies {
+			pol.yaml = migrator.AddLabels(pol.yaml, migrator.ManagedLabels(ing.Namespace, ing.Name))
+			pol.yaml = migrator.AddAnnotations(pol.yaml, migrator.HashAnnotations(ing))
+			policies = append(policies, pol)
+		}
 	}
-	return generateEnvoyPolicies(scan)
+	return policies
 }
 
 // generateTraefikGatewayPolicies creates Traefik Middleware CRDs for Gateway API mode.
-func generateTraefikGatewayPolicies(scan *scanner.ScanResult) []policyFile {
+func generateTraefikGatewayPolicies(ing scanner.IngressInfo, fh *scanner.ForwardedHeaders) []policyFile {
 	var policies []policyFile
+	annotations := ing.NginxAnnotations
 
-	for _, ing := range scan.Ingresses {
-		annotations := ing.NginxAnnotations
-
-		// Rate limiting via Traefik Middleware
-		if _, hasRPS := annotations["limit-rps"]; hasRPS {
-			policies = append(policies, generateTraefikRateLimitMiddleware(ing))
-		}
+	// Rate limiting via Traefik Middleware
+	if _, hasRPS := annotations["limit-rps"]; hasRPS {
+		policies = append(policies, generateTraefikRateLimitMiddleware(ing))
+	}
 
-		// External auth via Traefik ForwardAuth Middleware
-		if authURL, ok := annotations["auth-url"]; ok && authURL != "" {
-			policies = append(policies, generateTraefikForwardAuthMiddleware(ing))
-		}
+	// External auth via Traefik ForwardAuth Middleware
+	if authURL, ok := annotations["auth-url"]; ok && authURL != "" {
+		policies = append(policies, generateTraefikForwardAuthMiddleware(ing))
+	}
 
-		// IP allowlist via Traefik Middleware
-		if allowList, ok := annotations["whitelist-source-range"]; ok && allowList != "" {
-			policies = append(policies, generateTraefikIPAllowListMiddleware(ing, allowList))
+	// IP allow list via a Traefik Middleware. Traefik has no deny-list
+	// middleware, so denylist-source-range is only noted on the route.
+	if allowList, ok := annotations["whitelist-source-range"]; ok && allowList != "" {
+		if pol, ok := generateTraefikIPAllowListMiddleware(ing, allowList, fh); ok {
+			policies = append(policies, pol)
 		}
 	}
 
@@ -182,12 +309,10 @@ func generateTraefikForwardAuthMiddleware(ing scanner.IngressInfo) policyFile {
 
 	responseHeaders := ""
 	if rh, ok := ing.NginxAnnotations["auth-response-headers"]; ok && rh != "" {
-		headers := strings.Split(rh, ",")
-		var lines []string
-		for _, h := range headers {
-			lines = append(lines, fmt.Sprintf("    - \"%s\"", strings.TrimSpace(h)))
-		}
-		responseHeaders = fmt.Sprintf("  authResponseHeaders:\n%s\n", strings.Join(lines, "\n"))
+		responseHeaders = fmt.Sprintf("    authResponseHeaders:\n%s\n", migrator.YAMLList(scanner.SplitList(rh), "      "))
+	}
+	if rh := ing.NginxAnnotations["auth-request-headers"]; rh != "" {
+		responseHeaders += fmt.Sprintf("    authRequestHeaders:\n%s\n", migrator.YAMLList(scanner.SplitList(rh), "      "))
 	}
 
 	yaml := fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
@@ -202,52 +327,52 @@ spec:
 	return policyFile{name: name, yaml: yaml}
 }
 
-func generateTraefikIPAllowListMiddleware(ing scanner.IngressInfo, cidr string) policyFile {
+// generateTraefikIPAllowListMiddleware is the ipAllowList Middleware for
+// cidr. ok is false when no entry of cidr is valid (see
+// migrator.SourceRangeNotes).
+func generateTraefikIPAllowListMiddleware(ing scanner.IngressInfo, cidr string, fh *scanner.ForwardedHeaders) (policyFile, bool) {
 	name := fmt.Sprintf("%s-%s-ipallowlist", ing.Namespace, ing.Name)
-	cidrs := strings.Split(cidr, ",")
-	var cidrLines []string
-	for _, c := range cidrs {
-		c = strings.TrimSpace(c)
-		if c != "" {
-			cidrLines = append(cidrLines, fmt.Sprintf("    - \"%s\"", c))
-		}
+	ranges, invalid := migrator.ParseSourceRanges(cidr)
+	if len(ranges) == 0 {
+		return policyFile{}, false
 	}
-	yaml := fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+	strategy, notes := migrator.TraefikIPStrategy(fh, "    ")
+	notes = append(migrator.ClientIPNotes(fh), notes...)
+	yaml := migrator.NoteComments(notes) + fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
 kind: Middleware
 metadata:
   name: %s
   namespace: %s
 spec:
   ipAllowList:
-    sourceRange:
-%s
-`, name, ing.Namespace, strings.Join(cidrLines, "\n"))
-	return policyFile{name: name, yaml: yaml}
+    sourceRange:%s
+%s`, name, ing.Namespace, migrator.SourceRangeYAML(ranges, invalid, "    "), strategy)
+	return policyFile{name: name, yaml: yaml, notes: append(notes, migrator.InvalidRangeNotes(invalid)...)}, true
 }
 
 // generateEnvoyPolicies creates Envoy Gateway extension policies for advanced features.
-func generateEnvoyPolicies(scan *scanner.ScanResult) []policyFile {
+func generateEnvoyPolicies(ing scanner.IngressInfo, fh *scanner.ForwardedHeaders) []policyFile {
 	var policies []policyFile
+	annotations := ing.NginxAnnotations
+
+	// Rate limiting and buffer limits share one BackendTrafficPolicy — Envoy
+	// Gateway applies only one BackendTrafficPolicy per HTTPRoute.
+	bufferLimit, hasBuffer := migrator.NginxSizeToQuantity(annotations["proxy-buffer-size"])
+	if _, hasRPS := annotations["limit-rps"]; hasRPS {
+		policies = append(policies, generateRateLimitPolicy(ing, bufferLimit))
+	} else if hasBuffer {
+		policies = append(policies, generateBufferLimitPolicy(ing, bufferLimit))
+	}
 
-	for _, ing := range scan.Ingresses {
-		annotations := ing.NginxAnnotations
-
-		// Rate limiting via BackendTrafficPolicy
-		if _, hasRPS := annotations["limit-rps"]; hasRPS {
-			policies = append(policies, generateRateLimitPolicy(ing))
-		}
-
-		// External auth via SecurityPolicy
-		if authURL, ok := annotations["auth-url"]; ok && authURL != "" {
-			policies = append(policies, generateSecurityPolicy(ing))
-		}
+	// External auth via SecurityPolicy
+	if authURL, ok := annotations["auth-url"]; ok && authURL != "" {
+		policies = append(policies, generateSecurityPolicy(ing))
+	}
 
-		// IP filter via SecurityPolicy
-		if denyList, ok := annotations["denylist-source-range"]; ok && denyList != "" {
-			policies = append(policies, generateIPFilterPolicy(ing, denyList, "Deny"))
-		}
-		if allowList, ok := annotations["whitelist-source-range"]; ok && allowList != "" {
-			policies = append(policies, generateIPFilterPolicy(ing, allowList, "Allow"))
+	// IP filter via SecurityPolicy
+	if denyList, allowList := annotations["denylist-source-range"], annotations["whitelist-source-range"]; denyList != "" || allowList != "" {
+		if pol, ok := generateIPFilterPolicy(ing, denyList, allowList, fh); ok {
+			policies = append(policies, pol)
 		}
 	}
 
@@ -255,11 +380,12 @@ func generateEnvoyPolicies(scan *scanner.ScanResult) []policyFile {
 }
 
 type policyFile struct {
-	name string
-	yaml string
+	name  string
+	yaml  string
+	notes []string // the NOTE comments in yaml
 }
 
-func generateRateLimitPolicy(ing scanner.IngressInfo) policyFile {
+func generateRateLimitPolicy(ing scanner.IngressInfo, bufferLimit string) policyFile {
 	rps := ing.NginxAnnotations["limit-rps"]
 	if rps == "" {
 		rps = "100"
@@ -274,7 +400,7 @@ metadata:
 spec:
   targetRef:
     group: gateway.networking.k8s.io
-    kind: HTTPRoute
+    kind: %s
     name: %s
   rateLimit:
     type: Global
@@ -287,23 +413,55 @@ spec:
         limit:
           requests: %s
           unit: Second
-`, name, ing.Namespace, ing.Name, rps)
+%s`, name, ing.Namespace, routeKind(ing), ing.Name, rps, connectionBufferLimit(bufferLimit))
+
+	return policyFile{name: name, yaml: yaml}
+}
+
+// generateBufferLimitPolicy translates proxy-buffer-size when no other
+// BackendTrafficPolicy is generated for the route.
+func generateBufferLimitPolicy(ing scanner.IngressInfo, bufferLimit string) policyFile {
+	name := fmt.Sprintf("%s-%s-buffer", ing.Namespace, ing.Name)
+	yaml := fmt.Sprintf(`apiVersion: gateway.envoyproxy.io/v1alpha1
+kind: BackendTrafficPolicy
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  targetRef:
+    group: gateway.networking.k8s.io
+    kind: %s
+    name: %s
+%s`, name, ing.Namespace, routeKind(ing), ing.Name, connectionBufferLimit(bufferLimit))
 
 	return policyFile{name: name, yaml: yaml}
 }
 
+// connectionBufferLimit renders the BackendTrafficPolicy connection block for
+// proxy-buffer-size. Envoy buffers per connection rather than per response
+// header, so this is an approximation of the nginx setting.
+func connectionBufferLimit(bufferLimit string) string {
+	if bufferLimit == "" {
+		return ""
+	}
+	return fmt.Sprintf(`  # From nginx proxy-buffer-size. Envoy's limit is per connection, not per
+  # header buffer; response headers up to 60Ki are accepted by default.
+  connection:
+    bufferLimit: %s
+`, bufferLimit)
+}
+
 func generateSecurityPolicy(ing scanner.IngressInfo) policyFile {
 	authURL := ing.NginxAnnotations["auth-url"]
 	name := fmt.Sprintf("%s-%s-extauth", ing.Namespace, ing.Name)
 
 	responseHeaders := "[]"
 	if rh, ok := ing.NginxAnnotations["auth-response-headers"]; ok && rh != "" {
-		headers := strings.Split(rh, ",")
-		var lines []string
-		for _, h := range headers {
-			lines = append(lines, fmt.Sprintf("      - \"%s\"", strings.TrimSpace(h)))
-		}
-		responseHeaders = "\n" + strings.Join(lines, "\n")
+		responseHeaders = "\n" + migrator.YAMLList(scanner.SplitList(rh), "      ")
+	}
+	requestHeaders := ""
+	if rh := ing.NginxAnnotations["auth-request-headers"]; rh != "" {
+		requestHeaders = "    headersToExtAuth:\n" + migrator.YAMLList(scanner.SplitList(rh), "    ") + "\n"
 	}
 
 	yaml := fmt.Sprintf(`apiVersion: gateway.envoyproxy.io/v1alpha1
@@ -314,33 +472,54 @@ metadata:
 spec:
   targetRef:
     group: gateway.networking.k8s.io
-    kind: HTTPRoute
+    kind: %s
     name: %s
   extAuth:
-    http:
+%s    http:
       backendRef:
         name: auth-service   # Replace with your auth service name
         port: 9001           # Replace with your auth service port
       # Original auth-url: %s
       # The auth service URL above should match your auth-url service
       headersToBackend:%s
-`, name, ing.Namespace, ing.Name, authURL, responseHeaders)
+`, name, ing.Namespace, routeKind(ing), ing.Name, requestHeaders, authURL, responseHeaders)
 
 	return policyFile{name: name, yaml: yaml}
 }
 
-func generateIPFilterPolicy(ing scanner.IngressInfo, cidr, action string) policyFile {
+// generateIPFilterPolicy matches clientCIDRs against the client IP Envoy
+// derives per the Gateway's ClientTrafficPolicy (see generateClientIPDetection).
+// Both lists go in one policy, as Envoy Gateway applies a single
+// SecurityPolicy per route. Its rules are evaluated in order and the first
+// match wins, so the deny rule comes first: as in ingress-nginx, a client in
+// both lists is denied. An allow list denies everyone else. A list with no
+// valid entry gets no rule (see migrator.SourceRangeNotes), and ok is false
+// when neither list has one.
+func generateIPFilterPolicy(ing scanner.IngressInfo, denyList, allowList string, fh *scanner.ForwardedHeaders) (policyFile, bool) {
 	name := fmt.Sprintf("%s-%s-ipfilter", ing.Namespace, ing.Name)
-	cidrs := strings.Split(cidr, ",")
-	var cidrLines []string
-	for _, c := range cidrs {
-		c = strings.TrimSpace(c)
-		if c != "" {
-			cidrLines = append(cidrLines, fmt.Sprintf("      - cidrRange: \"%s\"", c))
+	notes := migrator.ClientIPNotes(fh)
+
+	rules := ""
+	defaultAction := "Allow"
+	for _, r := range []struct{ action, cidr string }{{"Deny", denyList}, {"Allow", allowList}} {
+		ranges, invalid := migrator.ParseSourceRanges(r.cidr)
+		if len(ranges) == 0 {
+			continue
+		}
+		rules += fmt.Sprintf(`
+    - action: %s
+      principal:
+        clientCIDRs:%s`, r.action, migrator.SourceRangeYAML(ranges, invalid, "        "))
+		notes = append(notes, migrator.InvalidRangeNotes(invalid)...)
+		if r.action == "Allow" {
+			defaultAction = "Deny"
 		}
 	}
+	if rules == "" {
+		return policyFile{}, false
+	}
 
-	yaml := fmt.Sprintf(`apiVersion: gateway.envoyproxy.io/v1alpha1
+	yaml := migrator.NoteComments(migrator.ClientIPNotes(fh)) + fmt.Sprintf(`apiVersion: gateway.envoyproxy.io/v1alpha1
 kind: SecurityPolicy
 metadata:
   name: %s
@@ -348,18 +527,55 @@ metadata:
 spec:
   targetRef:
     group: gateway.networking.k8s.io
-    kind: HTTPRoute
+    kind: %s
     name: %s
   authorization:
     defaultAction: %s
-    rules:
-    - action: %s
-      principal:
-        clientCIDRs:
-%s
-`, name, ing.Namespace, ing.Name,
-		map[string]string{"Allow": "Deny", "Deny": "Allow"}[action], // default is opposite
-		action, strings.Join(cidrLines, "\n"))
+    rules:%s
+`, name, ing.Namespace, routeKind(ing), ing.Name, defaultAction, rules)
 
-	return policyFile{name: name, yaml: yaml}
+	return policyFile{name: name, yaml: yaml, notes: notes}, true
+}
+
+// generateClientIPDetection is the ClientTrafficPolicy that makes Envoy take
+// the client IP from X-Forwarded-For as ingress-nginx did with
+// use-forwarded-headers, so ipfilter SecurityPolicies match the same address.
+// Trusted proxy CIDRs map to trustedCIDRs; trusting every proxy
+// (proxy-real-ip-cidr 0.0.0.0/0) maps to one trusted hop. ok is false when no
+// ingress filters on source ranges or nginx used the connection address,
+// which is also Envoy's default.
+func generateClientIPDetection(scan *scanner.ScanResult) (policyFile, bool) {
+	fh := scan.Controller.ForwardedHeaders
+	if !migrator.HasSourceRanges(scan.Ingresses) || fh == nil || (!fh.UseForwardedHeaders && !fh.UseProxyProtocol) {
+		return policyFile{}, false
+	}
+
+	var notes []string
+	spec := ""
+	if fh.UseForwardedHeaders {
+		if fh.TrustsAllProxies() {
+			spec += "  clientIPDetection:\n    xForwardedFor:\n      numTrustedHops: 1\n"
+			notes = append(notes, "ingress-nginx trusts every proxy (proxy-real-ip-cidr 0.0.0.0/0) and uses the leftmost X-Forwarded-For address; numTrustedHops 1 uses the one added by the nearest proxy, raise it for each extra proxy hop")
+		} else {
+			spec += fmt.Sprintf("  clientIPDetection:\n    xForwardedFor:\n      trustedCIDRs:\n%s\n", migrator.YAMLList(fh.ProxyRealIPCIDR, "      "))
+		}
+	}
+	if fh.UseProxyProtocol {
+		spec += "  enableProxyProtocol: true\n"
+	}
+
+	name := "client-ip-detection"
+	yaml := fmt.Sprintf(`# Client IP settings from the ingress-nginx ConfigMap %s
+%sapiVersion: gateway.envoyproxy.io/v1alpha1
+kind: ClientTrafficPolicy
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  targetRef:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: %s
+%s`, fh.ConfigMap, migrator.NoteComments(notes), name, defaultGatewayNamespace, defaultGatewayName, spec)
+	return policyFile{name: name, yaml: yaml, notes: notes}, true
 }
@@ -0,0 +1,50 @@
+package gatewayapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// TestGenerateIPFilterPolicy checks that a list with no valid entry gets no
+// rule — an empty clientCIDRs matches no client — and that an allow list
+// only denies everyone else when its rule was generated.
+func TestGenerateIPFilterPolicy(t *testing.T) {
+	ing := scanner.IngressInfo{Name: "web", Namespace: "shop"}
+	tests := []struct {
+		name                string
+		denyList, allowList string
+		wantOK              bool
+		want                []string
+	}{
+		{
+			name: "both lists", denyList: "10.1.0.0/16", allowList: "10.0.0.0/8", wantOK: true,
+			want: []string{"    defaultAction: Deny\n", "    - action: Deny\n", "    - action: Allow\n"},
+		},
+		{
+			name: "allow list without a valid entry", denyList: "10.1.0.0/16", allowList: "example.com", wantOK: true,
+			want: []string{"    defaultAction: Allow\n", "    - action: Deny\n"},
+		},
+		{name: "no valid entry", denyList: "10.0.0.0/33", allowList: "example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol, ok := generateIPFilterPolicy(ing, tt.denyList, tt.allowList, &scanner.ForwardedHeaders{})
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if strings.Contains(pol.yaml, "[]") {
+				t.Errorf("policy has an empty list:\n%s", pol.yaml)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(pol.yaml, want) {
+					t.Errorf("policy lacks\n%s\nin\n%s", want, pol.yaml)
+				}
+			}
+			if tt.allowList == "example.com" && strings.Contains(pol.yaml, "action: Allow") {
+				t.Errorf("policy has an allow rule for an allow list with no valid entry:\n%s", pol.yaml)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// grpcServiceName and grpcMethodName are the names a GRPCRoute Exact method
+// match accepts, e.g. "com.example.UserService" and "GetUser".
+var (
+	grpcServiceName = regexp.MustCompile(`^(?i)\.?[a-z_][a-z_0-9]*(\.[a-z_][a-z_0-9]*)*$`)
+	grpcMethodName  = regexp.MustCompile(`^[A-Za-z_][A-Za-z_0-9]*$`)
+)
+
+// grpcRouteBlockers are the annotations a GRPCRoute has no filter for. An
+// ingress using one keeps its HTTPRoute, whose paths match the same calls.
+var grpcRouteBlockers = []string{
+	"rewrite-target", "permanent-redirect", "temporal-redirect", "app-root",
+	"enable-cors", "mirror-target", "custom-headers", "custom-headers-remove",
+	"custom-request-headers-remove",
+}
+
+// isGRPC reports whether ingress-nginx proxies the ingress to gRPC backends.
+func isGRPC(ing scanner.IngressInfo) bool {
+	switch strings.ToUpper(strings.TrimSpace(ing.NginxAnnotations["backend-protocol"])) {
+	case "GRPC", "GRPCS":
+		return true
+	}
+	return ing.NginxAnnotations["grpc-backend"] == "true"
+}
+
+// grpcMethodMatch turns an ingress path into the service and method of a
+// GRPCRoute method match: "/pkg.Service/Method" matches that method,
+// "/pkg.Service" (or "/pkg.Service/") every method of the service, and "/"
+// every call, with service and method both "". ok is false for a path that
+// does not name a gRPC service, e.g. "/api/v1/users" or a regex.
+func grpcMethodMatch(path string) (service, method string, ok bool) {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/")
+	if trimmed == "" {
+		return "", "", true
+	}
+	service, method, _ = strings.Cut(trimmed, "/")
+	if !grpcServiceName.MatchString(service) {
+		return "", "", false
+	}
+	if method != "" && !grpcMethodName.MatchString(method) {
+		return "", "", false
+	}
+	return service, method, true
+}
+
+// grpcRouteFallback returns why a gRPC ingress cannot become a GRPCRoute,
+// or "" when it can (or is not gRPC at all).
+func grpcRouteFallback(ing scanner.IngressInfo) string {
+	if !isGRPC(ing) {
+		return ""
+	}
+	for _, key := range grpcRouteBlockers {
+		if ing.NginxAnnotations[key] != "" {
+			return fmt.Sprintf("%s has no GRPCRoute equivalent", key)
+		}
+	}
+	for _, p := range ing.Paths {
+		if _, _, ok := grpcMethodMatch(p.Path); !ok {
+			return fmt.Sprintf("path %s does not name a gRPC service (/package.Service[/Method])", p.Path)
+		}
+	}
+	return ""
+}
+
+// usesGRPCRoute reports whether the ingress is migrated to a GRPCRoute
+// instead of an HTTPRoute.
+func usesGRPCRoute(ing scanner.IngressInfo) bool {
+	return isGRPC(ing) && grpcRouteFallback(ing) == ""
+}
+
+// routeKind is the kind of the route generated for the ingress, which its
+// policies target.
+func routeKind(ing scanner.IngressInfo) string {
+	if usesGRPCRoute(ing) {
+		return "GRPCRoute"
+	}
+	return "HTTPRoute"
+}
+
+// grpcFallbackNotes explains why a gRPC ingress got an HTTPRoute.
+func grpcFallbackNotes(ing scanner.IngressInfo) []string {
+	reason := grpcRouteFallback(ing)
+	if reason == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("gRPC ingress migrated to an HTTPRoute, not a GRPCRoute: %s; its path matches select the same /package.Service/Method calls", reason)}
+}
+
+// generateGRPCRoute converts a gRPC Ingress to a GRPCRoute with a method
+// match per path (see grpcMethodMatch). gRPC clients do not follow
+// redirects, so with ssl-redirect/force-ssl-redirect no redirect route is
+// generated: the GRPCRoute attaches to the HTTPS listener of its host only,
+// and plaintext calls find no route, as they found a redirect before.
+func generateGRPCRoute(ing scanner.IngressInfo, gatewayName, gatewayNamespace string, hostnameToSection map[string]string) (string, []string) {
+	annotations := ing.NginxAnnotations
+	var notes []string
+
+	parentRef := fmt.Sprintf("  - name: %s\n    namespace: %s", gatewayName, gatewayNamespace)
+	if sslRedirectCode(annotations) != 0 && len(ing.Hosts) > 0 {
+		if section := hostnameToSection[ing.Hosts[0]]; section != "" {
+			parentRef += fmt.Sprintf("\n    sectionName: %s", section)
+			notes = append(notes, "gRPC clients do not follow redirects: no HTTP→HTTPS redirect route is generated and the GRPCRoute only attaches to the HTTPS listener")
+		}
+	}
+	if timeout := annotations["proxy-read-timeout"]; timeout != "" {
+		notes = append(notes, fmt.Sprintf("proxy-read-timeout %ss is not migrated: GRPCRoute rules have no timeouts — set it with the controller's own policy", timeout))
+	}
+
+	isCanary := annotations["canary"] == "true"
+	canaryWeight, stableWeight, hasWeight := canaryWeights(annotations)
+	var rules []string
+	for _, p := range routePaths(ing) {
+		service, method, _ := grpcMethodMatch(p.Path)
+		backendSection, backendNotes := buildBackendRefs(p, isCanary && hasWeight, canaryWeight, stableWeight)
+		notes = migrator.AppendNotes(notes, backendNotes...)
+		match := buildGRPCMatches(service, method, annotations)
+		if match == "" {
+			backendSection = "  - " + strings.TrimPrefix(backendSection, "    ")
+		}
+		rules = append(rules, match+backendSection)
+	}
+
+	return migrator.NoteComments(notes) + fmt.Sprintf(`apiVersion: gateway.networking.k8s.io/v1
+kind: GRPCRoute
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  parentRefs:
+%s
+%s  rules:
+%s`, ing.Name, ing.Namespace, parentRef, buildHostnameSection(ing.Hosts), strings.Join(rules, "")), notes
+}
+
+// buildGRPCMatches renders the matches of a GRPCRoute rule, with the
+// canary-by-header header match. It is "" for a rule matching every call,
+// which takes no matches.
+func buildGRPCMatches(service, method string, annotations map[string]string) string {
+	headerMatches := buildHeaderMatches(annotations)
+	if service == "" {
+		if headerMatches == "" {
+			return ""
+		}
+		return "  - matches:\n    - " + strings.TrimPrefix(headerMatches, "\n      ") + "\n"
+	}
+	match := fmt.Sprintf("  - matches:\n    - method:\n        service: %s\n", service)
+	if method != "" {
+		match += fmt.Sprintf("        method: %s\n", method)
+	}
+	if headerMatches != "" {
+		match += strings.TrimPrefix(headerMatches, "\n") + "\n"
+	}
+	return match
+}
@@ -0,0 +1,104 @@
+package gatewayapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+func TestGRPCMethodMatch(t *testing.T) {
+	tests := []struct {
+		path            string
+		service, method string
+		ok              bool
+	}{
+		{"/", "", "", true},
+		{"", "", "", true},
+		{"/com.example.UserService", "com.example.UserService", "", true},
+		{"/com.example.UserService/", "com.example.UserService", "", true},
+		{"/com.example.UserService/GetUser", "com.example.UserService", "GetUser", true},
+		{"/helloworld.Greeter/SayHello/", "helloworld.Greeter", "SayHello", true},
+		{"/Greeter", "Greeter", "", true},
+		{"/api/v1/users", "", "", false},
+		{"/com.example.UserService/Get-User", "", "", false},
+		{"/grpc(/|$)(.*)", "", "", false},
+		{"/1bad.Service", "", "", false},
+	}
+	for _, tt := range tests {
+		service, method, ok := grpcMethodMatch(tt.path)
+		if service != tt.service || method != tt.method || ok != tt.ok {
+			t.Errorf("grpcMethodMatch(%q) = %q, %q, %v; want %q, %q, %v", tt.path, service, method, ok, tt.service, tt.method, tt.ok)
+		}
+	}
+}
+
+func grpcIngress(annotations map[string]string, paths ...string) scanner.IngressInfo {
+	ing := scanner.IngressInfo{
+		Name:             "grpc",
+		Namespace:        "platform",
+		Hosts:            []string{"grpc.example.com"},
+		NginxAnnotations: annotations,
+	}
+	for _, p := range paths {
+		ing.Paths = append(ing.Paths, scanner.PathInfo{Host: "grpc.example.com", Path: p, PathType: "Prefix", ServiceName: "grpc-backend", ServicePort: 50051})
+	}
+	return ing
+}
+
+func TestGenerateGRPCRouteMatches(t *testing.T) {
+	ing := grpcIngress(map[string]string{"backend-protocol": "GRPC"}, "/com.example.UserService/GetUser", "/com.example.OrderService")
+	yaml, _ := generateGRPCRoute(ing, "gw", "default", nil)
+
+	want := `  rules:
+  - matches:
+    - method:
+        service: com.example.UserService
+        method: GetUser
+    backendRefs:
+    - name: grpc-backend
+      port: 50051
+  - matches:
+    - method:
+        service: com.example.OrderService
+    backendRefs:
+    - name: grpc-backend
+      port: 50051
+`
+	if !strings.Contains(yaml, "kind: GRPCRoute\n") || !strings.HasSuffix(yaml, want) {
+		t.Errorf("generateGRPCRoute rules:\n%s\nwant suffix:\n%s", yaml, want)
+	}
+}
+
+func TestGenerateGRPCRouteCatchAll(t *testing.T) {
+	ing := grpcIngress(map[string]string{"grpc-backend": "true"}, "/")
+	yaml, _ := generateGRPCRoute(ing, "gw", "default", nil)
+
+	want := `  rules:
+  - backendRefs:
+    - name: grpc-backend
+      port: 50051
+`
+	if !strings.HasSuffix(yaml, want) {
+		t.Errorf("generateGRPCRoute rules:\n%s\nwant suffix:\n%s", yaml, want)
+	}
+}
+
+func TestRouteKind(t *testing.T) {
+	tests := []struct {
+		name string
+		ing  scanner.IngressInfo
+		want string
+	}{
+		{"plain HTTP", grpcIngress(nil, "/"), "HTTPRoute"},
+		{"grpc-backend", grpcIngress(map[string]string{"grpc-backend": "true"}, "/"), "GRPCRoute"},
+		{"backend-protocol GRPCS", grpcIngress(map[string]string{"backend-protocol": "grpcs"}, "/pkg.Service"), "GRPCRoute"},
+		{"path that is not a service", grpcIngress(map[string]string{"backend-protocol": "GRPC"}, "/api/v1/users"), "HTTPRoute"},
+		{"rewrite-target", grpcIngress(map[string]string{"backend-protocol": "GRPC", "rewrite-target": "/"}, "/"), "HTTPRoute"},
+	}
+	for _, tt := range tests {
+		if got := routeKind(tt.ing); got != tt.want {
+			t.Errorf("%s: routeKind = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
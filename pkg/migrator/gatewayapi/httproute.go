@@ -2,8 +2,10 @@ package gatewayapi
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
 )
 
@@ -22,19 +24,30 @@ import (
 // live in separate routes attached to separate listeners to avoid redirect loops.
 // Without sectionName the same route attaches to both HTTP and HTTPS listeners
 // and RequestRedirect fires on HTTPS requests too (creating an infinite loop).
-func generateHTTPRoute(ing scanner.IngressInfo, gatewayName, gatewayNamespace string, hostnameToSection map[string]string) string {
-	annotations := ing.NginxAnnotations
-	hasSSLRedirect := annotations["force-ssl-redirect"] == "true" || annotations["ssl-redirect"] == "true"
-
-	if hasSSLRedirect {
+//
+// notes are the NOTE comments placed in the rules, each listed once.
+func generateHTTPRoute(ing scanner.IngressInfo, gatewayName, gatewayNamespace string, hostnameToSection map[string]string) (yaml string, notes []string) {
+	if sslRedirectCode(ing.NginxAnnotations) != 0 {
 		return generateSplitHTTPRoutes(ing, gatewayName, gatewayNamespace, hostnameToSection)
 	}
 	return generateSingleHTTPRoute(ing, gatewayName, gatewayNamespace, "")
 }
 
+// sslRedirectCode returns the HTTP→HTTPS redirect status for an ingress:
+// 301 for force-ssl-redirect, 302 for ssl-redirect alone, 0 for none.
+func sslRedirectCode(annotations map[string]string) int {
+	switch {
+	case annotations["force-ssl-redirect"] == "true":
+		return 301
+	case annotations["ssl-redirect"] == "true":
+		return 302
+	}
+	return 0
+}
+
 // generateSplitHTTPRoutes creates two HTTPRoute docs in one YAML file:
 // a redirect route (HTTP listener) and a backend route (HTTPS listener).
-func generateSplitHTTPRoutes(ing scanner.IngressInfo, gatewayName, gatewayNamespace string, hostnameToSection map[string]string) string {
+func generateSplitHTTPRoutes(ing scanner.IngressInfo, gatewayName, gatewayNamespace string, hostnameToSection map[string]string) (string, []string) {
 	annotations := ing.NginxAnnotations
 
 	// Determine HTTPS listener sectionName from the ingress's primary hostname.
@@ -43,10 +56,7 @@ func generateSplitHTTPRoutes(ing scanner.IngressInfo, gatewayName, gatewayNamesp
 		httpsSectionName = hostnameToSection[ing.Hosts[0]]
 	}
 
-	statusCode := 301
-	if annotations["ssl-redirect"] == "true" && annotations["force-ssl-redirect"] != "true" {
-		statusCode = 302
-	}
+	statusCode := sslRedirectCode(annotations)
 
 	// Build hostname section (shared between both routes)
 	hostnameSection := buildHostnameSection(ing.Hosts)
@@ -57,10 +67,10 @@ func generateSplitHTTPRoutes(ing scanner.IngressInfo, gatewayName, gatewayNamesp
     namespace: %s
     sectionName: http`, gatewayName, gatewayNamespace)
 
-	redirectRules := buildRedirectOnlyRules(ing, statusCode)
+	redirectRules, redirectNotes := buildRedirectOnlyRules(ing, statusCode)
 
 	redirectRoute := fmt.Sprintf(`# HTTP→HTTPS redirect route (attached to HTTP listener only)
-apiVersion: gateway.networking.k8s.io/v1
+%sapiVersion: gateway.networking.k8s.io/v1
 kind: HTTPRoute
 metadata:
   name: %s-redirect
@@ -69,18 +79,18 @@ spec:
   parentRefs:
 %s
 %s  rules:
-%s`, ing.Name, ing.Namespace, redirectParentRef, hostnameSection, redirectRules)
+%s`, migrator.NoteComments(redirectNotes), ing.Name, ing.Namespace, redirectParentRef, hostnameSection, redirectRules)
 
 	// ── Backend route ─────────────────────────────────────────────────────────
 	// Attached to HTTPS listener via sectionName: https-N (no redirect filter).
-	backendRoute := generateSingleHTTPRoute(ing, gatewayName, gatewayNamespace, httpsSectionName)
+	backendRoute, notes := generateSingleHTTPRoute(ing, gatewayName, gatewayNamespace, httpsSectionName)
 
-	return redirectRoute + "---\n" + backendRoute
+	return redirectRoute + "---\n" + backendRoute, migrator.AppendNotes(redirectNotes, notes...)
 }
 
 // generateSingleHTTPRoute creates one HTTPRoute doc with no redirect filter.
 // sectionName is added to parentRef when non-empty.
-func generateSingleHTTPRoute(ing scanner.IngressInfo, gatewayName, gatewayNamespace, sectionName string) string {
+func generateSingleHTTPRoute(ing scanner.IngressInfo, gatewayName, gatewayNamespace, sectionName string) (string, []string) {
 	annotations := ing.NginxAnnotations
 
 	parentRef := fmt.Sprintf("  - name: %s\n    namespace: %s", gatewayName, gatewayNamespace)
@@ -89,7 +99,7 @@ func generateSingleHTTPRoute(ing scanner.IngressInfo, gatewayName, gatewayNamesp
 	}
 
 	hostnameSection := buildHostnameSection(ing.Hosts)
-	rules := buildBackendOnlyRules(ing, annotations)
+	rules, notes := buildBackendOnlyRules(ing, annotations)
 
 	return fmt.Sprintf(`apiVersion: gateway.networking.k8s.io/v1
 kind: HTTPRoute
@@ -100,7 +110,7 @@ spec:
   parentRefs:
 %s
 %s  rules:
-%s`, ing.Name, ing.Namespace, parentRef, hostnameSection, rules)
+%s`, ing.Name, ing.Namespace, parentRef, hostnameSection, rules), notes
 }
 
 func buildHostnameSection(hosts []string) string {
@@ -114,10 +124,11 @@ func buildHostnameSection(hosts []string) string {
 	return fmt.Sprintf("  hostnames:\n%s\n", strings.Join(lines, "\n"))
 }
 
-// buildRedirectOnlyRules generates one redirect rule per path (no backendRefs).
-func buildRedirectOnlyRules(ing scanner.IngressInfo, statusCode int) string {
-	annotations := ing.NginxAnnotations
-
+// routePaths returns the ingress paths grouped by host, in rule order. The
+// rules of an HTTPRoute apply to all of its hostnames, so a path that only
+// repeats an earlier one for another host (such as a server-alias host) is
+// left out.
+func routePaths(ing scanner.IngressInfo) []scanner.PathInfo {
 	hostOrder := []string{}
 	hostPaths := make(map[string][]scanner.PathInfo)
 	for _, p := range ing.Paths {
@@ -127,73 +138,126 @@ func buildRedirectOnlyRules(ing scanner.IngressInfo, statusCode int) string {
 		hostPaths[p.Host] = append(hostPaths[p.Host], p)
 	}
 
-	var rules []string
+	var paths []scanner.PathInfo
+	seen := make(map[scanner.PathInfo]bool)
 	for _, host := range hostOrder {
 		for _, p := range hostPaths[host] {
-			pathMatch := buildPathMatch(p, annotations)
-			headerMatches := buildHeaderMatches(annotations)
-
-			match := fmt.Sprintf("  - matches:\n    - %s", pathMatch)
-			if headerMatches != "" {
-				match += headerMatches
+			key := p
+			key.Host = ""
+			if seen[key] {
+				continue
 			}
-			match += "\n"
+			seen[key] = true
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
 
-			rule := match + fmt.Sprintf(`    filters:
+// buildRedirectOnlyRules generates one redirect rule per path (no backendRefs),
+// to the port ingress-nginx named in its redirects (use-port-in-redirects)
+// when that is not 443. notes explain a port that could not be determined.
+func buildRedirectOnlyRules(ing scanner.IngressInfo, statusCode int) (string, []string) {
+	annotations := ing.NginxAnnotations
+	port := ""
+	redirectPort, notes := migrator.RedirectPort(annotations)
+	if redirectPort != 0 {
+		port = fmt.Sprintf("        port: %d\n", redirectPort)
+	}
+
+	var rules []string
+	for _, p := range routePaths(ing) {
+		// Every method is redirected, as by ingress-nginx; the HTTPS rules
+		// are the ones scoped to methods
+		match := buildMatches(buildPathMatch(p, annotations, pathRewrite(ing, p)), annotations, nil)
+		rule := match + fmt.Sprintf(`    filters:
     - type: RequestRedirect
       requestRedirect:
         scheme: https
-        statusCode: %d
-`, statusCode)
-			rules = append(rules, rule)
-		}
+%s        statusCode: %d
+`, port, statusCode)
+		rules = append(rules, rule)
 	}
-	return strings.Join(rules, "")
+	return strings.Join(rules, ""), notes
 }
 
 // buildBackendOnlyRules generates one backend rule per path (no RequestRedirect).
 // URLRewrite, CORS, custom headers, backendRefs, and timeouts are included here.
-func buildBackendOnlyRules(ing scanner.IngressInfo, annotations map[string]string) string {
-	hostOrder := []string{}
-	hostPaths := make(map[string][]scanner.PathInfo)
-	for _, p := range ing.Paths {
-		if _, exists := hostPaths[p.Host]; !exists {
-			hostOrder = append(hostOrder, p.Host)
-		}
-		hostPaths[p.Host] = append(hostPaths[p.Host], p)
-	}
-
+// notes are the NOTE comments in the rules, each listed once.
+func buildBackendOnlyRules(ing scanner.IngressInfo, annotations map[string]string) (string, []string) {
 	isCanary := annotations["canary"] == "true"
-	canaryWeight := annotations["canary-weight"]
+	canaryWeight, stableWeight, hasWeight := canaryWeights(annotations)
+	externalRedirect, hasRedirect := migrator.ParseRedirect(annotations)
 
-	var rules []string
-	for _, host := range hostOrder {
-		for _, p := range hostPaths[host] {
-			pathMatch := buildPathMatch(p, annotations)
-			headerMatches := buildHeaderMatches(annotations)
+	methods, _ := migrator.MatchMethods(ing)
 
-			match := fmt.Sprintf("  - matches:\n    - %s", pathMatch)
-			if headerMatches != "" {
-				match += headerMatches
-			}
-			match += "\n"
-
-			filters := buildBackendFilters(annotations)
-			filterSection := ""
-			if len(filters) > 0 {
-				filterSection = "    filters:\n" + strings.Join(filters, "")
-			}
+	var rules, notes []string
+	if appRoot := annotations["app-root"]; appRoot != "" && !hasRedirect {
+		rules = append(rules, buildAppRootRule(appRoot))
+	}
+	for _, p := range routePaths(ing) {
+		if hasRedirect {
+			// permanent-/temporal-redirect replaces proxying entirely, and
+			// RequestRedirect may not be combined with URLRewrite or backends.
+			match := fmt.Sprintf("  - matches:\n    - %s\n", buildPathMatch(p, annotations, nil))
+			filter, filterNotes := buildExternalRedirectFilter(externalRedirect)
+			rules = append(rules, match+"    filters:\n"+filter)
+			notes = migrator.AppendNotes(notes, filterNotes...)
+			continue
+		}
 
-			backendSection := buildBackendRefs(p, isCanary, canaryWeight)
-			timeoutSection := buildTimeouts(annotations)
+		rw := pathRewrite(ing, p)
+		match := buildMatches(buildPathMatch(p, annotations, rw), annotations, methods)
 
-			rules = append(rules, match+filterSection+backendSection+timeoutSection)
+		filters, filterNotes := buildBackendFilters(annotations, migrator.RewriteApplies(ing.Annotations, p.Path), rw)
+		notes = migrator.AppendNotes(notes, filterNotes...)
+		filterSection := ""
+		if len(filters) > 0 {
+			filterSection = "    filters:\n" + strings.Join(filters, "")
+		}
+		if target := annotations["rewrite-target"]; target != "" && filterSection != "" &&
+			migrator.RewriteApplies(ing.Annotations, p.Path) && migrator.RewriteLacksCaptures(target, p.Path) {
+			filterSection += fmt.Sprintf("# NOTE: rewrite-target %q references capture groups this path does not define.\n"+
+				"# If it was meant for another path, list those paths in the %s annotation.\n",
+				target, migrator.RewritePathsAnnotation)
+			notes = migrator.AppendNotes(notes, fmt.Sprintf("rewrite-target %q references capture groups path %s does not define; if it was meant for another path, list those paths in the %s annotation",
+				target, p.Path, migrator.RewritePathsAnnotation))
 		}
+
+		backendSection, backendNotes := buildBackendRefs(p, isCanary && hasWeight, canaryWeight, stableWeight)
+		notes = migrator.AppendNotes(notes, backendNotes...)
+		timeoutSection := buildTimeouts(annotations)
+
+		rules = append(rules, match+filterSection+backendSection+timeoutSection)
 	}
-	return strings.Join(rules, "")
+	return strings.Join(rules, ""), notes
 }
 
-func buildPathMatch(path scanner.PathInfo, annotations map[string]string) string {
+// pathRewrite returns the ReplacePrefixMatch equivalent of the ingress
+// rewrite-target for path p, or nil when the rewrite does not apply to p or
+// does not fit one of the prefix idioms migrator.TranslatePrefixRewrite knows.
+func pathRewrite(ing scanner.IngressInfo, p scanner.PathInfo) *migrator.PrefixRewrite {
+	target := ing.NginxAnnotations["rewrite-target"]
+	if target == "" || !migrator.RewriteApplies(ing.Annotations, p.Path) {
+		return nil
+	}
+	rw, ok := migrator.TranslatePrefixRewrite(p.Path, target)
+	if !ok {
+		return nil
+	}
+	return &rw
+}
+
+// buildPathMatch renders the path match for a rule. A translated prefix
+// rewrite forces a PathPrefix match on its literal prefix, since Gateway API
+// only allows ReplacePrefixMatch together with PathPrefix.
+func buildPathMatch(path scanner.PathInfo, annotations map[string]string, rw *migrator.PrefixRewrite) string {
+	if rw != nil {
+		return fmt.Sprintf(`path:
+        type: PathPrefix
+        value: "%s"`, rw.Prefix)
+	}
+
 	pathValue := path.Path
 	if pathValue == "" {
 		pathValue = "/"
@@ -229,6 +293,21 @@ func pathHasRegexChars(path string) bool {
 	return false
 }
 
+// buildMatches renders the matches of a rule for pathMatch: one match, or
+// one per method when the Ingress is scoped to methods (see
+// migrator.MatchMethods), each with the canary-by-header header match.
+func buildMatches(pathMatch string, annotations map[string]string, methods []string) string {
+	headerMatches := buildHeaderMatches(annotations)
+	if len(methods) == 0 {
+		return "  - matches:\n    - " + pathMatch + headerMatches + "\n"
+	}
+	match := "  - matches:\n"
+	for _, method := range methods {
+		match += fmt.Sprintf("    - %s\n      method: %s%s\n", pathMatch, method, headerMatches)
+	}
+	return match
+}
+
 func buildHeaderMatches(annotations map[string]string) string {
 	header := annotations["canary-by-header"]
 	headerValue := annotations["canary-by-header-value"]
@@ -247,22 +326,97 @@ func buildHeaderMatches(annotations map[string]string) string {
         type: Present`, header)
 }
 
+// buildAppRootRule redirects requests for exactly "/" to the app-root path
+// with a 302, as nginx does. An Exact match outranks the PathPrefix "/" rule,
+// so every other path still reaches the backend.
+func buildAppRootRule(appRoot string) string {
+	return fmt.Sprintf(`  - matches:
+    - path:
+        type: Exact
+        value: "/"
+    filters:
+    - type: RequestRedirect
+      requestRedirect:
+        path:
+          type: ReplaceFullPath
+          replaceFullPath: %q
+        statusCode: 302
+`, appRoot)
+}
+
+// buildExternalRedirectFilter renders a RequestRedirect filter for an nginx
+// permanent-redirect/temporal-redirect URL, split into its parts.
+func buildExternalRedirectFilter(rd migrator.Redirect) (string, []string) {
+	var b strings.Builder
+	var notes []string
+	b.WriteString("    - type: RequestRedirect\n      requestRedirect:\n")
+	if rd.Scheme != "" {
+		fmt.Fprintf(&b, "        scheme: %s\n", rd.Scheme)
+	}
+	if rd.Hostname != "" {
+		fmt.Fprintf(&b, "        hostname: %q\n", rd.Hostname)
+	}
+	if rd.Port != 0 {
+		fmt.Fprintf(&b, "        port: %d\n", rd.Port)
+	}
+	// Without a path modifier the redirect keeps the request path, as nginx
+	// does for a target ending in $request_uri or $uri.
+	if !rd.KeepURI {
+		path := rd.Path
+		if path == "" {
+			path = "/" // nginx redirects to the bare URL, not the request path
+		}
+		fmt.Fprintf(&b, "        path:\n          type: ReplaceFullPath\n          replaceFullPath: %q\n", path)
+	} else if rd.Path != "" && rd.Path != "/" {
+		note := fmt.Sprintf("RequestRedirect keeps the request path but cannot prefix it; %s from %s is dropped", rd.Path, rd.URL)
+		notes = append(notes, note)
+		b.WriteString(migrator.NoteComments([]string{note}))
+	}
+
+	code := rd.Code
+	if code != 301 && code != 302 {
+		note := fmt.Sprintf("RequestRedirect statusCode only allows 301 or 302; nginx used %d", code)
+		notes = append(notes, note)
+		b.WriteString(migrator.NoteComments([]string{note}))
+		code = 302
+		if rd.Permanent {
+			code = 301
+		}
+	}
+	fmt.Fprintf(&b, "        statusCode: %d\n", code)
+	if rd.Query != "" {
+		note := fmt.Sprintf("RequestRedirect cannot set a query string; ?%s from %s is dropped", rd.Query, rd.URL)
+		notes = append(notes, note)
+		b.WriteString(migrator.NoteComments([]string{note}))
+	}
+	return b.String(), notes
+}
+
 // buildBackendFilters builds filters for backend rules (no RequestRedirect).
 // URLRewrite is safe here since it never appears alongside RequestRedirect.
-func buildBackendFilters(annotations map[string]string) []string {
-	var filters []string
+// rewrite is false for paths excluded by migrator.RewritePathsAnnotation;
+// rw is the path's translated prefix rewrite, if any (see pathRewrite).
+func buildBackendFilters(annotations map[string]string, rewrite bool, rw *migrator.PrefixRewrite) (filters, notes []string) {
 
-	// URL rewrite
-	if target, ok := annotations["rewrite-target"]; ok && target != "" {
+	// URL rewrite — $request_uri passes the original URI through unchanged
+	if target, ok := annotations["rewrite-target"]; ok && target != "" && rewrite && !migrator.RequestURIIdiom(target) {
 		_, useRegex := annotations["use-regex"]
-		if useRegex {
+		if rw != nil {
+			filters = append(filters, fmt.Sprintf(`    - type: URLRewrite
+      urlRewrite:
+        path:
+          type: ReplacePrefixMatch
+          replacePrefixMatch: "%s"
+`, rw.Replacement))
+		} else if useRegex || migrator.MaxCaptureRef(target) > 0 {
 			filters = append(filters, fmt.Sprintf(`    - type: URLRewrite
       urlRewrite:
         path:
           type: ReplaceFullPath
           replaceFullPath: "%s"
-# NOTE: For regex captures like $1, manual conversion to ReplacePrefixMatch may be needed
+# NOTE: Path/target is not a prefix-swap idiom (e.g. /api(/|$)(.*) → /$2); Gateway API has no regex captures — review this rewrite
 `, target))
+			notes = append(notes, fmt.Sprintf("rewrite-target %q is not a prefix-swap idiom (e.g. /api(/|$)(.*) → /$2); Gateway API has no regex captures — review this rewrite", target))
 		} else {
 			filters = append(filters, fmt.Sprintf(`    - type: URLRewrite
       urlRewrite:
@@ -275,24 +429,87 @@ func buildBackendFilters(annotations map[string]string) []string {
 
 	// CORS
 	if annotations["enable-cors"] == "true" {
-		filters = append(filters, buildCORSFilter(annotations))
+		filter, corsNotes := buildCORSFilter(annotations)
+		filters = append(filters, filter)
+		notes = append(notes, corsNotes...)
+	}
+
+	// Request mirroring
+	if m, ok := migrator.ParseMirror(annotations); ok {
+		filter, mirrorNotes := buildMirrorFilter(m)
+		filters = append(filters, filter)
+		notes = append(notes, mirrorNotes...)
+	}
+
+	// Request headers set and removed in the configuration-snippet; a rule
+	// takes one RequestHeaderModifier. The NOTEs for headers set from nginx
+	// variables are route-wide, see routeIngressNotes.
+	set, _ := migrator.SnippetRequestHeaders(annotations)
+	remove := scanner.SplitList(annotations["custom-request-headers-remove"])
+	if len(set) > 0 || len(remove) > 0 {
+		filter := "    - type: RequestHeaderModifier\n      requestHeaderModifier:\n"
+		if len(set) > 0 {
+			filter += "        set:\n"
+			for _, h := range set {
+				filter += fmt.Sprintf("          - name: %q\n            value: %q\n", h.Name, h.Value)
+			}
+		}
+		if len(remove) > 0 {
+			filter += "        remove:\n" + migrator.YAMLList(remove, "        ") + "\n"
+		}
+		filters = append(filters, filter)
 	}
 
-	// Custom response headers
-	if _, hasCustom := annotations["custom-headers"]; hasCustom {
-		filters = append(filters, `    - type: ResponseHeaderModifier
-      responseHeaderModifier:
-        add:
+	// Custom response headers, and response headers removed in the
+	// configuration-snippet; a rule takes one ResponseHeaderModifier
+	_, hasCustom := annotations["custom-headers"]
+	removeResponse := scanner.SplitList(annotations["custom-headers-remove"])
+	if hasCustom || len(removeResponse) > 0 {
+		filter := "    - type: ResponseHeaderModifier\n      responseHeaderModifier:\n"
+		if hasCustom {
+			filter += `        add:
           - name: "X-Custom-Header"
             value: "value"
-# NOTE: Populate headers from your ConfigMap reference in nginx annotation
-`)
+`
+		}
+		if len(removeResponse) > 0 {
+			filter += "        remove:\n" + migrator.YAMLList(removeResponse, "        ") + "\n"
+		}
+		if hasCustom {
+			filter += "# NOTE: Populate headers from your ConfigMap reference in nginx annotation\n"
+			notes = append(notes, fmt.Sprintf("custom-headers ConfigMap %s is not read — populate the ResponseHeaderModifier from it", annotations["custom-headers"]))
+		}
+		filters = append(filters, filter)
 	}
 
-	return filters
+	return filters, notes
 }
 
-func buildCORSFilter(annotations map[string]string) string {
+// buildMirrorFilter renders a RequestMirror filter for an nginx mirror-target.
+// A mirror that is not a cluster Service only gets NOTEs: backendRef cannot
+// name an external host.
+func buildMirrorFilter(m migrator.Mirror) (string, []string) {
+	notes := m.Notes()
+	if !m.Body {
+		notes = append(notes, "mirror-request-body: off has no Gateway API equivalent; request bodies are mirrored")
+	}
+	filter := ""
+	if m.Service != "" {
+		namespace := ""
+		if m.Namespace != "" {
+			namespace = fmt.Sprintf("          namespace: %s\n", m.Namespace)
+		}
+		filter = fmt.Sprintf(`    - type: RequestMirror
+      requestMirror:
+        backendRef:
+          name: %s
+%s          port: %d
+`, m.Service, namespace, m.Port)
+	}
+	return filter + migrator.NoteComments(notes), notes
+}
+
+func buildCORSFilter(annotations map[string]string) (string, []string) {
 	origin := getAnnotation(annotations, "cors-allow-origin", "*")
 	methods := getAnnotation(annotations, "cors-allow-methods", "GET, PUT, POST, DELETE, PATCH, OPTIONS")
 	allowHeaders := getAnnotation(annotations, "cors-allow-headers", "Content-Type, Authorization")
@@ -300,38 +517,22 @@ func buildCORSFilter(annotations map[string]string) string {
 	exposeHeaders := getAnnotation(annotations, "cors-expose-headers", "")
 	maxAge := getAnnotation(annotations, "cors-max-age", "86400")
 
-	// Native CORS filter (Standard in Gateway API v1.5)
-	var origins []string
-	for _, o := range strings.Split(origin, ",") {
-		o = strings.TrimSpace(o)
-		if o != "" {
-			origins = append(origins, fmt.Sprintf(`        - type: Exact
-          value: "%s"`, o))
-		}
-	}
-	originsYAML := strings.Join(origins, "\n")
-	if origin == "*" {
-		originsYAML = `        - type: Exact
-          value: "*"`
-	}
-
-	var methodLines []string
-	for _, m := range strings.Split(methods, ",") {
-		m = strings.TrimSpace(m)
-		if m != "" {
-			methodLines = append(methodLines, fmt.Sprintf(`        - "%s"`, m))
-		}
-	}
-
-	var headerLines []string
-	for _, h := range strings.Split(allowHeaders, ",") {
-		h = strings.TrimSpace(h)
-		if h != "" {
-			headerLines = append(headerLines, fmt.Sprintf(`        - "%s"`, h))
-		}
+	// Native CORS filter (Standard in Gateway API v1.5). It reflects whichever
+	// allowed origin the request carries, so multiple origins work here — unlike
+	// a static ResponseHeaderModifier, which can only send one.
+	originList := scanner.SplitList(origin)
+
+	result := ""
+	var notes []string
+	if len(originList) > 1 {
+		notes = append(notes, fmt.Sprintf("%d CORS origins configured: if your Gateway does not implement the CORS filter, do not fall back to a ResponseHeaderModifier (it can only send %q); on Envoy Gateway use a SecurityPolicy with spec.cors.allowOrigins", len(originList), originList[0]))
+		result = fmt.Sprintf(`# NOTE: %d CORS origins configured. The CORS filter below reflects the matching
+# request Origin. If your Gateway does not implement the CORS filter, do NOT fall
+# back to a ResponseHeaderModifier (it can only send %q); on Envoy Gateway use a
+# SecurityPolicy with spec.cors.allowOrigins, or an EnvoyPatchPolicy, instead.
+`, len(originList), originList[0])
 	}
-
-	result := fmt.Sprintf(`    - type: CORS
+	result += fmt.Sprintf(`    - type: CORS
       cors:
         allowOrigins:
 %s
@@ -340,45 +541,78 @@ func buildCORSFilter(annotations map[string]string) string {
         allowHeaders:
 %s
         allowCredentials: %s
-        maxAge: "%ss"
-`, originsYAML, strings.Join(methodLines, "\n"), strings.Join(headerLines, "\n"), credentials, maxAge)
+`, migrator.YAMLList(originList, "        "), migrator.YAMLList(scanner.SplitList(methods), "        "),
+		migrator.YAMLList(scanner.SplitList(allowHeaders), "        "), credentials)
+	// maxAge is in seconds, as cors-max-age; the filter's default is 5
+	if seconds, err := strconv.Atoi(maxAge); err == nil && seconds > 0 {
+		result += fmt.Sprintf("        maxAge: %d\n", seconds)
+	}
 
 	if exposeHeaders != "" {
-		var exposeLines []string
-		for _, h := range strings.Split(exposeHeaders, ",") {
-			h = strings.TrimSpace(h)
-			if h != "" {
-				exposeLines = append(exposeLines, fmt.Sprintf(`        - "%s"`, h))
-			}
-		}
-		result += fmt.Sprintf("        exposeHeaders:\n%s\n", strings.Join(exposeLines, "\n"))
+		result += fmt.Sprintf("        exposeHeaders:\n%s\n", migrator.YAMLList(scanner.SplitList(exposeHeaders), "        "))
 	}
 
-	return result
+	return result, notes
 }
 
-func buildBackendRefs(path scanner.PathInfo, isCanary bool, canaryWeight string) string {
+// buildBackendRefs renders the rule's backendRefs. A backend outside the
+// route's namespace gets an explicit namespace; generateReferenceGrants
+// emits the ReferenceGrant that lets the route use it.
+func buildBackendRefs(path scanner.PathInfo, isCanary bool, canaryWeight, stableWeight int) (string, []string) {
 	port := path.ServicePort
 	if port == 0 {
 		port = 80
 	}
+	namespace := ""
+	if path.ServiceNamespace != "" {
+		namespace = fmt.Sprintf("      namespace: %s\n", path.ServiceNamespace)
+	}
 
-	if isCanary && canaryWeight != "" {
+	if isCanary {
+		note := fmt.Sprintf("canary backend %s gets %d of %d = %s of traffic: add the stable backend to its backendRefs with weight %d",
+			path.ServiceName, canaryWeight, canaryWeight+stableWeight, canaryPercent(canaryWeight, canaryWeight+stableWeight), stableWeight)
 		return fmt.Sprintf(`    backendRefs:
     - name: %s
-      port: %d
-      weight: %s
-    # NOTE: Add your stable backend below with weight = (100 - %s):
+%s      port: %d
+      weight: %d
+    # NOTE: Add your stable backend below (canary gets %d of %d = %s of traffic):
     # - name: stable-service
     #   port: %d
-    #   weight:
-`, path.ServiceName, port, canaryWeight, canaryWeight, port)
+    #   weight: %d
+`, path.ServiceName, namespace, port, canaryWeight, canaryWeight, canaryWeight+stableWeight,
+			canaryPercent(canaryWeight, canaryWeight+stableWeight), port, stableWeight), []string{note}
 	}
 
 	return fmt.Sprintf(`    backendRefs:
     - name: %s
-      port: %d
-`, path.ServiceName, port)
+%s      port: %d
+`, path.ServiceName, namespace, port), nil
+}
+
+// canaryWeights converts canary-weight and canary-weight-total (default 100)
+// into relative backendRef weights. Gateway API weights are proportional, so
+// the canary keeps its weight and the stable backend gets total - weight;
+// canary-weight: 50 with canary-weight-total: 1000 stays 5%, not 50%.
+func canaryWeights(annotations map[string]string) (canary, stable int, ok bool) {
+	canary, err := strconv.Atoi(strings.TrimSpace(annotations["canary-weight"]))
+	if err != nil || canary < 0 {
+		return 0, 0, false
+	}
+	total := 100
+	if v := strings.TrimSpace(annotations["canary-weight-total"]); v != "" {
+		if t, err := strconv.Atoi(v); err == nil && t > 0 {
+			total = t
+		}
+	}
+	if canary > total {
+		canary = total
+	}
+	return canary, total - canary, true
+}
+
+// canaryPercent formats weight/total as a percentage, e.g. "5%" or "0.5%".
+func canaryPercent(weight, total int) string {
+	return strconv.FormatFloat(float64(weight)*100/float64(total), 'f', -1, 64) + "%"
 }
 
 func buildTimeouts(annotations map[string]string) string {
@@ -398,3 +632,17 @@ func getAnnotation(annotations map[string]string, key, defaultVal string) string
 	}
 	return defaultVal
 }
+
+// backendClientCertNotes explains what becomes of proxy-ssl-secret, the
+// client certificate ingress-nginx presents to HTTPS backends: Gateway API
+// has no per-route or per-backend client certificate.
+func backendClientCertNotes(ing scanner.IngressInfo) []string {
+	secret := ing.NginxAnnotations["proxy-ssl-secret"]
+	if secret == "" {
+		return nil
+	}
+	if !strings.Contains(secret, "/") {
+		secret = ing.Namespace + "/" + secret
+	}
+	return []string{fmt.Sprintf("proxy-ssl-secret %s is not migrated: backends needing this client certificate reject the Gateway until it is set as the Gateway's spec.tls.backend.clientCertificateRef (Gateway API v1.4+ experimental), which presents it to every backend", secret)}
+}
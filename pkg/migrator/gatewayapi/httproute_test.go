@@ -0,0 +1,206 @@
+package gatewayapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+func TestBuildCORSFilterListItems(t *testing.T) {
+	filter, notes := buildCORSFilter(map[string]string{
+		"cors-allow-origin":   "https://a.example.com, https://b.example.com",
+		"cors-allow-methods":  "GET,POST, OPTIONS",
+		"cors-allow-headers":  "Content-Type,X-Request-ID",
+		"cors-expose-headers": "X-Total-Count, X-Page",
+		"cors-max-age":        "600",
+	})
+
+	for _, want := range []string{
+		"        allowOrigins:\n        - \"https://a.example.com\"\n        - \"https://b.example.com\"\n",
+		"        allowMethods:\n        - \"GET\"\n        - \"POST\"\n        - \"OPTIONS\"\n",
+		"        allowHeaders:\n        - \"Content-Type\"\n        - \"X-Request-ID\"\n",
+		"        exposeHeaders:\n        - \"X-Total-Count\"\n        - \"X-Page\"\n",
+		"        maxAge: 600\n",
+	} {
+		if !strings.Contains(filter, want) {
+			t.Errorf("CORS filter lacks\n%s\nin\n%s", want, filter)
+		}
+	}
+	if len(notes) != 1 {
+		t.Errorf("notes = %q, want one about the two origins", notes)
+	}
+}
+
+func TestBuildExternalRedirectFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+		wantNotes   int
+	}{
+		{
+			name:        "fixed URL replaces the path",
+			annotations: map[string]string{"permanent-redirect": "https://new.example.com"},
+			want: "      requestRedirect:\n        scheme: https\n        hostname: \"new.example.com\"\n" +
+				"        path:\n          type: ReplaceFullPath\n          replaceFullPath: \"/\"\n        statusCode: 301\n",
+		},
+		{
+			name:        "trailing $request_uri keeps the path",
+			annotations: map[string]string{"permanent-redirect": "https://new.example.com$request_uri"},
+			want:        "      requestRedirect:\n        scheme: https\n        hostname: \"new.example.com\"\n        statusCode: 301\n",
+		},
+		{
+			name:        "trailing $uri with a path prefix",
+			annotations: map[string]string{"temporal-redirect": "https://new.example.com:8443/v2$uri"},
+			want:        "        hostname: \"new.example.com\"\n        port: 8443\n",
+			wantNotes:   1,
+		},
+		{
+			name:        "custom code",
+			annotations: map[string]string{"permanent-redirect": "https://new.example.com/landing", "permanent-redirect-code": "308"},
+			want:        "          replaceFullPath: \"/landing\"\n",
+			wantNotes:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd, ok := migrator.ParseRedirect(tt.annotations)
+			if !ok {
+				t.Fatalf("ParseRedirect(%v) failed", tt.annotations)
+			}
+			filter, notes := buildExternalRedirectFilter(rd)
+			if !strings.Contains(filter, tt.want) {
+				t.Errorf("RequestRedirect lacks\n%s\nin\n%s", tt.want, filter)
+			}
+			if strings.Contains(filter, "$") {
+				t.Errorf("RequestRedirect keeps an nginx variable:\n%s", filter)
+			}
+			if len(notes) != tt.wantNotes {
+				t.Errorf("notes = %q, want %d", notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestCanaryWeights(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		canary, stable int
+		ok             bool
+	}{
+		{name: "default total", annotations: map[string]string{"canary-weight": "30"}, canary: 30, stable: 70, ok: true},
+		{name: "total of 1000", annotations: map[string]string{"canary-weight": "50", "canary-weight-total": "1000"}, canary: 50, stable: 950, ok: true},
+		{name: "weight above total is capped", annotations: map[string]string{"canary-weight": "20", "canary-weight-total": "10"}, canary: 10, stable: 0, ok: true},
+		{name: "invalid total uses 100", annotations: map[string]string{"canary-weight": "5", "canary-weight-total": "zero"}, canary: 5, stable: 95, ok: true},
+		{name: "no weight", annotations: map[string]string{"canary-weight-total": "1000"}},
+		{name: "negative weight", annotations: map[string]string{"canary-weight": "-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canary, stable, ok := canaryWeights(tt.annotations)
+			if canary != tt.canary || stable != tt.stable || ok != tt.ok {
+				t.Errorf("canaryWeights() = %d, %d, %v, want %d, %d, %v", canary, stable, ok, tt.canary, tt.stable, tt.ok)
+			}
+		})
+	}
+}
+
+func TestBuildBackendRefsCanaryPercent(t *testing.T) {
+	canary, stable, _ := canaryWeights(map[string]string{"canary-weight": "50", "canary-weight-total": "1000"})
+	refs, notes := buildBackendRefs(scanner.PathInfo{ServiceName: "web-canary", ServicePort: 8080}, true, canary, stable)
+
+	if !strings.Contains(refs, "      weight: 50\n") {
+		t.Errorf("canary backendRef lacks weight 50:\n%s", refs)
+	}
+	if !strings.Contains(refs, "#   weight: 950\n") {
+		t.Errorf("stable backendRef hint lacks weight 950:\n%s", refs)
+	}
+	if len(notes) != 1 || !strings.Contains(notes[0], "= 5% of traffic") {
+		t.Errorf("notes = %q, want the canary at 5%%", notes)
+	}
+}
+
+// TestBuildAppRootRule checks that app-root redirects only an exact "/" and
+// every other path, "/" prefix included, still reaches the backend.
+func TestBuildAppRootRule(t *testing.T) {
+	ing := scanner.IngressInfo{
+		Name:      "web",
+		Namespace: "shop",
+		Paths: []scanner.PathInfo{
+			{Path: "/", PathType: "Prefix", ServiceName: "web", ServicePort: 80},
+			{Path: "/api", PathType: "Prefix", ServiceName: "api", ServicePort: 8080},
+		},
+		NginxAnnotations: map[string]string{"app-root": "/app"},
+	}
+	rules, _ := buildBackendOnlyRules(ing, ing.NginxAnnotations)
+	parts := strings.Split(rules, "  - matches:\n")[1:]
+	if len(parts) != 3 {
+		t.Fatalf("got %d rules, want the app-root rule and one per path:\n%s", len(parts), rules)
+	}
+
+	appRoot := parts[0]
+	for _, want := range []string{
+		"    - path:\n        type: Exact\n        value: \"/\"\n",
+		"    - type: RequestRedirect\n      requestRedirect:\n        path:\n          type: ReplaceFullPath\n          replaceFullPath: \"/app\"\n        statusCode: 302\n",
+	} {
+		if !strings.Contains(appRoot, want) {
+			t.Errorf("app-root rule lacks\n%s\nin\n%s", want, appRoot)
+		}
+	}
+	if strings.Contains(appRoot, "backendRefs") {
+		t.Errorf("app-root rule has backendRefs:\n%s", appRoot)
+	}
+	for _, rule := range parts[1:] {
+		if strings.Contains(rule, "RequestRedirect") || !strings.Contains(rule, "backendRefs") {
+			t.Errorf("path rule is redirected instead of proxied:\n%s", rule)
+		}
+	}
+
+	// A permanent-redirect replaces proxying, app-root included
+	ing.NginxAnnotations["permanent-redirect"] = "https://example.com"
+	if rules, _ := buildBackendOnlyRules(ing, ing.NginxAnnotations); strings.Contains(rules, "type: Exact") {
+		t.Errorf("app-root rule generated next to permanent-redirect:\n%s", rules)
+	}
+}
+
+// TestBuildMatchesMethodPathMatrix checks that a method-scoped Ingress gets
+// one match per method in every path's rule, each keeping the path and the
+// canary-by-header match.
+func TestBuildMatchesMethodPathMatrix(t *testing.T) {
+	ing := scanner.IngressInfo{
+		Name:        "web",
+		Namespace:   "shop",
+		Annotations: map[string]string{migrator.MethodsAnnotation: "get, POST, FETCH"},
+		Paths: []scanner.PathInfo{
+			{Path: "/", PathType: "Prefix", ServiceName: "web", ServicePort: 80},
+			{Path: "/api", PathType: "Exact", ServiceName: "api", ServicePort: 8080},
+		},
+		NginxAnnotations: map[string]string{"enable-cors": "true", "canary-by-header": "X-Canary"},
+	}
+	rules, _ := buildBackendOnlyRules(ing, ing.NginxAnnotations)
+	parts := strings.Split(rules, "  - matches:\n")[1:]
+	if len(parts) != 2 {
+		t.Fatalf("got %d rules, want one per path:\n%s", len(parts), rules)
+	}
+	for i, path := range []string{"type: PathPrefix\n        value: \"/\"", "type: Exact\n        value: \"/api\""} {
+		var want string
+		for _, method := range []string{"GET", "POST", "OPTIONS"} {
+			want += "    - path:\n        " + path + "\n      method: " + method +
+				"\n      headers:\n      - name: \"X-Canary\"\n        type: Present\n"
+		}
+		if !strings.HasPrefix(parts[i], want) {
+			t.Errorf("rule %d matches\n%s\nwant\n%s", i, parts[i], want)
+		}
+	}
+	if strings.Contains(rules, "FETCH") {
+		t.Errorf("rules match the invalid method FETCH:\n%s", rules)
+	}
+
+	// Without methods the rule has a single match for every method
+	if got, want := buildMatches(`path: "/"`, nil, nil), "  - matches:\n    - path: \"/\"\n"; got != want {
+		t.Errorf("buildMatches() = %q, want %q", got, want)
+	}
+}
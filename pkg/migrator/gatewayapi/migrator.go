@@ -6,22 +6,85 @@ import (
 
 	"github.com/saiyam1814/ing-switch/pkg/analyzer"
 	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
 )
 
 // Migrator generates Gateway API migration files.
 type Migrator struct {
-	provider Provider
+	provider          Provider
+	consolidateByHost bool
+	allowedRoutes     string
+	emitNetworkPolicy bool
+	entryPoints       migrator.EntryPoints
+	resourceNamespace string
+	apiVersion        string
+	extraAnnotations  map[string]string
 }
 
 // NewMigrator creates a new Gateway API Migrator using Envoy Gateway.
 func NewMigrator() *Migrator {
-	return &Migrator{provider: EnvoyProvider}
+	return &Migrator{provider: EnvoyProvider, entryPoints: migrator.DefaultEntryPoints()}
 }
 
 // NewTraefikGatewayMigrator creates a Gateway API Migrator using Traefik.
 func NewTraefikGatewayMigrator() *Migrator {
-	return &Migrator{provider: TraefikProvider}
+	return &Migrator{provider: TraefikProvider, entryPoints: migrator.DefaultEntryPoints()}
+}
+
+// SetConsolidateByHost merges same-namespace ingresses that share a single
+// host into one HTTPRoute per host instead of one HTTPRoute per Ingress.
+func (m *Migrator) SetConsolidateByHost(enabled bool) {
+	m.consolidateByHost = enabled
+}
+
+// SetAllowedRoutes sets the Gateway listeners' allowedRoutes.namespaces.from
+// mode: AllowedRoutesAll (default), AllowedRoutesSame or AllowedRoutesSelector.
+func (m *Migrator) SetAllowedRoutes(mode string) {
+	m.allowedRoutes = mode
+}
+
+// SetEmitNetworkPolicy adds a NetworkPolicy per backend namespace admitting
+// the controller namespace to the backend pods, whether or not existing
+// policies were detected.
+func (m *Migrator) SetEmitNetworkPolicy(enabled bool) {
+	m.emitNetworkPolicy = enabled
+}
+
+// SetExtraAnnotations adds annotations to every generated HTTPRoute.
+func (m *Migrator) SetExtraAnnotations(annotations map[string]string) {
+	m.extraAnnotations = annotations
+}
+
+// SetResourceNamespace creates every HTTPRoute and policy in ns instead of
+// the source Ingress's namespace; "" keeps them there. Backends stay in
+// their namespaces, reached through explicit backendRef namespaces and
+// ReferenceGrants.
+func (m *Migrator) SetResourceNamespace(ns string) {
+	m.resourceNamespace = ns
+}
+
+// SetAPIVersion sets the Gateway API version of the generated GatewayClass,
+// Gateway and HTTPRoutes: APIVersionV1 (default) or APIVersionV1Beta1.
+func (m *Migrator) SetAPIVersion(version string) {
+	m.apiVersion = version
+}
+
+// relocated returns ingresses as their HTTPRoutes see them (see
+// migrator.Relocate).
+func (m *Migrator) relocated(ingresses []scanner.IngressInfo) []scanner.IngressInfo {
+	out := make([]scanner.IngressInfo, len(ingresses))
+	for i, ing := range ingresses {
+		out[i] = migrator.Relocate(ing, m.resourceNamespace)
+	}
+	return out
+}
+
+// SetEntryPoints names the Traefik entrypoints serving HTTP and HTTPS, for
+// an existing Traefik whose entrypoints are not called web and websecure.
+// Envoy Gateway has no entrypoints; it ignores them.
+func (m *Migrator) SetEntryPoints(eps migrator.EntryPoints) {
+	m.entryPoints = eps
 }
 
 // Migrate generates all files for Gateway API migration.
@@ -32,11 +95,21 @@ func (m *Migrator) Migrate(scan *scanner.ScanResult, report *analyzer.AnalysisRe
 	// 1. Install Gateway API CRDs
 	files = append(files, generateCRDInstall())
 
-	// 2. Install the gateway controller
-	if p.Name == "traefik" {
+	// 2. Install the gateway controller, or reuse the one already running
+	tc, installed := scan.InstalledTarget(scanner.TargetControllerType(p.Target))
+	if installed {
+		p.ControllerNamespace = tc.Namespace
+	}
+	switch {
+	case p.Name == "traefik" && installed:
+		files = append(files, generateTraefikGatewayUpgrade(tc))
+		files = append(files, generateTraefikGatewayUpgradeValues(scan.Controller.ForwardedHeaders, m.entryPoints))
+	case p.Name == "traefik":
 		files = append(files, generateTraefikGatewayInstall())
-		files = append(files, generateTraefikGatewayValues())
-	} else {
+		files = append(files, generateTraefikGatewayValues(scan.Controller.ForwardedHeaders, m.entryPoints))
+	case installed:
+		files = append(files, generateEnvoyGatewaySkipInstall(tc, p))
+	default:
 		files = append(files, generateEnvoyGatewayInstall())
 		files = append(files, generateEnvoyGatewayValues())
 	}
@@ -48,31 +121,102 @@ func (m *Migrator) Migrate(scan *scanner.ScanResult, report *analyzer.AnalysisRe
 	}
 	files = append(files, generator.GeneratedFile{
 		RelPath:     "03-gateway/gatewayclass.yaml",
-		Content:     generateGatewayClass(p),
+		Content:     migrator.AddLabels(generateGatewayClass(p), migrator.ManagedLabels("", "")),
 		Description: fmt.Sprintf("GatewayClass using %s controller", providerLabel),
 		Category:    "gateway",
 	})
-	files = append(files, generator.GeneratedFile{
-		RelPath:     "03-gateway/gateway.yaml",
-		Content:     generateGateway(scan, p),
-		Description: "Gateway with HTTP and HTTPS listeners",
-		Category:    "gateway",
-	})
+	// A Gateway no route would attach to only takes a load balancer
+	if len(scan.ListenerIngresses()) > 0 || len(scan.StreamServices) > 0 {
+		gateway, gatewayNotes := generateGateway(scan, p, m.allowedRoutes, m.resourceNamespace)
+		files = append(files, generator.GeneratedFile{
+			RelPath:     "03-gateway/gateway.yaml",
+			Content:     migrator.AddLabels(gateway, migrator.ManagedLabels("", "")),
+			Description: "Gateway with HTTP and HTTPS listeners",
+			Category:    "gateway",
+			Warnings:    gatewayNotes,
+		})
+	}
 
-	// 4. HTTPRoutes — one per Ingress
+	// 4. HTTPRoutes — one per Ingress, or one per shared host when consolidating.
+	// gRPC ingresses get a GRPCRoute each.
 	hostnameToSection := buildHostnameToSection(scan)
+	canaryNotes := analyzer.CanaryWarnings(scan.ListenerIngresses())
+	consolidated := map[string]bool{}
+	if m.consolidateByHost {
+		for _, g := range consolidationGroups(scan, p) {
+			httpRouteYAML, notes := generateConsolidatedHTTPRoute(g.relocate(m.resourceNamespace), defaultGatewayName, defaultGatewayNamespace, hostnameToSection)
+			// One route, several sources: they are listed in an annotation,
+			// as a label can only name one
+			httpRouteYAML = migrator.AddLabels(httpRouteYAML, migrator.ManagedLabels("", ""))
+			httpRouteYAML = migrator.AddAnnotations(httpRouteYAML, migrator.SourceAnnotations(g.sources()))
+			httpRouteYAML = migrator.AddAnnotations(httpRouteYAML, migrator.HashAnnotations(g.ingresses...))
+			httpRouteYAML = migrator.AddAnnotations(httpRouteYAML, m.extraAnnotations)
+			for _, ing := range g.ingresses {
+				routeNotes := routeIngressNotes(ing, canaryNotes, p.Target)
+				httpRouteYAML = migrator.NoteComments(routeNotes) + httpRouteYAML
+				notes = migrator.AppendNotes(routeNotes, notes...)
+			}
+			files = append(files, generator.GeneratedFile{
+				RelPath:     fmt.Sprintf("04-httproutes/%s-%s.yaml", g.namespace, g.name()),
+				Content:     httpRouteYAML,
+				Description: fmt.Sprintf("HTTPRoute for host %s (from %s)", g.host, strings.Join(g.sources(), ", ")),
+				Category:    "httproute",
+				Warnings:    notes,
+			})
+			for _, ing := range g.ingresses {
+				consolidated[ing.Namespace+"/"+ing.Name] = true
+			}
+		}
+	}
 	for _, ing := range scan.Ingresses {
-		httpRouteYAML := generateHTTPRoute(ing, defaultGatewayName, defaultGatewayNamespace, hostnameToSection)
+		if consolidated[ing.Namespace+"/"+ing.Name] {
+			continue
+		}
+		kind := routeKind(ing)
+		var httpRouteYAML string
+		var notes []string
+		if kind == "GRPCRoute" {
+			httpRouteYAML, notes = generateGRPCRoute(migrator.Relocate(ing, m.resourceNamespace), defaultGatewayName, defaultGatewayNamespace, hostnameToSection)
+		} else {
+			httpRouteYAML, notes = generateHTTPRoute(migrator.Relocate(ing, m.resourceNamespace), defaultGatewayName, defaultGatewayNamespace, hostnameToSection)
+		}
+		httpRouteYAML = migrator.AddLabels(httpRouteYAML, migrator.ManagedLabels(ing.Namespace, ing.Name))
+		httpRouteYAML = migrator.AddAnnotations(httpRouteYAML, migrator.HashAnnotations(ing))
+		httpRouteYAML = migrator.AddAnnotations(httpRouteYAML, m.extraAnnotations)
+		routeNotes := routeIngressNotes(ing, canaryNotes, p.Target)
+		httpRouteYAML = migrator.NoteComments(routeNotes) + httpRouteYAML
+		notes = migrator.AppendNotes(routeNotes, notes...)
 		files = append(files, generator.GeneratedFile{
 			RelPath:     fmt.Sprintf("04-httproutes/%s-%s.yaml", ing.Namespace, ing.Name),
 			Content:     httpRouteYAML,
-			Description: fmt.Sprintf("HTTPRoute for %s/%s", ing.Namespace, ing.Name),
+			Description: fmt.Sprintf("%s for %s/%s", kind, ing.Namespace, ing.Name),
 			Category:    "httproute",
+			Warnings:    notes,
 		})
 	}
 
+	// Backends outside the route's namespace need a ReferenceGrant
+	if grants, ok := generateReferenceGrants(m.relocated(scan.Ingresses)); ok {
+		files = append(files, grants)
+	}
+
+	// Envoy Gateway reaches ExternalName backends through a Backend resource
+	if p.Name != "traefik" {
+		files = append(files, generateExternalNameBackends(scan.Ingresses)...)
+	}
+
+	// TCP/UDP services from the ingress-nginx ConfigMaps
+	if len(scan.StreamServices) > 0 {
+		files = append(files, generateStreamRoutes(scan.StreamServices, p))
+	}
+
 	// 5. Extension Policies / Middlewares
-	policies := generatePolicies(scan, p)
+	policies := generatePolicies(scan, p, m.resourceNamespace)
+	if p.Name != "traefik" {
+		if pol, ok := generateClientIPDetection(scan); ok {
+			policies = append(policies, pol)
+		}
+	}
 	policyDesc := "Envoy Gateway policy"
 	if p.Name == "traefik" {
 		policyDesc = "Traefik Middleware"
@@ -83,25 +227,36 @@ func (m *Migrator) Migrate(scan *scanner.ScanResult, report *analyzer.AnalysisRe
 			Content:     pol.yaml,
 			Description: fmt.Sprintf("%s: %s", policyDesc, pol.name),
 			Category:    "policy",
+			Warnings:    pol.notes,
 		})
 	}
 
+	// Backend namespaces whose NetworkPolicies would block the new data plane
+	files = append(files, migrator.NetworkPolicyFiles(scan.NetworkPolicies, providerLabel, p.ControllerNamespace)...)
+	if m.emitNetworkPolicy {
+		files = append(files, migrator.BackendNetworkPolicies(scan.Ingresses, p.ControllerNamespace)...)
+	}
+
 	// 6. Verify script
 	files = append(files, generateGatewayVerifyScript(scan))
 
 	// 7. Cleanup
 	files = append(files, generateGatewayCleanup())
 
-	return files, nil
+	// 8. Per-ingress fix guides
+	files = append(files, migrator.IngressGuides(report)...)
+
+	return withAPIVersion(files, m.apiVersion), nil
 }
 
 // buildHostnameToSection maps each TLS-enabled ingress's primary hostname to
 // its corresponding HTTPS listener sectionName (https-0, https-1, …).
-// The index order matches the TLS listener generation in generateGateway().
+// The index order matches the TLS listener generation in generateGateway(),
+// and uses every scanned ingress so a filtered scan maps to the same listeners.
 func buildHostnameToSection(scan *scanner.ScanResult) map[string]string {
 	m := make(map[string]string)
 	idx := 0
-	for _, ing := range scan.Ingresses {
+	for _, ing := range scan.ListenerIngresses() {
 		if ing.TLSEnabled && len(ing.TLSSecrets) > 0 {
 			for range ing.TLSSecrets {
 				if len(ing.Hosts) > 0 {
@@ -114,6 +269,30 @@ func buildHostnameToSection(scan *scanner.ScanResult) map[string]string {
 	return m
 }
 
+// routeIngressNotes are the NOTEs placed above an ingress's route: canary
+// pairing, ExternalName backends, narrowed path prefixes, a gRPC ingress
+// left on an HTTPRoute, headers the configuration-snippet set from nginx
+// variables, a redirect target with nginx variables, source ranges with no
+// valid entry (or a deny list Traefik cannot enforce) and the backend client
+// certificate.
+func routeIngressNotes(ing scanner.IngressInfo, canaryNotes map[string][]string, target string) []string {
+	notes := append([]string{}, canaryNotes[ing.Namespace+"/"+ing.Name]...)
+	notes = append(notes, analyzer.ExternalNameWarnings(ing, target)...)
+	notes = append(notes, analyzer.PathPrefixWarnings(ing, target)...)
+	notes = append(notes, analyzer.BackendProtocolWarnings(ing, target)...)
+	notes = append(notes, grpcFallbackNotes(ing)...)
+	_, methodNotes := migrator.MatchMethods(ing)
+	notes = append(notes, methodNotes...)
+	_, headerNotes := migrator.SnippetRequestHeaders(ing.NginxAnnotations)
+	notes = append(notes, headerNotes...)
+	notes = append(notes, migrator.RedirectNotes(ing.NginxAnnotations)...)
+	notes = append(notes, migrator.SourceRangeNotes(ing.NginxAnnotations)...)
+	if target == "gateway-api-traefik" {
+		notes = append(notes, migrator.TraefikDenyListNotes(ing.NginxAnnotations)...)
+	}
+	return append(notes, backendClientCertNotes(ing)...)
+}
+
 func generateTraefikGatewayInstall() generator.GeneratedFile {
 	return generator.GeneratedFile{
 		RelPath: "02-install-traefik-gateway/helm-install.sh",
@@ -148,7 +327,14 @@ echo "  kubectl apply -f ../03-gateway/"
 	}
 }
 
-func generateTraefikGatewayValues() generator.GeneratedFile {
+// generateTraefikGatewayValues renders the Traefik chart values for Gateway
+// API mode, trusting the proxies ingress-nginx trusted (fh) on the web and
+// websecure entrypoints (named by eps).
+func generateTraefikGatewayValues(fh *scanner.ForwardedHeaders, eps migrator.EntryPoints) generator.GeneratedFile {
+	ports := ""
+	if trust := migrator.TraefikEntryPointTrust(fh, "    "); trust != "" {
+		ports = "\n# Entrypoints\nports:\n  " + eps.Web + ":\n" + trust + "  " + eps.WebSecure + ":\n" + trust
+	}
 	return generator.GeneratedFile{
 		RelPath: "02-install-traefik-gateway/values.yaml",
 		Content: `# Traefik Helm values — Gateway API mode
@@ -176,7 +362,7 @@ gateway:
     websecure:
       port: 8443
       protocol: HTTPS
-
+` + ports + `
 # High availability
 deployment:
   replicas: 2
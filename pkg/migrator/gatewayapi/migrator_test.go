@@ -0,0 +1,63 @@
+package gatewayapi
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+var (
+	docSeparator = regexp.MustCompile(`(?m)^---[ \t]*\n`)
+	topLevelKind = regexp.MustCompile(`(?m)^kind: `)
+)
+
+func TestMigrateLabelsGeneratedResources(t *testing.T) {
+	scan := &scanner.ScanResult{Ingresses: []scanner.IngressInfo{{
+		Name:       "web",
+		Namespace:  "shop",
+		Hosts:      []string{"shop.example.com"},
+		TLSEnabled: true,
+		TLSSecrets: []string{"shop-tls"},
+		Paths:      []scanner.PathInfo{{Host: "shop.example.com", Path: "/", PathType: "Prefix", ServiceName: "web", ServicePort: 80}},
+		NginxAnnotations: map[string]string{
+			"ssl-redirect": "true",
+			"limit-rps":    "10",
+		},
+	}}}
+	files, err := NewMigrator().Migrate(scan, analyzer.NewAnalyzer("gateway-api").Analyze(scan))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	const (
+		managed = `app.kubernetes.io/managed-by: "ing-switch"`
+		source  = `ing-switch.io/source-ingress: "shop.web"`
+	)
+	checked := map[string]bool{}
+	for _, f := range files {
+		if f.Category != "gateway" && f.Category != "httproute" && f.Category != "policy" {
+			continue
+		}
+		for _, doc := range docSeparator.Split(f.Content, -1) {
+			if !topLevelKind.MatchString(doc) {
+				continue
+			}
+			checked[f.Category] = true
+			if !strings.Contains(doc, managed) {
+				t.Errorf("%s: document lacks %s:\n%s", f.RelPath, managed, doc)
+			}
+			// GatewayClass and Gateway are shared by every ingress
+			if hasSource := strings.Contains(doc, source); hasSource == (f.Category == "gateway") {
+				t.Errorf("%s: source-ingress label present = %v:\n%s", f.RelPath, hasSource, doc)
+			}
+		}
+	}
+	for _, category := range []string{"gateway", "httproute", "policy"} {
+		if !checked[category] {
+			t.Errorf("no %s generated", category)
+		}
+	}
+}
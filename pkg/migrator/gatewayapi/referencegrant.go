@@ -0,0 +1,84 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// generateReferenceGrants emits one ReferenceGrant per (backend namespace,
+// route namespace) pair for backendRefs that cross namespaces. Without it the
+// HTTPRoute is accepted but the rule reports ResolvedRefs=False and serves 500s.
+// Request mirrors in another namespace need one too. The grant names the
+// route kinds (HTTPRoute, GRPCRoute) that use the backends.
+// ok is false when every backend is in its route's namespace.
+func generateReferenceGrants(ingresses []scanner.IngressInfo) (generator.GeneratedFile, bool) {
+	// backend namespace → route namespace → service names, and route kinds
+	grants := make(map[string]map[string]map[string]bool)
+	kinds := make(map[string]map[string]map[string]bool)
+	grant := func(to, from, kind, service string) {
+		if to == "" || to == from || service == "" {
+			return
+		}
+		if grants[to] == nil {
+			grants[to] = make(map[string]map[string]bool)
+			kinds[to] = make(map[string]map[string]bool)
+		}
+		if grants[to][from] == nil {
+			grants[to][from] = make(map[string]bool)
+			kinds[to][from] = make(map[string]bool)
+		}
+		grants[to][from][service] = true
+		kinds[to][from][kind] = true
+	}
+	for _, ing := range ingresses {
+		kind := routeKind(ing)
+		for _, p := range ing.Paths {
+			grant(p.ServiceNamespace, ing.Namespace, kind, p.ServiceName)
+		}
+		if m, ok := migrator.ParseMirror(ing.NginxAnnotations); ok {
+			grant(m.Namespace, ing.Namespace, kind, m.Service)
+		}
+	}
+	if len(grants) == 0 {
+		return generator.GeneratedFile{}, false
+	}
+
+	var docs []string
+	for _, to := range slices.Sorted(maps.Keys(grants)) {
+		for _, from := range slices.Sorted(maps.Keys(grants[to])) {
+			var services []string
+			for _, name := range slices.Sorted(maps.Keys(grants[to][from])) {
+				services = append(services, fmt.Sprintf("  - group: \"\"\n    kind: Service\n    name: %s", name))
+			}
+			var froms []string
+			for _, kind := range slices.Sorted(maps.Keys(kinds[to][from])) {
+				froms = append(froms, fmt.Sprintf("  - group: gateway.networking.k8s.io\n    kind: %s\n    namespace: %s", kind, from))
+			}
+			doc := fmt.Sprintf(`apiVersion: gateway.networking.k8s.io/v1beta1
+kind: ReferenceGrant
+metadata:
+  name: ing-switch-httproutes-from-%s
+  namespace: %s
+spec:
+  from:
+%s
+  to:
+%s
+`, from, to, strings.Join(froms, "\n"), strings.Join(services, "\n"))
+			docs = append(docs, migrator.AddLabels(doc, migrator.ManagedLabels("", "")))
+		}
+	}
+
+	return generator.GeneratedFile{
+		RelPath:     "04-httproutes/reference-grants.yaml",
+		Content:     "# Lets routes reference backend Services in other namespaces.\n" + strings.Join(docs, "---\n"),
+		Description: fmt.Sprintf("ReferenceGrants for cross-namespace backends in %d namespace(s)", len(grants)),
+		Category:    "httproute",
+	}, true
+}
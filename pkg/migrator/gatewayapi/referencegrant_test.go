@@ -0,0 +1,65 @@
+package gatewayapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// TestCrossNamespaceBackend checks that a backend in another namespace gets
+// an explicit backendRef namespace and a ReferenceGrant in the backend's
+// namespace admitting HTTPRoutes from the route's namespace.
+func TestCrossNamespaceBackend(t *testing.T) {
+	scan := &scanner.ScanResult{Ingresses: []scanner.IngressInfo{
+		{
+			Name:      "web",
+			Namespace: "shop",
+			Hosts:     []string{"shop.example.com"},
+			Paths: []scanner.PathInfo{
+				{Host: "shop.example.com", Path: "/api", PathType: "Prefix", ServiceName: "api", ServiceNamespace: "backend", ServicePort: 8080},
+				{Host: "shop.example.com", Path: "/", PathType: "Prefix", ServiceName: "web", ServicePort: 80},
+			},
+		},
+		{
+			Name:      "local",
+			Namespace: "backend",
+			Hosts:     []string{"backend.example.com"},
+			Paths:     []scanner.PathInfo{{Host: "backend.example.com", Path: "/", PathType: "Prefix", ServiceName: "api", ServiceNamespace: "backend", ServicePort: 8080}},
+		},
+	}}
+	files, err := NewMigrator().Migrate(scan, analyzer.NewAnalyzer("gateway-api").Analyze(scan))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	var route, grants string
+	for _, f := range files {
+		switch {
+		case strings.HasSuffix(f.RelPath, "reference-grants.yaml"):
+			grants = f.Content
+		case f.Category == "httproute" && strings.Contains(f.Content, "name: web\n"):
+			route = f.Content
+		}
+	}
+	if want := "    - name: api\n      namespace: backend\n      port: 8080\n"; !strings.Contains(route, want) {
+		t.Errorf("HTTPRoute lacks backendRef\n%s\nin\n%s", want, route)
+	}
+	if want := "    - name: web\n      port: 80\n"; !strings.Contains(route, want) {
+		t.Errorf("HTTPRoute lacks same-namespace backendRef\n%s\nin\n%s", want, route)
+	}
+
+	if n := len(topLevelKind.FindAllString(grants, -1)); n != 1 {
+		t.Fatalf("got %d ReferenceGrants, want 1 (none for the same-namespace route):\n%s", n, grants)
+	}
+	for _, want := range []string{
+		"kind: ReferenceGrant\nmetadata:\n  name: ing-switch-httproutes-from-shop\n  namespace: backend\n",
+		"  from:\n  - group: gateway.networking.k8s.io\n    kind: HTTPRoute\n    namespace: shop\n",
+		"  to:\n  - group: \"\"\n    kind: Service\n    name: api\n",
+	} {
+		if !strings.Contains(grants, want) {
+			t.Errorf("ReferenceGrant lacks\n%s\nin\n%s", want, grants)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// streamListenerName names the Gateway listener for a TCP/UDP port, e.g. "tcp-9000".
+func streamListenerName(st scanner.StreamService) string {
+	return fmt.Sprintf("%s-%d", strings.ToLower(st.Protocol), st.Port)
+}
+
+// buildStreamListeners renders one TCP/UDP Gateway listener per stream service.
+func buildStreamListeners(streams []scanner.StreamService, allowed string) string {
+	var sb strings.Builder
+	for _, st := range streams {
+		sb.WriteString(fmt.Sprintf(`  - name: %s
+    protocol: %s
+    port: %d
+%s      kinds:
+      - kind: %sRoute
+`, streamListenerName(st), st.Protocol, st.Port, allowed, st.Protocol))
+	}
+	return sb.String()
+}
+
+// generateStreamRoutes converts tcp-services/udp-services entries into
+// TCPRoute / UDPRoute resources attached to the matching Gateway listener.
+func generateStreamRoutes(streams []scanner.StreamService, p Provider) generator.GeneratedFile {
+	var docs, warnings []string
+	for _, st := range streams {
+		port := st.ServicePort
+		note := ""
+		for _, c := range st.ServicePort {
+			if c < '0' || c > '9' {
+				port = "0"
+				note = fmt.Sprintf("      # TODO: named port %q — replace 0 with the Service port number\n", st.ServicePort)
+				warnings = append(warnings, fmt.Sprintf("%s/%s named port %q — replace port 0 with the Service port number", st.Namespace, st.Service, st.ServicePort))
+				break
+			}
+		}
+		if st.DecodeProxy || st.EncodeProxy {
+			note += "# NOTE: PROXY protocol was enabled in ingress-nginx; Gateway API has no standard field for it —\n" +
+				"# configure it on the provider (Envoy: ClientTrafficPolicy.enableProxyProtocol / BackendTrafficPolicy.proxyProtocol).\n"
+			warnings = append(warnings, fmt.Sprintf("PROXY protocol was enabled in ingress-nginx for %s port %d; Gateway API has no standard field for it — configure it on the provider (Envoy: ClientTrafficPolicy.enableProxyProtocol / BackendTrafficPolicy.proxyProtocol)", st.Protocol, st.Port))
+		}
+		doc := fmt.Sprintf(`apiVersion: gateway.networking.k8s.io/v1alpha2
+kind: %sRoute
+metadata:
+  name: %s-%s
+  namespace: %s
+spec:
+  parentRefs:
+  - name: %s
+    namespace: %s
+    sectionName: %s
+  rules:
+  - backendRefs:
+    - name: %s
+      port: %s
+%s`, st.Protocol, streamListenerName(st), st.Service, st.Namespace,
+			defaultGatewayName, defaultGatewayNamespace, streamListenerName(st),
+			st.Service, port, note)
+		docs = append(docs, migrator.AddLabels(doc, migrator.ManagedLabels("", "")))
+	}
+
+	header := `# TCP/UDP routes migrated from the ingress-nginx tcp-services/udp-services ConfigMaps.
+# TCPRoute and UDPRoute are in the Gateway API EXPERIMENTAL channel — install those CRDs first:
+#   kubectl apply -f https://github.com/kubernetes-sigs/gateway-api/releases/download/v1.5.0/experimental-install.yaml
+`
+	if p.Name == "traefik" {
+		header += `# Traefik also needs providers.kubernetesGateway.experimentalChannel: true and an
+# entrypoint per port (see the TCP/UDP listeners in 03-gateway/gateway.yaml).
+`
+	}
+	return generator.GeneratedFile{
+		RelPath:     "04-httproutes/tcp-udp-routes.yaml",
+		Content:     header + strings.Join(docs, "---\n"),
+		Description: fmt.Sprintf("TCPRoute/UDPRoute for %d TCP/UDP service(s)", len(streams)),
+		Category:    "httproute",
+		Warnings:    warnings,
+	}
+}
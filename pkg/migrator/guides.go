@@ -0,0 +1,89 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+)
+
+// IngressGuides returns guides/<namespace>-<name>.md for every ingress in
+// report that needs work: its partial and unsupported annotations, each with
+// the mapping note and the full fix guide for the target, so one ingress can
+// be worked through without the whole migration report.
+func IngressGuides(report *analyzer.AnalysisReport) []generator.GeneratedFile {
+	if report == nil {
+		return nil
+	}
+	var files []generator.GeneratedFile
+	for _, ir := range report.IngressReports {
+		if ir.OverallStatus == "ready" {
+			continue
+		}
+		files = append(files, generator.GeneratedFile{
+			RelPath:     fmt.Sprintf("guides/%s-%s.md", ir.Namespace, ir.Name),
+			Content:     ingressGuide(report.Target, ir),
+			Description: fmt.Sprintf("Fix guide for %s/%s", ir.Namespace, ir.Name),
+			Category:    "guide",
+		})
+	}
+	return files
+}
+
+func ingressGuide(target string, ir analyzer.IngressReport) string {
+	var sb strings.Builder
+
+	status := map[string]string{
+		"workaround": "⚠️  Needs workaround",
+		"breaking":   "❌ Has unsupported annotations",
+	}[ir.OverallStatus]
+	sb.WriteString(fmt.Sprintf("# %s/%s\n\n", ir.Namespace, ir.Name))
+	sb.WriteString(fmt.Sprintf("**Target Controller:** %s\n\n**Status:** %s\n\n", target, status))
+	for _, warn := range ir.Warnings {
+		sb.WriteString(fmt.Sprintf("> ⚠️ %s\n\n", warn))
+	}
+
+	for _, m := range ir.Mappings {
+		if m.Status != analyzer.StatusPartial && m.Status != analyzer.StatusUnsupported {
+			continue
+		}
+		key := strings.TrimPrefix(m.OriginalKey, "nginx.ingress.kubernetes.io/")
+		icon := map[analyzer.MappingStatus]string{
+			analyzer.StatusPartial:     "⚠️ partial",
+			analyzer.StatusUnsupported: "❌ unsupported",
+		}[m.Status]
+		sb.WriteString(fmt.Sprintf("## `%s` — %s\n\n", key, icon))
+		if m.OriginalValue != "" {
+			sb.WriteString(fmt.Sprintf("**Value:** `%s`\n\n", m.OriginalValue))
+		}
+		if m.TargetResource != "" {
+			sb.WriteString(fmt.Sprintf("**Target Resource:** %s\n\n", m.TargetResource))
+		}
+		if m.Note != "" {
+			sb.WriteString(m.Note + "\n\n")
+		}
+
+		guide := analyzer.GetValueGuide(target, key, m.OriginalValue)
+		if guide.What != "" {
+			sb.WriteString(fmt.Sprintf("**What it does:** %s\n\n", guide.What))
+		}
+		if guide.Fix != "" {
+			sb.WriteString(fmt.Sprintf("**Fix:** %s\n\n", guide.Fix))
+		}
+		if guide.Consequence != "" {
+			sb.WriteString(fmt.Sprintf("**If not migrated:** %s\n\n", guide.Consequence))
+		}
+		if guide.Example != "" {
+			sb.WriteString("```\n" + guide.Example + "\n```\n\n")
+		}
+		if guide.DocsLink != "" {
+			sb.WriteString(fmt.Sprintf("Docs: %s\n\n", guide.DocsLink))
+		}
+		if guide.IssueUrl != "" {
+			sb.WriteString(fmt.Sprintf("Upstream issue: %s\n\n", guide.IssueUrl))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,44 @@
+package migrator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+	"github.com/saiyam1814/ing-switch/pkg/version"
+)
+
+// ContentHashAnnotation records a hash of the source Ingress(es) and the
+// ing-switch version a resource was generated from. Comparing it with a
+// freshly computed ContentHash detects Ingresses edited after migration.
+const ContentHashAnnotation = "ing-switch.io/content-hash"
+
+// ContentHash hashes the parts of the given ingresses that drive generation
+// (class, hosts, paths, TLS secrets, feature annotations) together with the
+// tool version. Metadata such as resourceVersion or unrelated annotations do
+// not affect it.
+func ContentHash(ingresses ...scanner.IngressInfo) string {
+	h := sha256.New()
+	h.Write([]byte(version.Version))
+	for _, ing := range ingresses {
+		// encoding/json sorts map keys, so the encoding is stable.
+		b, _ := json.Marshal(struct {
+			Namespace   string
+			Name        string
+			Class       string
+			Hosts       []string
+			Paths       []scanner.PathInfo
+			TLSSecrets  []string
+			Annotations map[string]string
+		}{ing.Namespace, ing.Name, ing.IngressClass, ing.Hosts, ing.Paths, ing.TLSSecrets, ing.NginxAnnotations})
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// HashAnnotations returns the content-hash annotation for resources
+// generated from the given ingresses, for use with AddAnnotations.
+func HashAnnotations(ingresses ...scanner.IngressInfo) map[string]string {
+	return map[string]string{ContentHashAnnotation: ContentHash(ingresses...)}
+}
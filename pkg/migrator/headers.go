@@ -0,0 +1,41 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// RequestHeader is a request header a configuration-snippet sets with
+// proxy_set_header.
+type RequestHeader struct {
+	Name  string
+	Value string
+}
+
+// SnippetRequestHeaders returns the custom-request-headers with a literal
+// value, for a header middleware or filter to set. The others are set from
+// one of scanner.ForwardedVariables, which a static header cannot carry;
+// the targets pass that value in its usual header anyway, so each gets a
+// NOTE naming the header the backend should read instead (none when it
+// already is that header, e.g. X-Forwarded-Proto $scheme).
+func SnippetRequestHeaders(annotations map[string]string) (headers []RequestHeader, notes []string) {
+	for _, line := range strings.Split(annotations["custom-request-headers"], "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" {
+			continue
+		}
+		header, isVariable := scanner.ForwardedVariables[value]
+		if !isVariable {
+			headers = append(headers, RequestHeader{Name: name, Value: value})
+			continue
+		}
+		if !strings.EqualFold(name, header) {
+			notes = AppendNotes(notes, fmt.Sprintf("proxy_set_header %s %s is not carried over: the value differs per request, "+
+				"and the backend already receives it in %s — read it from there", name, value, header))
+		}
+	}
+	return headers, notes
+}
@@ -0,0 +1,86 @@
+package migrator
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// ParseSourceRanges parses a whitelist-source-range/denylist-source-range
+// value. Valid entries are returned in CIDR notation — bare addresses get /32
+// (IPv4) or /128 (IPv6), since Envoy Gateway requires a mask. Entries that
+// are neither an IP nor a CIDR are returned in invalid, untouched.
+func ParseSourceRanges(value string) (ranges, invalid []string) {
+	for _, item := range scanner.SplitList(value) {
+		entry := strings.TrimSuffix(strings.TrimPrefix(item, "["), "]")
+		if _, _, err := net.ParseCIDR(entry); err == nil {
+			ranges = append(ranges, entry)
+			continue
+		}
+		ip := net.ParseIP(entry)
+		switch {
+		case ip == nil:
+			invalid = append(invalid, item)
+		case ip.To4() != nil && !strings.Contains(entry, ":"):
+			// ::ffff:a.b.c.d is IPv4 to net but IPv6 text: /32 would be a /32 IPv6 range
+			ranges = append(ranges, entry+"/32")
+		default:
+			ranges = append(ranges, entry+"/128")
+		}
+	}
+	return ranges, invalid
+}
+
+// SourceRangeYAML renders parsed source ranges as the YAML value of a list
+// field, a block sequence under indent. Invalid entries become trailing NOTE
+// comments instead of YAML. ranges must not be empty: see SourceRangeNotes.
+func SourceRangeYAML(ranges, invalid []string, indent string) string {
+	out := "\n" + YAMLList(ranges, indent)
+	for _, n := range InvalidRangeNotes(invalid) {
+		out += "\n# NOTE: " + n
+	}
+	return out
+}
+
+// SourceRangeNotes explains a whitelist-source-range or denylist-source-range
+// with no valid entry. An empty list would allow (or deny) no client at all,
+// so no filter is generated for it.
+func SourceRangeNotes(annotations map[string]string) []string {
+	var notes []string
+	for _, l := range []struct{ key, filter string }{{"denylist-source-range", "IP deny list"}, {"whitelist-source-range", "IP allow list"}} {
+		if v := annotations[l.key]; v != "" {
+			if ranges, _ := ParseSourceRanges(v); len(ranges) == 0 {
+				notes = append(notes, fmt.Sprintf("%s %q has no valid IP or CIDR, so no %s is generated — fix the value", l.key, v, l.filter))
+			}
+		}
+	}
+	return notes
+}
+
+// TraefikDenyListNotes explains that denylist-source-range is not migrated
+// to Traefik: it only has an ipAllowList middleware, and an allow list cannot
+// exclude ranges from everyone else.
+func TraefikDenyListNotes(annotations map[string]string) []string {
+	v := annotations["denylist-source-range"]
+	ranges, _ := ParseSourceRanges(v)
+	if len(ranges) == 0 {
+		return nil // nothing to deny, or SourceRangeNotes explains it
+	}
+	rules := make([]string, len(ranges))
+	for i, r := range ranges {
+		rules[i] = fmt.Sprintf("!ClientIP(`%s`)", r)
+	}
+	return []string{fmt.Sprintf("denylist-source-range %q is not migrated: Traefik has no deny-list middleware — block these ranges "+
+		"at the load balancer or firewall, or add %s to an IngressRoute match", v, strings.Join(rules, " && "))}
+}
+
+// InvalidRangeNotes returns the notes SourceRangeYAML adds for invalid.
+func InvalidRangeNotes(invalid []string) []string {
+	var notes []string
+	for _, bad := range invalid {
+		notes = append(notes, fmt.Sprintf("%q is not a valid IP or CIDR and was skipped", bad))
+	}
+	return notes
+}
@@ -0,0 +1,88 @@
+package migrator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSourceRanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantRanges  []string
+		wantInvalid []string
+	}{
+		{name: "bare IPv4", value: "10.0.0.1", wantRanges: []string{"10.0.0.1/32"}},
+		{name: "bare IPv6", value: "2001:db8::1", wantRanges: []string{"2001:db8::1/128"}},
+		{name: "bracketed IPv6", value: "[2001:db8::1]", wantRanges: []string{"2001:db8::1/128"}},
+		{name: "IPv4-mapped IPv6", value: "::ffff:10.0.0.1", wantRanges: []string{"::ffff:10.0.0.1/128"}},
+		{name: "every IPv6 address", value: "::/0", wantRanges: []string{"::/0"}},
+		{
+			name:       "mixed list",
+			value:      "10.0.0.0/8, 192.168.1.1 ,2001:db8::/32,::1",
+			wantRanges: []string{"10.0.0.0/8", "192.168.1.1/32", "2001:db8::/32", "::1/128"},
+		},
+		{
+			name:        "invalid CIDRs",
+			value:       "10.0.0.0/33, 2001:db8::/129, example.com, 10.0.0.0/8",
+			wantRanges:  []string{"10.0.0.0/8"},
+			wantInvalid: []string{"10.0.0.0/33", "2001:db8::/129", "example.com"},
+		},
+		{name: "empty", value: " , "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, invalid := ParseSourceRanges(tt.value)
+			if !reflect.DeepEqual(ranges, tt.wantRanges) || !reflect.DeepEqual(invalid, tt.wantInvalid) {
+				t.Errorf("ParseSourceRanges(%q) = %q, %q, want %q, %q", tt.value, ranges, invalid, tt.wantRanges, tt.wantInvalid)
+			}
+		})
+	}
+}
+
+func TestSourceRangeNotes(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantNotes   int
+	}{
+		{name: "no source ranges"},
+		{name: "some valid entries", annotations: map[string]string{"whitelist-source-range": "10.0.0.0/8, example.com"}},
+		{name: "no valid allow entry", annotations: map[string]string{"whitelist-source-range": "example.com"}, wantNotes: 1},
+		{name: "no valid entry in either list", annotations: map[string]string{
+			"whitelist-source-range": "10.0.0.0/33", "denylist-source-range": "bad",
+		}, wantNotes: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SourceRangeNotes(tt.annotations); len(got) != tt.wantNotes {
+				t.Errorf("SourceRangeNotes() = %q, want %d notes", got, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestTraefikDenyListNotes(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{
+		{name: "no deny list"},
+		{name: "no valid entry", annotations: map[string]string{"denylist-source-range": "bad"}},
+		{name: "valid entries", annotations: map[string]string{"denylist-source-range": "10.0.0.0/8, 192.168.1.1, bad"},
+			want: "!ClientIP(`10.0.0.0/8`) && !ClientIP(`192.168.1.1/32`)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TraefikDenyListNotes(tt.annotations)
+			switch {
+			case tt.want == "" && len(got) != 0:
+				t.Errorf("TraefikDenyListNotes() = %q, want none", got)
+			case tt.want != "" && (len(got) != 1 || !strings.Contains(got[0], tt.want)):
+				t.Errorf("TraefikDenyListNotes() = %q, want one note with %q", got, tt.want)
+			}
+		})
+	}
+}
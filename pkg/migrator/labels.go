@@ -0,0 +1,141 @@
+package migrator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// ManagedByLabel marks resources that ing-switch created and may delete.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue is the ManagedByLabel value for ing-switch resources.
+	ManagedByValue = "ing-switch"
+	// SourceIngressLabel records which Ingress a resource was generated from,
+	// as "<namespace>.<name>" ("/" is not allowed in label values; namespaces
+	// cannot contain dots, so the first "." separates the two).
+	SourceIngressLabel = "ing-switch.io/source-ingress"
+	// SourceIngressesAnnotation lists the Ingresses a resource merges, as
+	// comma-separated "<namespace>/<name>". Resources generated from several
+	// Ingresses (consolidated HTTPRoutes) carry it instead of SourceIngressLabel.
+	SourceIngressesAnnotation = "ing-switch.io/source-ingresses"
+
+	maxLabelValueLen = 63
+)
+
+// ManagedLabels returns the label set for a resource ing-switch creates.
+// Pass an empty namespace for shared resources (GatewayClass, Gateway) that
+// do not belong to a single Ingress.
+func ManagedLabels(namespace, name string) map[string]string {
+	labels := map[string]string{ManagedByLabel: ManagedByValue}
+	if namespace != "" {
+		labels[SourceIngressLabel] = SourceIngressValue(namespace, name)
+	}
+	return labels
+}
+
+// SourceLabels returns only the source label. It is used for manifests that
+// update a user's existing resource (e.g. the rewritten Ingress), which must
+// not be claimed as managed-by ing-switch or cleanup would delete it.
+func SourceLabels(namespace, name string) map[string]string {
+	return map[string]string{SourceIngressLabel: SourceIngressValue(namespace, name)}
+}
+
+// SourceAnnotations returns the SourceIngressesAnnotation for a resource
+// generated from refs ("namespace/name"), for use with AddAnnotations.
+func SourceAnnotations(refs []string) map[string]string {
+	return map[string]string{SourceIngressesAnnotation: strings.Join(refs, ",")}
+}
+
+// SourceIngressValue encodes an Ingress reference as a valid label value,
+// truncating with a hash suffix when it exceeds 63 characters.
+func SourceIngressValue(namespace, name string) string {
+	v := namespace + "." + name
+	if len(v) <= maxLabelValueLen {
+		return v
+	}
+	sum := sha256.Sum256([]byte(v))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	prefix := strings.TrimRight(v[:maxLabelValueLen-len(suffix)-1], "-_.")
+	return prefix + "-" + suffix
+}
+
+// AddLabels inserts labels into the top-level metadata of every document in a
+// (possibly multi-document) YAML string. Existing labels blocks are extended;
+// otherwise a labels block is added after metadata.name/namespace. Indented
+// metadata (e.g. pod templates) and comment-only documents are left alone.
+func AddLabels(yaml string, labels map[string]string) string {
+	return addMetadataEntries(yaml, "labels", labels)
+}
+
+// AddAnnotations is AddLabels for metadata.annotations.
+func AddAnnotations(yaml string, annotations map[string]string) string {
+	return addMetadataEntries(yaml, "annotations", annotations)
+}
+
+// addMetadataEntries adds entries to the metadata.<field> map of every
+// top-level document, creating the block when absent.
+func addMetadataEntries(yaml, field string, values map[string]string) string {
+	if len(values) == 0 {
+		return yaml
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var entries []string
+	for _, k := range keys {
+		entries = append(entries, fmt.Sprintf("    %s: %q", k, values[k]))
+	}
+
+	header := "  " + field + ":"
+	lines := strings.Split(yaml, "\n")
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		out = append(out, line)
+		if line != "metadata:" {
+			continue
+		}
+
+		// Look for an existing block within this metadata block.
+		blockAt := -1
+		for j := i + 1; j < len(lines) && strings.HasPrefix(lines[j], "  "); j++ {
+			if lines[j] == header {
+				blockAt = j
+				break
+			}
+		}
+		if blockAt < 0 {
+			// Keep name/namespace (and labels, for annotations) first, as
+			// hand-written manifests do.
+			j := i + 1
+			for j < len(lines) {
+				if strings.HasPrefix(lines[j], "  name:") || strings.HasPrefix(lines[j], "  namespace:") {
+					j++
+					continue
+				}
+				if field == "annotations" && lines[j] == "  labels:" {
+					j++
+					for j < len(lines) && strings.HasPrefix(lines[j], "    ") {
+						j++
+					}
+					continue
+				}
+				break
+			}
+			out = append(out, lines[i+1:j]...)
+			out = append(out, header)
+			out = append(out, entries...)
+			i = j - 1
+			continue
+		}
+		out = append(out, lines[i+1:blockAt+1]...)
+		out = append(out, entries...)
+		i = blockAt
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,131 @@
+package migrator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddLabels(t *testing.T) {
+	labels := ManagedLabels("shop", "web")
+
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{
+			name: "labels block added after name and namespace",
+			yaml: `apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: web-cors
+  namespace: shop
+spec:
+  headers: {}
+`,
+			want: `apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: web-cors
+  namespace: shop
+  labels:
+    app.kubernetes.io/managed-by: "ing-switch"
+    ing-switch.io/source-ingress: "shop.web"
+spec:
+  headers: {}
+`,
+		},
+		{
+			name: "existing labels block extended",
+			yaml: `kind: HTTPRoute
+metadata:
+  name: web
+  labels:
+    team: shop
+spec: {}
+`,
+			want: `kind: HTTPRoute
+metadata:
+  name: web
+  labels:
+    app.kubernetes.io/managed-by: "ing-switch"
+    ing-switch.io/source-ingress: "shop.web"
+    team: shop
+spec: {}
+`,
+		},
+		{
+			name: "every document, not nested metadata",
+			yaml: `kind: HTTPRoute
+metadata:
+  name: web-redirect
+---
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    metadata:
+      name: pod
+`,
+			want: `kind: HTTPRoute
+metadata:
+  name: web-redirect
+  labels:
+    app.kubernetes.io/managed-by: "ing-switch"
+    ing-switch.io/source-ingress: "shop.web"
+---
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app.kubernetes.io/managed-by: "ing-switch"
+    ing-switch.io/source-ingress: "shop.web"
+spec:
+  template:
+    metadata:
+      name: pod
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddLabels(tt.yaml, labels); got != tt.want {
+				t.Errorf("AddLabels() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddAnnotationsAfterLabels(t *testing.T) {
+	yaml := AddLabels("metadata:\n  name: web\nspec: {}\n", ManagedLabels("", ""))
+	got := AddAnnotations(yaml, map[string]string{ContentHashAnnotation: "abc"})
+	want := `metadata:
+  name: web
+  labels:
+    app.kubernetes.io/managed-by: "ing-switch"
+  annotations:
+    ing-switch.io/content-hash: "abc"
+spec: {}
+`
+	if got != want {
+		t.Errorf("AddAnnotations() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSourceIngressValue(t *testing.T) {
+	if got := SourceIngressValue("shop", "web"); got != "shop.web" {
+		t.Errorf("SourceIngressValue = %q, want shop.web", got)
+	}
+
+	long := SourceIngressValue("production-eu-west", strings.Repeat("storefront-", 6))
+	if len(long) > maxLabelValueLen {
+		t.Errorf("SourceIngressValue = %q (%d characters), want at most %d", long, len(long), maxLabelValueLen)
+	}
+	if long != SourceIngressValue("production-eu-west", strings.Repeat("storefront-", 6)) {
+		t.Error("SourceIngressValue is not deterministic")
+	}
+	if long == SourceIngressValue("production-eu-west", strings.Repeat("storefront-", 7)) {
+		t.Error("SourceIngressValue truncates two ingresses to the same value")
+	}
+}
@@ -0,0 +1,38 @@
+package migrator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// YAMLList renders items as a YAML block sequence, one quoted item per line,
+// each prefixed with indent. Use it wherever the target schema expects an array.
+func YAMLList(items []string, indent string) string {
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, fmt.Sprintf("%s- %q", indent, item))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AppendNotes appends the notes not already in notes. Builders that emit the
+// same NOTE for every rule of a route report it once.
+func AppendNotes(notes []string, more ...string) []string {
+	for _, n := range more {
+		if !slices.Contains(notes, n) {
+			notes = append(notes, n)
+		}
+	}
+	return notes
+}
+
+// NoteComments renders each note as a "# NOTE:" comment line, for
+// prepending to a generated manifest.
+func NoteComments(notes []string) string {
+	var sb strings.Builder
+	for _, n := range notes {
+		sb.WriteString("# NOTE: " + n + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,11 @@
+package migrator
+
+import "testing"
+
+func TestYAMLList(t *testing.T) {
+	got := YAMLList([]string{"GET", "X-Custom: \"quoted\""}, "      ")
+	want := "      - \"GET\"\n      - \"X-Custom: \\\"quoted\\\"\""
+	if got != want {
+		t.Errorf("YAMLList() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,43 @@
+package migrator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// MethodsAnnotation scopes the routes generated for an Ingress to some HTTP
+// methods. NGINX routes every method; listing methods here (comma-separated,
+// e.g. "GET, POST") makes each Gateway API rule match only those, for
+// method-scoped routes or a canary that should only take reads.
+const MethodsAnnotation = "ing-switch.io/methods"
+
+// httpMethods are the methods an HTTPRoute match accepts.
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH"}
+
+// MatchMethods returns the methods of ing's MethodsAnnotation, in the order
+// listed, or nil when it routes every method. With CORS enabled OPTIONS is
+// added: browsers send the preflight as OPTIONS, and a route that does not
+// match it never reaches the CORS filter. notes list the values an HTTPRoute
+// cannot match, which are left out.
+func MatchMethods(ing scanner.IngressInfo) (methods, notes []string) {
+	value, ok := ing.Annotations[MethodsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	for _, m := range scanner.SplitList(value) {
+		m = strings.ToUpper(m)
+		switch {
+		case !slices.Contains(httpMethods, m):
+			notes = append(notes, fmt.Sprintf("%s lists %s, which an HTTPRoute cannot match — use %s", MethodsAnnotation, m, strings.Join(httpMethods, ", ")))
+		case !slices.Contains(methods, m):
+			methods = append(methods, m)
+		}
+	}
+	if len(methods) > 0 && ing.NginxAnnotations["enable-cors"] == "true" && !slices.Contains(methods, "OPTIONS") {
+		methods = append(methods, "OPTIONS")
+	}
+	return methods, notes
+}
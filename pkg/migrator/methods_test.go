@@ -0,0 +1,43 @@
+package migrator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+func TestMatchMethods(t *testing.T) {
+	tests := []struct {
+		name        string
+		methods     string // MethodsAnnotation, unset when empty
+		cors        bool
+		wantMethods []string
+		wantNotes   int
+	}{
+		{name: "no annotation"},
+		{name: "no annotation with CORS", cors: true},
+		{name: "listed in order", methods: "get, POST,Put", wantMethods: []string{"GET", "POST", "PUT"}},
+		{name: "duplicates listed once", methods: "GET,get, GET", wantMethods: []string{"GET"}},
+		{name: "invalid methods", methods: "GET, FETCH, PURGE", wantMethods: []string{"GET"}, wantNotes: 2},
+		{name: "only invalid methods", methods: "FETCH", wantNotes: 1},
+		{name: "CORS adds OPTIONS", methods: "GET, POST", cors: true, wantMethods: []string{"GET", "POST", "OPTIONS"}},
+		{name: "CORS with OPTIONS listed", methods: "OPTIONS, GET", cors: true, wantMethods: []string{"OPTIONS", "GET"}},
+		{name: "CORS without valid methods", methods: "FETCH", cors: true, wantNotes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := scanner.IngressInfo{Annotations: map[string]string{}, NginxAnnotations: map[string]string{}}
+			if tt.methods != "" {
+				ing.Annotations[MethodsAnnotation] = tt.methods
+			}
+			if tt.cors {
+				ing.NginxAnnotations["enable-cors"] = "true"
+			}
+			methods, notes := MatchMethods(ing)
+			if !reflect.DeepEqual(methods, tt.wantMethods) || len(notes) != tt.wantNotes {
+				t.Errorf("MatchMethods() = %q, %q, want %q and %d notes", methods, notes, tt.wantMethods, tt.wantNotes)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package migrator
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Mirror is an nginx mirror-target: a copy of every request is sent to the
+// mirror and its response discarded. Targets only take a Service as the
+// mirror, so an in-cluster host is split into Service, Namespace and Port.
+type Mirror struct {
+	URL       string
+	Service   string // "" when the host is not a cluster Service name
+	Namespace string // "" when the host does not name one (the Ingress namespace)
+	Host      string
+	Port      int
+	Path      string // path in the target other than $request_uri, which targets cannot set
+	Body      bool   // mirror-request-body, on unless "off"
+}
+
+// ParseMirror reads mirror-target and mirror-request-body from the feature
+// annotations. Targets are URLs (https://shadow.prod.svc:8080$request_uri) or,
+// from the Istio scanner, bare hosts. A single-label host or one ending in
+// .svc / .svc.cluster.local is a cluster Service; anything else is external.
+// ok is false when mirror-target is unset or unparsable.
+func ParseMirror(annotations map[string]string) (Mirror, bool) {
+	target := annotations["mirror-target"]
+	if target == "" {
+		return Mirror{}, false
+	}
+	raw := strings.Replace(target, "$request_uri", "", 1)
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return Mirror{}, false
+	}
+
+	m := Mirror{
+		URL:  target,
+		Host: u.Hostname(),
+		Path: strings.TrimPrefix(u.Path, "/"),
+		Body: annotations["mirror-request-body"] != "off",
+	}
+	m.Port, _ = strconv.Atoi(u.Port())
+	if m.Port == 0 {
+		m.Port = 80
+		if u.Scheme == "https" {
+			m.Port = 443
+		}
+	}
+
+	labels := strings.Split(m.Host, ".")
+	switch {
+	case len(labels) == 1:
+		m.Service = labels[0]
+	case len(labels) >= 3 && labels[2] == "svc" &&
+		(len(labels) == 3 || strings.Join(labels[3:], ".") == "cluster.local"):
+		m.Service, m.Namespace = labels[0], labels[1]
+	}
+	return m, true
+}
+
+// Notes returns what the target cannot carry over from the nginx mirror:
+// an external host, or a path other than the original request URI.
+func (m Mirror) Notes() []string {
+	var notes []string
+	if m.Service == "" {
+		notes = append(notes, fmt.Sprintf("mirror-target %s is not a cluster Service: create a Service (e.g. type ExternalName) for %s and point the mirror at it", m.URL, m.Host))
+	}
+	if m.Path != "" {
+		notes = append(notes, fmt.Sprintf("mirror-target path /%s is dropped: mirrored requests keep the original path", m.Path))
+	}
+	return notes
+}
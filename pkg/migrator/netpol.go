@@ -0,0 +1,204 @@
+package migrator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// NetworkPolicyFiles returns a review guide plus NetworkPolicies admitting
+// controllerNamespace to each backend namespace whose existing policies would
+// block it (typically policies that only admit ingress-nginx). NetworkPolicies
+// are additive, so the generated ones widen access without editing the
+// originals. Nothing is returned when no policy needs review.
+//
+// The files use the non-applyable "networkpolicy" category: opening a
+// namespace to a new source is a security decision to make by hand.
+func NetworkPolicyFiles(policies []scanner.NetworkPolicyInfo, controller, controllerNamespace string) []generator.GeneratedFile {
+	var blocking []scanner.NetworkPolicyInfo
+	var namespaces []string
+	seen := make(map[string]bool)
+	for _, np := range policies {
+		if allowsNamespace(np, controllerNamespace) {
+			continue
+		}
+		blocking = append(blocking, np)
+		if !seen[np.Namespace] {
+			seen[np.Namespace] = true
+			namespaces = append(namespaces, np.Namespace)
+		}
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	var guide strings.Builder
+	fmt.Fprintf(&guide, "# NetworkPolicy review\n\n")
+	fmt.Fprintf(&guide, "%s runs in the `%s` namespace. The NetworkPolicies below restrict ingress traffic to\n", controller, controllerNamespace)
+	fmt.Fprintf(&guide, "namespaces with Ingress backends and do not admit `%s`, so requests routed by %s\n", controllerNamespace, controller)
+	fmt.Fprintf(&guide, "will time out once traffic moves off NGINX.\n\n")
+	fmt.Fprintf(&guide, "| Namespace | NetworkPolicy | Pod selector | Allowed namespaces | Backend services |\n")
+	fmt.Fprintf(&guide, "|-----------|---------------|--------------|--------------------|------------------|\n")
+	for _, np := range blocking {
+		selector := np.PodSelector
+		if selector == "" {
+			selector = "(all pods)"
+		}
+		allowed := strings.Join(np.AllowedNamespaces, ", ")
+		if allowed == "" {
+			allowed = "—"
+		}
+		fmt.Fprintf(&guide, "| %s | %s | `%s` | %s | %s |\n", np.Namespace, np.Name, selector, allowed, strings.Join(np.Services, ", "))
+	}
+	fmt.Fprintf(&guide, "\n`allow-%s.yaml` adds one NetworkPolicy per namespace admitting `%s`.\n", controllerNamespace, controllerNamespace)
+	fmt.Fprintf(&guide, "It selects all pods; narrow `podSelector` to the backend pods before applying:\n\n")
+	fmt.Fprintf(&guide, "```bash\nkubectl apply -f networkpolicy-review/allow-%s.yaml\n```\n", controllerNamespace)
+
+	var docs []string
+	for _, ns := range namespaces {
+		docs = append(docs, fmt.Sprintf(`apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: allow-from-%s
+  namespace: %s
+spec:
+  podSelector: {}  # narrow to the Ingress backend pods
+  policyTypes:
+  - Ingress
+  ingress:
+  - from:
+    - namespaceSelector:
+        matchLabels:
+          kubernetes.io/metadata.name: %s
+`, controllerNamespace, ns, controllerNamespace))
+	}
+
+	return []generator.GeneratedFile{
+		{
+			RelPath:     "networkpolicy-review/README.md",
+			Content:     guide.String(),
+			Description: fmt.Sprintf("%d NetworkPolicy(ies) that would block %s", len(blocking), controller),
+			Category:    "networkpolicy",
+		},
+		{
+			RelPath:     fmt.Sprintf("networkpolicy-review/allow-%s.yaml", controllerNamespace),
+			Content:     AddLabels(strings.Join(docs, "---\n"), ManagedLabels("", "")),
+			Description: fmt.Sprintf("NetworkPolicies admitting %s to backend namespaces (review first)", controllerNamespace),
+			Category:    "networkpolicy",
+		},
+	}
+}
+
+func allowsNamespace(np scanner.NetworkPolicyInfo, namespace string) bool {
+	for _, ns := range np.AllowedNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// BackendNetworkPolicies returns one file per backend namespace with a
+// NetworkPolicy per backend Service admitting controllerNamespace to the
+// Service's pods on the ports the ingresses route to. Unlike
+// NetworkPolicyFiles it does not depend on detected policies: it is opt-in
+// (--emit-networkpolicy) for clusters that deny ingress by default.
+// ExternalName backends have no pods and are skipped.
+func BackendNetworkPolicies(ingresses []scanner.IngressInfo, controllerNamespace string) []generator.GeneratedFile {
+	backends := make(map[string]map[string]scanner.ServiceRef) // namespace → name → service
+	for _, ing := range ingresses {
+		for _, svc := range ing.Services {
+			if svc.ExternalName != "" {
+				continue
+			}
+			if backends[svc.Namespace] == nil {
+				backends[svc.Namespace] = make(map[string]scanner.ServiceRef)
+			}
+			if prev, ok := backends[svc.Namespace][svc.Name]; ok {
+				svc.TargetPorts = mergePorts(prev.TargetPorts, svc.TargetPorts)
+				if svc.Port == 0 {
+					svc.Port = prev.Port
+				}
+			}
+			backends[svc.Namespace][svc.Name] = svc
+		}
+	}
+
+	var files []generator.GeneratedFile
+	for _, ns := range slices.Sorted(maps.Keys(backends)) {
+		var docs, warnings []string
+		for _, name := range slices.Sorted(maps.Keys(backends[ns])) {
+			doc, notes := backendNetworkPolicy(backends[ns][name], controllerNamespace)
+			docs = append(docs, doc)
+			warnings = append(warnings, notes...)
+		}
+		files = append(files, generator.GeneratedFile{
+			RelPath:     fmt.Sprintf("networkpolicy/%s-allow-%s.yaml", ns, controllerNamespace),
+			Content:     AddLabels(strings.Join(docs, "---\n"), ManagedLabels("", "")),
+			Description: fmt.Sprintf("NetworkPolicies admitting %s to %d backend(s) in %s", controllerNamespace, len(docs), ns),
+			Category:    "networkpolicy",
+			Warnings:    warnings,
+		})
+	}
+	return files
+}
+
+// backendNetworkPolicy admits controllerNamespace to one Service's pods.
+// Without a known selector (the Service was not found) it selects every pod
+// on the Service port, with a NOTE to narrow it, also returned in notes.
+func backendNetworkPolicy(svc scanner.ServiceRef, controllerNamespace string) (doc string, notes []string) {
+	var selector, ports string
+	if len(svc.Selector) > 0 {
+		selector = "\n    matchLabels:"
+		for _, k := range slices.Sorted(maps.Keys(svc.Selector)) {
+			selector += fmt.Sprintf("\n      %s: %q", k, svc.Selector[k])
+		}
+	} else {
+		notes = append(notes, fmt.Sprintf("Service %s/%s was not found: narrow podSelector to its pods; the port is the Service port, which may differ from the pod's", svc.Namespace, svc.Name))
+		selector = " {}"
+	}
+
+	targets := svc.TargetPorts
+	if len(targets) == 0 && svc.Port != 0 {
+		targets = []string{strconv.Itoa(int(svc.Port))}
+	}
+	if len(targets) > 0 {
+		ports = "\n    ports:"
+		for _, t := range targets {
+			if _, err := strconv.Atoi(t); err != nil {
+				t = strconv.Quote(t) // named container port
+			}
+			ports += fmt.Sprintf("\n    - protocol: TCP\n      port: %s", t)
+		}
+	}
+
+	return NoteComments(notes) + fmt.Sprintf(`apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: allow-%s-to-%s
+  namespace: %s
+spec:
+  podSelector:%s
+  policyTypes:
+  - Ingress
+  ingress:
+  - from:
+    - namespaceSelector:
+        matchLabels:
+          kubernetes.io/metadata.name: %s%s
+`, controllerNamespace, svc.Name, svc.Namespace, selector, controllerNamespace, ports), notes
+}
+
+func mergePorts(a, b []string) []string {
+	for _, p := range b {
+		if !slices.Contains(a, p) {
+			a = append(a, p)
+		}
+	}
+	return a
+}
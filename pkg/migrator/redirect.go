@@ -0,0 +1,116 @@
+package migrator
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Redirect is an nginx permanent-redirect or temporal-redirect: every request
+// to the Ingress is answered with a redirect to URL instead of being proxied.
+type Redirect struct {
+	URL       string
+	Permanent bool
+	Code      int // from *-redirect-code, else 301 (permanent) or 302 (temporal)
+
+	// URL split for targets that take the parts separately (Gateway API).
+	Scheme   string
+	Hostname string
+	Port     int
+	Path     string
+	Query    string
+
+	// KeepURI is set when the target ends in $request_uri or $uri: nginx
+	// appends the request path to it, and with $request_uri (KeepQuery)
+	// the query string as well. URL and its parts exclude the variable.
+	KeepURI   bool
+	KeepQuery bool
+}
+
+// redirectURIVariables are the nginx variables a redirect target may end in
+// to keep the request path; any other variable cannot be translated.
+var redirectURIVariables = []string{"$request_uri", "$uri"}
+
+// ParseRedirect reads temporal-redirect (which wins, as in ingress-nginx's
+// redirect annotation parser) or permanent-redirect from the feature
+// annotations. ok is false when neither is set or the value is not an
+// absolute URL or absolute path — some source scanners only record "true"
+// because the original target is not a URL — or when it uses an nginx
+// variable other than a trailing $request_uri or $uri (see RedirectNotes).
+func ParseRedirect(annotations map[string]string) (Redirect, bool) {
+	r := Redirect{Code: 302}
+	codeKey := "temporal-redirect-code"
+	target := annotations["temporal-redirect"]
+	if target == "" {
+		r = Redirect{Permanent: true, Code: 301}
+		codeKey = "permanent-redirect-code"
+		target = annotations["permanent-redirect"]
+	}
+	if target == "" {
+		return Redirect{}, false
+	}
+	for _, v := range redirectURIVariables {
+		if base, ok := strings.CutSuffix(target, v); ok {
+			target = base
+			r.KeepURI = true
+			r.KeepQuery = v == "$request_uri"
+			break
+		}
+	}
+	if strings.Contains(target, "$") {
+		return Redirect{}, false
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || (u.Host == "" && !strings.HasPrefix(u.Path, "/")) {
+		return Redirect{}, false
+	}
+	r.URL = target
+	r.Scheme = u.Scheme
+	r.Hostname = u.Hostname()
+	r.Path = u.Path
+	r.Query = u.RawQuery
+	if p := u.Port(); p != "" {
+		r.Port, _ = strconv.Atoi(p)
+	}
+	if c, err := strconv.Atoi(annotations[codeKey]); err == nil && c >= 300 && c < 400 {
+		r.Code = c
+	}
+	return r, true
+}
+
+// RedirectNotes explains a permanent-redirect or temporal-redirect target
+// that ParseRedirect rejects because it uses an nginx variable: no redirect
+// is generated for it and the Ingress proxies to its backends instead.
+func RedirectNotes(annotations map[string]string) []string {
+	key := "temporal-redirect"
+	if annotations[key] == "" {
+		key = "permanent-redirect"
+	}
+	target := annotations[key]
+	if _, ok := ParseRedirect(annotations); ok || !strings.Contains(target, "$") {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s %s uses nginx variables, which only a trailing $request_uri or $uri can be translated for: "+
+		"no redirect is generated and requests are proxied to the backends — write the redirect by hand", key, target)}
+}
+
+// RedirectPort returns the port an HTTP→HTTPS redirect must name, from the
+// use-port-in-redirects pseudo-annotation the scanner sets when ingress-nginx
+// redirects to a port other than 443, or 0 for the default port. notes
+// explain a port that could not be determined.
+func RedirectPort(annotations map[string]string) (port int, notes []string) {
+	v, ok := annotations["use-port-in-redirects"]
+	if !ok {
+		return 0, nil
+	}
+	if p, err := strconv.Atoi(v); err == nil && p > 0 && p <= 65535 {
+		if p == 443 {
+			return 0, nil
+		}
+		return p, nil
+	}
+	return 0, []string{"use-port-in-redirects is set in the ingress-nginx ConfigMap, but the controller's HTTPS port (--https-port) " +
+		"could not be read: the HTTPS redirect goes to the default port 443; set its port if clients reach HTTPS on another one"}
+}
@@ -0,0 +1,101 @@
+package migrator
+
+import "testing"
+
+func TestParseRedirect(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        Redirect
+		wantOK      bool
+	}{
+		{
+			name:        "permanent",
+			annotations: map[string]string{"permanent-redirect": "https://example.com/new?x=1"},
+			want:        Redirect{URL: "https://example.com/new?x=1", Permanent: true, Code: 301, Scheme: "https", Hostname: "example.com", Path: "/new", Query: "x=1"},
+			wantOK:      true,
+		},
+		{
+			name:        "permanent with permanent-redirect-code",
+			annotations: map[string]string{"permanent-redirect": "https://example.com:8443/", "permanent-redirect-code": "308"},
+			want:        Redirect{URL: "https://example.com:8443/", Permanent: true, Code: 308, Scheme: "https", Hostname: "example.com", Port: 8443, Path: "/"},
+			wantOK:      true,
+		},
+		{
+			name:        "permanent-redirect-code outside 3xx is ignored",
+			annotations: map[string]string{"permanent-redirect": "/new", "permanent-redirect-code": "200"},
+			want:        Redirect{URL: "/new", Permanent: true, Code: 301, Path: "/new"},
+			wantOK:      true,
+		},
+		{
+			name:        "temporal with temporal-redirect-code",
+			annotations: map[string]string{"temporal-redirect": "https://example.com/maintenance", "temporal-redirect-code": "307"},
+			want:        Redirect{URL: "https://example.com/maintenance", Code: 307, Scheme: "https", Hostname: "example.com", Path: "/maintenance"},
+			wantOK:      true,
+		},
+		{
+			name: "temporal wins over permanent",
+			annotations: map[string]string{
+				"permanent-redirect": "https://example.com/new", "permanent-redirect-code": "308",
+				"temporal-redirect": "https://example.com/maintenance",
+			},
+			want:   Redirect{URL: "https://example.com/maintenance", Code: 302, Scheme: "https", Hostname: "example.com", Path: "/maintenance"},
+			wantOK: true,
+		},
+		{
+			name:        "trailing $request_uri keeps the path and query",
+			annotations: map[string]string{"permanent-redirect": "https://new.example.com$request_uri"},
+			want:        Redirect{URL: "https://new.example.com", Permanent: true, Code: 301, Scheme: "https", Hostname: "new.example.com", KeepURI: true, KeepQuery: true},
+			wantOK:      true,
+		},
+		{
+			name:        "trailing $uri keeps the path only",
+			annotations: map[string]string{"temporal-redirect": "https://new.example.com/v2$uri"},
+			want:        Redirect{URL: "https://new.example.com/v2", Code: 302, Scheme: "https", Hostname: "new.example.com", Path: "/v2", KeepURI: true},
+			wantOK:      true,
+		},
+		{
+			name:        "other nginx variable",
+			annotations: map[string]string{"permanent-redirect": "https://$host/new"},
+		},
+		{
+			name:        "target that is not a URL",
+			annotations: map[string]string{"permanent-redirect": "true"},
+		},
+		{
+			name:        "no redirect",
+			annotations: map[string]string{"permanent-redirect-code": "308"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseRedirect(tt.annotations)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParseRedirect() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRedirectNotes(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantNote    bool
+	}{
+		{name: "plain URL", annotations: map[string]string{"permanent-redirect": "https://example.com/new"}},
+		{name: "trailing $request_uri", annotations: map[string]string{"permanent-redirect": "https://example.com$request_uri"}},
+		{name: "other variable", annotations: map[string]string{"permanent-redirect": "https://$host/new"}, wantNote: true},
+		{name: "variable in the winning temporal-redirect", annotations: map[string]string{
+			"permanent-redirect": "https://example.com/new", "temporal-redirect": "https://example.com/$arg_to",
+		}, wantNote: true},
+		{name: "not a URL", annotations: map[string]string{"permanent-redirect": "true"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedirectNotes(tt.annotations); (len(got) > 0) != tt.wantNote {
+				t.Errorf("RedirectNotes() = %q, want note: %v", got, tt.wantNote)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package migrator
+
+import (
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+var namespaceNameRe = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ValidateNamespace checks that ns is a valid namespace name (a DNS-1123
+// label), for --resource-namespace.
+func ValidateNamespace(ns string) bool {
+	return len(ns) <= 63 && namespaceNameRe.MatchString(ns)
+}
+
+// Relocated reports whether Relocate moves ing's routing resources out of
+// its namespace.
+func Relocated(ing scanner.IngressInfo, ns string) bool {
+	return ns != "" && ns != ing.Namespace
+}
+
+// Relocate returns ing as the generators must see it when its routing
+// resources (Middlewares, HTTPRoutes, policies) are created in namespace ns
+// rather than next to the Ingress: in ns, and named "<namespace>-<name>" so
+// same-named Ingresses from different namespaces do not collide there.
+// Everything it refers to stays where it is, so backends get an explicit
+// ServiceNamespace and the same-namespace Secrets and mirror Service of the
+// annotations are qualified with the source namespace. Labels and content
+// hashes must still come from the original ing. ing is not modified.
+func Relocate(ing scanner.IngressInfo, ns string) scanner.IngressInfo {
+	if !Relocated(ing, ns) {
+		return ing
+	}
+	src, name := ing.Namespace, ing.Name
+	ing.Namespace = ns
+	ing.Name = src + "-" + name
+
+	ing.Paths = append([]scanner.PathInfo(nil), ing.Paths...)
+	for i := range ing.Paths {
+		if ing.Paths[i].ServiceNamespace == "" {
+			ing.Paths[i].ServiceNamespace = src
+		}
+	}
+
+	annotations := maps.Clone(ing.NginxAnnotations)
+	if annotations["auth-type"] == "basic" {
+		// The default secret name derives from the Ingress name
+		secret := annotations["auth-secret"]
+		if secret == "" {
+			secret = name + "-basic-auth"
+		}
+		annotations["auth-secret"] = qualify(secret, src)
+	}
+	if secret := annotations["proxy-ssl-secret"]; secret != "" {
+		annotations["proxy-ssl-secret"] = qualify(secret, src)
+	}
+	if m, ok := ParseMirror(annotations); ok && m.Service != "" && m.Namespace == "" {
+		annotations["mirror-target"] = strings.Replace(m.URL, m.Host, m.Host+"."+src+".svc", 1)
+	}
+	ing.NginxAnnotations = annotations
+	return ing
+}
+
+// qualify returns a "name" annotation value as "namespace/name".
+func qualify(ref, namespace string) string {
+	if strings.Contains(ref, "/") {
+		return ref
+	}
+	return namespace + "/" + ref
+}
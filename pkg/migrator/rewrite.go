@@ -0,0 +1,149 @@
+package migrator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PrefixRewrite is an nginx regex rewrite expressed as a prefix swap: requests
+// under Prefix have that prefix replaced with Replacement. This is what
+// Gateway API ReplacePrefixMatch can express.
+type PrefixRewrite struct {
+	Prefix      string
+	Replacement string
+}
+
+// restIdioms are the path suffixes nginx users put after a literal prefix to
+// capture "the rest of the path", keyed by the suffix with the number of
+// groups it defines. The last group is always the remainder.
+var restIdioms = []struct {
+	suffix string
+	groups int
+}{
+	{"(/|$)(.*)", 2}, // /api(/|$)(.*)  +  /$2
+	{"/?(.*)", 1},    // /api/?(.*)     +  /$1
+	{"(/.*)", 1},     // /api(/.*)      +  $1
+	{"/(.*)", 1},     // /api/(.*)      +  /$1
+}
+
+var trailingCaptureRef = regexp.MustCompile(`\$([1-9])$`)
+
+// RequestURIIdiom reports whether target is nginx's "$request_uri" or "$uri"
+// used as a rewrite-target: the original URI is passed through unchanged,
+// so no rewrite is needed on the target controller.
+func RequestURIIdiom(target string) bool {
+	t := strings.TrimPrefix(target, "/")
+	return t == "$request_uri" || t == "$uri"
+}
+
+// CaptureGroups counts the capturing groups in an nginx path regex,
+// ignoring escaped parentheses and non-capturing (?...) groups.
+func CaptureGroups(path string) int {
+	n := 0
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			i++
+		case '(':
+			if i+1 < len(path) && path[i+1] == '?' {
+				continue
+			}
+			n++
+		}
+	}
+	return n
+}
+
+// MaxCaptureRef returns the highest $N referenced in target, or 0.
+func MaxCaptureRef(target string) int {
+	max := 0
+	for _, m := range captureRef.FindAllString(target, -1) {
+		if n, _ := strconv.Atoi(m[1:]); n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// BraceCaptureRefs rewrites nginx $N references as ${N}. Go's regexp
+// expansion (used by Traefik replacePathRegex) would otherwise read "$1abc"
+// as a group named "1abc".
+func BraceCaptureRefs(target string) string {
+	return captureRef.ReplaceAllStringFunc(target, func(ref string) string {
+		return "${" + ref[1:] + "}"
+	})
+}
+
+// TranslatePrefixRewrite recognizes the common "literal prefix + rest of
+// path" idioms and returns the equivalent prefix swap:
+//
+//	/api(/|$)(.*)  →  /$2        strip /api
+//	/api/(.*)      →  /v2/$1     /api → /v2
+//	/(.*)          →  /app/$1    prepend /app
+//
+// ok is false when path or target does not fit an idiom exactly; callers
+// should then fall back to a regex rewrite (Traefik) or a NOTE (Gateway API).
+func TranslatePrefixRewrite(path, target string) (PrefixRewrite, bool) {
+	m := trailingCaptureRef.FindStringSubmatch(target)
+	if m == nil {
+		return PrefixRewrite{}, false
+	}
+	replacement := strings.TrimSuffix(target, m[0])
+	if strings.Contains(replacement, "$") {
+		return PrefixRewrite{}, false
+	}
+	ref, _ := strconv.Atoi(m[1])
+
+	for _, idiom := range restIdioms {
+		if !strings.HasSuffix(path, idiom.suffix) || ref != idiom.groups {
+			continue
+		}
+		prefix := strings.TrimSuffix(path, idiom.suffix)
+		if strings.ContainsAny(prefix, `()[]{}|^$.*+?\`) {
+			return PrefixRewrite{}, false
+		}
+		// Every idiom except "(/.*)" swallows the slash after the prefix,
+		// so the replacement must end in one for the two to line up.
+		if idiom.suffix != "(/.*)" && !strings.HasSuffix(replacement, "/") {
+			return PrefixRewrite{}, false
+		}
+		return PrefixRewrite{Prefix: cleanPrefix(prefix), Replacement: cleanPrefix(replacement)}, true
+	}
+	return PrefixRewrite{}, false
+}
+
+// CombineRewriteRegex joins several regex paths that end in the same
+// rest-of-path idiom into one anchored regex, e.g. /users(/|$)(.*) and
+// /orders(/|$)(.*) → ^(?:/users|/orders)(/|$)(.*). The prefixes go in a
+// non-capturing group so $N still refers to the same capture in every path.
+// ok is false when the paths do not share an idiom.
+func CombineRewriteRegex(paths []string) (string, bool) {
+	if len(paths) == 1 {
+		return "^" + paths[0], true
+	}
+	for _, idiom := range restIdioms {
+		var prefixes []string
+		for _, p := range paths {
+			prefix := strings.TrimSuffix(p, idiom.suffix)
+			if prefix == p || CaptureGroups(prefix) > 0 {
+				break
+			}
+			prefixes = append(prefixes, prefix)
+		}
+		if len(prefixes) == len(paths) {
+			return "^(?:" + strings.Join(prefixes, "|") + ")" + idiom.suffix, true
+		}
+	}
+	return "", false
+}
+
+// cleanPrefix normalizes a path prefix for ReplacePrefixMatch: leading
+// slash, no trailing slash, "/" for the root.
+func cleanPrefix(p string) string {
+	p = strings.TrimSuffix(p, "/")
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return p
+}
@@ -0,0 +1,35 @@
+package migrator
+
+import "testing"
+
+func TestTranslatePrefixRewrite(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		target string
+		want   PrefixRewrite
+		wantOK bool
+	}{
+		{name: "strip prefix with /$1", path: "/api/(.*)", target: "/$1", want: PrefixRewrite{Prefix: "/api", Replacement: "/"}, wantOK: true},
+		{name: "optional slash with /$1", path: "/api/?(.*)", target: "/$1", want: PrefixRewrite{Prefix: "/api", Replacement: "/"}, wantOK: true},
+		{name: "strip prefix with /$2", path: "/api(/|$)(.*)", target: "/$2", want: PrefixRewrite{Prefix: "/api", Replacement: "/"}, wantOK: true},
+		{name: "keep prefix with /api/$1", path: "/api/(.*)", target: "/api/$1", want: PrefixRewrite{Prefix: "/api", Replacement: "/api"}, wantOK: true},
+		{name: "swap prefix", path: "/api/(.*)", target: "/v2/$1", want: PrefixRewrite{Prefix: "/api", Replacement: "/v2"}, wantOK: true},
+		{name: "prepend to root", path: "/(.*)", target: "/app/$1", want: PrefixRewrite{Prefix: "/", Replacement: "/app"}, wantOK: true},
+		{name: "slash kept in capture", path: "/api(/.*)", target: "$1", want: PrefixRewrite{Prefix: "/api", Replacement: "/"}, wantOK: true},
+		{name: "reference to a group that is not the rest", path: "/api(/|$)(.*)", target: "/$1"},
+		{name: "capture group in the prefix", path: "/(v1|v2)/(.*)", target: "/$1"},
+		{name: "several references", path: "/api/(.*)", target: "/$1$2"},
+		{name: "reference not at the end", path: "/api/(.*)", target: "/$1/index.html"},
+		{name: "replacement without trailing slash", path: "/api/(.*)", target: "/v2$1"},
+		{name: "no capture group", path: "/api", target: "/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := TranslatePrefixRewrite(tt.path, tt.target)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("TranslatePrefixRewrite(%q, %q) = %+v, %v, want %+v, %v", tt.path, tt.target, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
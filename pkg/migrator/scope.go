@@ -0,0 +1,40 @@
+package migrator
+
+import (
+	"regexp"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// RewritePathsAnnotation scopes rewrite-target to specific paths of an
+// Ingress. NGINX applies rewrite-target to every path, which is rarely what a
+// multi-service Ingress wants; listing paths here (comma-separated, exactly as
+// written in the Ingress spec) limits the generated URLRewrite to those rules.
+const RewritePathsAnnotation = "ing-switch.io/rewrite-paths"
+
+var captureRef = regexp.MustCompile(`\$[1-9]`)
+
+// RewriteApplies reports whether rewrite-target should be applied to path.
+// Without RewritePathsAnnotation every path is rewritten (NGINX semantics).
+func RewriteApplies(annotations map[string]string, path string) bool {
+	scope, ok := annotations[RewritePathsAnnotation]
+	if !ok {
+		return true
+	}
+	if path == "" {
+		path = "/"
+	}
+	for _, p := range scanner.SplitList(scope) {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// RewriteLacksCaptures reports whether target references capture groups
+// ($1, $2, ...) that path does not define — NGINX then substitutes empty
+// strings, which usually means the rewrite was meant for a different path.
+func RewriteLacksCaptures(target, path string) bool {
+	return MaxCaptureRef(target) > CaptureGroups(path)
+}
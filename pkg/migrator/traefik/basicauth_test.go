@@ -0,0 +1,77 @@
+package traefik
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+func TestGenerateBasicAuth(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+	}{
+		{
+			name:        "secret and realm",
+			annotations: map[string]string{"auth-type": "basic", "auth-secret": "admin-users", "auth-realm": "Admin area"},
+			want:        []string{"    secret: admin-basicauth\n", "    realm: \"Admin area\"\n", "ingress-nginx secret ops/admin-users"},
+		},
+		{
+			name:        "secret in another namespace",
+			annotations: map[string]string{"auth-type": "basic", "auth-secret": "security/ops-users"},
+			want:        []string{"    secret: admin-basicauth\n", "    realm: \"traefik\"\n", "ingress-nginx secret security/ops-users"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := generateBasicAuth("admin", "ops", tt.annotations)
+			if mw.Name != "admin-basicauth" || mw.Namespace != "ops" {
+				t.Errorf("middleware = %s/%s, want ops/admin-basicauth", mw.Namespace, mw.Name)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(mw.YAML, want) {
+					t.Errorf("middleware lacks %q:\n%s", want, mw.YAML)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateAuthSecretConvert(t *testing.T) {
+	ingresses := []scanner.IngressInfo{
+		{Name: "admin", Namespace: "ops", NginxAnnotations: map[string]string{"auth-type": "basic", "auth-secret": "admin-users", "auth-realm": "Admin"}},
+		{Name: "metrics", Namespace: "ops", NginxAnnotations: map[string]string{"auth-type": "basic", "auth-secret": "security/metrics-users", "auth-secret-type": "auth-map"}},
+		{Name: "public", Namespace: "ops", NginxAnnotations: map[string]string{}},
+	}
+
+	f, ok := generateAuthSecretConvert(ingresses, "")
+	if !ok {
+		t.Fatal("no auth-secret-convert.sh generated")
+	}
+	if f.RelPath != authSecretConvertPath {
+		t.Errorf("RelPath = %q, want %q", f.RelPath, authSecretConvertPath)
+	}
+	for _, want := range []string{
+		"convert_secret ops admin-users auth-file ops admin-basicauth   # Ingress ops/admin",
+		"convert_secret security metrics-users auth-map ops metrics-basicauth   # Ingress ops/metrics",
+	} {
+		if !strings.Contains(f.Content, want) {
+			t.Errorf("script lacks %q", want)
+		}
+	}
+	if strings.Contains(f.Content, "# Ingress ops/public") {
+		t.Error("script converts a secret for an ingress without basic auth")
+	}
+
+	// Relocated middlewares read the secret from the Ingress namespace
+	relocated, _ := generateAuthSecretConvert(ingresses[:1], "edge")
+	if want := "convert_secret ops admin-users auth-file edge ops-admin-basicauth"; !strings.Contains(relocated.Content, want) {
+		t.Errorf("relocated script lacks %q", want)
+	}
+
+	if _, ok := generateAuthSecretConvert(ingresses[2:], ""); ok {
+		t.Error("auth-secret-convert.sh generated without basic auth ingresses")
+	}
+}
@@ -2,8 +2,10 @@ package traefik
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
 )
 
@@ -12,117 +14,127 @@ type MiddlewareSpec struct {
 	Name      string
 	Namespace string
 	YAML      string
+	// Notes are the NOTE comments in YAML, as warnings of the file
+	Notes []string
 }
 
-// generateMiddlewares produces all necessary Traefik Middleware CRDs for an Ingress.
-func generateMiddlewares(ing scanner.IngressInfo) []MiddlewareSpec {
-	var middlewares []MiddlewareSpec
+// Chain stages, in the order Traefik runs an Ingress's middlewares: redirects
+// before anything else, client IP filtering before auth so blocked clients
+// never reach the auth server, auth before rate limiting so anonymous
+// requests do not use up the quota, then the response headers and finally
+// the path rewrite, which would otherwise change what the others see.
+const (
+	stageRedirect = iota
+	stageIPFilter
+	stageAuth
+	stageRateLimit
+	stageHeaders
+	stageRewrite
+)
+
+// generateMiddlewares produces all necessary Traefik Middleware CRDs for an
+// Ingress, sorted by chain stage: router.middlewares applies them in order.
+// fh is the ingress-nginx client-IP configuration, nil when unknown.
+func generateMiddlewares(ing scanner.IngressInfo, fh *scanner.ForwardedHeaders) []MiddlewareSpec {
+	type staged struct {
+		stage int
+		mw    MiddlewareSpec
+	}
+	var chain []staged
+	add := func(stage int, mw *MiddlewareSpec) {
+		if mw != nil {
+			chain = append(chain, staged{stage, *mw})
+		}
+	}
 	annotations := ing.NginxAnnotations
 
 	// SSL Redirect
 	if v, ok := annotations["ssl-redirect"]; ok && v == "true" {
-		mw := generateSSLRedirect(ing.Name, ing.Namespace, false)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageRedirect, generateSSLRedirect(ing.Name, ing.Namespace, false, annotations))
 	}
 	if v, ok := annotations["force-ssl-redirect"]; ok && v == "true" {
-		mw := generateSSLRedirect(ing.Name, ing.Namespace, true)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageRedirect, generateSSLRedirect(ing.Name, ing.Namespace, true, annotations))
+	}
+
+	// Permanent / temporal redirect to an external URL
+	if rd, ok := migrator.ParseRedirect(annotations); ok {
+		add(stageRedirect, generateRedirectMiddleware(ing.Name, ing.Namespace, rd))
 	}
 
 	// CORS
 	if v, ok := annotations["enable-cors"]; ok && v == "true" {
-		mw := generateCORSMiddleware(ing.Name, ing.Namespace, annotations)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageHeaders, generateCORSMiddleware(ing.Name, ing.Namespace, annotations))
 	}
 
 	// ForwardAuth
 	if authURL, ok := annotations["auth-url"]; ok && authURL != "" {
-		mw := generateForwardAuth(ing.Name, ing.Namespace, annotations)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageAuth, generateForwardAuth(ing.Name, ing.Namespace, annotations))
 	}
 
 	// BasicAuth
 	if authType, ok := annotations["auth-type"]; ok && authType == "basic" {
-		mw := generateBasicAuth(ing.Name, ing.Namespace, annotations)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageAuth, generateBasicAuth(ing.Name, ing.Namespace, annotations))
 	}
 
 	// RateLimit
 	if _, hasRPS := annotations["limit-rps"]; hasRPS {
-		mw := generateRateLimit(ing.Name, ing.Namespace, annotations)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageRateLimit, generateRateLimit(ing.Name, ing.Namespace, annotations))
 	} else if _, hasRPM := annotations["limit-rpm"]; hasRPM {
-		mw := generateRateLimit(ing.Name, ing.Namespace, annotations)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageRateLimit, generateRateLimit(ing.Name, ing.Namespace, annotations))
 	}
 
 	// InFlightReq (connection limit)
 	if _, hasConn := annotations["limit-connections"]; hasConn {
-		mw := generateInFlightReq(ing.Name, ing.Namespace, annotations)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageRateLimit, generateInFlightReq(ing.Name, ing.Namespace, annotations))
 	}
 
-	// IPAllowList
+	// IPAllowList. Traefik has no deny-list middleware, so
+	// denylist-source-range is left out (see migrator.TraefikDenyListNotes).
 	if cidr, ok := annotations["whitelist-source-range"]; ok && cidr != "" {
-		mw := generateIPAllowList(ing.Name, ing.Namespace, cidr)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
-	}
-
-	// IPDenyList
-	if cidr, ok := annotations["denylist-source-range"]; ok && cidr != "" {
-		mw := generateIPDenyList(ing.Name, ing.Namespace, cidr)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageIPFilter, generateIPAllowList(ing.Name, ing.Namespace, cidr, fh))
 	}
 
 	// ReplacePath / URL rewrite
 	if target, ok := annotations["rewrite-target"]; ok && target != "" {
-		mw := generateRewriteMiddleware(ing.Name, ing.Namespace, target, annotations)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageRewrite, generateRewriteMiddleware(ing, target))
 	}
 
 	// Custom headers
 	if _, hasCustom := annotations["custom-headers"]; hasCustom {
-		mw := generateHeadersMiddleware(ing.Name, ing.Namespace, annotations)
-		if mw != nil {
-			middlewares = append(middlewares, *mw)
-		}
+		add(stageHeaders, generateHeadersMiddleware(ing.Name, ing.Namespace, annotations))
 	}
 
+	// Header removal and proxy_set_header recognized in configuration-snippet
+	add(stageHeaders, generateHeaderRemoval(ing.Name, ing.Namespace, annotations))
+	add(stageHeaders, generateRequestHeaders(ing.Name, ing.Namespace, annotations))
+
+	// Stable, so middlewares of one stage keep the order above
+	slices.SortStableFunc(chain, func(a, b staged) int { return a.stage - b.stage })
+	middlewares := make([]MiddlewareSpec, 0, len(chain))
+	for _, c := range chain {
+		middlewares = append(middlewares, c.mw)
+	}
 	return middlewares
 }
 
-func generateSSLRedirect(ingName, ns string, permanent bool) *MiddlewareSpec {
+// generateSSLRedirect redirects to HTTPS, on the port ingress-nginx named in
+// its redirects (use-port-in-redirects) when that is not 443.
+func generateSSLRedirect(ingName, ns string, permanent bool, annotations map[string]string) *MiddlewareSpec {
 	name := ingName + "-ssl-redirect"
 	permanentStr := "false"
 	if permanent {
 		permanentStr = "true"
 		name = ingName + "-force-ssl-redirect"
 	}
+	port := ""
+	redirectPort, notes := migrator.RedirectPort(annotations)
+	if redirectPort != 0 {
+		port = fmt.Sprintf("    port: \"%d\"\n", redirectPort)
+	}
 	return &MiddlewareSpec{
 		Name:      name,
 		Namespace: ns,
+		Notes:     notes,
 		YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
 kind: Middleware
 metadata:
@@ -131,8 +143,48 @@ metadata:
 spec:
   redirectScheme:
     scheme: https
-    permanent: %s
-`, name, ns, permanentStr),
+%s    permanent: %s
+%s`, name, ns, port, permanentStr, migrator.NoteComments(notes)),
+	}
+}
+
+// generateRedirectMiddleware answers every request with a redirect to the
+// permanent-redirect/temporal-redirect URL, as nginx does, keeping the
+// request path when the target ended in $request_uri or $uri.
+func generateRedirectMiddleware(ingName, ns string, rd migrator.Redirect) *MiddlewareSpec {
+	name := ingName + "-temporal-redirect"
+	if rd.Permanent {
+		name = ingName + "-permanent-redirect"
+	}
+	var notes []string
+	if (rd.Permanent && rd.Code != 301) || (!rd.Permanent && rd.Code != 302) {
+		notes = append(notes, fmt.Sprintf("redirectRegex only emits 301/302 (308/307 for non-GET); custom code %d is not supported", rd.Code))
+	}
+	// Go's regexp expands $name in the replacement, so a literal $ is $$.
+	regex, replacement := "^.*$", strings.ReplaceAll(rd.URL, "$", "$$")
+	if rd.KeepURI {
+		// Append the request path (and query, for $request_uri) to the target.
+		regex = "^https?://[^/]+([^?]*)"
+		if rd.KeepQuery {
+			regex = "^https?://[^/]+(.*)"
+		}
+		replacement += "${1}"
+	}
+	return &MiddlewareSpec{
+		Name:      name,
+		Namespace: ns,
+		Notes:     notes,
+		YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  redirectRegex:
+    regex: %q
+    replacement: %q
+    permanent: %t
+%s`, name, ns, regex, replacement, rd.Permanent, migrator.NoteComments(notes)),
 	}
 }
 
@@ -145,9 +197,11 @@ func generateCORSMiddleware(ingName, ns string, annotations map[string]string) *
 	maxAge := getAnnotation(annotations, "cors-max-age", "1728000")
 	exposeHeaders := getAnnotation(annotations, "cors-expose-headers", "")
 
+	// Traefik expects one list item per origin/method/header, not a single
+	// comma-joined string.
 	exposeSection := ""
 	if exposeHeaders != "" {
-		exposeSection = fmt.Sprintf("\n    exposedHeaders:\n      - \"%s\"", exposeHeaders)
+		exposeSection = fmt.Sprintf("\n    accessControlExposeHeaders:\n%s", migrator.YAMLList(scanner.SplitList(exposeHeaders), "      "))
 	}
 
 	return &MiddlewareSpec{
@@ -161,14 +215,15 @@ metadata:
 spec:
   headers:
     accessControlAllowOriginList:
-      - "%s"
+%s
     accessControlAllowMethods:
-      - "%s"
+%s
     accessControlAllowHeaders:
-      - "%s"
+%s
     accessControlAllowCredentials: %s
     accessControlMaxAge: %s%s
-`, name, ns, origin, methods, headers, credentials, maxAge, exposeSection),
+`, name, ns, migrator.YAMLList(scanner.SplitList(origin), "      "), migrator.YAMLList(scanner.SplitList(methods), "      "),
+			migrator.YAMLList(scanner.SplitList(headers), "      "), credentials, maxAge, exposeSection),
 	}
 }
 
@@ -178,12 +233,11 @@ func generateForwardAuth(ingName, ns string, annotations map[string]string) *Mid
 
 	responseHeaders := ""
 	if rh, ok := annotations["auth-response-headers"]; ok && rh != "" {
-		headers := strings.Split(rh, ",")
-		var lines []string
-		for _, h := range headers {
-			lines = append(lines, fmt.Sprintf("      - \"%s\"", strings.TrimSpace(h)))
-		}
-		responseHeaders = fmt.Sprintf("\n    authResponseHeaders:\n%s", strings.Join(lines, "\n"))
+		responseHeaders = fmt.Sprintf("\n    authResponseHeaders:\n%s", migrator.YAMLList(scanner.SplitList(rh), "      "))
+	}
+	// Set from an auth-snippet; ForwardAuth sends every header without it
+	if rh := annotations["auth-request-headers"]; rh != "" {
+		responseHeaders += fmt.Sprintf("\n    authRequestHeaders:\n%s", migrator.YAMLList(scanner.SplitList(rh), "      "))
 	}
 
 	return &MiddlewareSpec{
@@ -202,14 +256,22 @@ spec:
 	}
 }
 
+// generateBasicAuth references a secret of its own, converted from the
+// ingress-nginx auth-secret by auth-secret-convert.sh: Traefik expects a
+// single htpasswd key in the middleware's namespace, while nginx secrets may
+// be auth-maps or live in another namespace. Each Ingress gets its own
+// middleware and secret, so paths split across Ingresses keep their own
+// realm and credentials.
 func generateBasicAuth(ingName, ns string, annotations map[string]string) *MiddlewareSpec {
 	name := ingName + "-basicauth"
-	secret := getAnnotation(annotations, "auth-secret", ingName+"-basic-auth")
 	realm := getAnnotation(annotations, "auth-realm", "traefik")
+	srcNs, srcName := basicAuthSource(ingName, ns, annotations)
 
 	return &MiddlewareSpec{
 		Name:      name,
 		Namespace: ns,
+		Notes: []string{fmt.Sprintf("Secret %s is converted from the ingress-nginx secret %s/%s by %s — run it before applying this middleware",
+			basicAuthSecret(ingName), srcNs, srcName, authSecretConvertPath)},
 		YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
 kind: Middleware
 metadata:
@@ -219,9 +281,9 @@ spec:
   basicAuth:
     secret: %s
     realm: "%s"
-# NOTE: Create the secret with htpasswd-encoded credentials:
-# kubectl create secret generic %s --from-literal=users="$(htpasswd -nb user password)" -n %s
-`, name, ns, secret, realm, secret, ns),
+# NOTE: Secret %s is converted from the ingress-nginx secret %s/%s by
+# %s — run it before applying this middleware.
+`, name, ns, basicAuthSecret(ingName), realm, basicAuthSecret(ingName), srcNs, srcName, authSecretConvertPath),
 	}
 }
 
@@ -277,69 +339,73 @@ spec:
 	}
 }
 
-func generateIPAllowList(ingName, ns, cidr string) *MiddlewareSpec {
+func generateIPAllowList(ingName, ns, cidr string, fh *scanner.ForwardedHeaders) *MiddlewareSpec {
 	name := ingName + "-ipallowlist"
-	cidrs := strings.Split(cidr, ",")
-	var cidrLines []string
-	for _, c := range cidrs {
-		c = strings.TrimSpace(c)
-		if c != "" {
-			cidrLines = append(cidrLines, fmt.Sprintf("      - \"%s\"", c))
-		}
+	ranges, invalid := migrator.ParseSourceRanges(cidr)
+	if len(ranges) == 0 {
+		return nil // see migrator.SourceRangeNotes
 	}
+	strategy, notes := migrator.TraefikIPStrategy(fh, "    ")
+	notes = append(migrator.ClientIPNotes(fh), notes...)
 
 	return &MiddlewareSpec{
 		Name:      name,
 		Namespace: ns,
-		YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+		Notes:     append(notes, migrator.InvalidRangeNotes(invalid)...),
+		YAML: migrator.NoteComments(notes) + fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
 kind: Middleware
 metadata:
   name: %s
   namespace: %s
 spec:
   ipAllowList:
-    sourceRange:
-%s
-`, name, ns, strings.Join(cidrLines, "\n")),
+    sourceRange:%s
+%s`, name, ns, migrator.SourceRangeYAML(ranges, invalid, "      "), strategy),
 	}
 }
 
-func generateIPDenyList(ingName, ns, cidr string) *MiddlewareSpec {
-	name := ingName + "-ipdenylist"
-	cidrs := strings.Split(cidr, ",")
-	var cidrLines []string
-	for _, c := range cidrs {
-		c = strings.TrimSpace(c)
-		if c != "" {
-			cidrLines = append(cidrLines, fmt.Sprintf("      - \"%s\"", c))
-		}
+// generateRewriteMiddleware translates rewrite-target. Capture-group targets
+// ($1, $2, ...) become a replacePathRegex anchored on the Ingress path regex
+// that defines the groups; literal targets become replacePath.
+func generateRewriteMiddleware(ing scanner.IngressInfo, target string) *MiddlewareSpec {
+	if migrator.RequestURIIdiom(target) {
+		return nil // original URI passes through unchanged — nothing to rewrite
 	}
+	name := ing.Name + "-rewrite"
+	ns := ing.Namespace
 
-	return &MiddlewareSpec{
-		Name:      name,
-		Namespace: ns,
-		YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+	_, useRegex := ing.NginxAnnotations["use-regex"]
+	if !useRegex && migrator.MaxCaptureRef(target) == 0 {
+		return &MiddlewareSpec{
+			Name:      name,
+			Namespace: ns,
+			YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
 kind: Middleware
 metadata:
   name: %s
   namespace: %s
 spec:
-  ipDenyList:
-    sourceRange:
-%s
-`, name, ns, strings.Join(cidrLines, "\n")),
+  replacePath:
+    path: "%s"
+`, name, ns, target),
+		}
 	}
-}
 
-func generateRewriteMiddleware(ingName, ns, target string, annotations map[string]string) *MiddlewareSpec {
-	name := ingName + "-rewrite"
+	var regexPaths []string
+	seen := make(map[string]bool)
+	for _, p := range ing.Paths {
+		if seen[p.Path] || migrator.CaptureGroups(p.Path) == 0 || !migrator.RewriteApplies(ing.Annotations, p.Path) {
+			continue
+		}
+		seen[p.Path] = true
+		regexPaths = append(regexPaths, p.Path)
+	}
 
-	// If regex is used, use ReplacePathRegex
-	if _, useRegex := annotations["use-regex"]; useRegex {
-		// Find a source pattern — for now use a general pattern
+	if len(regexPaths) == 0 {
 		return &MiddlewareSpec{
 			Name:      name,
 			Namespace: ns,
+			Notes:     []string{"No Ingress path defines capture groups — adjust regex to match your path pattern"},
 			YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
 kind: Middleware
 metadata:
@@ -349,23 +415,39 @@ spec:
   replacePathRegex:
     regex: "^/[^/]*(.*)"
     replacement: "%s"
-# NOTE: Adjust regex to match your path pattern from the Ingress spec
+# NOTE: No Ingress path defines capture groups — adjust regex to match your path pattern
 `, name, ns, target),
 		}
 	}
 
+	var notes []string
+	regex, ok := migrator.CombineRewriteRegex(regexPaths)
+	if !ok {
+		regex = "^" + regexPaths[0]
+		notes = append(notes, fmt.Sprintf("Only the first regex path is rewritten; %s need their own replacePathRegex middleware",
+			strings.Join(regexPaths[1:], ", ")))
+	}
+	for _, p := range regexPaths {
+		if migrator.RewriteLacksCaptures(target, p) {
+			notes = append(notes, fmt.Sprintf("rewrite-target references $%d but %s defines only %d capture group(s)",
+				migrator.MaxCaptureRef(target), p, migrator.CaptureGroups(p)))
+		}
+	}
+
 	return &MiddlewareSpec{
 		Name:      name,
 		Namespace: ns,
+		Notes:     notes,
 		YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
 kind: Middleware
 metadata:
   name: %s
   namespace: %s
 spec:
-  replacePath:
-    path: "%s"
-`, name, ns, target),
+  replacePathRegex:
+    regex: %q
+    replacement: %q
+%s`, name, ns, regex, migrator.BraceCaptureRefs(target), migrator.NoteComments(notes)),
 	}
 }
 
@@ -374,15 +456,18 @@ func generateHeadersMiddleware(ingName, ns string, annotations map[string]string
 	customHeaders := getAnnotation(annotations, "custom-headers", "")
 
 	note := ""
+	var notes []string
 	if customHeaders != "" {
 		note = fmt.Sprintf(`
 # NOTE: Original annotation referenced ConfigMap: %s
 # Inline the headers below from that ConfigMap`, customHeaders)
+		notes = append(notes, fmt.Sprintf("Original annotation referenced ConfigMap %s — inline its headers in the middleware", customHeaders))
 	}
 
 	return &MiddlewareSpec{
 		Name:      name,
 		Namespace: ns,
+		Notes:     notes,
 		YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
 kind: Middleware
 metadata:
@@ -396,6 +481,75 @@ spec:
 	}
 }
 
+// generateHeaderRemoval removes the headers listed in custom-headers-remove
+// (responses) and custom-request-headers-remove (requests): Traefik's Headers
+// middleware deletes a custom header whose value is empty.
+func generateHeaderRemoval(ingName, ns string, annotations map[string]string) *MiddlewareSpec {
+	response := scanner.SplitList(annotations["custom-headers-remove"])
+	request := scanner.SplitList(annotations["custom-request-headers-remove"])
+	if len(response) == 0 && len(request) == 0 {
+		return nil
+	}
+
+	var spec strings.Builder
+	for _, section := range []struct {
+		field   string
+		headers []string
+	}{{"customRequestHeaders", request}, {"customResponseHeaders", response}} {
+		if len(section.headers) == 0 {
+			continue
+		}
+		fmt.Fprintf(&spec, "    %s:\n", section.field)
+		for _, h := range section.headers {
+			fmt.Fprintf(&spec, "      %s: \"\"\n", h)
+		}
+	}
+
+	name := ingName + "-remove-headers"
+	return &MiddlewareSpec{
+		Name:      name,
+		Namespace: ns,
+		YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  headers:
+%s`, name, ns, spec.String()),
+	}
+}
+
+// generateRequestHeaders sets the literal request headers of
+// custom-request-headers with a Headers middleware. The NOTEs for headers
+// set from nginx variables go on the updated Ingress.
+func generateRequestHeaders(ingName, ns string, annotations map[string]string) *MiddlewareSpec {
+	headers, _ := migrator.SnippetRequestHeaders(annotations)
+	if len(headers) == 0 {
+		return nil
+	}
+
+	var spec strings.Builder
+	spec.WriteString("    customRequestHeaders:\n")
+	for _, h := range headers {
+		fmt.Fprintf(&spec, "      %s: %q\n", h.Name, h.Value)
+	}
+
+	name := ingName + "-request-headers"
+	return &MiddlewareSpec{
+		Name:      name,
+		Namespace: ns,
+		YAML: fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  headers:
+%s`, name, ns, spec.String()),
+	}
+}
+
 func getAnnotation(annotations map[string]string, key, defaultVal string) string {
 	if v, ok := annotations[key]; ok && v != "" {
 		return v
@@ -0,0 +1,149 @@
+package traefik
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// TestGenerateMiddlewaresChainOrder pins the router.middlewares order:
+// redirects, deny list before allow list, auth, rate limits, headers, and
+// the rewrite last.
+func TestGenerateMiddlewaresChainOrder(t *testing.T) {
+	ing := scanner.IngressInfo{
+		Name:      "web",
+		Namespace: "shop",
+		Paths:     []scanner.PathInfo{{Path: "/api/(.*)", PathType: "ImplementationSpecific", ServiceName: "web", ServicePort: 80}},
+		NginxAnnotations: map[string]string{
+			"rewrite-target":                "/$1",
+			"use-regex":                     "true",
+			"custom-request-headers":        "X-Team: shop",
+			"custom-request-headers-remove": "X-Debug",
+			"custom-headers":                "shop/headers",
+			"enable-cors":                   "true",
+			"limit-connections":             "10",
+			"limit-rps":                     "5",
+			"auth-type":                     "basic",
+			"auth-secret":                   "shop/basic-auth",
+			"auth-url":                      "http://auth.shop.svc.cluster.local/verify",
+			"whitelist-source-range":        "10.0.0.0/8",
+			"denylist-source-range":         "10.1.0.0/16",
+			"temporal-redirect":             "https://example.com/maintenance",
+			"force-ssl-redirect":            "true",
+		},
+	}
+
+	var got []string
+	for _, mw := range generateMiddlewares(ing, nil) {
+		got = append(got, mw.Name)
+	}
+	want := []string{
+		"web-force-ssl-redirect", "web-temporal-redirect",
+		"web-ipallowlist",
+		"web-auth", "web-basicauth",
+		"web-ratelimit", "web-inflightreq",
+		"web-cors", "web-headers", "web-remove-headers", "web-request-headers",
+		"web-rewrite",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("middleware chain\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestGenerateCORSMiddlewareListItems(t *testing.T) {
+	mw := generateCORSMiddleware("web", "shop", map[string]string{
+		"cors-allow-origin":   "https://a.example.com,https://b.example.com",
+		"cors-allow-methods":  "GET, PUT, POST",
+		"cors-allow-headers":  "Content-Type, Authorization",
+		"cors-expose-headers": "X-Total-Count,X-Page",
+	})
+
+	for _, want := range []string{
+		"    accessControlAllowOriginList:\n      - \"https://a.example.com\"\n      - \"https://b.example.com\"\n",
+		"    accessControlAllowMethods:\n      - \"GET\"\n      - \"PUT\"\n      - \"POST\"\n",
+		"    accessControlAllowHeaders:\n      - \"Content-Type\"\n      - \"Authorization\"\n",
+		"    accessControlExposeHeaders:\n      - \"X-Total-Count\"\n      - \"X-Page\"\n",
+	} {
+		if !strings.Contains(mw.YAML, want) {
+			t.Errorf("CORS middleware lacks\n%s\nin\n%s", want, mw.YAML)
+		}
+	}
+}
+
+func TestGenerateRedirectMiddleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{
+		{
+			name:        "fixed URL",
+			annotations: map[string]string{"permanent-redirect": "https://new.example.com/landing"},
+			want:        "    regex: \"^.*$\"\n    replacement: \"https://new.example.com/landing\"\n    permanent: true\n",
+		},
+		{
+			name:        "trailing $request_uri keeps path and query",
+			annotations: map[string]string{"permanent-redirect": "https://new.example.com$request_uri"},
+			want:        "    regex: \"^https?://[^/]+(.*)\"\n    replacement: \"https://new.example.com${1}\"\n    permanent: true\n",
+		},
+		{
+			name:        "trailing $uri keeps the path only",
+			annotations: map[string]string{"temporal-redirect": "https://new.example.com/v2$uri"},
+			want:        "    regex: \"^https?://[^/]+([^?]*)\"\n    replacement: \"https://new.example.com/v2${1}\"\n    permanent: false\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mw *MiddlewareSpec
+			for _, m := range generateMiddlewares(scanner.IngressInfo{Name: "web", Namespace: "shop", NginxAnnotations: tt.annotations}, nil) {
+				if strings.HasSuffix(m.Name, "-redirect") {
+					mw = &m
+				}
+			}
+			if mw == nil {
+				t.Fatal("no redirect middleware generated")
+			}
+			if !strings.Contains(mw.YAML, tt.want) {
+				t.Errorf("redirect middleware lacks\n%s\nin\n%s", tt.want, mw.YAML)
+			}
+		})
+	}
+}
+
+// TestGenerateRedirectMiddlewareVariables checks that a target using other
+// nginx variables gets no middleware, which would redirect to the literal
+// variable name.
+func TestGenerateRedirectMiddlewareVariables(t *testing.T) {
+	ing := scanner.IngressInfo{Name: "web", Namespace: "shop", NginxAnnotations: map[string]string{"permanent-redirect": "https://$host/new"}}
+	for _, mw := range generateMiddlewares(ing, nil) {
+		t.Errorf("generated %s for a redirect target with nginx variables", mw.Name)
+	}
+}
+
+// TestGenerateIPListsWithoutValidRanges checks that a source range with no
+// valid entry gets no middleware rather than an empty sourceRange, and that a
+// deny list gets none at all: Traefik has no deny-list middleware.
+func TestGenerateIPListsWithoutValidRanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+	}{
+		{name: "no valid allow entry", annotations: map[string]string{"whitelist-source-range": "example.com"}},
+		{name: "some valid allow entries", annotations: map[string]string{"whitelist-source-range": "10.0.0.0/33, 10.1.0.0/16"}, want: []string{"web-ipallowlist"}},
+		{name: "deny list", annotations: map[string]string{"denylist-source-range": "10.1.0.0/16"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, mw := range generateMiddlewares(scanner.IngressInfo{Name: "web", Namespace: "shop", NginxAnnotations: tt.annotations}, nil) {
+				got = append(got, mw.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("middlewares = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
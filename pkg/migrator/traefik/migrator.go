@@ -2,62 +2,156 @@ package traefik
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/saiyam1814/ing-switch/pkg/analyzer"
 	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
 )
 
 // Migrator generates Traefik migration files from an NGINX ingress setup.
-type Migrator struct{}
+type Migrator struct {
+	emitNetworkPolicy bool
+	entryPoints       migrator.EntryPoints
+	resourceNamespace string
+	extraAnnotations  map[string]string
+}
 
 // NewMigrator creates a new Traefik Migrator.
 func NewMigrator() *Migrator {
-	return &Migrator{}
+	return &Migrator{entryPoints: migrator.DefaultEntryPoints()}
+}
+
+// SetEntryPoints names the entrypoints serving HTTP and HTTPS, for an
+// existing Traefik whose entrypoints are not called web and websecure.
+func (m *Migrator) SetEntryPoints(eps migrator.EntryPoints) {
+	m.entryPoints = eps
+}
+
+// SetResourceNamespace creates the Middlewares and ServersTransports of every
+// Ingress in ns instead of the Ingress's namespace; "" keeps them there.
+// The Ingresses themselves, and the Secrets and Services they use, stay put.
+func (m *Migrator) SetResourceNamespace(ns string) {
+	m.resourceNamespace = ns
+}
+
+// SetExtraAnnotations adds annotations to every generated Ingress, over
+// those carried over or generated, e.g. a router priority.
+func (m *Migrator) SetExtraAnnotations(annotations map[string]string) {
+	m.extraAnnotations = annotations
+}
+
+// SetEmitNetworkPolicy adds a NetworkPolicy per backend namespace admitting
+// the traefik namespace to the backend pods, whether or not existing
+// policies were detected.
+func (m *Migrator) SetEmitNetworkPolicy(enabled bool) {
+	m.emitNetworkPolicy = enabled
 }
 
 // Migrate generates all files needed to migrate from NGINX to Traefik.
 func (m *Migrator) Migrate(scan *scanner.ScanResult, report *analyzer.AnalysisReport) ([]generator.GeneratedFile, error) {
 	var files []generator.GeneratedFile
 
-	// 1. Helm install
-	files = append(files, generateHelmInstall())
-	files = append(files, generateHelmValues())
+	// 1. Helm install, plus the default TLSStore for ingress-nginx
+	// --default-ssl-certificate, which needs Traefik's CRDs
+	certNs, certName, hasDefaultCert := scan.Controller.DefaultCertificate()
+	controllerNamespace := "traefik"
+	if tc, ok := scan.InstalledTarget(scanner.TargetTraefik); ok {
+		controllerNamespace = tc.Namespace
+		files = append(files, generateHelmUpgrade(tc, hasDefaultCert))
+		files = append(files, generateUpgradeValues(scan.StreamServices, scan.Controller.ForwardedHeaders, m.entryPoints))
+	} else {
+		files = append(files, generateHelmInstall(hasDefaultCert))
+		files = append(files, generateHelmValues(scan.StreamServices, scan.Controller.ForwardedHeaders, m.entryPoints))
+	}
+	if hasDefaultCert {
+		files = append(files, generateDefaultTLSStore(certNs, certName, scan.Controller.Namespace))
+	}
 
 	// 2. Middlewares — one file per ingress containing all its middlewares
 	middlewareNames := make(map[string][]string) // ingress key → middleware names
+	middlewareSpecs := make(map[string][]MiddlewareSpec)
 	for _, ing := range scan.Ingresses {
-		mws := generateMiddlewares(ing)
+		mws := generateMiddlewares(migrator.Relocate(ing, m.resourceNamespace), scan.Controller.ForwardedHeaders)
 		var mwYAMLs []string
 		var names []string
+		var notes []string
 		for _, mw := range mws {
 			mwYAMLs = append(mwYAMLs, mw.YAML)
-			names = append(names, fmt.Sprintf("%s-%s@kubernetescrd", ing.Namespace, mw.Name))
+			notes = append(notes, mw.Notes...)
+			names = append(names, fmt.Sprintf("%s-%s@kubernetescrd", mw.Namespace, mw.Name))
 		}
 		if len(mwYAMLs) > 0 {
 			key := ing.Namespace + "-" + ing.Name
 			middlewareNames[key] = names
+			middlewareSpecs[key] = mws
 			files = append(files, generator.GeneratedFile{
 				RelPath:     fmt.Sprintf("02-middlewares/%s-%s-middlewares.yaml", ing.Namespace, ing.Name),
-				Content:     strings.Join(mwYAMLs, "---\n"),
+				Content:     migrator.AddAnnotations(migrator.AddLabels(strings.Join(mwYAMLs, "---\n"), migrator.ManagedLabels(ing.Namespace, ing.Name)), migrator.HashAnnotations(ing)),
 				Description: fmt.Sprintf("Traefik Middlewares for %s/%s", ing.Namespace, ing.Name),
 				Category:    "middleware",
+				Warnings:    notes,
 			})
 		}
+		if needsStickyServicePatch(ing) {
+			files = append(files, generateStickyServicePatch(ing))
+		}
+		files = append(files, generateBackendTLS(ing, m.resourceNamespace)...)
+	}
+
+	if convert, ok := generateAuthSecretConvert(scan.Ingresses, m.resourceNamespace); ok {
+		files = append(files, convert)
 	}
 
 	// 3. Updated Ingress manifests (same format, updated annotations to attach middlewares)
+	canaryNotes := analyzer.CanaryWarnings(scan.ListenerIngresses())
 	for _, ing := range scan.Ingresses {
 		key := ing.Namespace + "-" + ing.Name
 		mwNames := middlewareNames[key]
-		ingressYAML := generateUpdatedIngress(ing, mwNames)
+		ingressYAML := migrator.AddLabels(generateUpdatedIngress(ing, mwNames, m.entryPoints, m.extraAnnotations), migrator.SourceLabels(ing.Namespace, ing.Name))
+		notes := append(append([]string{}, canaryNotes[ing.Namespace+"/"+ing.Name]...), analyzer.ExternalNameWarnings(ing, "traefik")...)
+		notes = append(notes, analyzer.BackendProtocolWarnings(ing, "traefik")...)
+		if _, ok := ing.Annotations[migrator.MethodsAnnotation]; ok {
+			notes = append(notes, migrator.MethodsAnnotation+" is not applied: Traefik matches Ingress rules by host and path only — route by method with an IngressRoute Method() matcher")
+		}
+		_, headerNotes := migrator.SnippetRequestHeaders(ing.NginxAnnotations)
+		notes = append(notes, headerNotes...)
+		notes = append(notes, migrator.RedirectNotes(ing.NginxAnnotations)...)
+		notes = append(notes, migrator.SourceRangeNotes(ing.NginxAnnotations)...)
+		notes = append(notes, migrator.TraefikDenyListNotes(ing.NginxAnnotations)...)
+		ingressYAML = migrator.NoteComments(notes) + ingressYAML
+		mirroring, hasMirroring := generateMirroring(ing, middlewareSpecs[key], m.entryPoints)
+		var mirrorNotes []string
+		if hasMirroring {
+			mirrorNotes = []string{"mirror-target is served by " + mirroring.RelPath + ", whose routes take precedence over this Ingress"}
+		} else if m, ok := migrator.ParseMirror(ing.NginxAnnotations); ok {
+			mirrorNotes = m.Notes()
+		}
+		ingressYAML = migrator.NoteComments(mirrorNotes) + ingressYAML
+		notes = append(mirrorNotes, notes...)
 		files = append(files, generator.GeneratedFile{
 			RelPath:     fmt.Sprintf("03-ingresses/%s-%s.yaml", ing.Namespace, ing.Name),
 			Content:     ingressYAML,
 			Description: fmt.Sprintf("Updated Ingress manifest for %s/%s with Traefik annotations", ing.Namespace, ing.Name),
 			Category:    "ingress",
+			Warnings:    notes,
 		})
+		if hasMirroring {
+			files = append(files, mirroring)
+		}
+	}
+
+	// TCP/UDP services from the ingress-nginx ConfigMaps
+	if len(scan.StreamServices) > 0 {
+		files = append(files, generateStreamRoutes(scan.StreamServices))
+	}
+
+	// Backend namespaces whose NetworkPolicies would block Traefik
+	files = append(files, migrator.NetworkPolicyFiles(scan.NetworkPolicies, "Traefik", controllerNamespace)...)
+	if m.emitNetworkPolicy {
+		files = append(files, migrator.BackendNetworkPolicies(scan.Ingresses, controllerNamespace)...)
 	}
 
 	// 4. Verify script
@@ -70,10 +164,53 @@ func (m *Migrator) Migrate(scan *scanner.ScanResult, report *analyzer.AnalysisRe
 	files = append(files, generatePreserveIngressClass())
 	files = append(files, generateCleanupScript())
 
+	// 7. Per-ingress fix guides
+	files = append(files, migrator.IngressGuides(report)...)
+
 	return files, nil
 }
 
-func generateHelmInstall() generator.GeneratedFile {
+// generateDefaultTLSStore serves the ingress-nginx default certificate for
+// hosts without a TLS secret of their own. Traefik only honours the TLSStore
+// named "default", and its secretName must be in the TLSStore's namespace.
+func generateDefaultTLSStore(namespace, name, controllerNamespace string) generator.GeneratedFile {
+	note := ""
+	var warnings []string
+	if namespace == controllerNamespace {
+		note = "# NOTE: this Secret lives in the ingress-nginx namespace — copy it elsewhere\n" +
+			"# (and move this TLSStore with it) before running cleanup.\n"
+		warnings = append(warnings, fmt.Sprintf("default certificate %s/%s lives in the ingress-nginx namespace — copy it elsewhere (and move this TLSStore with it) before running cleanup", namespace, name))
+	}
+	content := fmt.Sprintf(`# Default certificate (ingress-nginx --default-ssl-certificate=%s/%s).
+# Without it Traefik serves its self-signed certificate for unmatched hosts.
+%sapiVersion: traefik.io/v1alpha1
+kind: TLSStore
+metadata:
+  name: default
+  namespace: %s
+spec:
+  defaultCertificate:
+    secretName: %s
+`, namespace, name, note, namespace, name)
+	return generator.GeneratedFile{
+		RelPath:     "01-install-traefik/default-tlsstore.yaml",
+		Content:     migrator.AddLabels(content, migrator.ManagedLabels("", "")),
+		Description: fmt.Sprintf("Default TLSStore serving %s/%s", namespace, name),
+		Category:    "install",
+		Warnings:    warnings,
+	}
+}
+
+// generateHelmInstall installs Traefik and, with defaultCert, applies
+// default-tlsstore.yaml once the TLSStore CRD exists.
+func generateHelmInstall(defaultCert bool) generator.GeneratedFile {
+	tlsStore := ""
+	if defaultCert {
+		tlsStore = `
+echo "Applying the default TLSStore (ingress-nginx default certificate)..."
+kubectl apply -f default-tlsstore.yaml
+`
+	}
 	return generator.GeneratedFile{
 		RelPath: "01-install-traefik/helm-install.sh",
 		Content: `#!/bin/bash
@@ -94,7 +231,7 @@ helm upgrade --install traefik traefik/traefik \
 
 echo "Waiting for Traefik to be ready..."
 kubectl rollout status deployment/traefik -n traefik --timeout=120s
-
+` + tlsStore + `
 echo ""
 echo "Traefik installed successfully!"
 echo ""
@@ -109,10 +246,18 @@ echo "  bash ../04-verify.sh"
 	}
 }
 
-func generateHelmValues() generator.GeneratedFile {
+// generateHelmValues renders the Traefik chart values. fh carries the
+// ingress-nginx trusted-proxy settings over to the web and websecure
+// entrypoints (named by eps) so Traefik sees the same client IP.
+func generateHelmValues(streams []scanner.StreamService, fh *scanner.ForwardedHeaders, eps migrator.EntryPoints) generator.GeneratedFile {
+	trust := migrator.TraefikEntryPointTrust(fh, "    ")
+	disabled := ""
+	for _, name := range eps.UnusedDefaults() {
+		disabled += fmt.Sprintf("  # The chart's %s entrypoint would clash with the renamed ones\n  %s: null\n", name, name)
+	}
 	return generator.GeneratedFile{
 		RelPath: "01-install-traefik/values.yaml",
-		Content: `# Traefik Helm values for NGINX Ingress migration
+		Content: fmt.Sprintf(`# Traefik Helm values for NGINX Ingress migration
 # Requires Traefik v3.6.2+
 
 providers:
@@ -152,19 +297,19 @@ service:
 
 # Entrypoints
 ports:
-  web:
+%s  %s:
     port: 8000
     expose:
       default: true
     exposedPort: 80
-  websecure:
+%s  %s:
     port: 8443
     expose:
       default: true
     exposedPort: 443
     tls:
       enabled: true
-
+%s%s
 # Optional: Enable dashboard (access via kubectl port-forward)
 # api:
 #   dashboard: true
@@ -175,13 +320,13 @@ logs:
     level: INFO
   access:
     enabled: true
-`,
+`, disabled, eps.Web, trust, eps.WebSecure, trust, streamPorts(streams)),
 		Description: "Traefik Helm values file",
 		Category:    "install",
 	}
 }
 
-func generateUpdatedIngress(ing scanner.IngressInfo, middlewareNames []string) string {
+func generateUpdatedIngress(ing scanner.IngressInfo, middlewareNames []string, eps migrator.EntryPoints, extra map[string]string) string {
 	annotations := copyAnnotations(ing.Annotations)
 
 	// Remove nginx.ingress annotations that Traefik handles differently
@@ -205,6 +350,11 @@ func generateUpdatedIngress(ing scanner.IngressInfo, middlewareNames []string) s
 		"nginx.ingress.kubernetes.io/denylist-source-range",
 		"nginx.ingress.kubernetes.io/rewrite-target",
 		"nginx.ingress.kubernetes.io/use-regex",
+		"nginx.ingress.kubernetes.io/permanent-redirect",
+		"nginx.ingress.kubernetes.io/permanent-redirect-code",
+		"nginx.ingress.kubernetes.io/temporal-redirect",
+		"nginx.ingress.kubernetes.io/temporal-redirect-code",
+		"nginx.ingress.kubernetes.io/server-alias", // the aliases are rule hosts now
 	}
 	for _, k := range toRemove {
 		delete(annotations, k)
@@ -214,12 +364,20 @@ func generateUpdatedIngress(ing scanner.IngressInfo, middlewareNames []string) s
 	if len(middlewareNames) > 0 {
 		annotations["traefik.ingress.kubernetes.io/router.middlewares"] = strings.Join(middlewareNames, ",")
 	}
+	if eps.Custom() {
+		annotations["traefik.ingress.kubernetes.io/router.entrypoints"] = eps.Annotation()
+	}
+	// --set-annotation wins, e.g. to pin the entrypoints of one migration
+	for k, v := range extra {
+		annotations[k] = v
+	}
 
 	// Build annotations YAML
 	var annotationLines []string
 	for k, v := range annotations {
 		annotationLines = append(annotationLines, fmt.Sprintf("    %s: %q", k, v))
 	}
+	sort.Strings(annotationLines)
 
 	// Build TLS section
 	tlsSection := ""
@@ -263,14 +421,19 @@ spec:
 }
 
 func buildRulesSection(ing scanner.IngressInfo) string {
-	// Group paths by host
+	// Group paths by host, keeping the Ingress rule order
+	hostOrder := []string{}
 	hostPaths := make(map[string][]scanner.PathInfo)
 	for _, p := range ing.Paths {
+		if _, exists := hostPaths[p.Host]; !exists {
+			hostOrder = append(hostOrder, p.Host)
+		}
 		hostPaths[p.Host] = append(hostPaths[p.Host], p)
 	}
 
 	var rules []string
-	for host, paths := range hostPaths {
+	for _, host := range hostOrder {
+		paths := hostPaths[host]
 		hostLine := ""
 		if host != "" {
 			hostLine = fmt.Sprintf("  - host: %s\n", host)
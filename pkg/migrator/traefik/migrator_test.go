@@ -0,0 +1,61 @@
+package traefik
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+var (
+	docSeparator = regexp.MustCompile(`(?m)^---[ \t]*\n`)
+	topLevelKind = regexp.MustCompile(`(?m)^kind: `)
+)
+
+func TestMigrateLabelsGeneratedResources(t *testing.T) {
+	scan := &scanner.ScanResult{Ingresses: []scanner.IngressInfo{{
+		Name:      "web",
+		Namespace: "shop",
+		Hosts:     []string{"shop.example.com"},
+		Paths:     []scanner.PathInfo{{Host: "shop.example.com", Path: "/", PathType: "Prefix", ServiceName: "web", ServicePort: 80}},
+		NginxAnnotations: map[string]string{
+			"enable-cors": "true",
+			"limit-rps":   "10",
+		},
+	}}}
+	files, err := NewMigrator().Migrate(scan, analyzer.NewAnalyzer("traefik").Analyze(scan))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	const (
+		managed = `app.kubernetes.io/managed-by: "ing-switch"`
+		source  = `ing-switch.io/source-ingress: "shop.web"`
+	)
+	checked := map[string]bool{}
+	for _, f := range files {
+		if f.Category != "middleware" && f.Category != "ingress" {
+			continue
+		}
+		for _, doc := range docSeparator.Split(f.Content, -1) {
+			if !topLevelKind.MatchString(doc) {
+				continue
+			}
+			checked[f.Category] = true
+			if !strings.Contains(doc, source) {
+				t.Errorf("%s: document lacks %s:\n%s", f.RelPath, source, doc)
+			}
+			// The rewritten Ingress is the user's: cleanup must not delete it.
+			if isManaged := strings.Contains(doc, managed); isManaged != (f.Category == "middleware") {
+				t.Errorf("%s: managed-by label present = %v, want %v:\n%s", f.RelPath, isManaged, !isManaged, doc)
+			}
+		}
+	}
+	for _, category := range []string{"middleware", "ingress"} {
+		if !checked[category] {
+			t.Errorf("no %s generated", category)
+		}
+	}
+}
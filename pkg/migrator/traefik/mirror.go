@@ -0,0 +1,160 @@
+package traefik
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// mirrorRoutePriority lifts the mirroring IngressRoute's routes above the
+// routers Traefik builds from the updated Ingress for the same host and path,
+// whose priority is the length of their rule.
+const mirrorRoutePriority = 10000
+
+// generateMirroring carries an nginx mirror-target over as a TraefikService
+// of type mirroring per backend, served by an IngressRoute with the same
+// hosts, paths and middlewares as the Ingress: an Ingress backend can only
+// be a Service, so mirroring needs an IngressRoute. ok is false when the
+// mirror is not a cluster Service; the Ingress then only gets NOTEs.
+func generateMirroring(ing scanner.IngressInfo, middlewares []MiddlewareSpec, eps migrator.EntryPoints) (generator.GeneratedFile, bool) {
+	m, ok := migrator.ParseMirror(ing.NginxAnnotations)
+	if !ok || m.Service == "" {
+		return generator.GeneratedFile{}, false
+	}
+
+	var docs, routes []string
+	services := make(map[string]string) // backend name:port → TraefikService name
+	used := make(map[string]bool)
+	for _, p := range ing.Paths {
+		if p.ServiceName == "" {
+			continue
+		}
+		port := strconv.Itoa(int(p.ServicePort))
+		if p.ServicePort == 0 && p.ServicePortName != "" {
+			port = strconv.Quote(p.ServicePortName)
+		}
+		key := p.ServiceName + ":" + port
+		name, seen := services[key]
+		if !seen {
+			name = fmt.Sprintf("%s-mirror-%s", ing.Name, p.ServiceName)
+			if used[name] {
+				name += "-" + strings.ToLower(strings.Trim(port, `"`))
+			}
+			used[name] = true
+			services[key] = name
+			docs = append(docs, mirroringService(ing, p, port, name, m))
+		}
+
+		match := mirrorRouteMatch(ing, p)
+		route := fmt.Sprintf("  - match: %s\n    kind: Rule\n    priority: %d\n", match, mirrorRoutePriority+len(match))
+		if len(middlewares) > 0 {
+			route += "    middlewares:\n"
+			for _, mw := range middlewares {
+				route += fmt.Sprintf("    - name: %s\n", mw.Name)
+				if mw.Namespace != ing.Namespace {
+					route += fmt.Sprintf("      namespace: %s\n", mw.Namespace)
+				}
+			}
+		}
+		route += fmt.Sprintf("    services:\n    - name: %s\n      kind: TraefikService\n", name)
+		routes = append(routes, route)
+	}
+	if len(routes) == 0 {
+		return generator.GeneratedFile{}, false
+	}
+
+	tls := ""
+	if len(ing.TLSSecrets) > 0 {
+		tls = fmt.Sprintf("  tls:\n    secretName: %s\n", ing.TLSSecrets[0])
+	}
+	entryPoints := ""
+	if eps.Custom() {
+		entryPoints = fmt.Sprintf("  entryPoints:\n    - %s\n    - %s\n", eps.Web, eps.WebSecure)
+	}
+	docs = append(docs, fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+kind: IngressRoute
+metadata:
+  name: %s-mirror
+  namespace: %s
+spec:
+%s  routes:
+%s%s`, ing.Name, ing.Namespace, entryPoints, strings.Join(routes, ""), tls))
+
+	notes := m.Notes()
+	if len(ing.TLSSecrets) > 1 {
+		notes = append(notes, fmt.Sprintf("IngressRoute takes one TLS secret; %s is used, add a TLSStore or TLSOption for the others", ing.TLSSecrets[0]))
+	}
+	if m.Namespace != "" && m.Namespace != ing.Namespace {
+		notes = append(notes, "the mirror is in another namespace: enable providers.kubernetesCRD.allowCrossNamespace in Traefik")
+	}
+	if len(middlewares) > 0 && middlewares[0].Namespace != ing.Namespace {
+		notes = append(notes, fmt.Sprintf("the middlewares are in namespace %s: enable providers.kubernetesCRD.allowCrossNamespace in Traefik", middlewares[0].Namespace))
+	}
+	header := fmt.Sprintf("# Request mirroring for %s/%s to %s.\n", ing.Namespace, ing.Name, m.URL)
+	header += "# These routes take precedence over the updated Ingress for the same hosts and paths.\n"
+
+	return generator.GeneratedFile{
+		RelPath:     fmt.Sprintf("03-ingresses/%s-%s-mirroring.yaml", ing.Namespace, ing.Name),
+		Content:     header + migrator.NoteComments(notes) + migrator.AddLabels(strings.Join(docs, "---\n"), migrator.ManagedLabels(ing.Namespace, ing.Name)),
+		Description: fmt.Sprintf("Mirroring TraefikService + IngressRoute for %s/%s", ing.Namespace, ing.Name),
+		Category:    "ingress",
+		Warnings:    notes,
+	}, true
+}
+
+// mirroringService sends p's backend traffic on and a copy to the mirror.
+func mirroringService(ing scanner.IngressInfo, p scanner.PathInfo, port, name string, m migrator.Mirror) string {
+	backendNamespace := ""
+	if p.ServiceNamespace != "" && p.ServiceNamespace != ing.Namespace {
+		backendNamespace = fmt.Sprintf("    namespace: %s\n", p.ServiceNamespace)
+	}
+	body := ""
+	if !m.Body {
+		body = "    mirrorBody: false\n"
+	}
+	mirrorNamespace := ""
+	if m.Namespace != "" {
+		mirrorNamespace = fmt.Sprintf("      namespace: %s\n", m.Namespace)
+	}
+	return fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+kind: TraefikService
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  mirroring:
+    name: %s
+%s    port: %s
+%s    mirrors:
+    - name: %s
+%s      port: %d
+      percent: 100
+`, name, ing.Namespace, p.ServiceName, backendNamespace, port, body, m.Service, mirrorNamespace, m.Port)
+}
+
+// mirrorRouteMatch is the IngressRoute rule matching p the way the Ingress
+// does: Path for Exact, PathRegexp for regex paths, PathPrefix otherwise.
+func mirrorRouteMatch(ing scanner.IngressInfo, p scanner.PathInfo) string {
+	path := p.Path
+	if path == "" {
+		path = "/"
+	}
+	_, useRegex := ing.NginxAnnotations["use-regex"]
+	var rule string
+	switch {
+	case p.PathType == "Exact":
+		rule = fmt.Sprintf("Path(`%s`)", path)
+	case useRegex || strings.ContainsAny(path, "()[]{}|^$*+?\\"):
+		rule = fmt.Sprintf("PathRegexp(`^%s`)", path)
+	default:
+		rule = fmt.Sprintf("PathPrefix(`%s`)", path)
+	}
+	if p.Host != "" {
+		rule = fmt.Sprintf("Host(`%s`) && %s", p.Host, rule)
+	}
+	return rule
+}
@@ -0,0 +1,83 @@
+package traefik
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// serversTransportName is the ServersTransport carrying the backend TLS
+// settings of an Ingress.
+func serversTransportName(ingName string) string {
+	return ingName + "-backend-tls"
+}
+
+// generateBackendTLS translates proxy-ssl-secret, the client certificate
+// ingress-nginx presents to HTTPS backends, into a ServersTransport together
+// with proxy-ssl-verify (the secret's ca.crt becomes rootCAs) and
+// proxy-ssl-name. Traefik picks a ServersTransport per Service, so a second
+// file annotates the backend Services of ing to use it over HTTPS. The
+// ServersTransport is created in resourceNamespace, see migrator.Relocate.
+func generateBackendTLS(ing scanner.IngressInfo, resourceNamespace string) []generator.GeneratedFile {
+	st := migrator.Relocate(ing, resourceNamespace)
+	secret := st.NginxAnnotations["proxy-ssl-secret"]
+	if secret == "" {
+		return nil
+	}
+	var notes []string
+	if srcNs, name, ok := strings.Cut(secret, "/"); ok {
+		secret = name
+		if srcNs != st.Namespace {
+			notes = append(notes, fmt.Sprintf("proxy-ssl-secret %s/%s is in another namespace; Traefik reads certificatesSecrets and rootCAs from the ServersTransport's — copy it to %s", srcNs, name, st.Namespace))
+		}
+	}
+
+	name := serversTransportName(st.Name)
+	spec := ""
+	if v := ing.NginxAnnotations["proxy-ssl-name"]; v != "" {
+		spec += fmt.Sprintf("  serverName: %q\n", v)
+	}
+	// ingress-nginx does not verify backend certificates unless asked to
+	if ing.NginxAnnotations["proxy-ssl-verify"] == "on" {
+		spec += fmt.Sprintf("  insecureSkipVerify: false\n  rootCAs:\n    - secret: %s\n", secret)
+	} else {
+		spec += "  insecureSkipVerify: true\n"
+	}
+	spec += fmt.Sprintf("  certificatesSecrets:\n    - %s\n", secret)
+
+	yaml := migrator.NoteComments(notes) + fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+kind: ServersTransport
+metadata:
+  name: %s
+  namespace: %s
+spec:
+%s`, name, st.Namespace, spec)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#!/bin/bash\n# Backend mTLS for %s/%s. Traefik reads the ServersTransport and the HTTPS\n", ing.Namespace, ing.Name)
+	b.WriteString("# scheme from annotations on the backend Services, not the Ingress. Apply\n")
+	fmt.Fprintf(&b, "# %s-%s-serverstransport.yaml first, then run before cutover.\nset -e\n", ing.Namespace, ing.Name)
+	for _, svc := range ing.Services {
+		fmt.Fprintf(&b, "\nkubectl annotate service -n %s %s --overwrite \\\n  traefik.ingress.kubernetes.io/service.serversscheme=https \\\n  traefik.ingress.kubernetes.io/service.serverstransport=%s-%s@kubernetescrd\n",
+			svc.Namespace, svc.Name, st.Namespace, name)
+	}
+
+	return []generator.GeneratedFile{
+		{
+			RelPath:     fmt.Sprintf("02-middlewares/%s-%s-serverstransport.yaml", ing.Namespace, ing.Name),
+			Content:     migrator.AddAnnotations(migrator.AddLabels(yaml, migrator.ManagedLabels(ing.Namespace, ing.Name)), migrator.HashAnnotations(ing)),
+			Description: fmt.Sprintf("Backend mTLS ServersTransport for %s/%s", ing.Namespace, ing.Name),
+			Category:    "middleware",
+			Warnings:    notes,
+		},
+		{
+			RelPath:     fmt.Sprintf("02-middlewares/%s-%s-serverstransport-services.sh", ing.Namespace, ing.Name),
+			Content:     b.String(),
+			Description: fmt.Sprintf("ServersTransport Service annotations for %s/%s", ing.Namespace, ing.Name),
+			Category:    "patch",
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package traefik
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+const stickyAnnotationPrefix = "traefik.ingress.kubernetes.io/service.sticky.cookie"
+
+// needsStickyServicePatch reports whether cookie affinity must be configured
+// on the backend Services. Traefik's ingress-nginx provider reads the
+// community affinity annotations straight off the Ingress; affinity that came
+// from another source (F5 sticky-cookie-services, HAProxy, Kong) does not
+// survive on the updated Ingress, so it is carried by Service annotations.
+func needsStickyServicePatch(ing scanner.IngressInfo) bool {
+	if ing.NginxAnnotations["affinity"] != "cookie" {
+		return false
+	}
+	_, native := ing.Annotations["nginx.ingress.kubernetes.io/affinity"]
+	return !native
+}
+
+// stickyServiceAnnotations translates the session-cookie-* settings into
+// Traefik service.sticky.cookie.* annotations, sorted by key. Values Traefik
+// cannot take are returned as notes instead.
+func stickyServiceAnnotations(annotations map[string]string) (values []string, notes []string) {
+	set := map[string]string{
+		stickyAnnotationPrefix: "true",
+		// ingress-nginx always marks its affinity cookie HttpOnly; Traefik does not by default.
+		stickyAnnotationPrefix + ".httponly": "true",
+	}
+	if v := annotations["session-cookie-name"]; v != "" {
+		set[stickyAnnotationPrefix+".name"] = v
+	}
+	if v := annotations["session-cookie-secure"]; v != "" {
+		set[stickyAnnotationPrefix+".secure"] = strings.ToLower(v)
+	}
+	if v := annotations["session-cookie-samesite"]; v != "" {
+		// nginx accepts any case; Traefik only the lowercase names
+		switch s := strings.ToLower(strings.TrimSpace(v)); s {
+		case "none", "lax", "strict":
+			set[stickyAnnotationPrefix+".samesite"] = s
+		default:
+			notes = append(notes, fmt.Sprintf("session-cookie-samesite %q is not None, Lax or Strict; sameSite is left unset", v))
+		}
+	}
+	if v := annotations["session-cookie-max-age"]; v != "" {
+		set[stickyAnnotationPrefix+".maxage"] = v
+	}
+	if v := annotations["session-cookie-path"]; v != "" {
+		set[stickyAnnotationPrefix+".path"] = v
+	}
+
+	for k, v := range set {
+		values = append(values, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(values)
+	return values, notes
+}
+
+// generateStickyServicePatch writes a script annotating every backend
+// Service of ing with its cookie affinity settings.
+func generateStickyServicePatch(ing scanner.IngressInfo) generator.GeneratedFile {
+	values, notes := stickyServiceAnnotations(ing.NginxAnnotations)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#!/bin/bash\n# Cookie session affinity for %s/%s. Traefik reads it from annotations on\n", ing.Namespace, ing.Name)
+	b.WriteString("# the backend Services, not the Ingress. Run before cutover.\n")
+	for _, n := range notes {
+		fmt.Fprintf(&b, "# NOTE: %s\n", n)
+	}
+	b.WriteString("set -e\n")
+	for _, svc := range ing.Services {
+		fmt.Fprintf(&b, "\nkubectl annotate service -n %s %s --overwrite \\\n  %s\n", svc.Namespace, svc.Name, strings.Join(values, " \\\n  "))
+	}
+
+	return generator.GeneratedFile{
+		RelPath:     fmt.Sprintf("02-middlewares/%s-%s-sticky-services.sh", ing.Namespace, ing.Name),
+		Content:     b.String(),
+		Description: fmt.Sprintf("Sticky-cookie Service annotations for %s/%s", ing.Namespace, ing.Name),
+		Category:    "patch",
+		Warnings:    notes,
+	}
+}
@@ -0,0 +1,47 @@
+package traefik
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStickyServiceAnnotationsSameSite(t *testing.T) {
+	tests := []struct {
+		value     string
+		want      string // "" when sameSite is left unset
+		wantNotes int
+	}{
+		{value: "None", want: "none"},
+		{value: "LAX", want: "lax"},
+		{value: " strict ", want: "strict"},
+		{value: "Relaxed", wantNotes: 1},
+		{value: "none; Secure", wantNotes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			values, notes := stickyServiceAnnotations(map[string]string{"session-cookie-samesite": tt.value})
+			var got string
+			for _, v := range values {
+				if s, ok := cutKey(v, stickyAnnotationPrefix+".samesite"); ok {
+					got = s
+				}
+			}
+			if got != tt.want {
+				t.Errorf("samesite = %q, want %q", got, tt.want)
+			}
+			if len(notes) != tt.wantNotes {
+				t.Errorf("notes = %q, want %d", notes, tt.wantNotes)
+			}
+			if !slices.Contains(values, stickyAnnotationPrefix+"=true") {
+				t.Errorf("values %q lack %s=true", values, stickyAnnotationPrefix)
+			}
+		})
+	}
+}
+
+// cutKey returns the value of a key=value annotation when its key is key.
+func cutKey(annotation, key string) (string, bool) {
+	k, v, _ := strings.Cut(annotation, "=")
+	return v, k == key
+}
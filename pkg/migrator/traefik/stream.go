@@ -0,0 +1,111 @@
+package traefik
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// streamEntryPoint names the Traefik entrypoint for a TCP/UDP port, e.g. "tcp-9000".
+func streamEntryPoint(st scanner.StreamService) string {
+	return fmt.Sprintf("%s-%d", strings.ToLower(st.Protocol), st.Port)
+}
+
+// streamPorts renders the Helm values "ports" entries for each stream service.
+func streamPorts(streams []scanner.StreamService) string {
+	if len(streams) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("  # TCP/UDP ports migrated from the ingress-nginx tcp-services/udp-services ConfigMaps\n")
+	for _, st := range streams {
+		sb.WriteString(fmt.Sprintf(`  %s:
+    port: %d
+    expose:
+      default: true
+    exposedPort: %d
+    protocol: %s
+`, streamEntryPoint(st), st.Port, st.Port, st.Protocol))
+		if st.DecodeProxy {
+			sb.WriteString(`    proxyProtocol:
+      trustedIPs: []   # TODO: list the load balancer CIDRs that send PROXY protocol
+`)
+		}
+	}
+	return sb.String()
+}
+
+// generateStreamRoutes converts tcp-services/udp-services entries into
+// IngressRouteTCP / IngressRouteUDP resources, one per exposed port.
+func generateStreamRoutes(streams []scanner.StreamService) generator.GeneratedFile {
+	var docs, warnings []string
+	for _, st := range streams {
+		name := fmt.Sprintf("%s-%s", streamEntryPoint(st), st.Service)
+		port, note := streamServicePort(st)
+		if note != "" {
+			warnings = append(warnings, fmt.Sprintf("%s/%s port %s is a named port — make sure it matches a port name on the Service", st.Namespace, st.Service, port))
+		}
+		var doc string
+		if st.Protocol == "UDP" {
+			doc = fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+kind: IngressRouteUDP
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  entryPoints:
+  - %s
+  routes:
+  - services:
+    - name: %s
+      port: %s
+%s`, name, st.Namespace, streamEntryPoint(st), st.Service, port, note)
+		} else {
+			proxy := ""
+			if st.EncodeProxy {
+				proxy = "      proxyProtocol:\n        version: 1\n"
+			}
+			doc = fmt.Sprintf(`apiVersion: traefik.io/v1alpha1
+kind: IngressRouteTCP
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  entryPoints:
+  - %s
+  routes:
+  - match: HostSNI(`+"`*`"+`)
+    services:
+    - name: %s
+      port: %s
+%s%s`, name, st.Namespace, streamEntryPoint(st), st.Service, port, proxy, note)
+		}
+		docs = append(docs, migrator.AddLabels(doc, migrator.ManagedLabels("", "")))
+	}
+
+	header := `# TCP/UDP routes migrated from the ingress-nginx tcp-services/udp-services ConfigMaps.
+# Each route needs a matching entrypoint — see the TCP/UDP ports in 01-install-traefik/values.yaml.
+`
+	return generator.GeneratedFile{
+		RelPath:     "03-ingresses/tcp-udp-routes.yaml",
+		Content:     header + strings.Join(docs, "---\n"),
+		Description: fmt.Sprintf("IngressRouteTCP/IngressRouteUDP for %d TCP/UDP service(s)", len(streams)),
+		Category:    "ingress",
+		Warnings:    warnings,
+	}
+}
+
+// streamServicePort returns the YAML port value; named ports are quoted and
+// flagged because Traefik expects the Service port, not a container port name.
+func streamServicePort(st scanner.StreamService) (port, note string) {
+	for _, c := range st.ServicePort {
+		if c < '0' || c > '9' {
+			return fmt.Sprintf("%q", st.ServicePort),
+				"      # NOTE: named port — make sure it matches a port name on the Service\n"
+		}
+	}
+	return st.ServicePort, ""
+}
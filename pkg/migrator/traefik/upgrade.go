@@ -0,0 +1,62 @@
+package traefik
+
+import (
+	"fmt"
+
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// generateHelmUpgrade replaces helm-install.sh when the scan found Traefik
+// already running: a second install would fight the existing one over the
+// IngressClass and the load balancer ports, so the running release is
+// upgraded with values.yaml merged over its own values instead.
+func generateHelmUpgrade(tc scanner.TargetController, defaultCert bool) generator.GeneratedFile {
+	return generator.GeneratedFile{
+		RelPath:     "01-install-traefik/helm-install.sh",
+		Content:     migrator.TraefikHelmUpgradeScript(tc, "Enabling the Kubernetes Ingress NGINX provider", defaultCert),
+		Description: fmt.Sprintf("Helm upgrade script for the Traefik already running in %s", tc.Namespace),
+		Category:    "install",
+	}
+}
+
+// generateUpgradeValues is values.yaml for an upgrade of a running Traefik:
+// only the providers and entrypoint settings the migration needs, so the
+// replicas, Service and logging of the existing install are left alone.
+func generateUpgradeValues(streams []scanner.StreamService, fh *scanner.ForwardedHeaders, eps migrator.EntryPoints) generator.GeneratedFile {
+	return generator.GeneratedFile{
+		RelPath: "01-install-traefik/values.yaml",
+		Content: `# Traefik Helm values merged into the running Traefik release
+# (helm upgrade --reuse-values): only what the NGINX migration needs.
+# Requires Traefik v3.6.2+
+
+providers:
+  # Enable the Kubernetes Ingress NGINX compatibility provider
+  # This makes Traefik watch Ingress resources with ingressClassName: nginx
+  # and automatically translates common nginx.ingress.kubernetes.io annotations
+  kubernetesIngressNginx:
+    enabled: true
+
+  kubernetesIngress:
+    enabled: true
+` + upgradePorts(streams, fh, eps),
+		Description: "Traefik Helm values merged into the existing release",
+		Category:    "install",
+	}
+}
+
+// upgradePorts returns the "ports" values for an existing Traefik: client IP
+// trust on its HTTP and HTTPS entrypoints and the TCP/UDP entrypoints, or ""
+// when neither is needed.
+func upgradePorts(streams []scanner.StreamService, fh *scanner.ForwardedHeaders, eps migrator.EntryPoints) string {
+	ports := ""
+	if trust := migrator.TraefikEntryPointTrust(fh, "    "); trust != "" {
+		ports = "  " + eps.Web + ":\n" + trust + "  " + eps.WebSecure + ":\n" + trust
+	}
+	ports += streamPorts(streams)
+	if ports == "" {
+		return ""
+	}
+	return "\n# Entrypoints\nports:\n" + ports
+}
@@ -0,0 +1,33 @@
+package migrator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NginxSizeToQuantity converts an nginx size ("8k", "16K", "1m", "4096") to
+// a Kubernetes quantity ("8Ki", "16Ki", "1Mi", "4096"). nginx sizes are
+// binary, so k/m/g map to Ki/Mi/Gi. ok is false for values nginx would reject.
+func NginxSizeToQuantity(value string) (quantity string, ok bool) {
+	v := strings.TrimSpace(value)
+	if v == "" {
+		return "", false
+	}
+	suffix := ""
+	switch v[len(v)-1] {
+	case 'k', 'K':
+		suffix = "Ki"
+	case 'm', 'M':
+		suffix = "Mi"
+	case 'g', 'G':
+		suffix = "Gi"
+	}
+	if suffix != "" {
+		v = v[:len(v)-1]
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil || n == 0 {
+		return "", false
+	}
+	return strconv.FormatUint(n, 10) + suffix, true
+}
@@ -0,0 +1,72 @@
+package migrator
+
+import (
+	"fmt"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// TraefikHelmUpgradeScript upgrades the Helm release of the running Traefik
+// tc with --reuse-values, so only the settings in values.yaml change; action
+// says what the upgrade is for. The release name can be overridden with
+// TRAEFIK_RELEASE. Without a release the script stops, as a Traefik not
+// installed with Helm must be configured however it is managed.
+func TraefikHelmUpgradeScript(tc scanner.TargetController, action string, defaultCert bool) string {
+	release := tc.Release
+	if release == "" {
+		release = "traefik"
+	}
+	deployment := tc.Deployment
+	if deployment == "" {
+		deployment = "traefik"
+	}
+	k3s := ""
+	if tc.Namespace == "kube-system" {
+		k3s = fmt.Sprintf(`#
+# k3s and RKE2 install Traefik into kube-system through their helm-controller,
+# which reverts upgrades made with helm. There, put values.yaml into the
+# valuesContent of a HelmChartConfig named %s in kube-system instead.
+`, release)
+	}
+	tlsStore := ""
+	if defaultCert {
+		tlsStore = `
+echo "Applying the default TLSStore (ingress-nginx default certificate)..."
+kubectl apply -f default-tlsstore.yaml
+`
+	}
+	return fmt.Sprintf(`#!/bin/bash
+# Traefik %[1]s was already running in namespace %[2]s when these files were
+# generated, so it is upgraded in place instead of installed next to itself.
+# --reuse-values keeps its configuration; values.yaml is merged on top.
+%[3]sset -e
+
+RELEASE="${TRAEFIK_RELEASE:-%[4]s}"
+if ! helm status "$RELEASE" -n %[2]s >/dev/null 2>&1; then
+  echo "No Helm release $RELEASE in namespace %[2]s."
+  echo "Set TRAEFIK_RELEASE to the release that installed Traefik, or merge"
+  echo "values.yaml into the configuration of the running Traefik by hand."
+  exit 1
+fi
+
+echo "Adding Traefik Helm repository..."
+helm repo add traefik https://traefik.github.io/charts
+helm repo update
+
+echo "%[5]s on Traefik release $RELEASE..."
+helm upgrade "$RELEASE" traefik/traefik \
+  --namespace %[2]s \
+  --reuse-values \
+  --values values.yaml \
+  --version ">=3.6.2"
+
+echo "Waiting for Traefik to be ready..."
+kubectl rollout status deployment/%[6]s -n %[2]s --timeout=120s
+%[7]s
+echo ""
+echo "Traefik upgraded successfully!"
+echo ""
+echo "Get Traefik LoadBalancer IP:"
+kubectl get svc -n %[2]s -l app.kubernetes.io/name=traefik
+`, tc.Version, tc.Namespace, k3s, release, action, deployment, tlsStore)
+}
@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ingressAddresses returns the load balancer IPs and hostnames the serving
+// controller published in an Ingress's status.
+func ingressAddresses(status networkingv1.IngressStatus) []string {
+	var addrs []string
+	for _, lb := range status.LoadBalancer.Ingress {
+		switch {
+		case lb.IP != "":
+			addrs = append(addrs, lb.IP)
+		case lb.Hostname != "":
+			addrs = append(addrs, lb.Hostname)
+		}
+	}
+	return addrs
+}
+
+// scanControllerAddresses maps the load balancer address of every
+// LoadBalancer Service in the namespace of the detected controller, or of a
+// target controller already running, to that controller's type. Those are
+// the addresses the controllers publish on the Ingresses they serve.
+func (s *Scanner) scanControllerAddresses(controller ControllerInfo, targets []TargetController) map[string]string {
+	namespaces := map[string]string{} // namespace → controller type; the detected one first
+	if controller.Detected && controller.Namespace != "" {
+		namespaces[controller.Namespace] = controller.Type
+	}
+	for _, tc := range targets {
+		if _, ok := namespaces[tc.Namespace]; !ok {
+			namespaces[tc.Namespace] = tc.Type
+		}
+	}
+
+	owners := map[string]string{}
+	for ns, controllerType := range namespaces {
+		list, err := s.client.CoreV1().Services(ns).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			continue
+		}
+		for _, svc := range list.Items {
+			if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+				continue
+			}
+			for _, lb := range svc.Status.LoadBalancer.Ingress {
+				for _, addr := range []string{lb.IP, lb.Hostname} {
+					if addr != "" {
+						owners[addr] = controllerType
+					}
+				}
+			}
+		}
+	}
+	return owners
+}
+
+// markAddressControllers sets AddressController on each ingress whose
+// status address belongs to one of the controllers in owners.
+func markAddressControllers(ingresses []IngressInfo, owners map[string]string) {
+	for i := range ingresses {
+		for _, addr := range ingresses[i].Addresses {
+			if owner, ok := owners[addr]; ok {
+				ingresses[i].AddressController = owner
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package scanner
+
+import "strings"
+
+// ServerAliases returns the hostnames of a server-alias annotation value
+// that can be routed like a rule host, and the regular expressions (~...)
+// that cannot: Ingress, Traefik and Gateway API hosts are names or
+// wildcards only.
+func ServerAliases(value string) (hosts, regexes []string) {
+	for _, alias := range SplitList(value) {
+		if strings.HasPrefix(alias, "~") {
+			regexes = append(regexes, alias)
+		} else {
+			hosts = append(hosts, alias)
+		}
+	}
+	return hosts, regexes
+}
+
+// applyServerAlias adds the server-alias hostnames to the ingress as rule
+// hosts with a copy of the first rule host's paths: ingress-nginx serves an
+// alias from that host's server block. Aliases that already are a rule host
+// are skipped, as ingress-nginx ignores them, and so is an ingress without a
+// rule host, which has no server to alias.
+func applyServerAlias(info *IngressInfo) {
+	value, ok := info.NginxAnnotations["server-alias"]
+	if !ok || len(info.Paths) == 0 || info.Paths[0].Host == "" {
+		return
+	}
+	primary := info.Paths[0].Host
+	hostSet := make(map[string]bool, len(info.Hosts))
+	for _, h := range info.Hosts {
+		hostSet[h] = true
+	}
+
+	aliases, _ := ServerAliases(value)
+	paths := info.Paths
+	for _, alias := range aliases {
+		if hostSet[alias] {
+			continue
+		}
+		hostSet[alias] = true
+		info.Hosts = append(info.Hosts, alias)
+		for _, p := range paths {
+			if p.Host == primary {
+				p.Host = alias
+				info.Paths = append(info.Paths, p)
+			}
+		}
+	}
+}
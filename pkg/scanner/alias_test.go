@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestServerAliases(t *testing.T) {
+	tests := []struct {
+		value       string
+		wantHosts   []string
+		wantRegexes []string
+	}{
+		{value: "www.example.com", wantHosts: []string{"www.example.com"}},
+		{value: " a.example.com,, *.b.example.com , ", wantHosts: []string{"a.example.com", "*.b.example.com"}},
+		{value: `~^www\d+\.example\.com$`, wantRegexes: []string{`~^www\d+\.example\.com$`}},
+		{
+			value:       `a.example.com, ~^(?<app>.+)\.example\.com$, b.example.com`,
+			wantHosts:   []string{"a.example.com", "b.example.com"},
+			wantRegexes: []string{`~^(?<app>.+)\.example\.com$`},
+		},
+		{value: " , "},
+	}
+	for _, tt := range tests {
+		hosts, regexes := ServerAliases(tt.value)
+		if !reflect.DeepEqual(hosts, tt.wantHosts) || !reflect.DeepEqual(regexes, tt.wantRegexes) {
+			t.Errorf("ServerAliases(%q) = %q, %q, want %q, %q", tt.value, hosts, regexes, tt.wantHosts, tt.wantRegexes)
+		}
+	}
+}
+
+// TestApplyServerAlias checks that aliases become rule hosts with the first
+// rule host's paths, skipping regexes and aliases that already are a host.
+func TestApplyServerAlias(t *testing.T) {
+	paths := []PathInfo{
+		{Host: "shop.example.com", Path: "/", ServiceName: "web", ServicePort: 80},
+		{Host: "shop.example.com", Path: "/api", ServiceName: "api", ServicePort: 8080},
+		{Host: "admin.example.com", Path: "/", ServiceName: "admin", ServicePort: 80},
+	}
+	info := IngressInfo{
+		Hosts:            []string{"shop.example.com", "admin.example.com"},
+		Paths:            append([]PathInfo{}, paths...),
+		NginxAnnotations: map[string]string{"server-alias": "www.example.com, admin.example.com, ~^shop\\d+\\.example\\.com$"},
+	}
+	applyServerAlias(&info)
+
+	if want := []string{"shop.example.com", "admin.example.com", "www.example.com"}; !reflect.DeepEqual(info.Hosts, want) {
+		t.Errorf("Hosts = %q, want %q", info.Hosts, want)
+	}
+	want := append(append([]PathInfo{}, paths...),
+		PathInfo{Host: "www.example.com", Path: "/", ServiceName: "web", ServicePort: 80},
+		PathInfo{Host: "www.example.com", Path: "/api", ServiceName: "api", ServicePort: 8080},
+	)
+	if !reflect.DeepEqual(info.Paths, want) {
+		t.Errorf("Paths = %+v, want %+v", info.Paths, want)
+	}
+
+	// Without a rule host there is no server to alias
+	hostless := IngressInfo{
+		Paths:            []PathInfo{{Path: "/", ServiceName: "web", ServicePort: 80}},
+		NginxAnnotations: map[string]string{"server-alias": "www.example.com"},
+	}
+	applyServerAlias(&hostless)
+	if len(hostless.Hosts) != 0 || len(hostless.Paths) != 1 {
+		t.Errorf("hostless ingress got aliases: %+v", hostless)
+	}
+}
@@ -0,0 +1,44 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MigrationStatusAnnotation records the analysis outcome on a scanned Ingress
+// so readiness shows up in `kubectl describe ingress`.
+const MigrationStatusAnnotation = "ing-switch.io/migration-status"
+
+// IsIngressResource reports whether the scanned source is a networking.k8s.io
+// Ingress (as opposed to an IngressRoute or VirtualService CRD).
+func (i IngressInfo) IsIngressResource() bool {
+	switch i.SourceType {
+	case SourceNginxIngress, SourceKongIngress, SourceHAProxyIngress:
+		return true
+	}
+	return false
+}
+
+// AnnotateIngress sets a single annotation on an Ingress using a merge patch.
+// With dryRun the patch is validated server-side but not persisted.
+func (s *Scanner) AnnotateIngress(namespace, name, key, value string, dryRun bool) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.PatchOptions{FieldManager: "ing-switch"}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	_, err = s.client.NetworkingV1().Ingresses(namespace).Patch(context.Background(), name, types.MergePatchType, patch, opts)
+	return err
+}
@@ -1,8 +1,6 @@
 package scanner
 
 import (
-	"fmt"
-
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -13,25 +11,60 @@ type Scanner struct {
 	client      kubernetes.Interface
 	restConfig  *rest.Config
 	clusterName string
+
+	annotationSource string // AnnotationSource*; "" reads community annotations
+	config           Config // .ing-switch.yaml, loaded when the Scanner is created
 }
 
-// NewScanner creates a Scanner connected to the Kubernetes cluster.
-func NewScanner(kubeconfigPath, context string) (*Scanner, error) {
-	var loadingRules *clientcmd.ClientConfigLoadingRules
+// clusterOverride and userOverride are kubectl's --cluster and --user. They
+// are process-wide, so every connection honours them.
+var clusterOverride, userOverride string
+
+// SetConfigOverrides selects the kubeconfig cluster and user to connect with
+// instead of the current context's, as kubectl's --cluster and --user do.
+func SetConfigOverrides(cluster, user string) {
+	clusterOverride, userOverride = cluster, user
+}
+
+// KubectlConfigArgs returns the --cluster / --user flags to pass on to
+// kubectl so it connects the same way.
+func KubectlConfigArgs() []string {
+	var args []string
+	if clusterOverride != "" {
+		args = append(args, "--cluster", clusterOverride)
+	}
+	if userOverride != "" {
+		args = append(args, "--user", userOverride)
+	}
+	return args
+}
+
+// LoadingRules returns the kubeconfig loading rules kubectl would use.
+func LoadingRules(kubeconfigPath string) *clientcmd.ClientConfigLoadingRules {
 	if kubeconfigPath != "" {
 		// Explicit --kubeconfig flag: treat as a single file path.
-		loadingRules = &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath}
-	} else {
-		// No explicit path: use the default rules which correctly split
-		// KUBECONFIG on ":" (or ";" on Windows) and merge all files,
-		// falling back to ~/.kube/config when KUBECONFIG is unset.
-		loadingRules = clientcmd.NewDefaultClientConfigLoadingRules()
+		return &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath}
 	}
+	// No explicit path: use the default rules which correctly split
+	// KUBECONFIG on ":" (or ";" on Windows) and merge all files,
+	// falling back to ~/.kube/config when KUBECONFIG is unset.
+	return clientcmd.NewDefaultClientConfigLoadingRules()
+}
 
-	configOverrides := &clientcmd.ConfigOverrides{}
-	if context != "" {
-		configOverrides.CurrentContext = context
-	}
+// ConfigOverrides returns the kubeconfig overrides for context ("" for the
+// current one) and the --cluster / --user overrides.
+func ConfigOverrides(context string) *clientcmd.ConfigOverrides {
+	overrides := &clientcmd.ConfigOverrides{}
+	overrides.CurrentContext = context
+	overrides.Context.Cluster = clusterOverride
+	overrides.Context.AuthInfo = userOverride
+	return overrides
+}
+
+// NewScanner creates a Scanner connected to the Kubernetes cluster.
+func NewScanner(kubeconfigPath, context string) (*Scanner, error) {
+	loadingRules := LoadingRules(kubeconfigPath)
+	configOverrides := ConfigOverrides(context)
 
 	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
 	rawConfig, err := clientConfig.RawConfig()
@@ -47,7 +80,7 @@ func NewScanner(kubeconfigPath, context string) (*Scanner, error) {
 			break
 		}
 		if configOverrides.CurrentContext == "" {
-			return nil, fmt.Errorf("kubeconfig has no contexts defined")
+			return nil, ErrNoContexts
 		}
 		clientConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
 	}
@@ -57,15 +90,38 @@ func NewScanner(kubeconfigPath, context string) (*Scanner, error) {
 		return nil, err
 	}
 
+	clusterName := rawConfig.CurrentContext
+	if configOverrides.CurrentContext != "" {
+		clusterName = configOverrides.CurrentContext
+	}
+
+	return NewScannerFromConfig(restConfig, clusterName)
+}
+
+// NewScannerFromConfig creates a Scanner from an already-built rest.Config,
+// e.g. an API server URL + bearer token without any kubeconfig file.
+func NewScannerFromConfig(restConfig *rest.Config, clusterName string) (*Scanner, error) {
 	client, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		return nil, err
 	}
+	return &Scanner{client: client, restConfig: restConfig, clusterName: clusterName, config: LoadConfig()}, nil
+}
 
-	clusterName := rawConfig.CurrentContext
-	if configOverrides.CurrentContext != "" {
-		clusterName = configOverrides.CurrentContext
-	}
+// SetAnnotationSource selects which nginx annotation family Ingresses are
+// read with: AnnotationSourceCommunity (default) or AnnotationSourceF5.
+func (s *Scanner) SetAnnotationSource(source string) {
+	s.annotationSource = source
+}
 
-	return &Scanner{client: client, restConfig: restConfig, clusterName: clusterName}, nil
+// TokenConfig builds a rest.Config for an API server URL and bearer token.
+// caData is an optional PEM bundle; when empty the system roots are used.
+func TokenConfig(server, token string, caData []byte) *rest.Config {
+	return &rest.Config{
+		Host:        server,
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: caData,
+		},
+	}
 }
@@ -16,6 +16,13 @@ type Config struct {
 	//     - argocd.argoproj.io/
 	//     - my-company.io/internal-
 	IgnoreAnnotationPrefixes []string `json:"ignore_annotation_prefixes" yaml:"ignore_annotation_prefixes"`
+
+	// SetAnnotations are added to every generated Traefik Ingress and
+	// HTTPRoute by migrate, like --set-annotation (which overrides them).
+	// Example:
+	//   set_annotations:
+	//     traefik.ingress.kubernetes.io/router.priority: "10"
+	SetAnnotations map[string]string `json:"set_annotations" yaml:"set_annotations"`
 }
 
 // builtinIgnorePrefixes are system-generated annotation prefixes that are
@@ -31,9 +38,9 @@ var builtinIgnorePrefixes = []string{
 
 const configFileName = ".ing-switch.yaml"
 
-// loadConfig reads .ing-switch.yaml from the current directory.
+// LoadConfig reads .ing-switch.yaml from the current directory.
 // Missing file is not an error — returns an empty config.
-func loadConfig() Config {
+func LoadConfig() Config {
 	data, err := os.ReadFile(configFileName)
 	if err != nil {
 		return Config{}
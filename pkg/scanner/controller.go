@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -50,13 +51,17 @@ func (s *Scanner) detectController() (ControllerInfo, error) {
 			pod := pods.Items[0]
 			version := extractVersion(pod.Spec.Containers)
 
-			return ControllerInfo{
+			info := ControllerInfo{
 				Detected:  true,
 				Type:      cand.ctrlType,
 				Version:   version,
 				Namespace: ns,
 				PodName:   pod.Name,
-			}, nil
+			}
+			if cand.ctrlType == "ingress-nginx" {
+				info.DefaultSSLCertificate = defaultSSLCertificate(pod.Spec.Containers)
+			}
+			return info, nil
 		}
 	}
 
@@ -73,12 +78,32 @@ func (s *Scanner) detectController() (ControllerInfo, error) {
 			Version:   extractVersion(pod.Spec.Containers),
 			Namespace: pod.Namespace,
 			PodName:   pod.Name,
+
+			DefaultSSLCertificate: defaultSSLCertificate(pod.Spec.Containers),
 		}, nil
 	}
 
 	return ControllerInfo{Detected: false, Type: "unknown"}, nil
 }
 
+// defaultSSLCertificate returns the "namespace/name" value of the
+// --default-ssl-certificate flag, in either "--flag=value" or "--flag value"
+// form, or "" when the controller serves its built-in fake certificate.
+func defaultSSLCertificate(containers []corev1.Container) string {
+	const flag = "--default-ssl-certificate"
+	for _, c := range containers {
+		for i, arg := range c.Args {
+			if v, ok := strings.CutPrefix(arg, flag+"="); ok {
+				return v
+			}
+			if arg == flag && i+1 < len(c.Args) {
+				return c.Args[i+1]
+			}
+		}
+	}
+	return ""
+}
+
 func extractVersion(containers interface{ }) string {
 	// Use reflection-free approach — just return unknown for now
 	// In a real cluster, we'd inspect the container image tag
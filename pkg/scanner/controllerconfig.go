@@ -0,0 +1,52 @@
+package scanner
+
+import (
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// controllerConfigMaps are the main ConfigMap names tried when the pod has no
+// --configmap flag: the Helm chart's and the older static manifests'.
+var controllerConfigMaps = []string{"ingress-nginx-controller", "nginx-configuration"}
+
+// controllerConfigMap reads the ingress-nginx ConfigMap named by the
+// controller's --configmap flag, or the default names in the controller
+// namespace, returning its "namespace/name" and data. ok is false when the
+// controller is not ingress-nginx or no ConfigMap can be read.
+func (s *Scanner) controllerConfigMap(controller ControllerInfo) (ref string, data map[string]string, ok bool) {
+	if controller.Type != "ingress-nginx" || controller.Namespace == "" {
+		return "", nil, false
+	}
+
+	var refs []string
+	for _, n := range controllerConfigMaps {
+		refs = append(refs, controller.Namespace+"/"+n)
+	}
+	if controller.PodName != "" {
+		pod, err := s.client.CoreV1().Pods(controller.Namespace).Get(context.Background(), controller.PodName, metav1.GetOptions{})
+		if err == nil {
+			for _, c := range pod.Spec.Containers {
+				for _, arg := range c.Args {
+					if v, ok := strings.CutPrefix(arg, "--configmap="); ok {
+						refs = []string{v}
+					}
+				}
+			}
+		}
+	}
+
+	for _, ref := range refs {
+		ns, name, ok := strings.Cut(ref, "/")
+		if !ok {
+			continue
+		}
+		cm, err := s.client.CoreV1().ConfigMaps(ns).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		return ref, cm.Data, true
+	}
+	return "", nil, false
+}
@@ -0,0 +1,136 @@
+package scanner
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// serviceLookup returns the named Service, or nil when it does not exist or
+// cannot be read.
+type serviceLookup func(namespace, name string) *corev1.Service
+
+// clusterServices looks Services up in the cluster, fetching each at most once.
+func clusterServices(client kubernetes.Interface) serviceLookup {
+	cache := make(map[string]*corev1.Service) // "ns/name" → Service; nil when not found
+	return func(namespace, name string) *corev1.Service {
+		key := namespace + "/" + name
+		svc, cached := cache[key]
+		if !cached {
+			got, err := client.CoreV1().Services(namespace).Get(context.Background(), name, metav1.GetOptions{})
+			if err == nil {
+				svc = got
+			}
+			cache[key] = svc
+		}
+		return svc
+	}
+}
+
+// manifestServices looks Services up among those decoded from a manifest stream.
+func manifestServices(services []corev1.Service) serviceLookup {
+	index := make(map[string]*corev1.Service, len(services))
+	for i := range services {
+		index[services[i].Namespace+"/"+services[i].Name] = &services[i]
+	}
+	return func(namespace, name string) *corev1.Service {
+		return index[namespace+"/"+name]
+	}
+}
+
+// enrichIngresses fills in what parseIngress cannot know from the Ingress
+// object alone and needs the backend Services for. It runs once per scan,
+// after every source has been parsed, so parseIngress stays free of API calls
+// and works the same for cluster, --stdin, and pasted manifests.
+//
+// Services that cannot be found leave the ingress unchanged.
+func enrichIngresses(services serviceLookup, ingresses []IngressInfo) {
+	for i := range ingresses {
+		resolveNamedPorts(&ingresses[i], services)
+		resolveExternalNames(&ingresses[i], services)
+		resolveBackendPods(&ingresses[i], services)
+	}
+}
+
+// resolveNamedPorts replaces backend port names (service.port.name) with the
+// Service's port number, which Gateway API backendRefs require. The Service
+// is looked up in the path's backend namespace, e.g. of an Istio route.
+func resolveNamedPorts(info *IngressInfo, services serviceLookup) {
+	resolved := make(map[string]int32) // "namespace/name" → number
+	for i, p := range info.Paths {
+		if p.ServicePortName == "" || p.ServicePort != 0 {
+			continue
+		}
+		ns := info.Namespace
+		if p.ServiceNamespace != "" {
+			ns = p.ServiceNamespace
+		}
+		svc := services(ns, p.ServiceName)
+		if svc == nil {
+			continue
+		}
+		for _, port := range svc.Spec.Ports {
+			if port.Name == p.ServicePortName {
+				info.Paths[i].ServicePort = port.Port
+				resolved[ns+"/"+p.ServiceName] = port.Port
+				break
+			}
+		}
+	}
+	for i, svc := range info.Services {
+		if port, ok := resolved[svc.Namespace+"/"+svc.Name]; ok && svc.Port == 0 {
+			info.Services[i].Port = port
+		}
+	}
+}
+
+// resolveExternalNames records the external host of backends that are
+// ExternalName Services. Controllers disagree on how (and whether) they
+// proxy to those, so the analyzer warns about each one.
+func resolveExternalNames(info *IngressInfo, services serviceLookup) {
+	for i, ref := range info.Services {
+		if svc := services(ref.Namespace, ref.Name); svc != nil && svc.Spec.Type == corev1.ServiceTypeExternalName {
+			info.Services[i].ExternalName = svc.Spec.ExternalName
+		}
+	}
+}
+
+// resolveBackendPods records the pod selector and target ports of each
+// backend Service, so a NetworkPolicy admitting the new controller can be
+// limited to the pods and ports the ingress actually reaches.
+func resolveBackendPods(info *IngressInfo, services serviceLookup) {
+	for i, ref := range info.Services {
+		svc := services(ref.Namespace, ref.Name)
+		if svc == nil || svc.Spec.Type == corev1.ServiceTypeExternalName {
+			continue
+		}
+		info.Services[i].Selector = svc.Spec.Selector
+
+		seen := make(map[string]bool)
+		for _, p := range info.Paths {
+			ns := p.ServiceNamespace
+			if ns == "" {
+				ns = info.Namespace
+			}
+			if ns != ref.Namespace || p.ServiceName != ref.Name {
+				continue
+			}
+			for _, port := range svc.Spec.Ports {
+				if port.Port != p.ServicePort && (p.ServicePortName == "" || port.Name != p.ServicePortName) {
+					continue
+				}
+				target := port.TargetPort.String()
+				if port.TargetPort.IntValue() == 0 && port.TargetPort.StrVal == "" {
+					target = strconv.Itoa(int(port.Port)) // targetPort defaults to port
+				}
+				if !seen[target] {
+					seen[target] = true
+					info.Services[i].TargetPorts = append(info.Services[i].TargetPorts, target)
+				}
+			}
+		}
+	}
+}
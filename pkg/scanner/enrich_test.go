@@ -0,0 +1,43 @@
+package scanner
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveNamedPortsUsesBackendNamespace(t *testing.T) {
+	services := manifestServices([]corev1.Service{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "mesh", Name: "reviews"},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "reviews"},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 9080}}},
+		},
+	})
+	info := IngressInfo{
+		Namespace: "mesh",
+		Paths: []PathInfo{
+			{Path: "/", ServiceName: "reviews", ServicePortName: "http"},
+			{Path: "/prod", ServiceName: "reviews", ServiceNamespace: "prod", ServicePortName: "http"},
+		},
+		Services: []ServiceRef{
+			{Namespace: "mesh", Name: "reviews"},
+			{Namespace: "prod", Name: "reviews"},
+		},
+	}
+
+	resolveNamedPorts(&info, services)
+
+	for i, want := range []int32{8080, 9080} {
+		if got := info.Paths[i].ServicePort; got != want {
+			t.Errorf("path %s port = %d, want %d", info.Paths[i].Path, got, want)
+		}
+		if got := info.Services[i].Port; got != want {
+			t.Errorf("service %s/%s port = %d, want %d", info.Services[i].Namespace, info.Services[i].Name, got, want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Failures callers tell apart, e.g. to pick an HTTP status. They are
+// returned wrapped around the underlying error, so test with errors.Is.
+var (
+	ErrNoContexts  = errors.New("kubeconfig has no contexts defined")
+	ErrNoCluster   = errors.New("cluster unreachable")
+	ErrForbidden   = errors.New("access denied")
+	ErrNoIngresses = errors.New("no Ingress resources found")
+)
+
+// classifyAPIError wraps an API call error in ErrForbidden when the API
+// server refused the credentials or RBAC denied the request, and in
+// ErrNoCluster when the API server could not be reached at all. Other errors
+// are returned as they are.
+func classifyAPIError(err error) error {
+	var urlErr *url.Error
+	switch {
+	case err == nil:
+		return nil
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return fmt.Errorf("%w: %w", ErrForbidden, err)
+	case errors.As(err, &urlErr):
+		// client-go reports transport failures (DNS, refused, TLS) as *url.Error
+		return fmt.Errorf("%w: %w", ErrNoCluster, err)
+	}
+	return err
+}
+
+// RequireIngresses returns ErrNoIngresses when the scan found nothing to
+// migrate: no Ingresses and no TCP/UDP services. namespace is the one
+// scanned, "" for all.
+func (r *ScanResult) RequireIngresses(namespace string) error {
+	if len(r.Ingresses) > 0 || len(r.StreamServices) > 0 {
+		return nil
+	}
+	if namespace == "" {
+		return fmt.Errorf("%w in any namespace", ErrNoIngresses)
+	}
+	return fmt.Errorf("%w in namespace %s", ErrNoIngresses, namespace)
+}
@@ -0,0 +1,140 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	f5OrgAnnotationPrefix = "nginx.org/"
+	f5ComAnnotationPrefix = "nginx.com/"
+)
+
+// Annotation sources selectable with --source. The community ingress-nginx
+// controller uses nginx.ingress.kubernetes.io/*; F5 NGINX Ingress Controller
+// uses nginx.org/* and (NGINX Plus) nginx.com/* with different names.
+const (
+	AnnotationSourceCommunity = "community"
+	AnnotationSourceF5        = "f5"
+)
+
+// ValidateAnnotationSource checks a --source value: "community" (or ""
+// for the default) or "f5".
+func ValidateAnnotationSource(source string) error {
+	switch source {
+	case "", AnnotationSourceCommunity, AnnotationSourceF5:
+		return nil
+	}
+	return fmt.Errorf("unknown annotation source %q — use 'community' or 'f5'", source)
+}
+
+// f5AnnotationMap translates F5 NGINX annotations whose value carries over
+// unchanged to the equivalent community pseudo-annotation.
+var f5AnnotationMap = map[string]string{
+	"proxy-connect-timeout":   "proxy-connect-timeout",
+	"proxy-read-timeout":      "proxy-read-timeout",
+	"proxy-send-timeout":      "proxy-send-timeout",
+	"client-max-body-size":    "proxy-body-size",
+	"proxy-buffering":         "proxy-buffering",
+	"proxy-buffer-size":       "proxy-buffer-size",
+	"ssl-redirect":            "ssl-redirect",
+	"redirect-to-https":       "ssl-redirect",
+	"hsts":                    "hsts",
+	"hsts-max-age":            "hsts-max-age",
+	"hsts-include-subdomains": "hsts-include-subdomains",
+	"server-snippets":         "server-snippet",
+	"location-snippets":       "configuration-snippet",
+	"basic-auth-secret":       "auth-secret",
+	"basic-auth-realm":        "auth-realm",
+}
+
+// mapF5Annotation translates one F5 NGINX annotation (key without prefix)
+// onto info.NginxAnnotations. Annotations without a community equivalent —
+// nginx.org/rewrites (per-service prefix rewrites), ssl-services or
+// grpc-services naming only some backends, lb-method algorithms the
+// community controller lacks, JWT, mergeable ingresses — are kept under
+// their full key so the analyzer flags them for manual review.
+func mapF5Annotation(info *IngressInfo, prefix, key, value string) {
+	if community, ok := f5AnnotationMap[key]; ok {
+		info.NginxAnnotations[community] = value
+		if key == "basic-auth-secret" {
+			info.NginxAnnotations["auth-type"] = "basic"
+		}
+		return
+	}
+
+	switch key {
+	case "ssl-services", "grpc-services":
+		// Per-service in F5, per-Ingress in the community controller: only
+		// a list naming every backend carries over.
+		if !listsEveryService(info, value) {
+			info.NginxAnnotations[prefix+key] = value
+			break
+		}
+		info.NginxAnnotations["backend-protocol"] = "HTTPS"
+		if key == "grpc-services" {
+			info.NginxAnnotations["backend-protocol"] = "GRPC"
+		}
+	case "lb-method":
+		mapF5LBMethod(info, prefix+key, value)
+	case "websocket-services":
+		// WebSockets need no configuration on Traefik or Gateway API.
+	case "sticky-cookie-services":
+		// "serviceName=tea-svc srv_id expires=1h path=/" — second field is the cookie name.
+		info.NginxAnnotations["affinity"] = "cookie"
+		for _, rule := range strings.Split(value, ";") {
+			if fields := strings.Fields(rule); len(fields) > 1 {
+				info.NginxAnnotations["session-cookie-name"] = fields[1]
+				break
+			}
+		}
+	default:
+		info.NginxAnnotations[prefix+key] = value
+	}
+}
+
+// listsEveryService reports whether the comma-separated Service names in
+// list include every backend of info.
+func listsEveryService(info *IngressInfo, list string) bool {
+	listed := map[string]bool{}
+	for _, name := range strings.Split(list, ",") {
+		listed[strings.TrimSpace(name)] = true
+	}
+	for _, svc := range info.Services {
+		if !listed[svc.Name] {
+			return false
+		}
+	}
+	return len(info.Services) > 0
+}
+
+// mapF5LBMethod translates nginx.org/lb-method. round_robin, ip_hash and
+// hash have community equivalents; least_conn, least_time and the random
+// methods do not, and are kept under fullKey.
+func mapF5LBMethod(info *IngressInfo, fullKey, value string) {
+	fields := strings.Fields(value)
+	switch {
+	case len(fields) == 1 && fields[0] == "round_robin":
+		info.NginxAnnotations["load-balance"] = "round_robin"
+	case len(fields) == 1 && fields[0] == "ip_hash":
+		info.NginxAnnotations["upstream-hash-by"] = "$binary_remote_addr"
+	case len(fields) >= 2 && fields[0] == "hash":
+		// "hash $request_uri consistent": the community controller always
+		// hashes consistently
+		info.NginxAnnotations["upstream-hash-by"] = fields[1]
+	default:
+		info.NginxAnnotations[fullKey] = value
+	}
+}
+
+// extractF5Annotation handles k when it carries an F5 NGINX prefix and
+// reports whether it did.
+func extractF5Annotation(info *IngressInfo, k, v string) bool {
+	for _, prefix := range []string{f5OrgAnnotationPrefix, f5ComAnnotationPrefix} {
+		if strings.HasPrefix(k, prefix) {
+			mapF5Annotation(info, prefix, strings.TrimPrefix(k, prefix), v)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMapF5Annotation(t *testing.T) {
+	services := []ServiceRef{{Namespace: "shop", Name: "web"}, {Namespace: "shop", Name: "api"}}
+
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  map[string]string
+	}{
+		{
+			name:  "ssl-services listing every backend",
+			key:   "ssl-services",
+			value: "web, api",
+			want:  map[string]string{"backend-protocol": "HTTPS"},
+		},
+		{
+			name:  "ssl-services listing some backends",
+			key:   "ssl-services",
+			value: "api",
+			want:  map[string]string{"nginx.org/ssl-services": "api"},
+		},
+		{
+			name:  "grpc-services listing every backend",
+			key:   "grpc-services",
+			value: "web,api",
+			want:  map[string]string{"backend-protocol": "GRPC"},
+		},
+		{
+			name:  "rewrites",
+			key:   "rewrites",
+			value: "serviceName=api rewrite=/",
+			want:  map[string]string{"nginx.org/rewrites": "serviceName=api rewrite=/"},
+		},
+		{
+			name:  "lb-method round_robin",
+			key:   "lb-method",
+			value: "round_robin",
+			want:  map[string]string{"load-balance": "round_robin"},
+		},
+		{
+			name:  "lb-method ip_hash",
+			key:   "lb-method",
+			value: "ip_hash",
+			want:  map[string]string{"upstream-hash-by": "$binary_remote_addr"},
+		},
+		{
+			name:  "lb-method hash",
+			key:   "lb-method",
+			value: "hash $request_uri consistent",
+			want:  map[string]string{"upstream-hash-by": "$request_uri"},
+		},
+		{
+			name:  "lb-method least_conn",
+			key:   "lb-method",
+			value: "least_conn",
+			want:  map[string]string{"nginx.org/lb-method": "least_conn"},
+		},
+		{
+			name:  "lb-method random two",
+			key:   "lb-method",
+			value: "random two least_conn",
+			want:  map[string]string{"nginx.org/lb-method": "random two least_conn"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &IngressInfo{Services: services, NginxAnnotations: map[string]string{}}
+			mapF5Annotation(info, f5OrgAnnotationPrefix, tt.key, tt.value)
+			if !reflect.DeepEqual(info.NginxAnnotations, tt.want) {
+				t.Errorf("annotations = %v, want %v", info.NginxAnnotations, tt.want)
+			}
+		})
+	}
+}
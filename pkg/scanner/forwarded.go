@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"strings"
+)
+
+// ForwardedHeaders is how ingress-nginx determines the client IP, from the
+// global settings in its ConfigMap. whitelist-source-range and
+// denylist-source-range match that IP, so the new controller must derive it
+// the same way or the allowlists match the load balancer instead.
+type ForwardedHeaders struct {
+	ConfigMap               string   `json:"configMap"` // "namespace/name" it was read from
+	UseForwardedHeaders     bool     `json:"useForwardedHeaders"`
+	ComputeFullForwardedFor bool     `json:"computeFullForwardedFor"`
+	UseProxyProtocol        bool     `json:"useProxyProtocol"`
+	ProxyRealIPCIDR         []string `json:"proxyRealIPCIDR"`    // trusted proxies (set_real_ip_from)
+	ForwardedForHeader      string   `json:"forwardedForHeader"` // header the client IP is read from
+}
+
+// TrustsAllProxies reports whether proxy-real-ip-cidr is the nginx default,
+// 0.0.0.0/0: every hop is trusted and the leftmost address is the client.
+func (f ForwardedHeaders) TrustsAllProxies() bool {
+	for _, cidr := range f.ProxyRealIPCIDR {
+		if cidr == "0.0.0.0/0" || cidr == "::/0" {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanForwardedHeaders reads the client-IP settings from the ingress-nginx
+// ConfigMap. It returns nil when the controller is not ingress-nginx or no
+// ConfigMap can be read (RBAC, a renamed release).
+func (s *Scanner) ScanForwardedHeaders(controller ControllerInfo) *ForwardedHeaders {
+	ref, data, ok := s.controllerConfigMap(controller)
+	if !ok {
+		return nil
+	}
+	return ParseForwardedHeaders(ref, data)
+}
+
+// ParseForwardedHeaders reads the client-IP settings from ingress-nginx
+// ConfigMap data, applying the controller's defaults for unset keys.
+func ParseForwardedHeaders(configMap string, data map[string]string) *ForwardedHeaders {
+	f := &ForwardedHeaders{
+		ConfigMap:               configMap,
+		UseForwardedHeaders:     data["use-forwarded-headers"] == "true",
+		ComputeFullForwardedFor: data["compute-full-forwarded-for"] == "true",
+		UseProxyProtocol:        data["use-proxy-protocol"] == "true",
+		ProxyRealIPCIDR:         []string{"0.0.0.0/0"},
+		ForwardedForHeader:      "X-Forwarded-For",
+	}
+	if v := strings.TrimSpace(data["proxy-real-ip-cidr"]); v != "" {
+		f.ProxyRealIPCIDR = nil
+		for _, cidr := range strings.Split(v, ",") {
+			if cidr = strings.TrimSpace(cidr); cidr != "" {
+				f.ProxyRealIPCIDR = append(f.ProxyRealIPCIDR, cidr)
+			}
+		}
+	}
+	if v := strings.TrimSpace(data["forwarded-for-header"]); v != "" {
+		f.ForwardedForHeader = v
+	}
+	return f
+}
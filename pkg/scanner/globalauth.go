@@ -0,0 +1,89 @@
+package scanner
+
+import (
+	"strings"
+)
+
+// GlobalAuth is the external authentication ingress-nginx applies to every
+// Ingress from its ConfigMap (global-auth-url and friends). Ingresses opt out
+// with enable-global-auth: "false", or by setting their own auth-url.
+type GlobalAuth struct {
+	ConfigMap       string `json:"configMap"` // "namespace/name" it was read from
+	URL             string `json:"url"`
+	Method          string `json:"method,omitempty"`
+	Signin          string `json:"signin,omitempty"`
+	ResponseHeaders string `json:"responseHeaders,omitempty"`
+	RequestRedirect string `json:"requestRedirect,omitempty"`
+	CacheKey        string `json:"cacheKey,omitempty"`
+	Snippet         string `json:"snippet,omitempty"`
+}
+
+// annotations maps each GlobalAuth field to the per-ingress annotation it
+// becomes.
+func (g GlobalAuth) annotations() map[string]string {
+	return map[string]string{
+		"auth-url":              g.URL,
+		"auth-method":           g.Method,
+		"auth-signin":           g.Signin,
+		"auth-response-headers": g.ResponseHeaders,
+		"auth-request-redirect": g.RequestRedirect,
+		"auth-cache-key":        g.CacheKey,
+		"auth-snippet":          g.Snippet,
+	}
+}
+
+// ScanGlobalAuth reads the global external auth settings from the
+// ingress-nginx ConfigMap, or returns nil when none is configured or the
+// ConfigMap cannot be read.
+func (s *Scanner) ScanGlobalAuth(controller ControllerInfo) *GlobalAuth {
+	ref, data, ok := s.controllerConfigMap(controller)
+	if !ok {
+		return nil
+	}
+	return ParseGlobalAuth(ref, data)
+}
+
+// ParseGlobalAuth reads the global-auth-* keys from ingress-nginx ConfigMap
+// data, returning nil when global-auth-url is unset.
+func ParseGlobalAuth(configMap string, data map[string]string) *GlobalAuth {
+	url := strings.TrimSpace(data["global-auth-url"])
+	if url == "" {
+		return nil
+	}
+	return &GlobalAuth{
+		ConfigMap:       configMap,
+		URL:             url,
+		Method:          strings.TrimSpace(data["global-auth-method"]),
+		Signin:          strings.TrimSpace(data["global-auth-signin"]),
+		ResponseHeaders: strings.TrimSpace(data["global-auth-response-headers"]),
+		RequestRedirect: strings.TrimSpace(data["global-auth-request-redirect"]),
+		CacheKey:        strings.TrimSpace(data["global-auth-cache-key"]),
+		Snippet:         strings.TrimSpace(data["global-auth-snippet"]),
+	}
+}
+
+// applyGlobalAuth gives every ingress-nginx Ingress that global auth covers
+// the equivalent per-ingress auth-* annotations, so the migrators generate a
+// ForwardAuth middleware / ext-auth policy for it: the new controller has no
+// global auth, and without them the routes would be left unauthenticated.
+// The global-auth pseudo-annotation records the ConfigMap they came from.
+// Ingresses with their own auth-url, or enable-global-auth: "false", are left
+// alone, as nginx does.
+func applyGlobalAuth(ingresses []IngressInfo, auth *GlobalAuth) {
+	if auth == nil {
+		return
+	}
+	for i := range ingresses {
+		ann := ingresses[i].NginxAnnotations
+		if ingresses[i].SourceType != SourceNginxIngress || ann["auth-url"] != "" || ann["enable-global-auth"] == "false" {
+			continue
+		}
+		for key, v := range auth.annotations() {
+			if v != "" {
+				ann[key] = v
+			}
+		}
+		ann["global-auth"] = auth.ConfigMap
+		ingresses[i].Complexity = classifyComplexity(ann)
+	}
+}
@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// HTTPRouteGVRs — try the GA version first, fall back to v1beta1.
+var HTTPRouteGVRs = []schema.GroupVersionResource{
+	{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"},
+	{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "httproutes"},
+}
+
+// ListFirstAvailable lists the first GVR that the API server serves. It is used
+// where a CRD may be installed under more than one group/version.
+func ListFirstAvailable(ctx context.Context, dynClient dynamic.Interface, gvrs []schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+	for _, gvr := range gvrs {
+		list, err := dynClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err == nil {
+			return list, nil
+		}
+	}
+	return nil, fmt.Errorf("none of %d resource versions are served", len(gvrs))
+}
+
+// ScanHTTPRoutes lists existing HTTPRoutes so the analyzer can tell which
+// Ingresses already have a Gateway API counterpart.
+func (s *Scanner) ScanHTTPRoutes(namespace string, restConfig *rest.Config) ([]RouteInfo, error) {
+	dynClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating dynamic client: %w", err)
+	}
+
+	list, err := ListFirstAvailable(context.Background(), dynClient, HTTPRouteGVRs, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var routes []RouteInfo
+	for _, item := range list.Items {
+		routes = append(routes, RouteInfo{
+			Namespace: item.GetNamespace(),
+			Name:      item.GetName(),
+			Accepted:  routeAccepted(item.Object),
+		})
+	}
+	return routes, nil
+}
+
+// routeAccepted reports whether any parent Gateway has set Accepted=True.
+func routeAccepted(obj map[string]interface{}) bool {
+	parents, _, _ := unstructured.NestedSlice(obj, "status", "parents")
+	for _, p := range parents {
+		pm, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(pm, "conditions")
+		for _, c := range conditions {
+			cm, ok := c.(map[string]interface{})
+			if ok && cm["type"] == "Accepted" && cm["status"] == "True" {
+				return true
+			}
+		}
+	}
+	return false
+}
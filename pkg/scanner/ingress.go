@@ -9,7 +9,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const nginxAnnotationPrefix = "nginx.ingress.kubernetes.io/"
+const (
+	nginxAnnotationPrefix   = "nginx.ingress.kubernetes.io/"
+	traefikAnnotationPrefix = "traefik.ingress.kubernetes.io/"
+)
 
 // Scan performs a full cluster scan and returns a ScanResult.
 // It scans both standard Kubernetes Ingresses and Traefik IngressRoute CRDs.
@@ -66,31 +69,61 @@ func (s *Scanner) Scan(namespace string) (*ScanResult, error) {
 		}
 	}
 
+	// Cluster lookups parseIngress deliberately does not make
+	enrichIngresses(clusterServices(s.client), ingresses)
+	s.checkTLSSecrets(ingresses)
+
 	controller, err := s.detectController()
 	if err != nil {
 		// Non-fatal: controller may not be detectable with limited permissions
 		controller = ControllerInfo{Detected: false, Type: "unknown"}
 	}
+	controller.ForwardedHeaders = s.ScanForwardedHeaders(controller)
+	controller.GlobalAuth = s.ScanGlobalAuth(controller)
+	applyGlobalAuth(ingresses, controller.GlobalAuth)
+	applyRedirectPort(ingresses, s.ScanRedirectPort(controller))
+	s.scanIngressClasses(&controller)
+	markServed(ingresses, controller)
+	targets := s.scanTargetControllers()
+	markAddressControllers(ingresses, s.scanControllerAddresses(controller, targets))
 
 	namespaces := extractNamespaces(ingresses)
 
+	// TCP/UDP ports exposed via ConfigMaps — not Ingresses, so easily lost
+	var streams []StreamService
+	for _, st := range s.ScanStreamServices(controller) {
+		if namespace == "" || st.Namespace == namespace {
+			streams = append(streams, st)
+		}
+	}
+
+	var routes []RouteInfo
+	if s.restConfig != nil {
+		// Existing HTTPRoutes mark Ingresses as already migrated (non-fatal if CRDs don't exist)
+		routes, _ = s.ScanHTTPRoutes(namespace, s.restConfig)
+	}
+
 	return &ScanResult{
-		ClusterName: s.clusterName,
-		Controller:  controller,
-		Ingresses:   ingresses,
-		Namespaces:  namespaces,
+		ClusterName:       s.clusterName,
+		Controller:        controller,
+		Ingresses:         ingresses,
+		Namespaces:        namespaces,
+		HTTPRoutes:        routes,
+		StreamServices:    streams,
+		NetworkPolicies:   s.ScanNetworkPolicies(ingresses),
+		TargetControllers: targets,
 	}, nil
 }
 
 func (s *Scanner) listIngresses(namespace string) ([]IngressInfo, error) {
 	list, err := s.client.NetworkingV1().Ingresses(namespace).List(context.Background(), metav1.ListOptions{})
 	if err != nil {
-		return nil, err
+		return nil, classifyAPIError(err)
 	}
 
 	var infos []IngressInfo
 	for _, ing := range list.Items {
-		infos = append(infos, parseIngress(ing))
+		infos = append(infos, parseIngress(ing, s.annotationSource, s.config))
 	}
 
 	// Sort for deterministic output
@@ -104,13 +137,18 @@ func (s *Scanner) listIngresses(namespace string) ([]IngressInfo, error) {
 	return infos, nil
 }
 
-func parseIngress(ing networkingv1.Ingress) IngressInfo {
+// parseIngress converts an Ingress into an IngressInfo, reading nginx
+// annotations of the given source family and skipping those cfg ignores. It
+// makes no API calls, so it serves cluster scans and manifest input alike;
+// anything that needs a lookup belongs in enrichIngresses.
+func parseIngress(ing networkingv1.Ingress, source string, cfg Config) IngressInfo {
 	info := IngressInfo{
 		Namespace:        ing.Namespace,
 		Name:             ing.Name,
 		SourceType:       SourceNginxIngress,
 		Annotations:      ing.Annotations,
 		NginxAnnotations: make(map[string]string),
+		Addresses:        ingressAddresses(ing.Status),
 	}
 
 	// IngressClass
@@ -154,6 +192,7 @@ func parseIngress(ing networkingv1.Ingress) IngressInfo {
 				if path.Backend.Service.Port.Number != 0 {
 					pi.ServicePort = path.Backend.Service.Port.Number
 				}
+				pi.ServicePortName = path.Backend.Service.Port.Name
 				key := ing.Namespace + "/" + path.Backend.Service.Name
 				serviceSet[key] = ServiceRef{
 					Namespace: ing.Namespace,
@@ -173,9 +212,11 @@ func parseIngress(ing networkingv1.Ingress) IngressInfo {
 	for _, svc := range serviceSet {
 		info.Services = append(info.Services, svc)
 	}
+	sort.Slice(info.Services, func(i, j int) bool { return info.Services[i].Name < info.Services[j].Name })
 
-	// Extract nginx annotations, skipping system-generated / user-ignored ones
-	cfg := loadConfig()
+	// Extract nginx annotations, skipping system-generated / user-ignored ones.
+	// Traefik's go to their own bucket: they are the source config of an
+	// Ingress Traefik serves, not features to translate like nginx's.
 	for k, v := range ing.Annotations {
 		if shouldIgnoreAnnotation(k, cfg.IgnoreAnnotationPrefixes) {
 			continue
@@ -183,8 +224,22 @@ func parseIngress(ing networkingv1.Ingress) IngressInfo {
 		if strings.HasPrefix(k, nginxAnnotationPrefix) {
 			shortKey := strings.TrimPrefix(k, nginxAnnotationPrefix)
 			info.NginxAnnotations[shortKey] = v
+			continue
+		}
+		if strings.HasPrefix(k, traefikAnnotationPrefix) {
+			if info.TraefikAnnotations == nil {
+				info.TraefikAnnotations = make(map[string]string)
+			}
+			info.TraefikAnnotations[strings.TrimPrefix(k, traefikAnnotationPrefix)] = v
+			continue
+		}
+		if source == AnnotationSourceF5 {
+			extractF5Annotation(&info, k, v)
 		}
 	}
+	extractSnippetHeaders(&info)
+	extractAuthSnippetHeaders(&info)
+	applyServerAlias(&info)
 
 	info.Complexity = classifyComplexity(info.NginxAnnotations)
 	return info
@@ -202,22 +257,24 @@ func classifyComplexity(nginx map[string]string) string {
 		"snippets":                true,
 		"lua-resty-waf":           true,
 		"modsecurity-snippet":     true,
+		"enable-modsecurity":      true,
+		"enable-owasp-core-rules": true,
 	}
 
 	complexAnnotations := map[string]bool{
-		"auth-url":              true,
-		"auth-response-headers": true,
-		"canary":                true,
-		"canary-weight":         true,
-		"limit-rps":             true,
-		"limit-connections":     true,
-		"rewrite-target":        true,
-		"use-regex":             true,
-		"affinity":              true,
+		"auth-url":               true,
+		"auth-response-headers":  true,
+		"canary":                 true,
+		"canary-weight":          true,
+		"limit-rps":              true,
+		"limit-connections":      true,
+		"rewrite-target":         true,
+		"use-regex":              true,
+		"affinity":               true,
 		"whitelist-source-range": true,
 		"denylist-source-range":  true,
-		"proxy-read-timeout":    true,
-		"proxy-connect-timeout": true,
+		"proxy-read-timeout":     true,
+		"proxy-connect-timeout":  true,
 	}
 
 	for k := range nginx {
@@ -227,7 +284,8 @@ func classifyComplexity(nginx map[string]string) string {
 	}
 
 	for k := range nginx {
-		if complexAnnotations[k] {
+		// Full keys are vendor annotations with no community equivalent (F5 nginx.org/*)
+		if complexAnnotations[k] || strings.Contains(k, "/") {
 			return "complex"
 		}
 	}
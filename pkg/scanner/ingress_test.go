@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseIngressAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		"nginx.ingress.kubernetes.io/ssl-redirect":         "true",
+		"nginx.org/client-max-body-size":                   "8m",
+		"traefik.ingress.kubernetes.io/router.entrypoints": "websecure",
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+		"example.com/team":                                 "shop",
+	}
+
+	tests := []struct {
+		name        string
+		source      string
+		cfg         Config
+		wantNginx   map[string]string
+		wantTraefik map[string]string
+	}{
+		{
+			name:        "community source ignores F5 annotations",
+			source:      AnnotationSourceCommunity,
+			wantNginx:   map[string]string{"ssl-redirect": "true"},
+			wantTraefik: map[string]string{"router.entrypoints": "websecure"},
+		},
+		{
+			name:        "empty source reads community annotations",
+			source:      "",
+			wantNginx:   map[string]string{"ssl-redirect": "true"},
+			wantTraefik: map[string]string{"router.entrypoints": "websecure"},
+		},
+		{
+			name:        "F5 source translates nginx.org annotations",
+			source:      AnnotationSourceF5,
+			wantNginx:   map[string]string{"ssl-redirect": "true", "proxy-body-size": "8m"},
+			wantTraefik: map[string]string{"router.entrypoints": "websecure"},
+		},
+		{
+			name:      "config ignores annotation prefixes",
+			source:    AnnotationSourceF5,
+			cfg:       Config{IgnoreAnnotationPrefixes: []string{"nginx.org/", "traefik.ingress.kubernetes.io/"}},
+			wantNginx: map[string]string{"ssl-redirect": "true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", Annotations: annotations}}
+			info := parseIngress(ing, tt.source, tt.cfg)
+			if !reflect.DeepEqual(info.NginxAnnotations, tt.wantNginx) {
+				t.Errorf("NginxAnnotations = %v, want %v", info.NginxAnnotations, tt.wantNginx)
+			}
+			if !reflect.DeepEqual(info.TraefikAnnotations, tt.wantTraefik) {
+				t.Errorf("TraefikAnnotations = %v, want %v", info.TraefikAnnotations, tt.wantTraefik)
+			}
+		})
+	}
+}
@@ -186,6 +186,10 @@ func parseIngressRoute(ir unstructured.Unstructured, mwMap map[string]map[string
 				continue
 			}
 			svcName, _ := svcMap["name"].(string)
+			svcNs, _ := svcMap["namespace"].(string)
+			if svcNs == ir.GetNamespace() {
+				svcNs = ""
+			}
 			svcPort := int32(0)
 			if p, ok := svcMap["port"].(int64); ok {
 				svcPort = int32(p)
@@ -229,15 +233,21 @@ func parseIngressRoute(ir unstructured.Unstructured, mwMap map[string]map[string
 						PathType:    "Prefix",
 						ServiceName: svcName,
 						ServicePort: svcPort,
+
+						ServiceNamespace: svcNs,
 					}
 					info.Paths = append(info.Paths, pi)
 				}
 			}
 
 			if svcName != "" {
-				key := ir.GetNamespace() + "/" + svcName
+				ns := ir.GetNamespace()
+				if svcNs != "" {
+					ns = svcNs
+				}
+				key := ns + "/" + svcName
 				serviceSet[key] = ServiceRef{
-					Namespace: ir.GetNamespace(),
+					Namespace: ns,
 					Name:      svcName,
 					Port:      svcPort,
 				}
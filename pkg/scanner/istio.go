@@ -161,9 +161,9 @@ func parseVirtualService(vs unstructured.Unstructured) *IngressInfo {
 			}
 
 			// Short service name (strip .namespace.svc.cluster.local)
-			svcName := svcHost
-			if idx := strings.Index(svcName, "."); idx > 0 {
-				svcName = svcName[:idx]
+			svcName, svcNs := istioServiceHost(svcHost)
+			if svcNs == vs.GetNamespace() {
+				svcNs = ""
 			}
 
 			// Weighted routing (canary)
@@ -187,13 +187,19 @@ func parseVirtualService(vs unstructured.Unstructured) *IngressInfo {
 					PathType:    p.pathType,
 					ServiceName: svcName,
 					ServicePort: svcPort,
+
+					ServiceNamespace: svcNs,
 				}
 				info.Paths = append(info.Paths, pi)
 			}
 
-			key := vs.GetNamespace() + "/" + svcName
+			ns := vs.GetNamespace()
+			if svcNs != "" {
+				ns = svcNs
+			}
+			key := ns + "/" + svcName
 			serviceSet[key] = ServiceRef{
-				Namespace: vs.GetNamespace(),
+				Namespace: ns,
 				Name:      svcName,
 				Port:      svcPort,
 			}
@@ -526,3 +532,16 @@ func classifyIstioComplexity(info *IngressInfo) string {
 
 	return "simple"
 }
+
+// istioServiceHost splits a destination host into service name and
+// namespace. "reviews" has no namespace (the VirtualService's own);
+// "reviews.prod.svc" and "reviews.prod.svc.cluster.local" name namespace
+// prod. Any other host, e.g. "httpbin.org" from a ServiceEntry, is not a
+// cluster Service name and is returned unchanged, without a namespace.
+func istioServiceHost(host string) (name, namespace string) {
+	labels := strings.Split(host, ".")
+	if len(labels) >= 3 && labels[2] == "svc" && (len(labels) == 3 || strings.Join(labels[3:], ".") == "cluster.local") {
+		return labels[0], labels[1]
+	}
+	return host, ""
+}
@@ -0,0 +1,24 @@
+package scanner
+
+import "testing"
+
+func TestIstioServiceHost(t *testing.T) {
+	tests := []struct {
+		host            string
+		name, namespace string
+	}{
+		{"reviews", "reviews", ""},
+		{"reviews.prod.svc", "reviews", "prod"},
+		{"reviews.prod.svc.cluster.local", "reviews", "prod"},
+		{"httpbin.org", "httpbin.org", ""},
+		{"api.example.com", "api.example.com", ""},
+		{"reviews.prod", "reviews.prod", ""},
+		{"reviews.prod.svc.example.internal", "reviews.prod.svc.example.internal", ""},
+	}
+	for _, tt := range tests {
+		name, namespace := istioServiceHost(tt.host)
+		if name != tt.name || namespace != tt.namespace {
+			t.Errorf("istioServiceHost(%q) = %q, %q; want %q, %q", tt.host, name, namespace, tt.name, tt.namespace)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package scanner
+
+import "strings"
+
+// SplitList splits a comma-separated nginx annotation value (CIDRs, CORS
+// methods/headers/origins, auth response headers) into trimmed, non-empty items.
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
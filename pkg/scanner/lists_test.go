@@ -0,0 +1,24 @@
+package scanner
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"GET, PUT,POST", []string{"GET", "PUT", "POST"}},
+		{" 10.0.0.0/8 ,, 192.168.0.0/16, ", []string{"10.0.0.0/8", "192.168.0.0/16"}},
+		{"https://a.example.com", []string{"https://a.example.com"}},
+		{" , ", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := SplitList(tt.value); !slices.Equal(got, tt.want) {
+			t.Errorf("SplitList(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
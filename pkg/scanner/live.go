@@ -0,0 +1,180 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// Live diff change types.
+const (
+	ChangeAdd       = "add"
+	ChangeUpdate    = "change"
+	ChangeDelete    = "delete"
+	ChangeUnchanged = "unchanged"
+)
+
+// LiveChange describes how one generated resource differs from the cluster.
+type LiveChange struct {
+	Change    string `json:"change"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// ingressKind lets rewritten Ingresses (traefik target) be diffed too.
+var ingressKind = ManagedKind{Kind: "Ingress", GVR: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}, Namespaced: true}
+
+// DiffAgainstLive compares generated YAML documents with the live cluster.
+// A resource missing from the cluster is an add; one whose generated fields
+// differ from the live object is a change. Server-populated fields (status,
+// defaults) are ignored — only fields present in the generated document are
+// compared. Managed resources for target that are no longer generated are
+// reported as deletes. Kinds ing-switch does not generate are skipped.
+func (s *Scanner) DiffAgainstLive(docs []string, target, namespace string) ([]LiveChange, error) {
+	dynClient, err := dynamic.NewForConfig(s.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := append([]ManagedKind{ingressKind}, ManagedKindsForTarget(target)...)
+	byKind := map[string]ManagedKind{}
+	for _, k := range kinds {
+		byKind[k.Kind] = k
+	}
+
+	var changes []LiveChange
+	generated := map[string]bool{}
+	for _, doc := range docs {
+		objs, err := decodeObjects(doc)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			kind, _ := obj["kind"].(string)
+			k, ok := byKind[kind]
+			if !ok {
+				continue
+			}
+			meta, _ := obj["metadata"].(map[string]interface{})
+			name, _ := meta["name"].(string)
+			ns, _ := meta["namespace"].(string)
+			if !k.Namespaced {
+				ns = ""
+			} else if ns == "" {
+				ns = "default"
+			}
+			generated[kind+"/"+ns+"/"+name] = true
+
+			ri := dynClient.Resource(k.GVR)
+			var getter dynamic.ResourceInterface = ri
+			if k.Namespaced {
+				getter = ri.Namespace(ns)
+			}
+			change := LiveChange{Kind: kind, Namespace: ns, Name: name, Change: ChangeUnchanged}
+			live, err := getter.Get(context.Background(), name, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+				change.Change = ChangeAdd
+			case err != nil:
+				return nil, fmt.Errorf("getting %s %s: %w", kind, name, err)
+			default:
+				delete(obj, "status")
+				if !containsFields(live.Object, obj) {
+					change.Change = ChangeUpdate
+				}
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	managed, err := s.ListManaged(ManagedKindsForTarget(target), namespace)
+	if err != nil {
+		return nil, fmt.Errorf("listing managed resources: %w", err)
+	}
+	for _, r := range managed {
+		if !generated[r.Kind+"/"+r.Namespace+"/"+r.Name] {
+			changes = append(changes, LiveChange{Change: ChangeDelete, Kind: r.Kind, Namespace: r.Namespace, Name: r.Name})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Namespace != changes[j].Namespace {
+			return changes[i].Namespace < changes[j].Namespace
+		}
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
+// decodeObjects splits a YAML file into its non-empty documents.
+func decodeObjects(content string) ([]map[string]interface{}, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewBufferString(content)))
+
+	var objs []map[string]interface{}
+	for {
+		raw, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal(raw, &obj); err != nil {
+			return nil, fmt.Errorf("parsing generated YAML: %w", err)
+		}
+		if len(obj) > 0 {
+			objs = append(objs, obj)
+		}
+	}
+}
+
+// containsFields reports whether every field in want is present in have with
+// the same value. Maps may carry extra keys (server defaults); lists must
+// match element by element. Scalars compare by their printed form so YAML
+// float64 and JSON int64 numbers are equal.
+func containsFields(have, want interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		h, ok := have.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range w {
+			if !containsFields(h[k], v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		h, ok := have.([]interface{})
+		if !ok || len(h) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !containsFields(h[i], w[i]) {
+				return false
+			}
+		}
+		return true
+	case nil:
+		return true
+	default:
+		return fmt.Sprint(have) == fmt.Sprint(want)
+	}
+}
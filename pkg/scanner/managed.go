@@ -0,0 +1,153 @@
+package scanner
+
+import (
+	"context"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// ManagedSelector selects resources generated by ing-switch (see
+// migrator.ManagedByLabel). Rewritten Ingresses never carry it.
+const ManagedSelector = "app.kubernetes.io/managed-by=ing-switch"
+
+// ManagedKind is a resource type ing-switch may generate.
+type ManagedKind struct {
+	Kind       string
+	GVR        schema.GroupVersionResource
+	Namespaced bool
+}
+
+var (
+	traefikMiddlewareKinds = []ManagedKind{
+		{Kind: "Middleware", GVR: schema.GroupVersionResource{Group: "traefik.io", Version: "v1alpha1", Resource: "middlewares"}, Namespaced: true},
+	}
+	// traefikKinds are generated by the traefik target only
+	traefikKinds = []ManagedKind{
+		{Kind: "ServersTransport", GVR: schema.GroupVersionResource{Group: "traefik.io", Version: "v1alpha1", Resource: "serverstransports"}, Namespaced: true},
+		{Kind: "IngressRoute", GVR: schema.GroupVersionResource{Group: "traefik.io", Version: "v1alpha1", Resource: "ingressroutes"}, Namespaced: true},
+		{Kind: "TraefikService", GVR: schema.GroupVersionResource{Group: "traefik.io", Version: "v1alpha1", Resource: "traefikservices"}, Namespaced: true},
+		{Kind: "TLSStore", GVR: schema.GroupVersionResource{Group: "traefik.io", Version: "v1alpha1", Resource: "tlsstores"}, Namespaced: true},
+	}
+	gatewayAPIKinds = []ManagedKind{
+		{Kind: "HTTPRoute", GVR: schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}, Namespaced: true},
+		{Kind: "GRPCRoute", GVR: schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "grpcroutes"}, Namespaced: true},
+		{Kind: "Gateway", GVR: schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"}, Namespaced: true},
+		{Kind: "GatewayClass", GVR: schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gatewayclasses"}},
+		{Kind: "ReferenceGrant", GVR: schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "referencegrants"}, Namespaced: true},
+	}
+	traefikStreamKinds = []ManagedKind{
+		{Kind: "IngressRouteTCP", GVR: schema.GroupVersionResource{Group: "traefik.io", Version: "v1alpha1", Resource: "ingressroutetcps"}, Namespaced: true},
+		{Kind: "IngressRouteUDP", GVR: schema.GroupVersionResource{Group: "traefik.io", Version: "v1alpha1", Resource: "ingressrouteudps"}, Namespaced: true},
+	}
+	gatewayStreamKinds = []ManagedKind{
+		{Kind: "TCPRoute", GVR: schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Resource: "tcproutes"}, Namespaced: true},
+		{Kind: "UDPRoute", GVR: schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Resource: "udproutes"}, Namespaced: true},
+	}
+	// networkPolicyKinds admit the new controller to backend namespaces, on every target
+	networkPolicyKinds = []ManagedKind{
+		{Kind: "NetworkPolicy", GVR: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"}, Namespaced: true},
+	}
+	envoyPolicyKinds = []ManagedKind{
+		{Kind: "BackendTrafficPolicy", GVR: schema.GroupVersionResource{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Resource: "backendtrafficpolicies"}, Namespaced: true},
+		{Kind: "SecurityPolicy", GVR: schema.GroupVersionResource{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Resource: "securitypolicies"}, Namespaced: true},
+		{Kind: "Backend", GVR: schema.GroupVersionResource{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Resource: "backends"}, Namespaced: true},
+	}
+)
+
+// ManagedKindsForTarget returns the resource types ing-switch generates for a target.
+func ManagedKindsForTarget(target string) []ManagedKind {
+	switch target {
+	case "traefik":
+		return concatKinds(traefikMiddlewareKinds, traefikKinds, traefikStreamKinds, networkPolicyKinds)
+	case "gateway-api":
+		return concatKinds(gatewayAPIKinds, gatewayStreamKinds, envoyPolicyKinds, networkPolicyKinds)
+	case "gateway-api-traefik":
+		return concatKinds(gatewayAPIKinds, gatewayStreamKinds, traefikMiddlewareKinds, networkPolicyKinds)
+	}
+	return nil
+}
+
+func concatKinds(groups ...[]ManagedKind) []ManagedKind {
+	var kinds []ManagedKind
+	for _, g := range groups {
+		kinds = append(kinds, g...)
+	}
+	return kinds
+}
+
+// ManagedResource is a single ing-switch-managed object found in the cluster.
+type ManagedResource struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Source    string `json:"source,omitempty"` // ing-switch.io/source-ingress label
+
+	gvr schema.GroupVersionResource
+}
+
+// ListManaged returns every resource of the given kinds carrying the
+// managed-by label. Kinds whose CRD is not installed are skipped.
+// Cluster-scoped kinds are always listed cluster-wide.
+func (s *Scanner) ListManaged(kinds []ManagedKind, namespace string) ([]ManagedResource, error) {
+	dynClient, err := dynamic.NewForConfig(s.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []ManagedResource
+	for _, k := range kinds {
+		ri := dynClient.Resource(k.GVR)
+		var lister dynamic.ResourceInterface = ri
+		if k.Namespaced {
+			lister = ri.Namespace(namespace)
+		}
+		list, err := lister.List(context.Background(), metav1.ListOptions{LabelSelector: ManagedSelector})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue // CRD not installed
+			}
+			return nil, err
+		}
+		for _, item := range list.Items {
+			resources = append(resources, ManagedResource{
+				Kind:      k.Kind,
+				Namespace: item.GetNamespace(),
+				Name:      item.GetName(),
+				Source:    item.GetLabels()["ing-switch.io/source-ingress"],
+				gvr:       k.GVR,
+			})
+		}
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Namespace != resources[j].Namespace {
+			return resources[i].Namespace < resources[j].Namespace
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	return resources, nil
+}
+
+// DeleteManaged deletes a resource returned by ListManaged. With dryRun the
+// deletion is validated server-side but not persisted.
+func (s *Scanner) DeleteManaged(r ManagedResource, dryRun bool) error {
+	dynClient, err := dynamic.NewForConfig(s.restConfig)
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.DeleteOptions{}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	ri := dynClient.Resource(r.gvr)
+	if r.Namespace != "" {
+		return ri.Namespace(r.Namespace).Delete(context.Background(), r.Name, opts)
+	}
+	return ri.Delete(context.Background(), r.Name, opts)
+}
@@ -0,0 +1,154 @@
+package scanner_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator/gatewayapi"
+	"github.com/saiyam1814/ing-switch/pkg/migrator/traefik"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+	"sigs.k8s.io/yaml"
+)
+
+// extraManifests adds the inputs the examples lack for the generated kinds:
+// a mirror-target (IngressRoute, TraefikService) and an ExternalName backend
+// (Envoy Gateway Backend).
+const extraManifests = `
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: mirrored
+  namespace: shop
+  annotations:
+    nginx.ingress.kubernetes.io/mirror-target: "http://shadow.shop.svc.cluster.local$request_uri"
+spec:
+  rules:
+  - host: shop.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: external-api
+            port:
+              number: 443
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: external-api
+  namespace: shop
+spec:
+  type: ExternalName
+  externalName: api.example.net
+`
+
+var docSeparator = regexp.MustCompile(`(?m)^---[ \t]*\n`)
+
+// TestGeneratedKindsAreManaged pins ManagedKindsForTarget to what the
+// migrators generate: cleanup, live diff, status and --reconcile only see
+// the kinds listed there, so every kind carrying the managed-by label must
+// be among them.
+func TestGeneratedKindsAreManaged(t *testing.T) {
+	scan := scanExamples(t)
+
+	for _, target := range []string{"traefik", "gateway-api", "gateway-api-traefik"} {
+		t.Run(target, func(t *testing.T) {
+			managed := map[string]bool{}
+			for _, k := range scanner.ManagedKindsForTarget(target) {
+				managed[k.Kind] = true
+			}
+
+			report := analyzer.NewAnalyzer(target).Analyze(scan)
+			files, err := migrate(target, scan, report)
+			if err != nil {
+				t.Fatalf("migrate: %v", err)
+			}
+			generated := map[string]bool{}
+			for _, f := range files {
+				if !strings.HasSuffix(f.RelPath, ".yaml") {
+					continue
+				}
+				for _, doc := range docSeparator.Split(f.Content, -1) {
+					var obj struct {
+						Kind     string `json:"kind"`
+						Metadata struct {
+							Labels map[string]string `json:"labels"`
+						} `json:"metadata"`
+					}
+					if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+						t.Fatalf("%s: %v", f.RelPath, err)
+					}
+					if obj.Metadata.Labels["app.kubernetes.io/managed-by"] != "ing-switch" {
+						continue
+					}
+					generated[obj.Kind] = true
+					if !managed[obj.Kind] {
+						t.Errorf("%s: %s is labelled managed-by=ing-switch but is not in ManagedKindsForTarget(%q)", f.RelPath, obj.Kind, target)
+					}
+				}
+			}
+			for kind := range managed {
+				if !generated[kind] {
+					t.Errorf("ManagedKindsForTarget(%q) lists %s, which no test input generates", target, kind)
+				}
+			}
+		})
+	}
+}
+
+// scanExamples scans examples/*.yaml and extraManifests, with the
+// controller settings and stream services a cluster scan would add.
+func scanExamples(t *testing.T) *scanner.ScanResult {
+	t.Helper()
+	paths, err := filepath.Glob("../../examples/*.yaml")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no examples found: %v", err)
+	}
+	var docs []string
+	for _, p := range paths {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs = append(docs, string(b))
+	}
+	docs = append(docs, extraManifests)
+
+	scan, err := scanner.ScanManifests(strings.NewReader(strings.Join(docs, "\n---\n")), "", scanner.AnnotationSourceCommunity)
+	if err != nil {
+		t.Fatalf("ScanManifests: %v", err)
+	}
+	scan.Controller.Namespace = "ingress-nginx"
+	scan.Controller.DefaultSSLCertificate = "ingress-nginx/default-cert"
+	scan.StreamServices = []scanner.StreamService{
+		{Protocol: "TCP", Port: 5432, Namespace: "db", Service: "postgres", ServicePort: "5432", ConfigMap: "ingress-nginx/tcp-services"},
+		{Protocol: "UDP", Port: 53, Namespace: "dns", Service: "coredns", ServicePort: "53", ConfigMap: "ingress-nginx/udp-services"},
+	}
+	return scan
+}
+
+func migrate(target string, scan *scanner.ScanResult, report *analyzer.AnalysisReport) ([]generator.GeneratedFile, error) {
+	switch target {
+	case "traefik":
+		m := traefik.NewMigrator()
+		m.SetEmitNetworkPolicy(true)
+		return m.Migrate(scan, report)
+	case "gateway-api":
+		m := gatewayapi.NewMigrator()
+		m.SetEmitNetworkPolicy(true)
+		m.SetResourceNamespace("routes") // backends in other namespaces need ReferenceGrants
+		return m.Migrate(scan, report)
+	default:
+		m := gatewayapi.NewTraefikGatewayMigrator()
+		m.SetEmitNetworkPolicy(true)
+		m.SetResourceNamespace("routes")
+		return m.Migrate(scan, report)
+	}
+}
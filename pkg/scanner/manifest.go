@@ -0,0 +1,108 @@
+package scanner
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// ScanManifests builds a ScanResult from a multi-document YAML stream such as
+// `helm template` output. networking.k8s.io/v1 Ingress documents are parsed,
+// and v1 Services are used to resolve their backends the way a cluster scan
+// does; every other kind is skipped silently. Objects without a namespace
+// are placed in defaultNamespace (or "default" when empty). source selects
+// the nginx annotation family, as Scanner.SetAnnotationSource does.
+func ScanManifests(r io.Reader, defaultNamespace, source string) (*ScanResult, error) {
+	if defaultNamespace == "" {
+		defaultNamespace = "default"
+	}
+
+	ingresses, services, err := decodeManifests(r)
+	if err != nil {
+		return nil, err
+	}
+	for i := range services {
+		if services[i].Namespace == "" {
+			services[i].Namespace = defaultNamespace
+		}
+	}
+
+	cfg := LoadConfig()
+	var infos []IngressInfo
+	for _, ing := range ingresses {
+		if ing.Namespace == "" {
+			ing.Namespace = defaultNamespace
+		}
+		infos = append(infos, parseIngress(ing, source, cfg))
+	}
+	enrichIngresses(manifestServices(services), infos)
+	markServed(infos, ControllerInfo{})
+
+	// Sort for deterministic output
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Namespace != infos[j].Namespace {
+			return infos[i].Namespace < infos[j].Namespace
+		}
+		return infos[i].Name < infos[j].Name
+	})
+
+	return &ScanResult{
+		ClusterName: "manifests",
+		Controller:  ControllerInfo{Detected: false, Type: "unknown"},
+		Ingresses:   infos,
+		Namespaces:  extractNamespaces(infos),
+	}, nil
+}
+
+// decodeManifests splits a YAML stream into documents and returns the
+// Ingresses and Services. Lists (kind: List) are not expanded; helm template
+// never emits them.
+func decodeManifests(r io.Reader) ([]networkingv1.Ingress, []corev1.Service, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+
+	var ingresses []networkingv1.Ingress
+	var services []corev1.Service
+	for doc := 1; ; doc++ {
+		raw, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading document %d: %w", doc, err)
+		}
+
+		var meta struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		if err := yaml.Unmarshal(raw, &meta); err != nil {
+			return nil, nil, fmt.Errorf("parsing document %d: %w", doc, err)
+		}
+		if meta.Kind == "Service" && meta.APIVersion == "v1" {
+			var svc corev1.Service
+			if err := yaml.Unmarshal(raw, &svc); err != nil {
+				return nil, nil, fmt.Errorf("parsing Service in document %d: %w", doc, err)
+			}
+			services = append(services, svc)
+			continue
+		}
+		if meta.Kind != "Ingress" || meta.APIVersion != "networking.k8s.io/v1" {
+			continue
+		}
+
+		var ing networkingv1.Ingress
+		if err := yaml.Unmarshal(raw, &ing); err != nil {
+			return nil, nil, fmt.Errorf("parsing Ingress in document %d: %w", doc, err)
+		}
+		ingresses = append(ingresses, ing)
+	}
+
+	return ingresses, services, nil
+}
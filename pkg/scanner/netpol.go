@@ -0,0 +1,126 @@
+package scanner
+
+import (
+	"context"
+	"sort"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// namespaceNameLabel is set on every namespace by Kubernetes ≥1.21 and is the
+// usual way NetworkPolicies name an allowed source namespace.
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// NetworkPolicyInfo is a NetworkPolicy that restricts ingress traffic in a
+// namespace serving Ingress backends. A new controller running in its own
+// namespace is blocked by it unless that namespace is an allowed source.
+type NetworkPolicyInfo struct {
+	Namespace         string   `json:"namespace"`
+	Name              string   `json:"name"`
+	PodSelector       string   `json:"podSelector"`                 // "" selects every pod in the namespace
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"` // source namespaces named via namespaceSelector
+	Services          []string `json:"services"`                    // Ingress backend services in this namespace
+}
+
+// ScanNetworkPolicies lists NetworkPolicies that restrict ingress traffic in
+// the namespaces of the given ingresses' backends. Policies that admit every
+// source (an ingress rule without "from", or an empty namespaceSelector) are
+// skipped. Errors (e.g. RBAC) yield no results: this is an advisory check.
+func (s *Scanner) ScanNetworkPolicies(ingresses []IngressInfo) []NetworkPolicyInfo {
+	backends := make(map[string]map[string]bool) // namespace → service names
+	for _, ing := range ingresses {
+		if !ing.IsIngressResource() {
+			continue
+		}
+		for _, svc := range ing.Services {
+			if backends[svc.Namespace] == nil {
+				backends[svc.Namespace] = make(map[string]bool)
+			}
+			backends[svc.Namespace][svc.Name] = true
+		}
+	}
+
+	var result []NetworkPolicyInfo
+	for ns, services := range backends {
+		list, err := s.client.NetworkingV1().NetworkPolicies(ns).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			continue
+		}
+		var names []string
+		for name := range services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, np := range list.Items {
+			allowed, restricts := ingressSources(np)
+			if !restricts {
+				continue
+			}
+			result = append(result, NetworkPolicyInfo{
+				Namespace:         np.Namespace,
+				Name:              np.Name,
+				PodSelector:       metav1.FormatLabelSelector(&np.Spec.PodSelector),
+				AllowedNamespaces: allowed,
+				Services:          names,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// ingressSources returns the namespaces a policy admits by name and whether
+// it restricts ingress traffic at all.
+func ingressSources(np networkingv1.NetworkPolicy) (allowed []string, restricts bool) {
+	// Without policyTypes every policy affects ingress (see NetworkPolicySpec).
+	affectsIngress := len(np.Spec.PolicyTypes) == 0
+	for _, t := range np.Spec.PolicyTypes {
+		if t == networkingv1.PolicyTypeIngress {
+			affectsIngress = true
+		}
+	}
+	if !affectsIngress {
+		return nil, false
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range np.Spec.Ingress {
+		if len(rule.From) == 0 {
+			return nil, false // allows all sources
+		}
+		for _, peer := range rule.From {
+			sel := peer.NamespaceSelector
+			if sel == nil {
+				continue
+			}
+			if len(sel.MatchLabels) == 0 && len(sel.MatchExpressions) == 0 && peer.PodSelector == nil {
+				return nil, false // every pod in every namespace
+			}
+			var names []string
+			if name, ok := sel.MatchLabels[namespaceNameLabel]; ok {
+				names = append(names, name)
+			}
+			for _, expr := range sel.MatchExpressions {
+				if expr.Key == namespaceNameLabel && expr.Operator == metav1.LabelSelectorOpIn {
+					names = append(names, expr.Values...)
+				}
+			}
+			for _, name := range names {
+				if !seen[name] {
+					seen[name] = true
+					allowed = append(allowed, name)
+				}
+			}
+		}
+	}
+	sort.Strings(allowed)
+	return allowed, true
+}
@@ -0,0 +1,160 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+)
+
+// Reconcile actions, as in Reconciled.Action.
+const (
+	ReconcileCreate = "create" // not in the cluster: applied as generated
+	ReconcilePatch  = "patch"  // in the cluster without some generated fields: those are patched in
+	ReconcileSkip   = "skip"   // in the cluster with every generated field it can take
+)
+
+// The managedByLabel set to managedByValue claims a resource for ing-switch
+// cleanup (see ManagedSelector), which a resource written by hand must not
+// get from a patch.
+const (
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "ing-switch"
+)
+
+// Reconciled is what migrate --reconcile does with one generated resource
+// that may already exist in the cluster.
+type Reconciled struct {
+	Action    string `json:"action"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Resource is the kubectl resource name, e.g.
+	// httproutes.gateway.networking.k8s.io.
+	Resource string `json:"resource"`
+	// Patch holds the generated fields the live object lacks, as a JSON
+	// merge patch (ReconcilePatch only).
+	Patch map[string]interface{} `json:"patch,omitempty"`
+	// Kept are the fields, as dotted paths, the live object sets to another
+	// value than the generated one. They are left as they are.
+	Kept []string `json:"kept,omitempty"`
+}
+
+// Reconciler compares generated resources with the ones already in the
+// cluster, e.g. written by hand or applied by an earlier run, so that they
+// are completed instead of replaced.
+type Reconciler struct {
+	client dynamic.Interface
+	byKind map[string]ManagedKind
+}
+
+// NewReconciler returns a Reconciler for the resources ing-switch generates
+// for target. The rewritten Ingresses of the traefik target are not among
+// them: they are the user's Ingresses, updated on purpose.
+func (s *Scanner) NewReconciler(target string) (*Reconciler, error) {
+	client, err := dynamic.NewForConfig(s.restConfig)
+	if err != nil {
+		return nil, err
+	}
+	byKind := map[string]ManagedKind{}
+	for _, k := range ManagedKindsForTarget(target) {
+		byKind[k.Kind] = k
+	}
+	return &Reconciler{client: client, byKind: byKind}, nil
+}
+
+// Reconcile compares the single resource in doc with its live version. ok is
+// false when doc holds no resource of a kind the Reconciler handles, which is
+// then applied as generated. A resource labelled as managed by ing-switch
+// whose kind the Reconciler cannot look up is an error: applying it as
+// generated would replace a live copy instead of completing it.
+func (r *Reconciler) Reconcile(doc string) (rec Reconciled, ok bool, err error) {
+	objs, err := decodeObjects(doc)
+	if err != nil || len(objs) != 1 {
+		return Reconciled{}, false, err
+	}
+	obj := objs[0]
+	kind, _ := obj["kind"].(string)
+	meta, _ := obj["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	ns, _ := meta["namespace"].(string)
+	k, ok := r.byKind[kind]
+	if !ok {
+		if labels, _ := meta["labels"].(map[string]interface{}); labels[managedByLabel] == managedByValue {
+			return Reconciled{}, false, fmt.Errorf("%s %s is generated by ing-switch but is not a kind --reconcile can look up", kind, name)
+		}
+		return Reconciled{}, false, nil
+	}
+	if !k.Namespaced {
+		ns = ""
+	} else if ns == "" {
+		ns = "default"
+	}
+	rec = Reconciled{Action: ReconcileCreate, Kind: kind, Namespace: ns, Name: name, Resource: k.GVR.Resource + "." + k.GVR.Group}
+
+	var getter dynamic.ResourceInterface = r.client.Resource(k.GVR)
+	if k.Namespaced {
+		getter = r.client.Resource(k.GVR).Namespace(ns)
+	}
+	live, err := getter.Get(context.Background(), name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return rec, true, nil
+	case err != nil:
+		return Reconciled{}, false, fmt.Errorf("getting %s %s: %w", kind, name, err)
+	}
+
+	delete(obj, "status")
+	if labels, _ := meta["labels"].(map[string]interface{}); labels != nil {
+		delete(labels, managedByLabel)
+		if len(labels) == 0 {
+			delete(meta, "labels")
+		}
+	}
+	rec.Patch, rec.Kept = gapPatch(live.Object, obj, "")
+	sort.Strings(rec.Kept)
+	rec.Action = ReconcileSkip
+	if len(rec.Patch) > 0 {
+		rec.Action = ReconcilePatch
+	}
+	return rec, true, nil
+}
+
+// gapPatch returns the fields of want that have does not set, as a merge
+// patch, and the paths (under prefix) where have sets another value. Maps
+// are compared key by key; any other value, lists included, is patched in
+// whole when missing and kept when it differs, as a merge patch would
+// replace a list outright.
+func gapPatch(have, want map[string]interface{}, prefix string) (patch map[string]interface{}, kept []string) {
+	patch = map[string]interface{}{}
+	for key, w := range want {
+		h, present := have[key]
+		switch {
+		case !present || h == nil:
+			if w != nil {
+				patch[key] = w
+			}
+		case containsFields(h, w):
+		default:
+			hm, hIsMap := h.(map[string]interface{})
+			wm, wIsMap := w.(map[string]interface{})
+			if !hIsMap || !wIsMap {
+				kept = append(kept, prefix+key)
+				continue
+			}
+			sub, subKept := gapPatch(hm, wm, prefix+key+".")
+			if len(sub) > 0 {
+				patch[key] = sub
+			}
+			kept = append(kept, subKept...)
+		}
+	}
+	if len(patch) == 0 {
+		return nil, kept
+	}
+	return patch, kept
+}
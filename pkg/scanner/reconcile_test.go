@@ -0,0 +1,44 @@
+package scanner
+
+import "testing"
+
+func TestReconcileKindsOutsideTarget(t *testing.T) {
+	r := &Reconciler{byKind: map[string]ManagedKind{}}
+
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr bool
+	}{
+		{
+			name: "managed kind the reconciler cannot look up",
+			doc: `apiVersion: example.io/v1
+kind: Widget
+metadata:
+  name: web
+  labels:
+    app.kubernetes.io/managed-by: ing-switch
+`,
+			wantErr: true,
+		},
+		{
+			name: "unmanaged kind is applied as generated",
+			doc: `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok, err := r.Reconcile(tt.doc)
+			if ok {
+				t.Errorf("Reconcile ok = true, want false")
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Reconcile error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package scanner
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RedirectPortUnknown is the use-port-in-redirects pseudo-annotation value
+// when the setting is on but the controller's HTTPS port could not be read.
+const RedirectPortUnknown = "unknown"
+
+// ScanRedirectPort returns the port ingress-nginx names in its HTTP→HTTPS
+// redirects when its ConfigMap sets use-port-in-redirects: the controller's
+// --https-port, 443 by default. It returns "" when the setting is off or the
+// ConfigMap cannot be read, and RedirectPortUnknown when the controller pod
+// cannot be read.
+func (s *Scanner) ScanRedirectPort(controller ControllerInfo) string {
+	_, data, ok := s.controllerConfigMap(controller)
+	if !ok || data["use-port-in-redirects"] != "true" {
+		return ""
+	}
+	if controller.PodName == "" {
+		return RedirectPortUnknown
+	}
+	pod, err := s.client.CoreV1().Pods(controller.Namespace).Get(context.Background(), controller.PodName, metav1.GetOptions{})
+	if err != nil {
+		return RedirectPortUnknown
+	}
+	if port := containerFlag(pod.Spec.Containers, "--https-port"); port != "" {
+		return port
+	}
+	return "443"
+}
+
+// applyRedirectPort gives every ingress-nginx Ingress that redirects to HTTPS
+// the use-port-in-redirects pseudo-annotation with port, so the migrators
+// name the same port in their redirects: without it the new controller
+// redirects to 443. The default port needs nothing and is not recorded.
+func applyRedirectPort(ingresses []IngressInfo, port string) {
+	if port == "" || port == "443" {
+		return
+	}
+	for i := range ingresses {
+		ann := ingresses[i].NginxAnnotations
+		if ingresses[i].SourceType != SourceNginxIngress || (ann["ssl-redirect"] != "true" && ann["force-ssl-redirect"] != "true") {
+			continue
+		}
+		ann["use-port-in-redirects"] = port
+	}
+}
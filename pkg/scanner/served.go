@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ingressClassControllers is the IngressClass spec.controller each detected
+// controller type implements by default, and legacyIngressClasses the class
+// name it serves when no IngressClass resource names it.
+var (
+	ingressClassControllers = map[string]string{
+		"ingress-nginx": "k8s.io/ingress-nginx",
+		"traefik":       "traefik.io/ingress-controller",
+		"kong":          "ingress-controllers.konghq.com/kong",
+		"haproxy":       "haproxy-ingress.github.io/controller",
+	}
+	legacyIngressClasses = map[string]string{
+		"ingress-nginx": "nginx",
+		"traefik":       "traefik",
+		"kong":          "kong",
+		"haproxy":       "haproxy",
+	}
+)
+
+// sourceControllers is the controller type that serves each Ingress-based
+// source. Only ingresses whose controller was the one detected are checked.
+var sourceControllers = map[SourceType]string{
+	SourceNginxIngress:   "ingress-nginx",
+	SourceKongIngress:    "kong",
+	SourceHAProxyIngress: "haproxy",
+}
+
+const defaultClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+
+// scanIngressClasses sets the IngressClasses the detected controller serves:
+// those whose spec.controller is its controller class (--controller-class
+// for ingress-nginx), plus --ingress-class and the legacy class name.
+// Ingresses without a class are served when the default IngressClass is one
+// of them, or ingress-nginx runs with --watch-ingress-without-class.
+func (s *Scanner) scanIngressClasses(controller *ControllerInfo) {
+	controllerClass, ok := ingressClassControllers[controller.Type]
+	if !ok {
+		return
+	}
+	classes := []string{legacyIngressClasses[controller.Type]}
+
+	var containers []corev1.Container
+	if controller.PodName != "" {
+		pod, err := s.client.CoreV1().Pods(controller.Namespace).Get(context.Background(), controller.PodName, metav1.GetOptions{})
+		if err == nil {
+			containers = pod.Spec.Containers
+		}
+	}
+	if v := containerFlag(containers, "--controller-class"); v != "" {
+		controllerClass = v
+	}
+	if v := containerFlag(containers, "--ingress-class"); v != "" {
+		classes = append(classes, v)
+	}
+	if containerFlag(containers, "--watch-ingress-without-class") == "true" {
+		controller.ServesUnclassed = true
+	}
+
+	list, err := s.client.NetworkingV1().IngressClasses().List(context.Background(), metav1.ListOptions{})
+	if err == nil {
+		for _, ic := range list.Items {
+			if ic.Spec.Controller != controllerClass {
+				continue
+			}
+			classes = append(classes, ic.Name)
+			if ic.Annotations[defaultClassAnnotation] == "true" {
+				controller.ServesUnclassed = true
+			}
+		}
+	}
+
+	slices.Sort(classes)
+	controller.IngressClasses = slices.Compact(classes)
+}
+
+// markServed sets Served on each ingress: false only when its controller was
+// detected and its class is not one the controller serves. Without a
+// detected controller (or for CRD sources, which have no class) nothing is
+// known, so everything counts as served.
+func markServed(ingresses []IngressInfo, controller ControllerInfo) {
+	for i := range ingresses {
+		ing := &ingresses[i]
+		ing.Served = true
+		if !controller.Detected || len(controller.IngressClasses) == 0 ||
+			sourceControllers[ing.SourceType] != controller.Type {
+			continue
+		}
+		if ing.IngressClass == "" {
+			ing.Served = controller.ServesUnclassed
+		} else {
+			ing.Served = slices.Contains(controller.IngressClasses, ing.IngressClass)
+		}
+	}
+}
+
+// containerFlag returns the value of a "--flag=value" or "--flag value"
+// argument, or "" when no container sets it. A bare boolean flag is "true".
+func containerFlag(containers []corev1.Container, flag string) string {
+	for _, c := range containers {
+		for i, arg := range c.Args {
+			if v, ok := strings.CutPrefix(arg, flag+"="); ok {
+				return v
+			}
+			if arg == flag {
+				if i+1 < len(c.Args) && !strings.HasPrefix(c.Args[i+1], "-") {
+					return c.Args[i+1]
+				}
+				return "true"
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,236 @@
+package scanner
+
+import (
+	"strings"
+)
+
+// Pseudo-annotations for header removal recognized in a configuration-snippet.
+// Values are comma-separated header names.
+const (
+	removeResponseHeadersKey = "custom-headers-remove"
+	removeRequestHeadersKey  = "custom-request-headers-remove"
+)
+
+// setRequestHeadersKey is the pseudo-annotation for request headers a
+// configuration-snippet sets with proxy_set_header. Values are "Name: value"
+// lines; see ForwardedVariables for the values that are not literal.
+const setRequestHeadersKey = "custom-request-headers"
+
+// ForwardedVariables are the nginx variables a proxy_set_header in a
+// configuration-snippet may use besides literal values, mapped to the
+// request header both targets pass the same value in without being told:
+// the client address, the scheme and the host.
+var ForwardedVariables = map[string]string{
+	"$remote_addr":               "X-Forwarded-For",
+	"$proxy_add_x_forwarded_for": "X-Forwarded-For",
+	"$scheme":                    "X-Forwarded-Proto",
+	"$host":                      "Host",
+	"$http_host":                 "Host",
+}
+
+// authRequestHeadersKey is the pseudo-annotation for the only request headers
+// an auth-snippet sends to the auth service. Values are comma-separated
+// header names.
+const authRequestHeadersKey = "auth-request-headers"
+
+// snippetHeaderDirectives maps the nginx directives that remove a header to
+// the pseudo-annotation collecting it. proxy_set_header with an empty value
+// is handled separately: it stops nginx passing the header upstream.
+var snippetHeaderDirectives = map[string]string{
+	"more_clear_headers":       removeResponseHeadersKey,
+	"proxy_hide_header":        removeResponseHeadersKey,
+	"more_clear_input_headers": removeRequestHeadersKey,
+}
+
+// extractSnippetHeaders recognizes header removal in configuration-snippet
+// ("more_clear_headers Server;", the usual way to hide the Server header) and
+// records it as custom-headers-remove / custom-request-headers-remove, which
+// the migrators turn into header middleware or filters. Request headers set
+// with proxy_set_header to a literal or one of ForwardedVariables are
+// recorded as custom-request-headers the same way. When the snippet does
+// nothing else it is dropped, so the ingress no longer reports an
+// unsupported snippet; otherwise it is kept for review.
+func extractSnippetHeaders(info *IngressInfo) {
+	snippet, ok := info.NginxAnnotations["configuration-snippet"]
+	if !ok {
+		return
+	}
+
+	removed := map[string][]string{}
+	var set []string
+	other := false
+	for _, stmt := range strings.Split(stripSnippetComments(snippet), ";") {
+		fields := snippetFields(stmt)
+		if len(fields) == 0 {
+			continue
+		}
+		directive, args := fields[0], fields[1:]
+		key, isRemoval := snippetHeaderDirectives[directive]
+		switch {
+		case directive == "proxy_set_header" && len(args) == 2 && args[1] == "":
+			removed[removeRequestHeadersKey] = append(removed[removeRequestHeadersKey], args[0])
+		case directive == "proxy_set_header" && len(args) == 2 && plainHeaderNames(args[:1]) && translatableHeaderValue(args[1]):
+			set = append(set, args[0]+": "+args[1])
+		case isRemoval && len(args) > 0 && plainHeaderNames(args):
+			removed[key] = append(removed[key], args...)
+		default:
+			// Other directives, and removal with -s/-t conditions or
+			// wildcards, which header filters cannot express.
+			other = true
+		}
+	}
+	if len(removed) == 0 && len(set) == 0 {
+		return
+	}
+
+	for key, headers := range removed {
+		if prev := info.NginxAnnotations[key]; prev != "" {
+			headers = append(strings.Split(prev, ","), headers...)
+		}
+		info.NginxAnnotations[key] = strings.Join(dedupeHeaders(headers), ",")
+	}
+	if len(set) > 0 {
+		if prev := info.NginxAnnotations[setRequestHeadersKey]; prev != "" {
+			set = append(strings.Split(prev, "\n"), set...)
+		}
+		info.NginxAnnotations[setRequestHeadersKey] = strings.Join(set, "\n")
+	}
+	if !other {
+		delete(info.NginxAnnotations, "configuration-snippet")
+	}
+}
+
+// extractAuthSnippetHeaders recognizes the auth-snippet that limits the auth
+// subrequest to some of the client's headers:
+//
+//	proxy_pass_request_headers off;
+//	proxy_set_header Authorization $http_authorization;
+//
+// and records the headers as auth-request-headers, which the migrators turn
+// into ForwardAuth authRequestHeaders or extAuth headersToExtAuth. Passing a
+// header through without proxy_pass_request_headers off changes nothing (the
+// auth subrequest already gets every header), and ingress-nginx already
+// drops the body, so those statements are recognized too. As for
+// configuration-snippet, the snippet is dropped when it does nothing else.
+func extractAuthSnippetHeaders(info *IngressInfo) {
+	snippet, ok := info.NginxAnnotations["auth-snippet"]
+	if !ok {
+		return
+	}
+
+	var headers []string
+	only, other := false, false
+	for _, stmt := range strings.Split(stripSnippetComments(snippet), ";") {
+		fields := snippetFields(stmt)
+		if len(fields) == 0 {
+			continue
+		}
+		switch {
+		case len(fields) == 2 && fields[0] == "proxy_pass_request_headers" && fields[1] == "off":
+			only = true
+		case len(fields) == 2 && fields[0] == "proxy_pass_request_body" && fields[1] == "off":
+		case len(fields) == 3 && fields[0] == "proxy_set_header" && strings.EqualFold(fields[1], "Content-Length") && fields[2] == "":
+		case len(fields) == 3 && fields[0] == "proxy_set_header" && plainHeaderNames(fields[1:2]) && fields[2] == headerVariable(fields[1]):
+			headers = append(headers, fields[1])
+		default:
+			other = true
+		}
+	}
+	if only && len(headers) == 0 {
+		// No header at all: neither target can express it
+		return
+	}
+
+	if only {
+		info.NginxAnnotations[authRequestHeadersKey] = strings.Join(dedupeHeaders(headers), ",")
+	}
+	if !other {
+		delete(info.NginxAnnotations, "auth-snippet")
+	}
+}
+
+// translatableHeaderValue reports whether a proxy_set_header value is a
+// literal, or a variable in ForwardedVariables. Any other variable (a
+// cookie, another header, $request_id) has no equivalent on the targets.
+func translatableHeaderValue(value string) bool {
+	if _, ok := ForwardedVariables[value]; ok {
+		return true
+	}
+	return !strings.Contains(value, "$")
+}
+
+// headerVariable returns the nginx variable holding request header name,
+// e.g. $http_x_api_key for X-Api-Key.
+func headerVariable(name string) string {
+	return "$http_" + strings.ReplaceAll(strings.ToLower(name), "-", "_")
+}
+
+// stripSnippetComments removes "# ..." comments line by line.
+func stripSnippetComments(snippet string) string {
+	lines := strings.Split(snippet, "\n")
+	for i, line := range lines {
+		if j := strings.Index(line, "#"); j >= 0 {
+			lines[i] = line[:j]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// snippetFields splits one nginx statement into words, unquoting single- and
+// double-quoted arguments (an empty quoted argument becomes an empty string).
+func snippetFields(stmt string) []string {
+	var fields []string
+	var cur strings.Builder
+	var quote rune
+	inField := false
+	for _, r := range stmt {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inField = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inField {
+				fields = append(fields, cur.String())
+				cur.Reset()
+				inField = false
+			}
+		default:
+			cur.WriteRune(r)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, cur.String())
+	}
+	return fields
+}
+
+// plainHeaderNames reports whether every argument is a literal header name:
+// no option flags (-s 404, -t text/html) and no wildcards.
+func plainHeaderNames(args []string) bool {
+	for _, a := range args {
+		if a == "" || strings.HasPrefix(a, "-") || strings.ContainsAny(a, "*$") {
+			return false
+		}
+	}
+	return true
+}
+
+// dedupeHeaders drops repeated names, comparing case-insensitively as HTTP does.
+func dedupeHeaders(headers []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, h := range headers {
+		if k := strings.ToLower(h); !seen[k] {
+			seen[k] = true
+			out = append(out, h)
+		}
+	}
+	return out
+}
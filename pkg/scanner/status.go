@@ -0,0 +1,184 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+// Resource states, as in ResourceStatus.State.
+const (
+	StateProgrammed = "programmed" // the controller serves it
+	StateAccepted   = "accepted"   // the controller took it, nothing more to report
+	StateStored     = "stored"     // exists; its status has nothing to report
+	StatePending    = "pending"    // no verdict from the controller yet
+	StateRejected   = "rejected"
+	StateMissing    = "missing"
+)
+
+// ResourceStatus is what the target controller reported for one generated
+// resource.
+type ResourceStatus struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Reason    string `json:"reason,omitempty"` // condition reason and message when not accepted
+}
+
+// Settled reports whether the state is final: only pending resources may
+// still change.
+func (r ResourceStatus) Settled() bool {
+	return r.State != StatePending
+}
+
+// ResourceStatuses reads the live status of every resource in the generated
+// YAML documents from its conditions: Accepted for GatewayClasses, Programmed
+// for Gateways, Accepted and ResolvedRefs on every parent of a route or
+// ancestor of an Envoy Gateway policy, and the load balancer address of an
+// Ingress. Kinds ing-switch does not generate for target are skipped, as in
+// DiffAgainstLive.
+func (s *Scanner) ResourceStatuses(docs []string, target string) ([]ResourceStatus, error) {
+	dynClient, err := dynamic.NewForConfig(s.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	byKind := map[string]ManagedKind{}
+	for _, k := range append([]ManagedKind{ingressKind}, ManagedKindsForTarget(target)...) {
+		byKind[k.Kind] = k
+	}
+
+	var statuses []ResourceStatus
+	for _, doc := range docs {
+		objs, err := decodeObjects(doc)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			kind, _ := obj["kind"].(string)
+			k, ok := byKind[kind]
+			if !ok {
+				continue
+			}
+			meta, _ := obj["metadata"].(map[string]interface{})
+			name, _ := meta["name"].(string)
+			ns, _ := meta["namespace"].(string)
+			if !k.Namespaced {
+				ns = ""
+			} else if ns == "" {
+				ns = "default"
+			}
+
+			ri := dynClient.Resource(k.GVR)
+			var getter dynamic.ResourceInterface = ri
+			if k.Namespaced {
+				getter = ri.Namespace(ns)
+			}
+			status := ResourceStatus{Kind: kind, Namespace: ns, Name: name}
+			live, err := getter.Get(context.Background(), name, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+				status.State = StateMissing
+			case err != nil:
+				return nil, fmt.Errorf("getting %s %s: %w", kind, name, err)
+			default:
+				status.State, status.Reason = liveState(kind, live.Object)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+// liveState derives the state of a live object of kind from its status.
+func liveState(kind string, obj map[string]interface{}) (state, reason string) {
+	switch kind {
+	case "GatewayClass":
+		return conditionState(obj, "Accepted", StateAccepted, "status", "conditions")
+	case "Gateway":
+		return conditionState(obj, "Programmed", StateProgrammed, "status", "conditions")
+	case "HTTPRoute", "TCPRoute", "UDPRoute":
+		return parentsState(obj, "parents")
+	case "BackendTrafficPolicy", "SecurityPolicy":
+		return parentsState(obj, "ancestors")
+	case "Ingress":
+		lb, _, _ := unstructured.NestedSlice(obj, "status", "loadBalancer", "ingress")
+		if len(lb) == 0 {
+			// Not a failure: Traefik only publishes an address with
+			// publishedService set and a LoadBalancer that got one
+			return StateStored, "no load balancer address in its status"
+		}
+		return StateProgrammed, ""
+	}
+	return StateStored, ""
+}
+
+// conditionState maps the condType condition at fields in obj to ok when
+// True, rejected when False, and pending when not reported yet (or False
+// with reason Pending).
+func conditionState(obj map[string]interface{}, condType, ok string, fields ...string) (state, reason string) {
+	conds, _, _ := unstructured.NestedSlice(obj, fields...)
+	for _, c := range conds {
+		m, isMap := c.(map[string]interface{})
+		if !isMap || m["type"] != condType {
+			continue
+		}
+		if m["status"] == "True" {
+			return ok, ""
+		}
+		// Reason Pending is the Gateway API's "not done yet", e.g. a
+		// Gateway waiting for its load balancer
+		if m["status"] == "False" && m["reason"] != "Pending" {
+			return StateRejected, conditionReason(condType, m)
+		}
+	}
+	return StatePending, condType + " not reported yet"
+}
+
+// parentsState combines the Accepted and ResolvedRefs conditions of every
+// entry of status.<field> (a route's parents, a policy's ancestors). Policies
+// report no ResolvedRefs, which is not held against them.
+func parentsState(obj map[string]interface{}, field string) (state, reason string) {
+	parents, _, _ := unstructured.NestedSlice(obj, "status", field)
+	if len(parents) == 0 {
+		return StatePending, "no status." + field + " reported yet"
+	}
+	state = StateAccepted
+	var reasons []string
+	for _, p := range parents {
+		m, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, condType := range []string{"Accepted", "ResolvedRefs"} {
+			st, r := conditionState(m, condType, StateAccepted, "conditions")
+			switch {
+			case st == StateRejected:
+				state = StateRejected
+				reasons = append(reasons, r)
+			case st == StatePending && condType == "Accepted" && state != StateRejected:
+				state = StatePending
+				reasons = append(reasons, r)
+			}
+		}
+	}
+	return state, strings.Join(reasons, "; ")
+}
+
+// conditionReason renders a False condition as "Type: Reason — message".
+func conditionReason(condType string, cond map[string]interface{}) string {
+	reason := condType
+	if r, _ := cond["reason"].(string); r != "" {
+		reason += ": " + r
+	}
+	if msg, _ := cond["message"].(string); msg != "" {
+		reason += " — " + msg
+	}
+	return reason
+}
@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StreamService is a raw TCP/UDP port exposed by ingress-nginx through its
+// tcp-services / udp-services ConfigMaps. These are not Ingress resources,
+// so they are easy to miss during a migration.
+type StreamService struct {
+	Protocol    string `json:"protocol"` // "TCP" | "UDP"
+	Port        int32  `json:"port"`     // port exposed by the controller
+	Namespace   string `json:"namespace"`
+	Service     string `json:"service"`
+	ServicePort string `json:"servicePort"`           // number or named port
+	DecodeProxy bool   `json:"decodeProxy,omitempty"` // accept PROXY protocol from clients
+	EncodeProxy bool   `json:"encodeProxy,omitempty"` // send PROXY protocol to the backend
+	ConfigMap   string `json:"configMap"`             // "namespace/name" it was read from
+}
+
+// Default ConfigMap names: the upstream manifests use tcp-services/udp-services,
+// the Helm chart names them <release>-tcp/<release>-udp.
+var streamConfigMaps = map[string][]string{
+	"TCP": {"tcp-services", "ingress-nginx-tcp"},
+	"UDP": {"udp-services", "ingress-nginx-udp"},
+}
+
+// ScanStreamServices reads the ingress-nginx TCP/UDP ConfigMaps. The names
+// come from the controller's --tcp-services-configmap/--udp-services-configmap
+// flags when the pod is known, otherwise the default names are tried in the
+// controller namespace. Errors are ignored: missing ConfigMaps simply mean
+// no stream services.
+func (s *Scanner) ScanStreamServices(controller ControllerInfo) []StreamService {
+	ns := controller.Namespace
+	if ns == "" {
+		ns = "ingress-nginx"
+	}
+
+	refs := map[string][]string{}
+	for proto, names := range streamConfigMaps {
+		for _, n := range names {
+			refs[proto] = append(refs[proto], ns+"/"+n)
+		}
+	}
+	if controller.Type == "ingress-nginx" && controller.PodName != "" {
+		pod, err := s.client.CoreV1().Pods(ns).Get(context.Background(), controller.PodName, metav1.GetOptions{})
+		if err == nil {
+			for _, c := range pod.Spec.Containers {
+				for _, arg := range c.Args {
+					if v, ok := strings.CutPrefix(arg, "--tcp-services-configmap="); ok {
+						refs["TCP"] = []string{v}
+					}
+					if v, ok := strings.CutPrefix(arg, "--udp-services-configmap="); ok {
+						refs["UDP"] = []string{v}
+					}
+				}
+			}
+		}
+	}
+
+	var services []StreamService
+	for _, proto := range []string{"TCP", "UDP"} {
+		for _, ref := range refs[proto] {
+			cmNS, cmName, ok := strings.Cut(ref, "/")
+			if !ok {
+				continue
+			}
+			cm, err := s.client.CoreV1().ConfigMaps(cmNS).Get(context.Background(), cmName, metav1.GetOptions{})
+			if err != nil {
+				continue
+			}
+			services = append(services, ParseStreamServices(proto, ref, cm.Data)...)
+		}
+	}
+	return services
+}
+
+// ParseStreamServices parses tcp-services/udp-services ConfigMap data. Each
+// entry maps an exposed port to "namespace/service:port[:PROXY][:PROXY]",
+// where the first PROXY decodes and the second encodes the PROXY protocol.
+// Malformed entries are skipped.
+func ParseStreamServices(protocol, configMap string, data map[string]string) []StreamService {
+	var services []StreamService
+	for key, value := range data {
+		port, err := strconv.ParseInt(strings.TrimSpace(key), 10, 32)
+		if err != nil {
+			continue
+		}
+		parts := strings.Split(strings.TrimSpace(value), ":")
+		if len(parts) < 2 {
+			continue
+		}
+		ns, svc, ok := strings.Cut(parts[0], "/")
+		if !ok || ns == "" || svc == "" {
+			continue
+		}
+		services = append(services, StreamService{
+			Protocol:    protocol,
+			Port:        int32(port),
+			Namespace:   ns,
+			Service:     svc,
+			ServicePort: parts[1],
+			DecodeProxy: len(parts) > 2 && parts[2] == "PROXY",
+			EncodeProxy: len(parts) > 3 && parts[3] == "PROXY",
+			ConfigMap:   configMap,
+		})
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Port < services[j].Port })
+	return services
+}
@@ -0,0 +1,95 @@
+package scanner
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Target controller types, as in TargetController.Type.
+const (
+	TargetTraefik      = "traefik"
+	TargetEnvoyGateway = "envoy-gateway"
+)
+
+// helmReleaseAnnotation is set by Helm on every resource of a release.
+const helmReleaseAnnotation = "meta.helm.sh/release-name"
+
+// TargetController is a migration target controller already running in the
+// cluster. migrate upgrades it in place rather than installing a second one.
+type TargetController struct {
+	Type       string `json:"type"` // TargetTraefik | TargetEnvoyGateway
+	Namespace  string `json:"namespace"`
+	Version    string `json:"version"`
+	Deployment string `json:"deployment,omitempty"`
+	Release    string `json:"release,omitempty"` // Helm release; "" when not installed with Helm
+}
+
+// targetCandidates are the label selectors and namespaces each target
+// controller's pods are looked for in.
+var targetCandidates = map[string]struct {
+	selectors  []string
+	namespaces []string
+}{
+	TargetTraefik: {
+		selectors:  []string{"app.kubernetes.io/name=traefik", "app=traefik"},
+		namespaces: []string{"traefik", "kube-system", "default", "ingress"},
+	},
+	TargetEnvoyGateway: {
+		selectors:  []string{"app.kubernetes.io/name=envoy-gateway", "app=envoy-gateway", "control-plane=envoy-gateway"},
+		namespaces: []string{"envoy-gateway-system", "kube-system", "default"},
+	},
+}
+
+// TargetControllerType returns the controller type serving a migrate target.
+func TargetControllerType(target string) string {
+	if target == "gateway-api" {
+		return TargetEnvoyGateway
+	}
+	return TargetTraefik
+}
+
+// DetectTargetController looks for a running pod of the given controller
+// type and the Deployment (and Helm release) it belongs to.
+func DetectTargetController(ctx context.Context, client kubernetes.Interface, controllerType string) (TargetController, bool) {
+	cand := targetCandidates[controllerType]
+	for _, ns := range cand.namespaces {
+		for _, sel := range cand.selectors {
+			pods, err := client.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{LabelSelector: sel, Limit: 1})
+			if err != nil || len(pods.Items) == 0 {
+				continue
+			}
+			pod := pods.Items[0]
+			if pod.Status.Phase != corev1.PodRunning {
+				continue
+			}
+			tc := TargetController{Type: controllerType, Namespace: ns}
+			for _, c := range pod.Spec.Containers {
+				if parts := strings.Split(c.Image, ":"); len(parts) >= 2 {
+					tc.Version = parts[len(parts)-1]
+				}
+			}
+			deps, err := client.AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{LabelSelector: sel, Limit: 1})
+			if err == nil && len(deps.Items) > 0 {
+				tc.Deployment = deps.Items[0].Name
+				tc.Release = deps.Items[0].Annotations[helmReleaseAnnotation]
+			}
+			return tc, true
+		}
+	}
+	return TargetController{}, false
+}
+
+// scanTargetControllers finds every target controller already running.
+func (s *Scanner) scanTargetControllers() []TargetController {
+	var found []TargetController
+	for _, t := range []string{TargetTraefik, TargetEnvoyGateway} {
+		if tc, ok := DetectTargetController(context.Background(), s.client, t); ok {
+			found = append(found, tc)
+		}
+	}
+	return found
+}
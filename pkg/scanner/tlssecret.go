@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecretIssue is a TLS secret an ingress references that cannot serve its
+// hosts: it does not exist in the ingress namespace, or is not of type
+// kubernetes.io/tls.
+type SecretIssue struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"` // the Secret's type; "" when it does not exist
+}
+
+// Missing reports whether the secret does not exist.
+func (i SecretIssue) Missing() bool {
+	return i.Type == ""
+}
+
+// checkTLSSecrets records the TLS secrets of each ingress that are missing
+// or mistyped. The generated Gateway certificateRefs and Traefik TLS blocks
+// name the same secrets, so TLS fails after cutover just as it is broken
+// now. Secrets that cannot be read, e.g. when RBAC denies it, are not
+// reported: only a NotFound proves one missing.
+func (s *Scanner) checkTLSSecrets(ingresses []IngressInfo) {
+	cache := make(map[string]*SecretIssue) // "ns/name" → issue; nil when fine or unreadable
+	for i := range ingresses {
+		ing := &ingresses[i]
+		for _, name := range ing.TLSSecrets {
+			key := ing.Namespace + "/" + name
+			issue, cached := cache[key]
+			if !cached {
+				issue = s.tlsSecretIssue(ing.Namespace, name)
+				cache[key] = issue
+			}
+			if issue != nil {
+				ing.TLSSecretIssues = append(ing.TLSSecretIssues, *issue)
+			}
+		}
+	}
+}
+
+// tlsSecretIssue returns what is wrong with the named secret, nil when it is
+// a kubernetes.io/tls Secret or cannot be read.
+func (s *Scanner) tlsSecretIssue(namespace, name string) *SecretIssue {
+	secret, err := s.client.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return &SecretIssue{Name: name}
+	case err != nil:
+		return nil
+	case secret.Type != corev1.SecretTypeTLS:
+		typ := secret.Type
+		if typ == "" {
+			typ = corev1.SecretTypeOpaque // the API server's default
+		}
+		return &SecretIssue{Name: name, Type: string(typ)}
+	}
+	return nil
+}
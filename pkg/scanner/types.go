@@ -1,20 +1,107 @@
 package scanner
 
+import "strings"
+
 // ScanResult is the complete output of a cluster scan.
 type ScanResult struct {
-	ClusterName string          `json:"clusterName"`
-	Controller  ControllerInfo  `json:"controller"`
-	Ingresses   []IngressInfo   `json:"ingresses"`
-	Namespaces  []string        `json:"namespaces"`
+	ClusterName       string              `json:"clusterName"`
+	Controller        ControllerInfo      `json:"controller"`
+	Ingresses         []IngressInfo       `json:"ingresses"`
+	Namespaces        []string            `json:"namespaces"`
+	HTTPRoutes        []RouteInfo         `json:"httpRoutes,omitempty"`        // existing HTTPRoutes, for migration-state detection
+	StreamServices    []StreamService     `json:"streamServices,omitempty"`    // ingress-nginx tcp-services/udp-services entries
+	NetworkPolicies   []NetworkPolicyInfo `json:"networkPolicies,omitempty"`   // policies restricting ingress to backend namespaces
+	TargetControllers []TargetController  `json:"targetControllers,omitempty"` // target controllers already running
+
+	// listenerIngresses is the unfiltered ingress list after FilterIngress,
+	// so shared Gateway listener indexes stay the same as for a full scan.
+	listenerIngresses []IngressInfo
+}
+
+// FilterIngress returns a copy of the result containing only the named
+// ingress, so migrators can run on one ingress without re-scanning. The
+// receiver is not modified (scan results are shared via the UI cache).
+// ok is false when the ingress is not in the result.
+func (r *ScanResult) FilterIngress(namespace, name string) (filtered *ScanResult, ok bool) {
+	for _, ing := range r.Ingresses {
+		if ing.Namespace != namespace || ing.Name != name {
+			continue
+		}
+		single := *r
+		single.Ingresses = []IngressInfo{ing}
+		single.Namespaces = []string{namespace}
+		single.StreamServices = nil
+		single.NetworkPolicies = nil
+		single.listenerIngresses = r.ListenerIngresses()
+		return &single, true
+	}
+	return nil, false
+}
+
+// ListenerIngresses returns the ingresses Gateway listeners are derived from:
+// every scanned ingress, even after FilterIngress.
+func (r *ScanResult) ListenerIngresses() []IngressInfo {
+	if r.listenerIngresses != nil {
+		return r.listenerIngresses
+	}
+	return r.Ingresses
+}
+
+// RouteInfo is an HTTPRoute already present in the cluster.
+type RouteInfo struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Accepted  bool   `json:"accepted"` // a parent Gateway reported Accepted=True
 }
 
 // ControllerInfo describes the detected ingress controller.
 type ControllerInfo struct {
 	Detected  bool   `json:"detected"`
-	Type      string `json:"type"`      // "ingress-nginx" | "traefik" | "kong" | "unknown"
+	Type      string `json:"type"` // "ingress-nginx" | "traefik" | "kong" | "unknown"
 	Version   string `json:"version"`
 	Namespace string `json:"namespace"`
 	PodName   string `json:"podName"`
+
+	// DefaultSSLCertificate is the "namespace/name" Secret from ingress-nginx
+	// --default-ssl-certificate. NGINX serves it for hosts without a TLS
+	// secret of their own, so the new controller must serve it too.
+	DefaultSSLCertificate string `json:"defaultSSLCertificate,omitempty"`
+
+	// ForwardedHeaders is the ingress-nginx client-IP configuration; nil when
+	// its ConfigMap could not be read (or the scan read manifests).
+	ForwardedHeaders *ForwardedHeaders `json:"forwardedHeaders,omitempty"`
+
+	// GlobalAuth is the ingress-nginx global external auth; nil when none is
+	// configured or its ConfigMap could not be read.
+	GlobalAuth *GlobalAuth `json:"globalAuth,omitempty"`
+
+	// IngressClasses are the classes the controller serves; ServesUnclassed
+	// is whether it also serves Ingresses that name none.
+	IngressClasses  []string `json:"ingressClasses,omitempty"`
+	ServesUnclassed bool     `json:"servesUnclassed,omitempty"`
+}
+
+// InstalledTarget returns the running target controller of the given type,
+// if the scan found one.
+func (r *ScanResult) InstalledTarget(controllerType string) (TargetController, bool) {
+	for _, tc := range r.TargetControllers {
+		if tc.Type == controllerType {
+			return tc, true
+		}
+	}
+	return TargetController{}, false
+}
+
+// DefaultCertificate splits DefaultSSLCertificate into the Secret namespace
+// and name. A bare name is taken to be in the controller namespace.
+func (c ControllerInfo) DefaultCertificate() (namespace, name string, ok bool) {
+	if c.DefaultSSLCertificate == "" {
+		return "", "", false
+	}
+	if ns, n, found := strings.Cut(c.DefaultSSLCertificate, "/"); found {
+		return ns, n, true
+	}
+	return c.Namespace, c.DefaultSSLCertificate, true
 }
 
 // SourceType identifies what kind of resource was scanned.
@@ -30,20 +117,36 @@ const (
 
 // IngressInfo holds parsed data for a single Ingress or IngressRoute resource.
 type IngressInfo struct {
-	Namespace        string            `json:"namespace"`
-	Name             string            `json:"name"`
-	SourceType       SourceType        `json:"sourceType,omitempty"` // "nginx-ingress" | "traefik-ingressroute" | "kong-ingress"
-	IngressClass     string            `json:"ingressClass"`
-	Hosts            []string          `json:"hosts"`
-	Paths            []PathInfo        `json:"paths"`
-	TLSEnabled       bool              `json:"tlsEnabled"`
-	TLSSecrets       []string          `json:"tlsSecrets"`
-	Annotations      map[string]string `json:"annotations"`       // All annotations
-	NginxAnnotations map[string]string `json:"nginxAnnotations"`  // Extracted feature annotations (nginx or traefik pseudo-annotations)
-	Middlewares      []string          `json:"middlewares,omitempty"` // Traefik middleware names referenced by this route
-	Plugins         []string          `json:"plugins,omitempty"`    // Kong plugin names referenced by this ingress
-	Services         []ServiceRef      `json:"services"`
-	Complexity       string            `json:"complexity"` // "simple" | "complex" | "unsupported"
+	Namespace          string            `json:"namespace"`
+	Name               string            `json:"name"`
+	SourceType         SourceType        `json:"sourceType,omitempty"` // "nginx-ingress" | "traefik-ingressroute" | "kong-ingress"
+	IngressClass       string            `json:"ingressClass"`
+	Hosts              []string          `json:"hosts"`
+	Paths              []PathInfo        `json:"paths"`
+	TLSEnabled         bool              `json:"tlsEnabled"`
+	TLSSecrets         []string          `json:"tlsSecrets"`
+	Annotations        map[string]string `json:"annotations"`                  // All annotations
+	NginxAnnotations   map[string]string `json:"nginxAnnotations"`             // Extracted feature annotations (nginx or traefik pseudo-annotations)
+	TraefikAnnotations map[string]string `json:"traefikAnnotations,omitempty"` // traefik.ingress.kubernetes.io/* annotations, prefix stripped
+	Middlewares        []string          `json:"middlewares,omitempty"`        // Traefik middleware names referenced by this route
+	Plugins            []string          `json:"plugins,omitempty"`            // Kong plugin names referenced by this ingress
+	Services           []ServiceRef      `json:"services"`
+	Complexity         string            `json:"complexity"` // "simple" | "complex" | "unsupported"
+
+	// Served is false when the detected controller does not serve the
+	// ingress's class: dead config that need not be migrated.
+	Served bool `json:"served"`
+
+	// Addresses are the load balancer IPs and hostnames in the Ingress's
+	// status: set when a controller serves it. AddressController is the
+	// type of the detected or target controller whose LoadBalancer Service
+	// has one of them, "" when unknown.
+	Addresses         []string `json:"addresses,omitempty"`
+	AddressController string   `json:"addressController,omitempty"`
+
+	// TLSSecretIssues are the TLSSecrets that are missing or not of type
+	// kubernetes.io/tls. Only cluster scans check them.
+	TLSSecretIssues []SecretIssue `json:"tlsSecretIssues,omitempty"`
 }
 
 // PathInfo describes a single path rule in an Ingress.
@@ -53,6 +156,15 @@ type PathInfo struct {
 	PathType    string `json:"pathType"`
 	ServiceName string `json:"serviceName"`
 	ServicePort int32  `json:"servicePort"`
+
+	// ServicePortName is the backend's named port, if any. enrichIngresses
+	// resolves it to ServicePort when scanning a cluster.
+	ServicePortName string `json:"servicePortName,omitempty"`
+
+	// ServiceNamespace is set when the backend lives outside the source's
+	// namespace (IngressRoute services[].namespace, an Istio destination
+	// host like "svc.other"). Ingress backends are always local.
+	ServiceNamespace string `json:"serviceNamespace,omitempty"`
 }
 
 // ServiceRef is a reference to a backend service.
@@ -60,4 +172,14 @@ type ServiceRef struct {
 	Namespace string `json:"namespace"`
 	Name      string `json:"name"`
 	Port      int32  `json:"port"`
+
+	// ExternalName is the DNS name the Service aliases when it is of type
+	// ExternalName (set by enrichIngresses).
+	ExternalName string `json:"externalName,omitempty"`
+
+	// Selector and TargetPorts describe the backend pods (set by
+	// enrichIngresses): the Service's pod selector and the pod ports, as
+	// numbers or names, behind the Service ports the ingress routes to.
+	Selector    map[string]string `json:"selector,omitempty"`
+	TargetPorts []string          `json:"targetPorts,omitempty"`
 }
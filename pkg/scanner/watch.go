@@ -0,0 +1,43 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// WatchIngresses runs an informer on Ingresses in namespace ("" for all) and
+// calls onChange whenever one is added, updated or deleted, until ctx is
+// done. Objects from the informer's initial list are not reported, and
+// resyncs that leave an Ingress unchanged are ignored.
+func (s *Scanner) WatchIngresses(ctx context.Context, namespace string, onChange func()) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(s.client, 0, informers.WithNamespace(namespace))
+	informer := factory.Networking().V1().Ingresses().Informer()
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(_ interface{}, isInInitialList bool) {
+			if !isInInitialList {
+				onChange()
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldMeta, ok1 := oldObj.(interface{ GetResourceVersion() string })
+			newMeta, ok2 := newObj.(interface{ GetResourceVersion() string })
+			if ok1 && ok2 && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+				return
+			}
+			onChange()
+		},
+		DeleteFunc: func(interface{}) { onChange() },
+	})
+	if err != nil {
+		return fmt.Errorf("watching ingresses: %w", err)
+	}
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	factory.Shutdown()
+	return nil
+}
@@ -3,28 +3,43 @@ package server
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/saiyam1814/ing-switch/pkg/analyzer"
 	"github.com/saiyam1814/ing-switch/pkg/generator"
 	"github.com/saiyam1814/ing-switch/pkg/migrator/gatewayapi"
 	"github.com/saiyam1814/ing-switch/pkg/migrator/traefik"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
+	"github.com/saiyam1814/ing-switch/pkg/version"
 )
 
-// APIHandler handles all /api/* requests.
+// APIHandler handles all /api/* requests. It is safe for concurrent use:
+// scans are shared through the cache and treated as read-only, analyzers and
+// migrators are created per request, and writes to output directories are
+// serialized.
 type APIHandler struct {
-	kubeconfig  string
-	kubecontext string
+	kubeconfig   string
+	kubecontext  string
+	applyTimeout time.Duration
+	cache        *scanCache
+
+	annotationSource string                                                           // --source of the ui command
+	scanCluster      func(cluster clusterRef, ns string) (*scanner.ScanResult, error) // scanCluster outside tests
+
+	// writeMu serializes writes to output directories, so two requests for
+	// the same directory cannot both find it empty and mix their files.
+	writeMu sync.Mutex
 }
 
 // NewAPIHandler creates a new APIHandler.
 func NewAPIHandler(kubeconfig, kubecontext string) *APIHandler {
-	return &APIHandler{kubeconfig: kubeconfig, kubecontext: kubecontext}
+	return &APIHandler{kubeconfig: kubeconfig, kubecontext: kubecontext, applyTimeout: defaultApplyTimeout, cache: newScanCache(), scanCluster: scanCluster}
 }
 
 func (h *APIHandler) HandleScan(w http.ResponseWriter, r *http.Request) {
@@ -33,25 +48,16 @@ func (h *APIHandler) HandleScan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	kubeconfig := r.URL.Query().Get("kubeconfig")
-	if kubeconfig == "" {
-		kubeconfig = h.kubeconfig
-	}
-	kubecontext := r.URL.Query().Get("context")
-	if kubecontext == "" {
-		kubecontext = h.kubecontext
-	}
-	ns := r.URL.Query().Get("namespace")
-
-	s, err := scanner.NewScanner(kubeconfig, kubecontext)
+	cluster, err := h.clusterFromRequest(r)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, fmt.Sprintf("Cannot connect to cluster: %v", err))
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	ns := r.URL.Query().Get("namespace")
 
-	result, err := s.Scan(ns)
+	result, err := h.scan(cluster, ns, wantsRefresh(r))
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Scan failed: %v", err))
+		writeScanError(w, err)
 		return
 	}
 
@@ -72,15 +78,25 @@ func (h *APIHandler) HandleAnalyze(w http.ResponseWriter, r *http.Request) {
 
 	ns := r.URL.Query().Get("namespace")
 
-	s, err := scanner.NewScanner(h.kubeconfig, h.kubecontext)
+	cluster, err := h.clusterFromRequest(r)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, fmt.Sprintf("Cannot connect to cluster: %v", err))
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	scanResult, err := s.Scan(ns)
+	scanResult, err := h.scan(cluster, ns, wantsRefresh(r))
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Scan failed: %v", err))
+		writeScanError(w, err)
+		return
+	}
+
+	// target=all returns one report per target
+	if target == generator.TargetAll {
+		var reports []*analyzer.AnalysisReport
+		for _, o := range analyzeTargets(generator.AllTargets, scanResult) {
+			reports = append(reports, o.Report)
+		}
+		writeJSON(w, reports)
 		return
 	}
 
@@ -90,10 +106,61 @@ func (h *APIHandler) HandleAnalyze(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, report)
 }
 
+// maxPasteBytes caps the YAML accepted by HandleAnalyzePaste.
+const maxPasteBytes = 1 << 20
+
+// pasteAnalysisResponse is the analysis of pasted manifests, in the same
+// shapes the cluster-backed analyze and migrate endpoints return.
+type pasteAnalysisResponse struct {
+	Report     *analyzer.AnalysisReport  `json:"report"`
+	PerIngress []IngressMigrationSummary `json:"perIngress"`
+}
+
+// HandleAnalyzePaste analyzes Ingress YAML posted as the request body
+// (one or more documents) without touching a cluster, so the tool can be
+// tried on a manifest before it is given cluster access.
+func (h *APIHandler) HandleAnalyzePaste(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "POST the Ingress YAML as the request body")
+		return
+	}
+
+	target := r.URL.Query().Get("target")
+	switch target {
+	case "traefik", "gateway-api", "gateway-api-traefik":
+	default:
+		writeError(w, http.StatusBadRequest, "target parameter required (traefik, gateway-api, or gateway-api-traefik)")
+		return
+	}
+
+	scanResult, err := scanner.ScanManifests(http.MaxBytesReader(w, r.Body, maxPasteBytes), r.URL.Query().Get("namespace"), h.annotationSource)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("parsing YAML: %v", err))
+		return
+	}
+	if len(scanResult.Ingresses) == 0 {
+		writeError(w, http.StatusBadRequest, "no networking.k8s.io/v1 Ingress found in the pasted YAML")
+		return
+	}
+
+	report := analyzer.NewAnalyzer(target).Analyze(scanResult)
+	writeJSON(w, pasteAnalysisResponse{
+		Report:     report,
+		PerIngress: buildPerIngressSummaries(report, target, false),
+	})
+}
+
 type migrateRequest struct {
 	Target    string `json:"target"`
 	OutputDir string `json:"outputDir"`
 	Namespace string `json:"namespace"`
+	// IncludeSupported lists the cleanly mapped annotations in the report
+	// and the per-ingress summaries too
+	IncludeSupported bool `json:"includeSupported"`
 }
 
 // AnnotationIssue is a single annotation that needs attention during migration.
@@ -114,10 +181,14 @@ type AnnotationIssue struct {
 
 // IngressMigrationSummary describes per-ingress migration status.
 type IngressMigrationSummary struct {
+	Target        string            `json:"target,omitempty"` // set when target=all
 	Namespace     string            `json:"namespace"`
 	Name          string            `json:"name"`
 	OverallStatus string            `json:"overallStatus"` // "ready" | "workaround" | "breaking"
 	Issues        []AnnotationIssue `json:"issues"`        // partial + unsupported annotations
+	// Supported are the cleanly mapped annotations, with no guide fields;
+	// only set when the request asks for them
+	Supported []AnnotationIssue `json:"supported,omitempty"`
 }
 
 type migrateResponse struct {
@@ -125,6 +196,8 @@ type migrateResponse struct {
 	Summary      string                    `json:"summary"`
 	IngressCount int                       `json:"ingressCount"`
 	PerIngress   []IngressMigrationSummary `json:"perIngress"`
+	// Warnings are the manual steps of all files, see generator.AllWarnings
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 func (h *APIHandler) HandleMigrate(w http.ResponseWriter, r *http.Request) {
@@ -137,6 +210,7 @@ func (h *APIHandler) HandleMigrate(w http.ResponseWriter, r *http.Request) {
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		req.Target = r.URL.Query().Get("target")
 		req.Namespace = r.URL.Query().Get("namespace")
+		req.IncludeSupported = r.URL.Query().Get("includeSupported") == "true"
 	}
 
 	if req.Target == "" {
@@ -144,52 +218,56 @@ func (h *APIHandler) HandleMigrate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s, err := scanner.NewScanner(h.kubeconfig, h.kubecontext)
+	cluster, err := h.clusterFromRequest(r)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, fmt.Sprintf("Cannot connect to cluster: %v", err))
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	scanResult, err := s.Scan(req.Namespace)
+	scanResult, err := h.scan(cluster, req.Namespace, wantsRefresh(r))
+	if err == nil {
+		err = scanResult.RequireIngresses(req.Namespace)
+	}
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Scan failed: %v", err))
+		writeScanError(w, err)
+		return
+	}
+
+	if req.Target == generator.TargetAll {
+		h.migrateAll(w, req, scanResult)
 		return
 	}
 
 	a := analyzer.NewAnalyzer(req.Target)
 	report := a.Analyze(scanResult)
 
-	var files []generator.GeneratedFile
-	switch req.Target {
-	case "traefik":
-		m := traefik.NewMigrator()
-		files, err = m.Migrate(scanResult, report)
-	case "gateway-api":
-		m := gatewayapi.NewMigrator()
-		files, err = m.Migrate(scanResult, report)
-	case "gateway-api-traefik":
-		m := gatewayapi.NewTraefikGatewayMigrator()
-		files, err = m.Migrate(scanResult, report)
-	default:
+	files, ok, err := migrateFor(req.Target, scanResult, report)
+	if !ok {
 		writeError(w, http.StatusBadRequest, "unknown target")
 		return
 	}
-
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Migration failed: %v", err))
 		return
 	}
 
+	reportOpts := generator.ReportOptions{IncludeSupported: req.IncludeSupported}
 	if req.OutputDir != "" {
 		gen := generator.NewOutputGenerator(req.OutputDir)
-		if err := gen.Write(files, report); err != nil {
+		gen.SetReportOptions(reportOpts)
+		h.writeMu.Lock()
+		err := gen.Write(files, report)
+		h.writeMu.Unlock()
+		if err != nil {
 			writeError(w, http.StatusInternalServerError, fmt.Sprintf("Writing files: %v", err))
 			return
 		}
 	}
 
+	files = generator.StampVersion(files)
+
 	// Prepend the migration report as the first file in the response
-	reportContent := generator.GenerateMigrationReport(files, report)
+	reportContent := generator.GenerateMigrationReport(files, report, reportOpts)
 	reportFile := generator.GeneratedFile{
 		RelPath:     "00-migration-report.md",
 		Content:     reportContent,
@@ -199,16 +277,82 @@ func (h *APIHandler) HandleMigrate(w http.ResponseWriter, r *http.Request) {
 	allFiles := append([]generator.GeneratedFile{reportFile}, files...)
 
 	// Build per-ingress summaries
-	perIngress := buildPerIngressSummaries(report, req.Target)
+	perIngress := buildPerIngressSummaries(report, req.Target, req.IncludeSupported)
 
 	writeJSON(w, migrateResponse{
 		Files:        allFiles,
 		Summary:      fmt.Sprintf("Generated %d migration files for %s across %d ingresses", len(allFiles), req.Target, len(scanResult.Ingresses)),
 		IngressCount: len(scanResult.Ingresses),
 		PerIngress:   perIngress,
+		Warnings:     generator.AllWarnings(allFiles),
+	})
+}
+
+// migrateAll answers a target=all migrate request: every target's files
+// under "<target>/", a comparison report, and per-target ingress summaries.
+func (h *APIHandler) migrateAll(w http.ResponseWriter, req migrateRequest, scanResult *scanner.ScanResult) {
+	reportOpts := generator.ReportOptions{IncludeSupported: req.IncludeSupported}
+	outputs := analyzeTargets(generator.AllTargets, scanResult)
+	var perIngress []IngressMigrationSummary
+	for i, o := range outputs {
+		files, _, err := migrateFor(o.Target, scanResult, o.Report)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("Migration failed for %s: %v", o.Target, err))
+			return
+		}
+		outputs[i].Files = files
+		for _, sum := range buildPerIngressSummaries(o.Report, o.Target, req.IncludeSupported) {
+			sum.Target = o.Target
+			perIngress = append(perIngress, sum)
+		}
+	}
+
+	if req.OutputDir != "" {
+		gen := generator.NewOutputGenerator(req.OutputDir)
+		gen.SetReportOptions(reportOpts)
+		h.writeMu.Lock()
+		err := gen.WriteTargets(outputs)
+		h.writeMu.Unlock()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("Writing files: %v", err))
+			return
+		}
+	}
+
+	allFiles := generator.CombineTargets(outputs, generator.LayoutNumbered, reportOpts)
+	writeJSON(w, migrateResponse{
+		Files:        allFiles,
+		Summary:      fmt.Sprintf("Generated %d migration files for %s across %d ingresses", len(allFiles), strings.Join(generator.AllTargets, " and "), len(scanResult.Ingresses)),
+		IngressCount: len(scanResult.Ingresses),
+		PerIngress:   perIngress,
+		Warnings:     generator.AllWarnings(allFiles),
 	})
 }
 
+// analyzeTargets analyzes scanResult for each target, ready for migrateFor.
+func analyzeTargets(targets []string, scanResult *scanner.ScanResult) []generator.TargetOutput {
+	outputs := make([]generator.TargetOutput, len(targets))
+	for i, t := range targets {
+		outputs[i] = generator.TargetOutput{Target: t, Report: analyzer.NewAnalyzer(t).Analyze(scanResult)}
+	}
+	return outputs
+}
+
+// migrateFor runs target's migrator. ok is false for an unknown target.
+func migrateFor(target string, scanResult *scanner.ScanResult, report *analyzer.AnalysisReport) (files []generator.GeneratedFile, ok bool, err error) {
+	switch target {
+	case "traefik":
+		files, err = traefik.NewMigrator().Migrate(scanResult, report)
+	case "gateway-api":
+		files, err = gatewayapi.NewMigrator().Migrate(scanResult, report)
+	case "gateway-api-traefik":
+		files, err = gatewayapi.NewTraefikGatewayMigrator().Migrate(scanResult, report)
+	default:
+		return nil, false, nil
+	}
+	return files, true, err
+}
+
 // targetResourceToFileCategory maps a target resource description to the file category
 // it belongs to (for UI navigation to the right generated file).
 func targetResourceToFileCategory(targetResource string) string {
@@ -229,7 +373,10 @@ func targetResourceToFileCategory(targetResource string) string {
 	}
 }
 
-func buildPerIngressSummaries(report *analyzer.AnalysisReport, target string) []IngressMigrationSummary {
+// buildPerIngressSummaries lists each ingress's partial and unsupported
+// annotations with their fix guides, and its supported ones when
+// includeSupported is set.
+func buildPerIngressSummaries(report *analyzer.AnalysisReport, target string, includeSupported bool) []IngressMigrationSummary {
 	summaries := make([]IngressMigrationSummary, 0, len(report.IngressReports))
 	for _, ir := range report.IngressReports {
 		sum := IngressMigrationSummary{
@@ -239,11 +386,24 @@ func buildPerIngressSummaries(report *analyzer.AnalysisReport, target string) []
 			Issues:        []AnnotationIssue{}, // always a non-nil slice
 		}
 		for _, m := range ir.Mappings {
+			shortKey := strings.TrimPrefix(m.OriginalKey, "nginx.ingress.kubernetes.io/")
+			if m.Status == analyzer.StatusSupported {
+				if includeSupported {
+					sum.Supported = append(sum.Supported, AnnotationIssue{
+						Key:            shortKey,
+						Value:          m.OriginalValue,
+						Status:         string(m.Status),
+						TargetResource: m.TargetResource,
+						FileCategory:   targetResourceToFileCategory(m.TargetResource),
+						Note:           m.Note,
+					})
+				}
+				continue
+			}
 			if m.Status != analyzer.StatusPartial && m.Status != analyzer.StatusUnsupported {
 				continue
 			}
-			shortKey := strings.TrimPrefix(m.OriginalKey, "nginx.ingress.kubernetes.io/")
-			guide := GetAnnotationGuide(target, shortKey)
+			guide := analyzer.GetValueGuide(target, shortKey, m.OriginalValue)
 			issue := AnnotationIssue{
 				Key:            shortKey,
 				Value:          m.OriginalValue,
@@ -289,15 +449,6 @@ type applyResponse struct {
 	Error    string   `json:"error,omitempty"`
 }
 
-// applyableCategories are categories whose YAML files can be kubectl-applied directly.
-var applyableCategories = map[string]bool{
-	"middleware": true,
-	"ingress":    true,
-	"gateway":    true,
-	"httproute":  true,
-	"policy":     true,
-}
-
 func (h *APIHandler) HandleApply(w http.ResponseWriter, r *http.Request) {
 	setCORSHeaders(w)
 	if r.Method == http.MethodOptions {
@@ -316,40 +467,44 @@ func (h *APIHandler) HandleApply(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Non-kubectl categories: return instructions
-	if !applyableCategories[req.Category] {
+	if !generator.ApplyableCategories[req.Category] {
 		msg := categoryInstructions(req.Category, req.Target)
+		if req.Category == "install" {
+			if missing := missingToolError("helm"); missing != "" {
+				msg = "⚠️  " + missing + "\n\n" + msg
+			}
+		}
 		writeJSON(w, applyResponse{Success: true, Output: msg, DryRun: req.DryRun})
 		return
 	}
 
+	slog.Info("apply requested", "target", req.Target, "category", req.Category, "namespace", req.Namespace, "dryRun", req.DryRun)
+
+	if missing := missingToolError("kubectl"); missing != "" {
+		slog.Warn("kubectl not found on PATH")
+		writeJSON(w, applyResponse{Success: false, Error: missing, DryRun: req.DryRun})
+		return
+	}
+
 	// Generate migration files
-	s, err := scanner.NewScanner(h.kubeconfig, h.kubecontext)
+	cluster, err := h.clusterFromRequest(r)
 	if err != nil {
-		writeJSON(w, applyResponse{Success: false, Error: fmt.Sprintf("Cannot connect to cluster: %v", err)})
+		writeJSON(w, applyResponse{Success: false, Error: err.Error()})
 		return
 	}
 
-	scanResult, err := s.Scan(req.Namespace)
+	scanResult, err := h.scan(cluster, req.Namespace, wantsRefresh(r))
 	if err != nil {
-		writeJSON(w, applyResponse{Success: false, Error: fmt.Sprintf("Scan failed: %v", err)})
+		_, msg := scanErrorMessage(err)
+		writeJSON(w, applyResponse{Success: false, Error: msg})
 		return
 	}
 
 	a := analyzer.NewAnalyzer(req.Target)
 	report := a.Analyze(scanResult)
 
-	var files []generator.GeneratedFile
-	switch req.Target {
-	case "traefik":
-		m := traefik.NewMigrator()
-		files, err = m.Migrate(scanResult, report)
-	case "gateway-api":
-		m := gatewayapi.NewMigrator()
-		files, err = m.Migrate(scanResult, report)
-	case "gateway-api-traefik":
-		m := gatewayapi.NewTraefikGatewayMigrator()
-		files, err = m.Migrate(scanResult, report)
-	default:
+	files, ok, err := migrateFor(req.Target, scanResult, report)
+	if !ok {
 		writeJSON(w, applyResponse{Success: false, Error: "unknown target"})
 		return
 	}
@@ -392,13 +547,16 @@ func (h *APIHandler) HandleApply(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Build kubectl command
-	args := buildKubectlArgs(h.kubeconfig, h.kubecontext, req.DryRun, tmpDir)
-	cmd := exec.Command("kubectl", args...)
-	output, cmdErr := cmd.CombinedOutput()
+	args, err := buildKubectlArgs(cluster, req.DryRun, tmpDir)
+	if err != nil {
+		writeJSON(w, applyResponse{Success: false, Error: err.Error()})
+		return
+	}
+	output, cmdErr := runKubectl(h.applyTimeout, args)
 
 	resp := applyResponse{
 		Success: cmdErr == nil,
-		Output:  string(output),
+		Output:  output,
 		DryRun:  req.DryRun,
 		Applied: applied,
 	}
@@ -408,19 +566,16 @@ func (h *APIHandler) HandleApply(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, resp)
 }
 
-func buildKubectlArgs(kubeconfig, kubecontext string, dryRun bool, dir string) []string {
-	args := []string{}
-	if kubeconfig != "" {
-		args = append(args, "--kubeconfig", kubeconfig)
-	}
-	if kubecontext != "" {
-		args = append(args, "--context", kubecontext)
+func buildKubectlArgs(cluster clusterRef, dryRun bool, dir string) ([]string, error) {
+	args, err := cluster.kubectlArgs(dir)
+	if err != nil {
+		return nil, err
 	}
 	args = append(args, "apply", "-f", dir)
 	if dryRun {
 		args = append(args, "--dry-run=server")
 	}
-	return args
+	return args, nil
 }
 
 func categoryInstructions(category, target string) string {
@@ -456,7 +611,9 @@ func categoryInstructions(category, target string) string {
 	case "cleanup":
 		return "⚠️  CAUTION: Cleanup removes NGINX. Only run after DNS has been updated and traffic confirmed on the new controller.\n\n" +
 			"  Review 06-cleanup/remove-nginx.sh before running.\n" +
-			"  Backup your NGINX Helm values first: helm get values ingress-nginx -n ingress-nginx > nginx-values-backup.yaml"
+			"  Backup your NGINX Helm values first: helm get values ingress-nginx -n ingress-nginx > nginx-values-backup.yaml\n\n" +
+			"  The UI never runs it from Apply. GET /api/cleanup?target=... previews exactly what would be deleted;\n" +
+			"  POST /api/cleanup with {\"target\": \"...\", \"confirm\": \"remove-nginx\"} runs it."
 	}
 	return "Download the files from the file viewer and apply manually."
 }
@@ -477,7 +634,13 @@ func (h *APIHandler) HandleValidate(w http.ResponseWriter, r *http.Request) {
 	target := r.URL.Query().Get("target")
 	ns := r.URL.Query().Get("namespace")
 
-	result, err := runRichValidation(h.kubeconfig, h.kubecontext, target, ns)
+	cluster, err := h.clusterFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := runRichValidation(cluster, target, ns)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -499,43 +662,47 @@ func (h *APIHandler) HandleDownload(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ns := r.URL.Query().Get("namespace")
+	reportOpts := generator.ReportOptions{IncludeSupported: r.URL.Query().Get("includeSupported") == "true"}
 
-	s, err := scanner.NewScanner(h.kubeconfig, h.kubecontext)
+	cluster, err := h.clusterFromRequest(r)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, fmt.Sprintf("Cannot connect to cluster: %v", err))
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	scanResult, err := s.Scan(ns)
+	scanResult, err := h.scan(cluster, ns, wantsRefresh(r))
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Scan failed: %v", err))
+		writeScanError(w, err)
 		return
 	}
 
-	a := analyzer.NewAnalyzer(target)
-	report := a.Analyze(scanResult)
-
-	var files []generator.GeneratedFile
-	switch target {
-	case "traefik":
-		m := traefik.NewMigrator()
-		files, err = m.Migrate(scanResult, report)
-	case "gateway-api":
-		m := gatewayapi.NewMigrator()
-		files, err = m.Migrate(scanResult, report)
-	case "gateway-api-traefik":
-		m := gatewayapi.NewTraefikGatewayMigrator()
-		files, err = m.Migrate(scanResult, report)
-	default:
-		writeError(w, http.StatusBadRequest, "unknown target")
-		return
-	}
-	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
-		return
+	var zipData []byte
+	if target == generator.TargetAll {
+		outputs := analyzeTargets(generator.AllTargets, scanResult)
+		for i, o := range outputs {
+			if outputs[i].Files, _, err = migrateFor(o.Target, scanResult, o.Report); err != nil {
+				writeError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+		zipData, err = generator.CreateTargetsZip(outputs, reportOpts)
+	} else {
+		a := analyzer.NewAnalyzer(target)
+		report := a.Analyze(scanResult)
+
+		var files []generator.GeneratedFile
+		var ok bool
+		files, ok, err = migrateFor(target, scanResult, report)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "unknown target")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		zipData, err = generator.CreateZip(files, report, reportOpts)
 	}
-
-	zipData, err := generator.CreateZip(files, report)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Creating ZIP: "+err.Error())
 		return
@@ -546,6 +713,15 @@ func (h *APIHandler) HandleDownload(w http.ResponseWriter, r *http.Request) {
 	w.Write(zipData)
 }
 
+// HandleVersion returns the build metadata of the running binary.
+func (h *APIHandler) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		return
+	}
+	writeJSON(w, version.Get())
+}
+
 // Helpers
 
 func writeJSON(w http.ResponseWriter, v interface{}) {
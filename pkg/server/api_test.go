@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+func TestConcurrentRequestsShareOneScan(t *testing.T) {
+	var scans atomic.Int32
+	h := NewAPIHandler("", "")
+	h.scanCluster = func(clusterRef, string) (*scanner.ScanResult, error) {
+		scans.Add(1)
+		time.Sleep(50 * time.Millisecond) // keep the scan in flight while the others arrive
+		return &scanner.ScanResult{
+			Ingresses: []scanner.IngressInfo{{
+				Name:      "web",
+				Namespace: "default",
+				Hosts:     []string{"web.example.com"},
+				Paths:     []scanner.PathInfo{{Host: "web.example.com", Path: "/", PathType: "Prefix", ServiceName: "web", ServicePort: 80}},
+			}},
+		}, nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/scan", h.HandleScan)
+	mux.HandleFunc("/api/migrate", h.HandleMigrate)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			resp, err := http.Get(srv.URL + "/api/scan")
+			if err != nil {
+				t.Errorf("scan: %v", err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("scan: status %d", resp.StatusCode)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			resp, err := http.Post(srv.URL+"/api/migrate", "application/json", strings.NewReader(`{"target":"traefik"}`))
+			if err != nil {
+				t.Errorf("migrate: %v", err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("migrate: status %d", resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := scans.Load(); n != 1 {
+		t.Errorf("cluster scanned %d times, want 1", n)
+	}
+}
+
+func TestScanOnceReleasesWaitersOnPanic(t *testing.T) {
+	c := newScanCache()
+	key := scanCacheKey{namespace: "default"}
+
+	func() {
+		defer func() { _ = recover() }()
+		c.scanOnce(key, func() (*scanner.ScanResult, error) { panic("scan failed") })
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		c.scanOnce(key, func() (*scanner.ScanResult, error) { return &scanner.ScanResult{}, nil })
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scanOnce blocked on the scan that panicked")
+	}
+}
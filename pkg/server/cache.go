@@ -0,0 +1,154 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// scanCacheTTL is how long a cluster scan is reused across API calls. A UI
+// session clicking through Detect → Analyze → Migrate stays within this window
+// and scans the cluster once instead of once per tab.
+const scanCacheTTL = 30 * time.Second
+
+type scanCacheKey struct {
+	cluster   clusterRef
+	namespace string
+}
+
+type scanCacheEntry struct {
+	result  *scanner.ScanResult
+	scanned time.Time
+}
+
+// scanCall is a scan in progress. Requests for the same key wait for it
+// instead of scanning the cluster again, e.g. several UI tabs opened at once.
+type scanCall struct {
+	done   chan struct{}
+	result *scanner.ScanResult
+	err    error
+}
+
+// scanCache stores the most recent ScanResult per (cluster, namespace). It
+// is safe for concurrent use.
+type scanCache struct {
+	mu       sync.Mutex
+	entries  map[scanCacheKey]scanCacheEntry
+	inflight map[scanCacheKey]*scanCall
+}
+
+func newScanCache() *scanCache {
+	return &scanCache{
+		entries:  make(map[scanCacheKey]scanCacheEntry),
+		inflight: make(map[scanCacheKey]*scanCall),
+	}
+}
+
+func (c *scanCache) get(key scanCacheKey) (*scanner.ScanResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || time.Since(e.scanned) > scanCacheTTL {
+		return nil, false
+	}
+	return e.result, true
+}
+
+// scanOnce runs scan for key and caches its result, unless a scan for key is
+// already running: then it waits for that one and returns its outcome.
+// Failed scans are not cached.
+func (c *scanCache) scanOnce(key scanCacheKey, scan func() (*scanner.ScanResult, error)) (*scanner.ScanResult, error) {
+	c.mu.Lock()
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.result, call.err
+	}
+	call := &scanCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	// Release the waiters even if scan panics (net/http recovers handler
+	// panics), or every later request for key would block forever.
+	defer func() {
+		c.mu.Lock()
+		delete(c.inflight, key)
+		c.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.result, call.err = scan()
+	if call.err == nil {
+		c.mu.Lock()
+		c.entries[key] = scanCacheEntry{result: call.result, scanned: time.Now()}
+		c.mu.Unlock()
+	}
+	return call.result, call.err
+}
+
+// connectError marks a failure to build a client, as opposed to a failed scan.
+type connectError struct{ err error }
+
+func (e *connectError) Error() string { return e.err.Error() }
+func (e *connectError) Unwrap() error { return e.err }
+
+// scan returns a ScanResult for the given cluster and namespace, reusing a
+// cached result younger than scanCacheTTL unless refresh is set. Callers must
+// treat the result as read-only since it may be shared between requests.
+// Concurrent requests for the same cluster and namespace share one scan.
+func (h *APIHandler) scan(cluster clusterRef, ns string, refresh bool) (*scanner.ScanResult, error) {
+	key := scanCacheKey{cluster: cluster, namespace: ns}
+	if !refresh {
+		if result, ok := h.cache.get(key); ok {
+			return result, nil
+		}
+	}
+
+	return h.cache.scanOnce(key, func() (*scanner.ScanResult, error) {
+		return h.scanCluster(cluster, ns)
+	})
+}
+
+// scanCluster connects to cluster and scans namespace ns ("" for all).
+func scanCluster(cluster clusterRef, ns string) (*scanner.ScanResult, error) {
+	s, err := cluster.newScanner()
+	if err != nil {
+		return nil, &connectError{err: err}
+	}
+	return s.Scan(ns)
+}
+
+// wantsRefresh reports whether the request asked to bypass the scan cache.
+func wantsRefresh(r *http.Request) bool {
+	return r.URL.Query().Get("refresh") == "true"
+}
+
+// scanErrorMessage formats a scan error and picks the status code matching
+// its cause, so the UI can tell a missing kubeconfig, an unreachable cluster
+// and denied access apart.
+func scanErrorMessage(err error) (int, string) {
+	var ce *connectError
+	switch {
+	case errors.Is(err, scanner.ErrNoContexts):
+		return http.StatusBadRequest, fmt.Sprintf("Cannot connect to cluster: %v — start 'ing-switch ui' with --kubeconfig and --context, or pass server and token", err)
+	case errors.As(err, &ce):
+		return http.StatusBadRequest, fmt.Sprintf("Cannot connect to cluster: %v", ce.err)
+	case errors.Is(err, scanner.ErrNoCluster):
+		return http.StatusServiceUnavailable, fmt.Sprintf("Cannot reach cluster: %v", err)
+	case errors.Is(err, scanner.ErrForbidden):
+		return http.StatusForbidden, fmt.Sprintf("Scan failed: %v — the credentials need list access to Ingresses", err)
+	case errors.Is(err, scanner.ErrNoIngresses):
+		return http.StatusNotFound, fmt.Sprintf("Nothing to migrate: %v", err)
+	}
+	return http.StatusInternalServerError, fmt.Sprintf("Scan failed: %v", err)
+}
+
+// writeScanError writes err from h.scan as a JSON error response.
+func writeScanError(w http.ResponseWriter, err error) {
+	code, msg := scanErrorMessage(err)
+	writeError(w, code, msg)
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// cleanupConfirmToken must be sent as "confirm" before /api/cleanup runs
+// anything: removing NGINX takes down every route it still serves, and
+// cannot be undone from the UI.
+const cleanupConfirmToken = "remove-nginx"
+
+// nginxReleaseName is the Helm release the generated cleanup scripts
+// uninstall.
+const nginxReleaseName = "ingress-nginx"
+
+type cleanupRequest struct {
+	Target  string `json:"target"`
+	Confirm string `json:"confirm"`
+}
+
+// cleanupStep is one command of the NGINX removal. Deletes lists what it
+// removes that exists right now ("kind/name" as kubectl prints it), so the
+// preview shows exactly what will go.
+type cleanupStep struct {
+	Description string   `json:"description"`
+	Command     string   `json:"command"`
+	Deletes     []string `json:"deletes,omitempty"`
+	Output      string   `json:"output,omitempty"`
+	Error       string   `json:"error,omitempty"`
+
+	tool string
+	args []string
+}
+
+type cleanupResponse struct {
+	Executed bool              `json:"executed"`
+	Success  bool              `json:"success"`
+	Steps    []cleanupStep     `json:"steps"`
+	Safety   *PreCleanupResult `json:"safety,omitempty"`
+	Notes    []string          `json:"notes,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// HandleCleanup serves /api/cleanup, which removes ingress-nginx the way the
+// generated cleanup scripts do. GET (or a POST without the confirm token)
+// only returns the plan with the resources each step would delete, and the
+// result of preCleanupCheck; POST with {"confirm": "remove-nginx"} runs it
+// if that check passes, stopping at the first failure. It is deliberately
+// separate from /api/apply, which never deletes.
+func (h *APIHandler) HandleCleanup(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		return
+	}
+
+	req := cleanupRequest{Target: r.URL.Query().Get("target")}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	if req.Target == "" {
+		writeError(w, http.StatusBadRequest, "target required")
+		return
+	}
+	if missing := missingToolError("kubectl"); missing != "" {
+		writeJSON(w, cleanupResponse{Error: missing})
+		return
+	}
+
+	cluster, err := h.clusterFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// Never act on a cached scan
+	confirmed := r.Method == http.MethodPost && req.Confirm == cleanupConfirmToken
+	scanResult, err := h.scan(cluster, "", wantsRefresh(r) || confirmed)
+	if err != nil {
+		writeScanError(w, err)
+		return
+	}
+	if scanResult.Controller.Type != "ingress-nginx" {
+		writeJSON(w, cleanupResponse{Error: "no ingress-nginx controller detected — there is nothing to remove"})
+		return
+	}
+
+	dir, err := os.MkdirTemp("", "ing-switch-cleanup-*")
+	if err != nil {
+		writeJSON(w, cleanupResponse{Error: "Cannot create temp dir"})
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	steps, notes, err := h.cleanupPlan(cluster, dir, req.Target, scanResult.Controller.Namespace)
+	if err != nil {
+		writeJSON(w, cleanupResponse{Error: err.Error()})
+		return
+	}
+
+	safety, err := preCleanupCheck(r.Context(), cluster, req.Target, scanResult)
+	if err != nil {
+		writeJSON(w, cleanupResponse{Steps: steps, Notes: notes, Error: "pre-cleanup check: " + err.Error()})
+		return
+	}
+
+	if !confirmed {
+		resp := cleanupResponse{Success: true, Steps: steps, Notes: notes, Safety: safety}
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			resp.Success = false
+			resp.Error = fmt.Sprintf("confirm must be %q to remove NGINX — review the steps below first", cleanupConfirmToken)
+		}
+		writeJSON(w, resp)
+		return
+	}
+
+	if !safety.Passed {
+		w.WriteHeader(http.StatusConflict)
+		writeJSON(w, cleanupResponse{Steps: steps, Notes: notes, Safety: safety,
+			Error: "the target controller is not serving traffic yet — NGINX was not removed. Fix the failed checks first."})
+		return
+	}
+
+	slog.Warn("removing ingress-nginx", "target", req.Target, "namespace", scanResult.Controller.Namespace)
+	resp := cleanupResponse{Executed: true, Success: true, Steps: steps, Notes: notes, Safety: safety}
+	for i := range resp.Steps {
+		step := &resp.Steps[i]
+		output, err := h.runCleanupTool(step.tool, step.args)
+		step.Output = output
+		if err != nil {
+			step.Error = err.Error()
+			resp.Success = false
+			resp.Error = fmt.Sprintf("%s failed — later steps were not run", step.Description)
+			break
+		}
+	}
+	writeJSON(w, resp)
+}
+
+// cleanupPlan lists the steps of the target's cleanup script against the
+// detected controller namespace, skipping those with nothing to act on.
+func (h *APIHandler) cleanupPlan(cluster clusterRef, dir, target, ns string) ([]cleanupStep, []string, error) {
+	if ns == "" {
+		ns = nginxReleaseName
+	}
+	kubectl, err := cluster.kubectlArgs(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	existing := func(args ...string) []string {
+		out, err := h.runCleanupTool("kubectl", append(append([]string{}, kubectl...), append(args, "-o", "name", "--ignore-not-found")...))
+		if err != nil {
+			return nil
+		}
+		return strings.Fields(out)
+	}
+
+	var steps []cleanupStep
+	var notes []string
+	add := func(desc, tool string, deletes []string, args ...string) {
+		full := kubectl
+		if tool == "helm" {
+			full = cluster.helmArgs(dir)
+		}
+		full = append(append([]string{}, full...), args...)
+		steps = append(steps, cleanupStep{
+			Description: desc,
+			Command:     tool + " " + strings.Join(args, " "),
+			Deletes:     deletes,
+			tool:        tool,
+			args:        full,
+		})
+	}
+
+	switch target {
+	case "traefik":
+		// Traefik keeps serving the Ingresses through the nginx class
+		if len(existing("get", "ingressclass", "nginx")) > 0 {
+			add("Preserve the nginx IngressClass through the Helm uninstall", "kubectl", nil,
+				"annotate", "ingressclass", "nginx", "helm.sh/resource-policy=keep", "--overwrite")
+		} else {
+			notes = append(notes, "IngressClass nginx not found — apply 06-cleanup/01-preserve-ingressclass.yaml so Traefik keeps serving the Ingresses")
+		}
+	case "gateway-api", "gateway-api-traefik":
+	default:
+		return nil, nil, fmt.Errorf("unknown target %q", target)
+	}
+
+	for _, kind := range []string{"validatingwebhookconfiguration", "mutatingwebhookconfiguration"} {
+		if found := existing("get", kind, "ingress-nginx-admission"); len(found) > 0 {
+			add("Remove the NGINX admission webhook", "kubectl", found, "delete", kind, "ingress-nginx-admission", "--ignore-not-found")
+		}
+	}
+
+	if missing := missingToolError("helm"); missing != "" {
+		notes = append(notes, "Helm release not checked: "+missing)
+	} else if _, err := h.runCleanupTool("helm", append(cluster.helmArgs(dir), "status", nginxReleaseName, "-n", ns)); err == nil {
+		add(fmt.Sprintf("Uninstall the %s Helm release", nginxReleaseName), "helm",
+			[]string{fmt.Sprintf("helm release %s/%s and all of its resources", ns, nginxReleaseName)},
+			"uninstall", nginxReleaseName, "-n", ns)
+	} else {
+		notes = append(notes, fmt.Sprintf("No Helm release %s in %s — cluster-scoped resources of a non-Helm install must be removed by hand", nginxReleaseName, ns))
+	}
+
+	if found := existing("get", "namespace", ns); len(found) > 0 {
+		contents := existing("get", "all,configmaps,secrets,serviceaccounts,roles,rolebindings", "-n", ns)
+		add("Delete the controller namespace and everything in it", "kubectl", append(found, contents...),
+			"delete", "namespace", ns, "--ignore-not-found")
+	}
+	return steps, notes, nil
+}
+
+// runCleanupTool runs kubectl (with its retries) or helm.
+func (h *APIHandler) runCleanupTool(tool string, args []string) (string, error) {
+	if tool == "kubectl" {
+		return runKubectl(h.applyTimeout, args)
+	}
+	timeout := h.applyTimeout
+	if timeout <= 0 {
+		timeout = defaultApplyTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, tool, args...).CombinedOutput()
+	return string(out), err
+}
+
+// helmArgs returns the connection flags for helm, matching kubectlArgs. In
+// token mode it refers to the kubeconfig kubectlArgs wrote into dir.
+func (c clusterRef) helmArgs(dir string) []string {
+	if c.usesToken() {
+		return []string{"--kubeconfig", filepath.Join(dir, tokenKubeconfig)}
+	}
+	var args []string
+	if c.kubeconfig != "" {
+		args = append(args, "--kubeconfig", c.kubeconfig)
+	}
+	if c.kubecontext != "" {
+		args = append(args, "--kube-context", c.kubecontext)
+	}
+	return args
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// clusterRef identifies the cluster an API request targets. Either a
+// kubeconfig (+ context) is used — the default — or, when server is set, a
+// bearer token against that API server URL with an optional CA bundle.
+type clusterRef struct {
+	kubeconfig  string
+	kubecontext string
+	server      string
+	token       string
+	caData      string // PEM, already base64-decoded
+	source      string // annotation source Ingresses are read with
+}
+
+// clusterFromRequest reads the cluster to use from the query string:
+//
+//	?kubeconfig=...&context=...         kubeconfig file (defaults to the server flags)
+//	?server=https://...&token=...&ca=... bearer token; ca is base64-encoded PEM (optional)
+func (h *APIHandler) clusterFromRequest(r *http.Request) (clusterRef, error) {
+	q := r.URL.Query()
+	c := clusterRef{
+		kubeconfig:  q.Get("kubeconfig"),
+		kubecontext: q.Get("context"),
+		server:      q.Get("server"),
+		token:       q.Get("token"),
+		source:      h.annotationSource,
+	}
+
+	if c.server == "" {
+		if c.kubeconfig == "" {
+			c.kubeconfig = h.kubeconfig
+		}
+		if c.kubecontext == "" {
+			c.kubecontext = h.kubecontext
+		}
+		return c, nil
+	}
+
+	if c.token == "" {
+		return clusterRef{}, fmt.Errorf("token is required when server is set")
+	}
+	if ca := q.Get("ca"); ca != "" {
+		pem, err := base64.StdEncoding.DecodeString(ca)
+		if err != nil {
+			return clusterRef{}, fmt.Errorf("ca must be base64-encoded PEM: %w", err)
+		}
+		c.caData = string(pem)
+	}
+	return c, nil
+}
+
+func (c clusterRef) usesToken() bool {
+	return c.server != ""
+}
+
+// newScanner connects a Scanner to the referenced cluster.
+func (c clusterRef) newScanner() (*scanner.Scanner, error) {
+	var s *scanner.Scanner
+	var err error
+	if c.usesToken() {
+		s, err = scanner.NewScannerFromConfig(scanner.TokenConfig(c.server, c.token, []byte(c.caData)), c.server)
+	} else {
+		s, err = scanner.NewScanner(c.kubeconfig, c.kubecontext)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.SetAnnotationSource(c.source)
+	return s, nil
+}
+
+// loadRestConfig returns a rest.Config for either mode.
+func (c clusterRef) loadRestConfig() (*rest.Config, error) {
+	if c.usesToken() {
+		return scanner.TokenConfig(c.server, c.token, []byte(c.caData)), nil
+	}
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(scanner.LoadingRules(c.kubeconfig), scanner.ConfigOverrides(c.kubecontext)).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("cannot build kubeconfig: %w", err)
+	}
+	return cfg, nil
+}
+
+// tokenKubeconfig is the file name of the kubeconfig kubectlArgs writes
+// in token mode. It has no .yaml extension, so kubectl apply -f dir skips it.
+const tokenKubeconfig = "kubeconfig"
+
+// kubectlArgs returns the connection flags for kubectl. In token mode the
+// local kubeconfig is ignored entirely: the server, token and CA bundle are
+// written to a 0600 kubeconfig in dir, which the caller removes afterwards,
+// so the token never appears on a command line.
+func (c clusterRef) kubectlArgs(dir string) ([]string, error) {
+	if c.usesToken() {
+		path, err := c.writeKubeconfig(dir)
+		if err != nil {
+			return nil, err
+		}
+		return []string{"--kubeconfig", path}, nil
+	}
+	var args []string
+	if c.kubeconfig != "" {
+		args = append(args, "--kubeconfig", c.kubeconfig)
+	}
+	if c.kubecontext != "" {
+		args = append(args, "--context", c.kubecontext)
+	}
+	return append(args, scanner.KubectlConfigArgs()...), nil
+}
+
+// writeKubeconfig writes the token-mode connection as a kubeconfig into dir
+// and returns its path.
+func (c clusterRef) writeKubeconfig(dir string) (string, error) {
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters["ing-switch"] = &clientcmdapi.Cluster{Server: c.server, CertificateAuthorityData: []byte(c.caData)}
+	cfg.AuthInfos["ing-switch"] = &clientcmdapi.AuthInfo{Token: c.token}
+	cfg.Contexts["ing-switch"] = &clientcmdapi.Context{Cluster: "ing-switch", AuthInfo: "ing-switch"}
+	cfg.CurrentContext = "ing-switch"
+	data, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return "", fmt.Errorf("writing kubeconfig: %w", err)
+	}
+	path := filepath.Join(dir, tokenKubeconfig)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("writing kubeconfig: %w", err)
+	}
+	return path, nil
+}
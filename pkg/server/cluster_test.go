@@ -0,0 +1,52 @@
+package server
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestKubectlArgsKeepTokenOffCommandLine(t *testing.T) {
+	dir := t.TempDir()
+	c := clusterRef{server: "https://10.0.0.1:6443", token: "s3cr3t", caData: "-----BEGIN CERTIFICATE-----\n"}
+
+	args, err := c.kubectlArgs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, flags := range [][]string{args, c.helmArgs(dir)} {
+		if strings.Contains(strings.Join(flags, " "), c.token) {
+			t.Errorf("token on the command line: %v", flags)
+		}
+		if len(flags) != 2 || flags[0] != "--kubeconfig" {
+			t.Fatalf("flags = %v, want --kubeconfig <file>", flags)
+		}
+	}
+
+	info, err := os.Stat(args[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("kubeconfig mode = %o, want 600", perm)
+	}
+	cfg, err := clientcmd.LoadFromFile(args[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := cfg.Contexts[cfg.CurrentContext]
+	if ctx == nil {
+		t.Fatalf("no current context in %+v", cfg)
+	}
+	if got := cfg.Clusters[ctx.Cluster].Server; got != c.server {
+		t.Errorf("server = %q, want %q", got, c.server)
+	}
+	if got := string(cfg.Clusters[ctx.Cluster].CertificateAuthorityData); got != c.caData {
+		t.Errorf("CA data = %q, want %q", got, c.caData)
+	}
+	if got := cfg.AuthInfos[ctx.AuthInfo].Token; got != c.token {
+		t.Errorf("token = %q, want %q", got, c.token)
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// defaultApplyTimeout bounds a single kubectl invocation from HandleApply so a
+// hung API server cannot hang the HTTP handler.
+const defaultApplyTimeout = 60 * time.Second
+
+// kubectlRetries is how many extra attempts are made when kubectl fails with a
+// transient connection error. kubectl apply is idempotent, so a retry is safe.
+const kubectlRetries = 1
+
+// transientKubectlErrors are output fragments that indicate a flaky connection
+// rather than a problem with the manifests.
+var transientKubectlErrors = []string{
+	"connection refused",
+	"connection reset by peer",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"http2: client connection lost",
+	"unexpected EOF",
+}
+
+// runKubectl runs kubectl with the given args, killing it after timeout and
+// retrying once on transient connection errors. The returned error is already
+// phrased for display in the UI.
+func runKubectl(timeout time.Duration, args []string) (string, error) {
+	if timeout <= 0 {
+		timeout = defaultApplyTimeout
+	}
+
+	var output string
+	var err error
+	for attempt := 0; attempt <= kubectlRetries; attempt++ {
+		slog.Info("running kubectl", "args", redactArgs(args), "attempt", attempt+1, "timeout", timeout)
+		output, err = runKubectlOnce(timeout, args)
+		if err == nil || !isTransientKubectlError(output) {
+			break
+		}
+		slog.Warn("kubectl failed with a transient error, retrying", "error", err)
+	}
+	if err != nil {
+		slog.Error("kubectl failed", "args", redactArgs(args), "error", err)
+	} else {
+		slog.Info("kubectl succeeded", "args", redactArgs(args))
+	}
+	return output, err
+}
+
+func runKubectlOnce(timeout time.Duration, args []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "kubectl", args...).CombinedOutput()
+	output := string(out)
+	if err == nil {
+		return output, nil
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("kubectl timed out after %s — the API server may be unreachable or overloaded", timeout)
+	}
+	if hint := kubectlVersionHint(output); hint != "" {
+		return output, fmt.Errorf("%v — %s", err, hint)
+	}
+	return output, err
+}
+
+// redactArgs hides the value following --token so bearer tokens never reach logs.
+func redactArgs(args []string) []string {
+	out := make([]string, len(args))
+	copy(out, args)
+	for i := 0; i+1 < len(out); i++ {
+		if out[i] == "--token" {
+			out[i+1] = "REDACTED"
+		}
+	}
+	return out
+}
+
+func isTransientKubectlError(output string) bool {
+	for _, s := range transientKubectlErrors {
+		if strings.Contains(output, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// kubectlVersionHint recognises failures caused by a kubectl client that is
+// too old for --dry-run=server / server-side validation.
+func kubectlVersionHint(output string) string {
+	switch {
+	case strings.Contains(output, "unknown flag: --dry-run"),
+		strings.Contains(output, `invalid argument "server" for "--dry-run"`),
+		strings.Contains(output, "dry-run=server is not supported"):
+		return "your kubectl client is too old for server-side dry-run (needs v1.18+); upgrade kubectl to within one minor version of the cluster"
+	case strings.Contains(output, "the server could not find the requested resource") &&
+		strings.Contains(output, "apply"):
+		return "kubectl and the cluster may be too far apart in version; upgrade kubectl to within one minor version of the cluster"
+	}
+	return ""
+}
+
+// toolInstallHints point users at install docs when a CLI the UI depends on
+// is not on PATH.
+var toolInstallHints = map[string]string{
+	"kubectl": "https://kubernetes.io/docs/tasks/tools/#kubectl",
+	"helm":    "https://helm.sh/docs/intro/install/",
+}
+
+// missingToolError returns a friendly message if tool is not on PATH, or ""
+// when it is available.
+func missingToolError(tool string) string {
+	if _, err := exec.LookPath(tool); err == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s was not found on PATH. Install it (%s), make sure it is on the PATH of the shell that started 'ing-switch ui', then restart the UI.",
+		tool, toolInstallHints[tool])
+}
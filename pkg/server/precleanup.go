@@ -0,0 +1,392 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// preCleanupSampleHosts is how many hosts preCleanupCheck requests through
+// the target controller.
+const preCleanupSampleHosts = 5
+
+// preCleanupProbeTimeout bounds each sample request.
+const preCleanupProbeTimeout = 5 * time.Second
+
+// gatewayGVRs — try the GA version first, fall back to v1beta1.
+var gatewayGVRs = []schema.GroupVersionResource{
+	{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"},
+	{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "gateways"},
+}
+
+// PreCleanupResult reports whether the target controller is serving traffic
+// well enough for NGINX to be removed. Passed is false if any check failed.
+type PreCleanupResult struct {
+	Passed  bool              `json:"passed"`
+	Address string            `json:"address,omitempty"` // target LoadBalancer IP or hostname
+	Checks  []ValidationCheck `json:"checks"`
+}
+
+// preCleanupCheck codifies the "before you remove NGINX" steps of the DNS
+// migration guide: the target controller runs and has a LoadBalancer
+// address, the generated routes are accepted (Gateway API) or the Ingresses
+// publish that address (Traefik), and a sample of hosts answers 2xx/3xx when
+// requested through the target.
+func preCleanupCheck(ctx context.Context, cluster clusterRef, target string, sr *scanner.ScanResult) (*PreCleanupResult, error) {
+	restCfg, err := cluster.loadRestConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, err
+	}
+	dynClient, err := dynamic.NewForConfig(restCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PreCleanupResult{}
+	running, targetNamespace, _ := detectTargetController(ctx, client, target)
+	if !running {
+		result.Checks = append(result.Checks, ValidationCheck{
+			Name:    "Target controller running",
+			Status:  "fail",
+			Message: fmt.Sprintf("No running %s controller found — it must serve traffic before NGINX is removed", target),
+		})
+		return result, nil
+	}
+
+	switch target {
+	case "traefik":
+		result.Address = loadBalancerAddress(ctx, client, targetNamespace)
+		appendAddressCheck(&result.Checks, result.Address, fmt.Sprintf("Traefik LoadBalancer Service in '%s'", targetNamespace))
+		if result.Address != "" {
+			appendIngressStatusCheck(ctx, &result.Checks, client, sr, result.Address)
+		}
+	case "gateway-api", "gateway-api-traefik":
+		result.Address = appendGatewayCheck(ctx, &result.Checks, dynClient)
+		appendAddressCheck(&result.Checks, result.Address, "ing-switch Gateway status.addresses")
+		appendHTTPRouteCheck(ctx, &result.Checks, dynClient)
+	default:
+		return nil, fmt.Errorf("unknown target %q", target)
+	}
+
+	if result.Address != "" {
+		appendTrafficCheck(ctx, &result.Checks, result.Address, sr)
+	}
+
+	result.Passed = true
+	for _, c := range result.Checks {
+		if c.Status == "fail" {
+			result.Passed = false
+			break
+		}
+	}
+	return result, nil
+}
+
+// loadBalancerAddress returns the external IP (or hostname) of the first
+// LoadBalancer Service in namespace that has been assigned one.
+func loadBalancerAddress(ctx context.Context, client kubernetes.Interface, namespace string) string {
+	svcs, err := client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return ""
+	}
+	for _, svc := range svcs.Items {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		for _, ing := range svc.Status.LoadBalancer.Ingress {
+			if ing.IP != "" {
+				return ing.IP
+			}
+			if ing.Hostname != "" {
+				return ing.Hostname
+			}
+		}
+	}
+	return ""
+}
+
+func appendAddressCheck(checks *[]ValidationCheck, address, source string) {
+	if address == "" {
+		*checks = append(*checks, ValidationCheck{
+			Name:    "Target LoadBalancer address",
+			Status:  "fail",
+			Message: fmt.Sprintf("No external address assigned (%s). DNS cannot point at the target yet.", source),
+		})
+		return
+	}
+	*checks = append(*checks, ValidationCheck{
+		Name:    "Target LoadBalancer address",
+		Status:  "pass",
+		Message: fmt.Sprintf("%s (%s)", address, source),
+	})
+}
+
+// appendIngressStatusCheck checks that the served Ingresses publish the
+// Traefik address. Only a warning: NGINX publishes its own address on the
+// same Ingresses until it is removed, so the two may overwrite each other.
+func appendIngressStatusCheck(ctx context.Context, checks *[]ValidationCheck, client kubernetes.Interface,
+	sr *scanner.ScanResult, address string) {
+
+	served := make(map[string]bool)
+	for _, ing := range sr.Ingresses {
+		if ing.Served && (ing.SourceType == "" || ing.SourceType == scanner.SourceNginxIngress) {
+			served[ing.Namespace+"/"+ing.Name] = true
+		}
+	}
+	list, err := client.NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	if err != nil || len(served) == 0 {
+		return
+	}
+
+	var missing []string
+	for _, ing := range list.Items {
+		key := ing.Namespace + "/" + ing.Name
+		if !served[key] {
+			continue
+		}
+		published := false
+		for _, lb := range ing.Status.LoadBalancer.Ingress {
+			if lb.IP == address || lb.Hostname == address {
+				published = true
+			}
+		}
+		if !published {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+
+	if len(missing) == 0 {
+		*checks = append(*checks, ValidationCheck{
+			Name:    fmt.Sprintf("Ingresses programmed on Traefik (%d)", len(served)),
+			Status:  "pass",
+			Message: fmt.Sprintf("Every served Ingress reports %s in its status", address),
+		})
+		return
+	}
+	*checks = append(*checks, ValidationCheck{
+		Name:    fmt.Sprintf("Ingresses programmed on Traefik (%d/%d)", len(served)-len(missing), len(served)),
+		Status:  "warn",
+		Message: fmt.Sprintf("Not reporting %s in their status: %s. Enable providers.kubernetesIngress.publishedService in Traefik, or rely on the traffic check.", address, strings.Join(missing, ", ")),
+	})
+}
+
+// appendGatewayCheck checks that every ing-switch Gateway is Programmed and
+// returns the first address one of them reports.
+func appendGatewayCheck(ctx context.Context, checks *[]ValidationCheck, dynClient dynamic.Interface) string {
+	gateways, err := listManaged(ctx, dynClient, gatewayGVRs)
+	if err != nil || len(gateways) == 0 {
+		*checks = append(*checks, ValidationCheck{
+			Name:    "Gateway programmed",
+			Status:  "fail",
+			Message: "No ing-switch Gateway found. Apply the generated 03-gateway/ files.",
+		})
+		return ""
+	}
+
+	address := ""
+	var notProgrammed []string
+	for _, gw := range gateways {
+		if !conditionTrue(gw.Object, "Programmed", "status", "conditions") {
+			notProgrammed = append(notProgrammed, gw.GetNamespace()+"/"+gw.GetName())
+		}
+		addrs, _, _ := unstructured.NestedSlice(gw.Object, "status", "addresses")
+		for _, a := range addrs {
+			if m, ok := a.(map[string]interface{}); ok && address == "" {
+				address, _ = m["value"].(string)
+			}
+		}
+	}
+
+	if len(notProgrammed) > 0 {
+		*checks = append(*checks, ValidationCheck{
+			Name:    "Gateway programmed",
+			Status:  "fail",
+			Message: fmt.Sprintf("Programmed is not True on: %s. Check kubectl describe gateway for the reason.", strings.Join(notProgrammed, ", ")),
+		})
+	} else {
+		*checks = append(*checks, ValidationCheck{
+			Name:    fmt.Sprintf("Gateway programmed (%d)", len(gateways)),
+			Status:  "pass",
+			Message: "Every ing-switch Gateway reports Programmed=True",
+		})
+	}
+	return address
+}
+
+// appendHTTPRouteCheck checks that every ing-switch HTTPRoute is Accepted by
+// all of its parent Gateways.
+func appendHTTPRouteCheck(ctx context.Context, checks *[]ValidationCheck, dynClient dynamic.Interface) {
+	routes, err := listManaged(ctx, dynClient, scanner.HTTPRouteGVRs)
+	if err != nil || len(routes) == 0 {
+		*checks = append(*checks, ValidationCheck{
+			Name:    "HTTPRoutes accepted",
+			Status:  "fail",
+			Message: "No ing-switch HTTPRoutes found. Apply the generated 04-httproutes/ files.",
+		})
+		return
+	}
+
+	var rejected []string
+	for _, route := range routes {
+		parents, _, _ := unstructured.NestedSlice(route.Object, "status", "parents")
+		accepted := len(parents) > 0
+		for _, p := range parents {
+			m, ok := p.(map[string]interface{})
+			if !ok || !conditionTrue(m, "Accepted", "conditions") {
+				accepted = false
+			}
+		}
+		if !accepted {
+			rejected = append(rejected, route.GetNamespace()+"/"+route.GetName())
+		}
+	}
+
+	if len(rejected) > 0 {
+		*checks = append(*checks, ValidationCheck{
+			Name:    fmt.Sprintf("HTTPRoutes accepted (%d/%d)", len(routes)-len(rejected), len(routes)),
+			Status:  "fail",
+			Message: fmt.Sprintf("Not Accepted by their Gateway: %s. Their hosts would go dark without NGINX.", strings.Join(rejected, ", ")),
+		})
+		return
+	}
+	*checks = append(*checks, ValidationCheck{
+		Name:    fmt.Sprintf("HTTPRoutes accepted (%d)", len(routes)),
+		Status:  "pass",
+		Message: "Every ing-switch HTTPRoute reports Accepted=True on all parents",
+	})
+}
+
+// appendTrafficCheck requests a sample of the scanned hosts from the target
+// address, as 04-verify.sh does with curl --connect-to. Redirects are not
+// followed: a 3xx (e.g. the HTTP→HTTPS redirect) counts as served.
+func appendTrafficCheck(ctx context.Context, checks *[]ValidationCheck, address string, sr *scanner.ScanResult) {
+	samples := sampleHostPaths(sr, preCleanupSampleHosts)
+	if len(samples) == 0 {
+		*checks = append(*checks, ValidationCheck{
+			Name:    "Traffic through target",
+			Status:  "warn",
+			Message: "No Ingress hosts to request — traffic through the target was not tested",
+		})
+		return
+	}
+
+	httpClient := &http.Client{
+		Timeout:       preCleanupProbeTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	var failed []string
+	for _, s := range samples {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+net.JoinHostPort(address, "80")+s.path, nil)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s%s (%v)", s.host, s.path, err))
+			continue
+		}
+		req.Host = s.host
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s%s (%v)", s.host, s.path, err))
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			failed = append(failed, fmt.Sprintf("%s%s (HTTP %d)", s.host, s.path, resp.StatusCode))
+		}
+	}
+
+	if len(failed) > 0 {
+		*checks = append(*checks, ValidationCheck{
+			Name:    fmt.Sprintf("Traffic through target (%d/%d)", len(samples)-len(failed), len(samples)),
+			Status:  "fail",
+			Message: fmt.Sprintf("Not served with 2xx/3xx via %s: %s", address, strings.Join(failed, "; ")),
+		})
+		return
+	}
+	*checks = append(*checks, ValidationCheck{
+		Name:    fmt.Sprintf("Traffic through target (%d)", len(samples)),
+		Status:  "pass",
+		Message: fmt.Sprintf("%d sampled host(s) answered 2xx/3xx via %s", len(samples), address),
+	})
+}
+
+type hostPath struct {
+	host string
+	path string
+}
+
+// sampleHostPaths picks up to n distinct hosts of served ingresses, each with
+// the first of its paths that is a plain prefix (regex paths are skipped).
+func sampleHostPaths(sr *scanner.ScanResult, n int) []hostPath {
+	seen := make(map[string]bool)
+	var samples []hostPath
+	for _, ing := range sr.Ingresses {
+		if !ing.Served {
+			continue
+		}
+		for _, p := range ing.Paths {
+			if p.Host == "" || strings.HasPrefix(p.Host, "*") || seen[p.Host] {
+				continue
+			}
+			path := p.Path
+			if path == "" {
+				path = "/"
+			}
+			if !strings.HasPrefix(path, "/") || strings.ContainsAny(path, "()[]*+?^$|\\{}") {
+				continue
+			}
+			seen[p.Host] = true
+			samples = append(samples, hostPath{host: p.Host, path: path})
+		}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].host < samples[j].host })
+	if len(samples) > n {
+		samples = samples[:n]
+	}
+	return samples
+}
+
+// listManaged lists the resources ing-switch created, across namespaces.
+func listManaged(ctx context.Context, dynClient dynamic.Interface, gvrs []schema.GroupVersionResource) ([]unstructured.Unstructured, error) {
+	list, err := scanner.ListFirstAvailable(ctx, dynClient, gvrs, "")
+	if err != nil {
+		return nil, err
+	}
+	var managed []unstructured.Unstructured
+	for _, item := range list.Items {
+		if item.GetLabels()[migrator.ManagedByLabel] == migrator.ManagedByValue {
+			managed = append(managed, item)
+		}
+	}
+	return managed, nil
+}
+
+// conditionTrue reports whether the conditions list at fields in obj has
+// condType with status "True".
+func conditionTrue(obj map[string]interface{}, condType string, fields ...string) bool {
+	conds, _, _ := unstructured.NestedSlice(obj, fields...)
+	for _, c := range conds {
+		m, ok := c.(map[string]interface{})
+		if ok && m["type"] == condType {
+			return m["status"] == "True"
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator/gatewayapi"
+	"github.com/saiyam1814/ing-switch/pkg/migrator/traefik"
+)
+
+type ingressPreviewResponse struct {
+	IngressMigrationSummary
+	Target string                    `json:"target"`
+	Files  []generator.GeneratedFile `json:"files"`
+}
+
+// HandleMigrateIngress serves /api/migrate/ingress?namespace=X&name=Y&target=Z
+// (optional scope=<namespace> mirrors the namespace passed to /api/migrate).
+// It runs the migrator on that one ingress and returns only the files
+// generated for it (shared install scripts, Gateway, and guides other than
+// its own fix guide are left out) plus its annotation issues, for the UI's
+// per-ingress drill-down.
+func (h *APIHandler) HandleMigrateIngress(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		return
+	}
+
+	q := r.URL.Query()
+	target, ns, name := q.Get("target"), q.Get("namespace"), q.Get("name")
+	if target == "" || ns == "" || name == "" {
+		writeError(w, http.StatusBadRequest, "target, namespace, and name parameters required")
+		return
+	}
+
+	cluster, err := h.clusterFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Scan with the same namespace scope as /api/migrate (all namespaces by
+	// default) so Gateway listener sectionNames match the full migration.
+	scanResult, err := h.scan(cluster, q.Get("scope"), wantsRefresh(r))
+	if err != nil {
+		writeScanError(w, err)
+		return
+	}
+
+	single, ok := scanResult.FilterIngress(ns, name)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("ingress %s/%s not found", ns, name))
+		return
+	}
+
+	report := analyzer.NewAnalyzer(target).Analyze(single)
+
+	var files []generator.GeneratedFile
+	switch target {
+	case "traefik":
+		m := traefik.NewMigrator()
+		files, err = m.Migrate(single, report)
+	case "gateway-api":
+		m := gatewayapi.NewMigrator()
+		files, err = m.Migrate(single, report)
+	case "gateway-api-traefik":
+		m := gatewayapi.NewTraefikGatewayMigrator()
+		files, err = m.Migrate(single, report)
+	default:
+		writeError(w, http.StatusBadRequest, "unknown target")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Migration failed: %v", err))
+		return
+	}
+
+	resp := ingressPreviewResponse{
+		Target: target,
+		Files:  ingressFiles(generator.StampVersion(files), ns, name),
+	}
+	if summaries := buildPerIngressSummaries(report, target, false); len(summaries) > 0 {
+		resp.IngressMigrationSummary = summaries[0]
+	}
+	writeJSON(w, resp)
+}
+
+// ingressFiles keeps the files labelled with the ingress's source label —
+// its middlewares, rewritten Ingress, HTTPRoutes, and policies — and its
+// fix guide.
+func ingressFiles(files []generator.GeneratedFile, ns, name string) []generator.GeneratedFile {
+	label := fmt.Sprintf("%s: %q", migrator.SourceIngressLabel, migrator.SourceIngressValue(ns, name))
+	guide := fmt.Sprintf("guides/%s-%s.md", ns, name)
+	out := []generator.GeneratedFile{} // always a non-nil slice
+	for _, f := range files {
+		if strings.Contains(f.Content, label) || f.RelPath == guide {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -3,7 +3,11 @@ package server
 import (
 	"embed"
 	"io/fs"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 //go:embed dist
@@ -11,9 +15,12 @@ var embeddedUI embed.FS
 
 // Server is the local web server that serves the React UI and REST API.
 type Server struct {
-	addr        string
-	kubeconfig  string
-	kubecontext string
+	addr         string
+	kubeconfig   string
+	kubecontext  string
+	applyTimeout time.Duration
+
+	annotationSource string
 }
 
 // NewServer creates a new Server.
@@ -25,18 +32,39 @@ func NewServer(addr, kubeconfig, kubecontext string) *Server {
 	}
 }
 
+// SetApplyTimeout overrides how long a single kubectl apply from the UI may
+// run before it is killed. Zero keeps the default.
+func (s *Server) SetApplyTimeout(d time.Duration) {
+	s.applyTimeout = d
+}
+
+// SetAnnotationSource selects the nginx annotation family scans read, as
+// scanner.Scanner.SetAnnotationSource does.
+func (s *Server) SetAnnotationSource(source string) {
+	s.annotationSource = source
+}
+
 // Start begins serving HTTP requests.
 func (s *Server) Start() error {
 	mux := http.NewServeMux()
 
 	// Register API handlers
 	api := NewAPIHandler(s.kubeconfig, s.kubecontext)
+	if s.applyTimeout > 0 {
+		api.applyTimeout = s.applyTimeout
+	}
+	api.annotationSource = s.annotationSource
 	mux.HandleFunc("/api/scan", api.HandleScan)
 	mux.HandleFunc("/api/analyze", api.HandleAnalyze)
+	mux.HandleFunc("/api/analyze/paste", api.HandleAnalyzePaste)
 	mux.HandleFunc("/api/migrate", api.HandleMigrate)
+	mux.HandleFunc("/api/migrate/ingress", api.HandleMigrateIngress)
 	mux.HandleFunc("/api/validate", api.HandleValidate)
+	mux.HandleFunc("/api/validate/watch", api.HandleValidateWatch)
 	mux.HandleFunc("/api/download", api.HandleDownload)
 	mux.HandleFunc("/api/apply", api.HandleApply)
+	mux.HandleFunc("/api/cleanup", api.HandleCleanup)
+	mux.HandleFunc("/api/version", api.HandleVersion)
 
 	// Serve embedded React UI for all other paths
 	uiFS, err := fs.Sub(embeddedUI, "dist")
@@ -48,7 +76,54 @@ func (s *Server) Start() error {
 		mux.Handle("/", SPAHandler{fileServer: fileServer, uiFS: uiFS})
 	}
 
-	return http.ListenAndServe(s.addr, mux)
+	return http.ListenAndServe(s.addr, logRequests(mux))
+}
+
+// redactQuery encodes query parameters for logging with credentials masked.
+func redactQuery(q url.Values) string {
+	if q.Has("token") {
+		q.Set("token", "REDACTED")
+	}
+	return q.Encode()
+}
+
+// statusRecorder captures the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets streaming handlers (/api/validate/watch) flush through the recorder.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// logRequests logs every API request with its status and duration. Static UI
+// assets are logged at debug level only.
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		level := slog.LevelDebug
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			level = slog.LevelInfo
+		}
+		slog.Log(r.Context(), level, "http request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"query", redactQuery(r.URL.Query()),
+			"status", rec.status,
+			"duration", time.Since(start).Round(time.Millisecond))
+	})
 }
 
 // SPAHandler serves the React SPA, falling back to index.html for unknown routes.
@@ -5,12 +5,11 @@ import (
 	"fmt"
 	"strings"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/saiyam1814/ing-switch/pkg/migrator"
 	"github.com/saiyam1814/ing-switch/pkg/scanner"
 )
 
@@ -24,19 +23,13 @@ type RichValidationResult struct {
 	NextSteps []string          `json:"nextSteps"`
 }
 
-func runRichValidation(kubeconfig, kubecontext, target, ns string) (*RichValidationResult, error) {
+func runRichValidation(cluster clusterRef, target, ns string) (*RichValidationResult, error) {
 	result := &RichValidationResult{Target: target}
 
 	// Build k8s clients
-	rules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig}
-	overrides := &clientcmd.ConfigOverrides{}
-	if kubecontext != "" {
-		overrides.CurrentContext = kubecontext
-	}
-	cfg := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
-	restCfg, err := cfg.ClientConfig()
+	restCfg, err := cluster.loadRestConfig()
 	if err != nil {
-		return nil, fmt.Errorf("cannot build kubeconfig: %w", err)
+		return nil, err
 	}
 
 	client, err := kubernetes.NewForConfig(restCfg)
@@ -53,13 +46,15 @@ func runRichValidation(kubeconfig, kubecontext, target, ns string) (*RichValidat
 	// --- Gather facts ---
 
 	// 1. Scan cluster for ingresses + current controller
-	s, scanErr := scanner.NewScanner(kubeconfig, kubecontext)
+	s, scanErr := cluster.newScanner()
+	var scanResult *scanner.ScanResult
 	var ingressCount int
 	nginxPresent := false
 	nginxNamespace := ""
 	if scanErr == nil {
 		sr, err := s.Scan(ns)
 		if err == nil {
+			scanResult = sr
 			ingressCount = len(sr.Ingresses)
 			nginxPresent = sr.Controller.Detected && sr.Controller.Type == "ingress-nginx"
 			nginxNamespace = sr.Controller.Namespace
@@ -85,17 +80,9 @@ func runRichValidation(kubeconfig, kubecontext, target, ns string) (*RichValidat
 	}
 
 	// 4. Count migration resources in cluster
-	middlewareCount := countResource(ctx, dynClient, traefikCRDsInstalled,
-		[]schema.GroupVersionResource{
-			{Group: "traefik.io", Version: "v1alpha1", Resource: "middlewares"},
-			{Group: "traefik.containo.us", Version: "v1alpha1", Resource: "middlewares"},
-		})
+	middlewareCount := countResource(ctx, dynClient, traefikCRDsInstalled, traefikMiddlewareGVRs)
 
-	httprouteCount := countResource(ctx, dynClient, gatewayAPICRDsInstalled,
-		[]schema.GroupVersionResource{
-			{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"},
-			{Group: "gateway.networking.k8s.io", Version: "v1beta1", Resource: "httproutes"},
-		})
+	httprouteCount := countResource(ctx, dynClient, gatewayAPICRDsInstalled, scanner.HTTPRouteGVRs)
 
 	// 5. Check ingresses for traefik middleware annotations (ingress update applied?)
 	ingressesWithTraefikAnnotations := 0
@@ -159,6 +146,16 @@ func runRichValidation(kubeconfig, kubecontext, target, ns string) (*RichValidat
 			gatewayAPICRDsInstalled, httprouteCount, ingressCount, "Traefik")
 	}
 
+	// Check: source Ingresses edited since the migration files were applied
+	if scanResult != nil {
+		switch {
+		case target != "traefik" && gatewayAPICRDsInstalled:
+			appendDriftCheck(ctx, &checks, dynClient, scanner.HTTPRouteGVRs, scanResult, ns, target)
+		case target == "traefik" && traefikCRDsInstalled:
+			appendDriftCheck(ctx, &checks, dynClient, traefikMiddlewareGVRs, scanResult, ns, target)
+		}
+	}
+
 	result.Checks = checks
 
 	// --- Determine migration phase ---
@@ -194,53 +191,114 @@ func runRichValidation(kubeconfig, kubecontext, target, ns string) (*RichValidat
 	return result, nil
 }
 
-func detectTargetController(ctx context.Context, client kubernetes.Interface, target string) (running bool, namespace, version string) {
-	var selectors []string
-	var namespaces []string
+// traefikMiddlewareGVRs — current API group first, then the pre-v3 one.
+var traefikMiddlewareGVRs = []schema.GroupVersionResource{
+	{Group: "traefik.io", Version: "v1alpha1", Resource: "middlewares"},
+	{Group: "traefik.containo.us", Version: "v1alpha1", Resource: "middlewares"},
+}
 
-	switch target {
-	case "traefik", "gateway-api-traefik":
-		selectors = []string{"app.kubernetes.io/name=traefik", "app=traefik"}
-		namespaces = []string{"traefik", "kube-system", "default", "ingress"}
-	case "gateway-api":
-		selectors = []string{"app.kubernetes.io/name=envoy-gateway", "app=envoy-gateway", "control-plane=envoy-gateway"}
-		namespaces = []string{"envoy-gateway-system", "kube-system", "default"}
+// appendDriftCheck compares the content-hash annotation on live ing-switch
+// resources with a hash of their source Ingress as scanned now. A mismatch
+// means the Ingress (or the ing-switch version) changed after the files were
+// generated, so they should be regenerated and re-applied.
+func appendDriftCheck(ctx context.Context, checks *[]ValidationCheck, dynClient dynamic.Interface,
+	gvrs []schema.GroupVersionResource, sr *scanner.ScanResult, ns, target string) {
+
+	list, err := scanner.ListFirstAvailable(ctx, dynClient, gvrs, ns)
+	if err != nil {
+		return
+	}
+	sources := make(map[string]scanner.IngressInfo)
+	for _, ing := range sr.Ingresses {
+		// Applying the Traefik 03-ingresses/ files rewrites the source
+		// Ingress itself, so its hash no longer describes the original.
+		if target == "traefik" && ing.Annotations["traefik.ingress.kubernetes.io/router.middlewares"] != "" {
+			continue
+		}
+		sources[migrator.SourceIngressValue(ing.Namespace, ing.Name)] = ing
 	}
 
-	for _, ns := range namespaces {
-		for _, sel := range selectors {
-			pods, err := client.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{LabelSelector: sel, Limit: 1})
-			if err != nil || len(pods.Items) == 0 {
-				continue
-			}
-			pod := pods.Items[0]
-			if pod.Status.Phase == "Running" {
-				running = true
-				namespace = ns
-				for _, c := range pod.Spec.Containers {
-					parts := strings.Split(c.Image, ":")
-					if len(parts) >= 2 {
-						version = parts[len(parts)-1]
-					}
-				}
-				return
+	seen := make(map[string]bool)
+	var drifted []string
+	checked := 0
+	for _, item := range list.Items {
+		hash := item.GetAnnotations()[migrator.ContentHashAnnotation]
+		if hash == "" {
+			continue // hand-written or pre-hash
+		}
+		ings, ok := resourceSources(item.GetLabels(), item.GetAnnotations(), sources)
+		if !ok {
+			continue
+		}
+		checked++
+		if hash == migrator.ContentHash(ings...) {
+			continue
+		}
+		for _, ing := range ings {
+			if ref := ing.Namespace + "/" + ing.Name; !seen[ref] {
+				seen[ref] = true
+				drifted = append(drifted, ref)
 			}
 		}
 	}
-	return
+	if checked == 0 {
+		return
+	}
+
+	if len(drifted) == 0 {
+		*checks = append(*checks, ValidationCheck{
+			Name:    "Source Ingresses unchanged",
+			Status:  "pass",
+			Message: fmt.Sprintf("%d generated resource(s) match the current Ingress definitions", checked),
+		})
+		return
+	}
+	*checks = append(*checks, ValidationCheck{
+		Name:    fmt.Sprintf("Source Ingresses changed since migration (%d)", len(drifted)),
+		Status:  "warn",
+		Message: fmt.Sprintf("Edited after the migration files were generated: %s. Regenerate and re-apply their files.", strings.Join(drifted, ", ")),
+	})
+}
+
+// resourceSources returns the scanned ingresses a generated resource came
+// from: the one its source label names, or those its source-ingresses
+// annotation lists when it was consolidated from several. ok is false when
+// it names none or one of them was not scanned.
+func resourceSources(labels, annotations map[string]string, sources map[string]scanner.IngressInfo) ([]scanner.IngressInfo, bool) {
+	if source := labels[migrator.SourceIngressLabel]; source != "" {
+		ing, ok := sources[source]
+		return []scanner.IngressInfo{ing}, ok
+	}
+	refs := annotations[migrator.SourceIngressesAnnotation]
+	if refs == "" {
+		return nil, false
+	}
+	var ings []scanner.IngressInfo
+	for _, ref := range strings.Split(refs, ",") {
+		ns, name, _ := strings.Cut(ref, "/")
+		ing, ok := sources[migrator.SourceIngressValue(ns, name)]
+		if !ok {
+			return nil, false
+		}
+		ings = append(ings, ing)
+	}
+	return ings, true
+}
+
+func detectTargetController(ctx context.Context, client kubernetes.Interface, target string) (running bool, namespace, version string) {
+	tc, ok := scanner.DetectTargetController(ctx, client, scanner.TargetControllerType(target))
+	return ok, tc.Namespace, tc.Version
 }
 
 func countResource(ctx context.Context, dynClient dynamic.Interface, installed bool, gvrs []schema.GroupVersionResource) int {
 	if !installed {
 		return 0
 	}
-	for _, gvr := range gvrs {
-		list, err := dynClient.Resource(gvr).Namespace("").List(ctx, metav1.ListOptions{})
-		if err == nil {
-			return len(list.Items)
-		}
+	list, err := scanner.ListFirstAvailable(ctx, dynClient, gvrs, "")
+	if err != nil {
+		return 0
 	}
-	return 0
+	return len(list.Items)
 }
 
 func appendTraefikChecks(checks *[]ValidationCheck, targetRunning bool, namespace, version string,
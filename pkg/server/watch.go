@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// validateDebounce coalesces bursts of watch events (e.g. kubectl apply of
+	// a whole directory) into a single re-validation.
+	validateDebounce = 2 * time.Second
+	// watchRetryDelay is how long a closed or failed watch waits before it is
+	// re-established. This also picks up CRDs installed mid-migration.
+	watchRetryDelay = 5 * time.Second
+	// sseHeartbeat keeps idle connections open through proxies.
+	sseHeartbeat = 25 * time.Second
+)
+
+// watchedCRDs are the migration resources whose changes affect validation.
+var watchedCRDs = []schema.GroupVersionResource{
+	{Group: "traefik.io", Version: "v1alpha1", Resource: "middlewares"},
+	{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"},
+	{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"},
+}
+
+// HandleValidateWatch streams a RichValidationResult as Server-Sent Events:
+// one immediately, then a fresh one whenever Ingresses, controller
+// Deployments, or the target CRDs change. The stream ends when the client
+// disconnects.
+func (h *APIHandler) HandleValidateWatch(w http.ResponseWriter, r *http.Request) {
+	setCORSHeaders(w)
+	if r.Method == http.MethodOptions {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	target := r.URL.Query().Get("target")
+	ns := r.URL.Query().Get("namespace")
+
+	cluster, err := h.clusterFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	restCfg, err := cluster.loadRestConfig()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	client, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	dynClient, err := dynamic.NewForConfig(restCfg)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ctx := r.Context()
+	changed := make(chan struct{}, 1)
+
+	go watchLoop(ctx, changed, func(ctx context.Context) (watch.Interface, error) {
+		return client.NetworkingV1().Ingresses(ns).Watch(ctx, metav1.ListOptions{})
+	})
+	go watchLoop(ctx, changed, func(ctx context.Context) (watch.Interface, error) {
+		return client.AppsV1().Deployments("").Watch(ctx, metav1.ListOptions{})
+	})
+	for _, gvr := range watchedCRDs {
+		gvr := gvr
+		go watchLoop(ctx, changed, func(ctx context.Context) (watch.Interface, error) {
+			return dynClient.Resource(gvr).Namespace("").Watch(ctx, metav1.ListOptions{})
+		})
+	}
+
+	send := func() bool {
+		result, err := runRichValidation(cluster, target, ns)
+		if err != nil {
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", mustJSON(map[string]string{"error": err.Error()}))
+		} else {
+			fmt.Fprintf(w, "event: validation\ndata: %s\n\n", mustJSON(result))
+		}
+		flusher.Flush()
+		return ctx.Err() == nil
+	}
+
+	if !send() {
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+			if debounce == nil {
+				debounce = time.After(validateDebounce)
+			}
+		case <-debounce:
+			debounce = nil
+			if !send() {
+				return
+			}
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// watchLoop keeps a watch open until ctx is done, signalling changed on every
+// event. Watches that fail (e.g. CRD not installed yet) or are closed by the
+// API server are retried after watchRetryDelay.
+func watchLoop(ctx context.Context, changed chan<- struct{}, start func(context.Context) (watch.Interface, error)) {
+	for {
+		wi, err := start(ctx)
+		if err == nil {
+			drainWatch(ctx, wi, changed)
+			wi.Stop()
+		} else {
+			slog.Debug("validate watch not established, retrying", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryDelay):
+		}
+	}
+}
+
+func drainWatch(ctx context.Context, wi watch.Interface, changed chan<- struct{}) {
+	// The initial ADDED events replay existing objects; the first result is
+	// already sent, so these only cause one extra (harmless) refresh.
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-wi.ResultChan():
+			if !ok {
+				return
+			}
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func mustJSON(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return []byte(`{"error":"encoding failed"}`)
+	}
+	return b
+}
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator/gatewayapi"
+	"github.com/saiyam1814/ing-switch/pkg/migrator/traefik"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+// Targets are the migration targets offered in the target picker.
+var Targets = []string{"traefik", "gateway-api", "gateway-api-traefik"}
+
+// Config holds the cluster and output settings for a TUI session.
+type Config struct {
+	Kubeconfig  string
+	Kubecontext string
+	Namespace   string
+	OutputDir   string
+	Source      string // nginx annotation family, see scanner.Scanner.SetAnnotationSource
+
+	// ApplyTimeout bounds the apply and dry-run kubectl calls; zero uses
+	// defaultApplyTimeout.
+	ApplyTimeout time.Duration
+}
+
+// defaultApplyTimeout matches the ui command's --apply-timeout default: a
+// hung API server must not freeze the TUI.
+const defaultApplyTimeout = 60 * time.Second
+
+func (c Config) scan() (*scanner.ScanResult, error) {
+	s, err := scanner.NewScanner(c.Kubeconfig, c.Kubecontext)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to cluster: %w", err)
+	}
+	s.SetAnnotationSource(c.Source)
+	result, err := s.Scan(c.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("scanning cluster: %w", err)
+	}
+	return result, nil
+}
+
+func migrate(target string, scan *scanner.ScanResult, report *analyzer.AnalysisReport) ([]generator.GeneratedFile, error) {
+	var files []generator.GeneratedFile
+	var err error
+	switch target {
+	case "traefik":
+		files, err = traefik.NewMigrator().Migrate(scan, report)
+	case "gateway-api":
+		files, err = gatewayapi.NewMigrator().Migrate(scan, report)
+	case "gateway-api-traefik":
+		files, err = gatewayapi.NewTraefikGatewayMigrator().Migrate(scan, report)
+	default:
+		return nil, fmt.Errorf("unknown target %q — use 'traefik', 'gateway-api', or 'gateway-api-traefik'", target)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("generating migration files: %w", err)
+	}
+	return files, nil
+}
+
+// generate writes the migration output directory, like 'ing-switch migrate'.
+func (c Config) generate(target string, scan *scanner.ScanResult, report *analyzer.AnalysisReport) (string, error) {
+	files, err := migrate(target, scan, report)
+	if err != nil {
+		return "", err
+	}
+	g := generator.NewOutputGenerator(c.OutputDir)
+	g.SetProgressOutput(io.Discard)
+	if err := g.Write(files, report); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Wrote %d file(s) to %s", len(files)+1, c.OutputDir), nil
+}
+
+// apply sends every applyable YAML file to kubectl, like 'ing-switch apply'
+// without --category, and returns kubectl's combined output.
+func (c Config) apply(target string, scan *scanner.ScanResult, report *analyzer.AnalysisReport, dryRun bool) (string, error) {
+	files, err := migrate(target, scan, report)
+	if err != nil {
+		return "", err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ing-switch-tui-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	written := 0
+	for _, f := range generator.StampVersion(files) {
+		if !generator.ApplyableCategories[f.Category] {
+			continue
+		}
+		if !strings.HasSuffix(f.RelPath, ".yaml") && !strings.HasSuffix(f.RelPath, ".yml") {
+			continue
+		}
+		dest := filepath.Join(tmpDir, filepath.Base(f.RelPath))
+		if err := os.WriteFile(dest, []byte(f.Content), 0644); err != nil {
+			return "", fmt.Errorf("writing %s: %w", f.RelPath, err)
+		}
+		written++
+	}
+	if written == 0 {
+		return "No applyable YAML files were generated.", nil
+	}
+
+	args := []string{}
+	if c.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", c.Kubeconfig)
+	}
+	if c.Kubecontext != "" {
+		args = append(args, "--context", c.Kubecontext)
+	}
+	args = append(args, scanner.KubectlConfigArgs()...)
+	args = append(args, "apply", "-f", tmpDir)
+	if dryRun {
+		args = append(args, "--dry-run=server")
+	}
+
+	timeout := c.ApplyTimeout
+	if timeout <= 0 {
+		timeout = defaultApplyTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "kubectl", args...).CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return string(out), fmt.Errorf("kubectl apply timed out after %s — the API server may be unreachable or overloaded", timeout)
+	}
+	if err != nil {
+		return string(out), fmt.Errorf("kubectl apply failed: %w", err)
+	}
+	return string(out), nil
+}
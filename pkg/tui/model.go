@@ -0,0 +1,362 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+type screen int
+
+const (
+	screenLoading screen = iota
+	screenTargets
+	screenIngresses
+	screenMatrix
+	screenOutput
+)
+
+// Messages returned by the background commands.
+type scanDoneMsg struct {
+	result *scanner.ScanResult
+	err    error
+}
+
+type actionDoneMsg struct {
+	title  string
+	output string
+	err    error
+}
+
+type model struct {
+	cfg Config
+
+	screen  screen
+	busy    string // non-empty while a scan/generate/apply runs
+	err     error
+	status  string
+	confirm bool // waiting for y/N before a real apply
+
+	scan   *scanner.ScanResult
+	target string
+	report *analyzer.AnalysisReport
+
+	targetCursor  int
+	ingressCursor int
+	scroll        int
+
+	outputTitle string
+	output      string
+
+	height int
+}
+
+// Run starts the interactive terminal UI and blocks until the user quits.
+func Run(cfg Config, target string) error {
+	m := &model{cfg: cfg, target: target, screen: screenLoading, busy: "Scanning cluster..."}
+	for i, t := range Targets {
+		if t == target {
+			m.targetCursor = i
+		}
+	}
+	_, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	return err
+}
+
+func (m *model) Init() tea.Cmd {
+	return m.scanCmd()
+}
+
+func (m *model) scanCmd() tea.Cmd {
+	cfg := m.cfg
+	return func() tea.Msg {
+		result, err := cfg.scan()
+		return scanDoneMsg{result: result, err: err}
+	}
+}
+
+func (m *model) generateCmd() tea.Cmd {
+	cfg, target, scan, report := m.cfg, m.target, m.scan, m.report
+	return func() tea.Msg {
+		out, err := cfg.generate(target, scan, report)
+		return actionDoneMsg{title: "Generate (" + target + ")", output: out, err: err}
+	}
+}
+
+func (m *model) applyCmd(dryRun bool) tea.Cmd {
+	cfg, target, scan, report := m.cfg, m.target, m.scan, m.report
+	title := "Apply (" + target + ")"
+	if dryRun {
+		title = "Dry-run apply (" + target + ")"
+	}
+	return func() tea.Msg {
+		out, err := cfg.apply(target, scan, report, dryRun)
+		return actionDoneMsg{title: title, output: out, err: err}
+	}
+}
+
+func (m *model) analyze() {
+	m.report = analyzer.NewAnalyzer(m.target).Analyze(m.scan)
+	if m.ingressCursor >= len(m.report.IngressReports) {
+		m.ingressCursor = 0
+	}
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+
+	case scanDoneMsg:
+		m.busy = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.scan = msg.result
+		m.status = fmt.Sprintf("Scanned %d resource(s)", len(m.scan.Ingresses))
+		if m.target == "" {
+			m.screen = screenTargets
+		} else {
+			m.analyze()
+			m.screen = screenIngresses
+		}
+		return m, nil
+
+	case actionDoneMsg:
+		m.busy = ""
+		m.outputTitle = msg.title
+		m.output = strings.TrimRight(msg.output, "\n")
+		if msg.err != nil {
+			m.output = strings.TrimSpace(m.output + "\n\nError: " + msg.err.Error())
+		}
+		m.scroll = 0
+		m.screen = screenOutput
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if m.busy != "" {
+		return m, nil
+	}
+	if m.err != nil {
+		switch key {
+		case "r":
+			m.err = nil
+			m.busy = "Scanning cluster..."
+			return m, m.scanCmd()
+		case "q", "esc":
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+	if m.confirm {
+		m.confirm = false
+		if key == "y" || key == "Y" {
+			m.busy = "Applying to cluster..."
+			return m, m.applyCmd(false)
+		}
+		m.status = "Apply cancelled"
+		return m, nil
+	}
+
+	switch m.screen {
+	case screenTargets:
+		switch key {
+		case "up", "k":
+			if m.targetCursor > 0 {
+				m.targetCursor--
+			}
+		case "down", "j":
+			if m.targetCursor < len(Targets)-1 {
+				m.targetCursor++
+			}
+		case "enter":
+			m.target = Targets[m.targetCursor]
+			m.analyze()
+			m.screen = screenIngresses
+		case "esc":
+			if m.target != "" {
+				m.screen = screenIngresses
+			}
+		case "q":
+			return m, tea.Quit
+		}
+
+	case screenIngresses:
+		switch key {
+		case "up", "k":
+			if m.ingressCursor > 0 {
+				m.ingressCursor--
+			}
+		case "down", "j":
+			if m.ingressCursor < len(m.report.IngressReports)-1 {
+				m.ingressCursor++
+			}
+		case "enter":
+			if len(m.report.IngressReports) > 0 {
+				m.scroll = 0
+				m.screen = screenMatrix
+			}
+		case "t":
+			m.screen = screenTargets
+		case "r":
+			m.busy = "Scanning cluster..."
+			return m, m.scanCmd()
+		case "g":
+			m.busy = "Generating migration files..."
+			return m, m.generateCmd()
+		case "d":
+			m.busy = "Running server-side dry-run..."
+			return m, m.applyCmd(true)
+		case "a":
+			m.confirm = true
+		case "q":
+			return m, tea.Quit
+		}
+
+	case screenMatrix, screenOutput:
+		switch key {
+		case "up", "k":
+			if m.scroll > 0 {
+				m.scroll--
+			}
+		case "down", "j":
+			m.scroll++
+		case "esc", "backspace":
+			m.screen = screenIngresses
+		case "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m *model) View() string {
+	var sb strings.Builder
+	sb.WriteString("\n  ing-switch")
+	if m.target != "" {
+		sb.WriteString(" — target: " + m.target)
+	}
+	sb.WriteString("\n\n")
+
+	switch {
+	case m.busy != "":
+		sb.WriteString("  " + m.busy + "\n")
+		return sb.String()
+	case m.err != nil:
+		sb.WriteString("  Error: " + m.err.Error() + "\n\n")
+		sb.WriteString("  r retry · q quit\n")
+		return sb.String()
+	}
+
+	switch m.screen {
+	case screenTargets:
+		m.viewTargets(&sb)
+	case screenIngresses:
+		m.viewIngresses(&sb)
+	case screenMatrix:
+		m.viewMatrix(&sb)
+	case screenOutput:
+		m.viewOutput(&sb)
+	}
+	return sb.String()
+}
+
+func (m *model) viewTargets(sb *strings.Builder) {
+	sb.WriteString("  Select a migration target:\n\n")
+	for i, t := range Targets {
+		sb.WriteString(cursor(i == m.targetCursor) + t + "\n")
+	}
+	sb.WriteString("\n  ↑/↓ move · enter select · q quit\n")
+}
+
+func (m *model) viewIngresses(sb *strings.Builder) {
+	s := m.report.Summary
+	fmt.Fprintf(sb, "  %d ingress(es): %d ready · %d workaround · %d breaking\n\n",
+		s.Total, s.FullyCompatible, s.NeedsWorkaround, s.HasUnsupported)
+	if len(m.report.IngressReports) == 0 {
+		sb.WriteString("  No ingress resources found.\n")
+	}
+	for i, ing := range m.report.IngressReports {
+		fmt.Fprintf(sb, "%s%-12s %s/%s  (%d annotation(s))\n",
+			cursor(i == m.ingressCursor), "["+ing.OverallStatus+"]", ing.Namespace, ing.Name, len(ing.Mappings))
+	}
+	sb.WriteString("\n")
+	if m.confirm {
+		fmt.Fprintf(sb, "  Apply %s manifests to the cluster? y/N\n", m.target)
+		return
+	}
+	if m.status != "" {
+		sb.WriteString("  " + m.status + "\n\n")
+	}
+	sb.WriteString("  ↑/↓ move · enter annotations · t target · g generate · d dry-run · a apply · r rescan · q quit\n")
+}
+
+func (m *model) viewMatrix(sb *strings.Builder) {
+	ing := m.report.IngressReports[m.ingressCursor]
+	fmt.Fprintf(sb, "  %s/%s — %s\n\n", ing.Namespace, ing.Name, ing.OverallStatus)
+	var lines []string
+	if len(ing.Mappings) == 0 {
+		lines = append(lines, "  No annotations — routing migrates as-is.")
+	}
+	for _, mp := range ing.Mappings {
+		lines = append(lines, fmt.Sprintf("  %-14s %s", "["+string(mp.Status)+"]", mp.OriginalKey))
+		if mp.TargetResource != "" {
+			lines = append(lines, "                   → "+mp.TargetResource)
+		}
+		if mp.Note != "" {
+			lines = append(lines, "                   "+mp.Note)
+		}
+	}
+	m.writePage(sb, lines)
+	sb.WriteString("\n  ↑/↓ scroll · esc back · q quit\n")
+}
+
+func (m *model) viewOutput(sb *strings.Builder) {
+	sb.WriteString("  " + m.outputTitle + "\n\n")
+	var lines []string
+	for _, l := range strings.Split(m.output, "\n") {
+		lines = append(lines, "  "+l)
+	}
+	m.writePage(sb, lines)
+	sb.WriteString("\n  ↑/↓ scroll · esc back · q quit\n")
+}
+
+// writePage renders the visible window of lines, clamping the scroll offset.
+func (m *model) writePage(sb *strings.Builder, lines []string) {
+	page := len(lines)
+	if m.height > 8 && page > m.height-8 {
+		page = m.height - 8
+	}
+	if m.scroll > len(lines)-page {
+		m.scroll = len(lines) - page
+	}
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+	for _, l := range lines[m.scroll : m.scroll+page] {
+		sb.WriteString(l + "\n")
+	}
+}
+
+func cursor(selected bool) string {
+	if selected {
+		return "  > "
+	}
+	return "    "
+}
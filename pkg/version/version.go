@@ -0,0 +1,31 @@
+// Package version holds build metadata injected at link time:
+//
+//	go build -ldflags "-X github.com/saiyam1814/ing-switch/pkg/version.Version=v1.2.3 \
+//	  -X github.com/saiyam1814/ing-switch/pkg/version.Commit=abc1234 \
+//	  -X github.com/saiyam1814/ing-switch/pkg/version.Date=2026-01-01T00:00:00Z"
+package version
+
+import "fmt"
+
+var (
+	Version = "dev"
+	Commit  = "none"
+	Date    = "unknown"
+)
+
+// Info is the build metadata in a JSON-friendly form.
+type Info struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// Get returns the current build metadata.
+func Get() Info {
+	return Info{Version: Version, Commit: Commit, Date: Date}
+}
+
+// String returns a one-line description, e.g. "v1.2.3 (commit abc1234, built 2026-01-01)".
+func String() string {
+	return fmt.Sprintf("%s (commit %s, built %s)", Version, Commit, Date)
+}
@@ -0,0 +1,383 @@
+// Package conformance validates every generator's output against the CRD
+// schemas of the controllers it targets, vendored under testdata/crds by
+// hack/update-crds.sh (Gateway API, Envoy Gateway, Traefik). Schema drift — a
+// renamed field, a list rendered as a scalar — fails the test. Built-in
+// Kubernetes kinds are decoded strictly into their API types instead.
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	"github.com/saiyam1814/ing-switch/pkg/analyzer"
+	"github.com/saiyam1814/ing-switch/pkg/generator"
+	"github.com/saiyam1814/ing-switch/pkg/migrator/gatewayapi"
+	"github.com/saiyam1814/ing-switch/pkg/migrator/traefik"
+	"github.com/saiyam1814/ing-switch/pkg/scanner"
+)
+
+var (
+	docSeparator = regexp.MustCompile(`(?m)^---[ \t]*\n`)
+	topLevelKind = regexp.MustCompile(`(?m)^kind: `)
+)
+
+// TestGeneratedManifests migrates the examples/ manifests for each target,
+// with NetworkPolicies, and validates every generated manifest.
+func TestGeneratedManifests(t *testing.T) {
+	if testing.Short() {
+		t.Skip("validating against the vendored CRD schemas is slow; skipped with -short")
+	}
+	schemas := loadCRDSchemas(t, "testdata/crds")
+
+	var manifests []byte
+	examples, err := filepath.Glob("../../examples/*.yaml")
+	if err != nil || len(examples) == 0 {
+		t.Fatalf("no examples: %v", err)
+	}
+	for _, path := range examples {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests = append(manifests, b...)
+	}
+
+	for _, target := range []string{"traefik", "gateway-api", "gateway-api-traefik"} {
+		t.Run(target, func(t *testing.T) {
+			scan, err := scanner.ScanManifests(bytes.NewReader(manifests), "", "")
+			if err != nil {
+				t.Fatalf("reading examples: %v", err)
+			}
+			files, err := migrate(target, scan)
+			if err != nil {
+				t.Fatalf("migrate: %v", err)
+			}
+			validated := 0
+			for _, f := range files {
+				if !strings.HasSuffix(f.RelPath, ".yaml") {
+					continue
+				}
+				for _, doc := range docSeparator.Split(f.Content, -1) {
+					// Only documents that declare a kind are manifests (values.yaml is Helm input)
+					if !topLevelKind.MatchString(doc) {
+						continue
+					}
+					validated++
+					for _, err := range validateManifest(schemas, doc) {
+						t.Errorf("%s: %v", f.RelPath, err)
+					}
+				}
+			}
+			if validated == 0 {
+				t.Error("no manifests generated")
+			}
+		})
+	}
+}
+
+// migrate runs target's migrator with its defaults and NetworkPolicies.
+func migrate(target string, scan *scanner.ScanResult) ([]generator.GeneratedFile, error) {
+	report := analyzer.NewAnalyzer(target).Analyze(scan)
+	switch target {
+	case "traefik":
+		m := traefik.NewMigrator()
+		m.SetEmitNetworkPolicy(true)
+		return m.Migrate(scan, report)
+	case "gateway-api":
+		m := gatewayapi.NewMigrator()
+		m.SetEmitNetworkPolicy(true)
+		return m.Migrate(scan, report)
+	case "gateway-api-traefik":
+		m := gatewayapi.NewTraefikGatewayMigrator()
+		m.SetEmitNetworkPolicy(true)
+		return m.Migrate(scan, report)
+	}
+	return nil, fmt.Errorf("unknown target %q", target)
+}
+
+// validateManifest checks one YAML document: custom resources against the
+// served version of their CRD, built-in kinds by strict decoding.
+func validateManifest(schemas map[schema.GroupVersionKind]map[string]any, doc string) []error {
+	var obj map[string]any
+	if err := unmarshal([]byte(doc), &obj); err != nil {
+		return []error{fmt.Errorf("invalid YAML: %w", err)}
+	}
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	name := fmt.Sprintf("%s %s", apiVersion, kind)
+	if meta, ok := obj["metadata"].(map[string]any); ok {
+		name = fmt.Sprintf("%s %v", kind, meta["name"])
+	}
+
+	if typed, err := scheme.Scheme.New(gvk); err == nil {
+		if err := yaml.UnmarshalStrict([]byte(doc), typed); err != nil {
+			return []error{fmt.Errorf("%s: %w", name, err)}
+		}
+		return nil
+	}
+	s, ok := schemas[gvk]
+	if !ok {
+		return []error{fmt.Errorf("%s: no CRD schema for %s — vendor its CRD with hack/update-crds.sh", name, gvk)}
+	}
+
+	var errs []error
+	if meta, ok := obj["metadata"]; ok {
+		b, _ := json.Marshal(meta)
+		if err := yaml.UnmarshalStrict(b, &metav1.ObjectMeta{}); err != nil {
+			errs = append(errs, fmt.Errorf("%s: metadata: %w", name, err))
+		}
+	}
+	obj["metadata"] = map[string]any{} // checked above: CRD schemas leave it open
+	for _, err := range validate("", obj, s, true) {
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+	}
+	return errs
+}
+
+// loadCRDSchemas indexes the openAPIV3Schema of every served version of the
+// CRDs in dir by group, version and kind.
+func loadCRDSchemas(t *testing.T, dir string) map[schema.GroupVersionKind]map[string]any {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*.y*ml"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no CRDs in %s: %v", dir, err)
+	}
+	schemas := map[schema.GroupVersionKind]map[string]any{}
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, doc := range docSeparator.Split(string(b), -1) {
+			var crd struct {
+				Kind string `json:"kind"`
+				Spec struct {
+					Group string `json:"group"`
+					Names struct {
+						Kind string `json:"kind"`
+					} `json:"names"`
+					Versions []struct {
+						Name   string `json:"name"`
+						Served bool   `json:"served"`
+						Schema struct {
+							OpenAPIV3Schema map[string]any `json:"openAPIV3Schema"`
+						} `json:"schema"`
+					} `json:"versions"`
+				} `json:"spec"`
+			}
+			if err := unmarshal([]byte(doc), &crd); err != nil {
+				t.Fatalf("%s: %v", path, err)
+			}
+			if crd.Kind != "CustomResourceDefinition" {
+				continue
+			}
+			for _, v := range crd.Spec.Versions {
+				if v.Served {
+					schemas[schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind}] = v.Schema.OpenAPIV3Schema
+				}
+			}
+		}
+	}
+	return schemas
+}
+
+// unmarshal decodes YAML keeping numbers as json.Number, so integers and
+// numbers can be told apart.
+func unmarshal(doc []byte, v any) error {
+	b, err := yaml.YAMLToJSON(doc)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// validate checks value against a structural OpenAPI v3 schema as the API
+// server does: types, enums, required and unknown fields, and the size,
+// range and pattern constraints. CEL rules (x-kubernetes-validations) are not
+// evaluated. fields is false inside allOf/anyOf/oneOf, which only constrain
+// values: the fields an object may have come from the enclosing schema.
+func validate(path string, value any, s map[string]any, fields bool) []error {
+	if value == nil {
+		if s["nullable"] == true {
+			return nil
+		}
+		return []error{fmt.Errorf("%s: null is not allowed", display(path))}
+	}
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: "+format, append([]any{display(path)}, args...)...))
+	}
+
+	if s["x-kubernetes-int-or-string"] == true {
+		if _, isString := value.(string); !isString && !isInteger(value) {
+			fail("must be an integer or a string, got %v", value)
+		}
+	} else if typ, ok := s["type"].(string); ok && !hasType(value, typ) {
+		fail("must be of type %s, got %T %v", typ, value, value)
+		return errs
+	}
+	if enum, ok := s["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return equal(e, value) }) {
+		fail("%v is not one of %v", value, enum)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, r := range list(s["required"]) {
+			if _, ok := v[r.(string)]; !ok {
+				fail("required field %s is missing", r)
+			}
+		}
+		props, _ := s["properties"].(map[string]any)
+		if n, ok := number(s["maxProperties"]); ok && float64(len(v)) > n {
+			fail("has %d fields, more than %v", len(v), n)
+		}
+		for k, fv := range v {
+			switch ps, known := props[k].(map[string]any); {
+			case known:
+				errs = append(errs, validate(path+"."+k, fv, ps, true)...)
+			case s["additionalProperties"] != nil && s["additionalProperties"] != false:
+				if as, ok := s["additionalProperties"].(map[string]any); ok {
+					errs = append(errs, validate(path+"."+k, fv, as, true)...)
+				}
+			case fields && s["x-kubernetes-preserve-unknown-fields"] != true:
+				fail("unknown field %q", k)
+			}
+		}
+	case []any:
+		if n, ok := number(s["maxItems"]); ok && float64(len(v)) > n {
+			fail("has %d items, more than %v", len(v), n)
+		}
+		if n, ok := number(s["minItems"]); ok && float64(len(v)) < n {
+			fail("has %d items, fewer than %v", len(v), n)
+		}
+		if is, ok := s["items"].(map[string]any); ok {
+			for i, item := range v {
+				errs = append(errs, validate(fmt.Sprintf("%s[%d]", path, i), item, is, true)...)
+			}
+		}
+	case string:
+		if n, ok := number(s["maxLength"]); ok && float64(len([]rune(v))) > n {
+			fail("%q is longer than %v", v, n)
+		}
+		if n, ok := number(s["minLength"]); ok && float64(len([]rune(v))) < n {
+			fail("%q is shorter than %v", v, n)
+		}
+		// Patterns are ECMA-262; the few Go cannot compile are not checked.
+		if p, ok := s["pattern"].(string); ok {
+			if re, err := regexp.Compile(p); err == nil && !re.MatchString(v) {
+				fail("%q does not match %s", v, p)
+			}
+		}
+	case json.Number:
+		f, _ := v.Float64()
+		if n, ok := number(s["minimum"]); ok && f < n {
+			fail("%v is less than %v", v, n)
+		}
+		if n, ok := number(s["maximum"]); ok && f > n {
+			fail("%v is greater than %v", v, n)
+		}
+	}
+
+	for _, sub := range list(s["allOf"]) {
+		errs = append(errs, validate(path, value, sub.(map[string]any), false)...)
+	}
+	if anyOf := list(s["anyOf"]); len(anyOf) > 0 && !slices.ContainsFunc(anyOf, func(sub any) bool {
+		return len(validate(path, value, sub.(map[string]any), false)) == 0
+	}) {
+		fail("matches none of the anyOf schemas")
+	}
+	if oneOf := list(s["oneOf"]); len(oneOf) > 0 {
+		matched := 0
+		for _, sub := range oneOf {
+			if len(validate(path, value, sub.(map[string]any), false)) == 0 {
+				matched++
+			}
+		}
+		if matched != 1 {
+			fail("matches %d of the oneOf schemas, want exactly one", matched)
+		}
+	}
+	if not, ok := s["not"].(map[string]any); ok && len(validate(path, value, not, false)) == 0 {
+		fail("matches a schema it must not")
+	}
+	return errs
+}
+
+func hasType(value any, typ string) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		return isInteger(value)
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	}
+	return true
+}
+
+func isInteger(value any) bool {
+	n, ok := value.(json.Number)
+	if !ok {
+		return false
+	}
+	_, err := n.Int64()
+	return err == nil
+}
+
+// equal compares a schema enum entry with a value, numbers by value.
+func equal(a, b any) bool {
+	na, aok := number(a)
+	nb, bok := number(b)
+	if aok && bok {
+		return na == nb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func number(v any) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func list(v any) []any {
+	l, _ := v.([]any)
+	return l
+}
+
+func display(path string) string {
+	if path == "" {
+		return "."
+	}
+	return strings.TrimPrefix(path, ".")
+}
@@ -0,0 +1,26 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Install Traefik as Gateway API controller
+set -e
+
+echo "Adding Traefik Helm repository..."
+helm repo add traefik https://traefik.github.io/charts
+helm repo update
+
+echo "Installing Traefik with Gateway API provider..."
+helm upgrade --install traefik traefik/traefik \
+  --namespace traefik \
+  --create-namespace \
+  --values values.yaml
+
+echo "Waiting for Traefik to be ready..."
+kubectl rollout status deployment/traefik -n traefik --timeout=120s
+
+echo ""
+echo "Traefik Gateway API controller installed!"
+echo ""
+echo "Verify GatewayClass is accepted:"
+echo "  kubectl get gatewayclass traefik"
+echo ""
+echo "Next: Apply the GatewayClass and Gateway resources"
+echo "  kubectl apply -f ../03-gateway/"
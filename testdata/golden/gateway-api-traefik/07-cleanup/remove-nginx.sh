@@ -0,0 +1,24 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Remove Ingress NGINX after Gateway API migration is complete
+set -e
+
+echo "=== Removing Ingress NGINX Controller ==="
+
+# Remove admission webhooks
+kubectl delete validatingwebhookconfiguration ingress-nginx-admission --ignore-not-found
+kubectl delete mutatingwebhookconfiguration ingress-nginx-admission --ignore-not-found
+
+# Uninstall NGINX
+if helm list -n ingress-nginx | grep -q ingress-nginx; then
+  helm uninstall ingress-nginx -n ingress-nginx
+fi
+
+# Remove namespace
+kubectl delete namespace ingress-nginx --ignore-not-found
+
+echo ""
+echo "NGINX removed. Your Gateway API routes are now the only ingress path."
+echo ""
+echo "Verify HTTPRoutes are all healthy:"
+kubectl get httproutes --all-namespaces
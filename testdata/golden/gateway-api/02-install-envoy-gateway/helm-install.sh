@@ -0,0 +1,24 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Install Envoy Gateway
+set -e
+
+echo "Adding Envoy Gateway Helm repository..."
+helm repo add eg https://charts.gateway.envoyproxy.io
+helm repo update
+
+echo "Installing Envoy Gateway..."
+helm upgrade --install eg eg/gateway-helm \
+  --namespace envoy-gateway-system \
+  --create-namespace \
+  --version v1.7.1 \
+  --values values.yaml
+
+echo "Waiting for Envoy Gateway to be ready..."
+kubectl rollout status deployment/envoy-gateway -n envoy-gateway-system --timeout=120s
+
+echo ""
+echo "Envoy Gateway installed successfully!"
+echo ""
+echo "Next: Apply the GatewayClass and Gateway resources"
+echo "  kubectl apply -f ../03-gateway/"
@@ -0,0 +1,213 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Verify Gateway API (Envoy Gateway) is handling your routes
+set -e
+
+echo "=== ing-switch Gateway API Verification ==="
+echo ""
+
+# Check gateway status
+echo "Gateway status:"
+kubectl get gateway ing-switch-gateway -n default
+echo ""
+
+# Check HTTPRoutes
+echo "HTTPRoute status:"
+kubectl get httproutes --all-namespaces
+echo ""
+
+echo "Testing routes..."
+echo ""
+
+echo "Testing ecommerce/ecommerce-shop → shop.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "shop.example.com:80:${GATEWAY_IP}:80" "http://shop.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing enterprise/enterprise-app → api.enterprise.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "api.enterprise.com:80:${GATEWAY_IP}:80" "http://api.enterprise.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing enterprise/enterprise-app → enterprise.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "enterprise.com:80:${GATEWAY_IP}:80" "http://enterprise.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing enterprise/enterprise-app-canary → enterprise.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "enterprise.com:80:${GATEWAY_IP}:80" "http://enterprise.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing fintech/secure-banking-app → api.banking.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "api.banking.example.com:80:${GATEWAY_IP}:80" "http://api.banking.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing fintech/secure-banking-app → banking.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "banking.example.com:80:${GATEWAY_IP}:80" "http://banking.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing fintech/secure-banking-app → www.banking.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "www.banking.example.com:80:${GATEWAY_IP}:80" "http://www.banking.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing messaging/realtime-chat → chat.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "chat.example.com:80:${GATEWAY_IP}:80" "http://chat.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing messaging/realtime-chat → notifications.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "notifications.example.com:80:${GATEWAY_IP}:80" "http://notifications.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing ops/ops-admin → ops.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "ops.example.com:80:${GATEWAY_IP}:80" "http://ops.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing ops/ops-metrics → ops.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "ops.example.com:80:${GATEWAY_IP}:80" "http://ops.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing platform/grpc-service → grpc.platform.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "grpc.platform.example.com:80:${GATEWAY_IP}:80" "http://grpc.platform.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing platform/grpc-service-secure → secure-grpc.platform.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "secure-grpc.platform.example.com:80:${GATEWAY_IP}:80" "http://secure-grpc.platform.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing platform/public-api → api.platform.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "api.platform.example.com:80:${GATEWAY_IP}:80" "http://api.platform.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing production/myapp-canary → myapp.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "myapp.example.com:80:${GATEWAY_IP}:80" "http://myapp.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing production/myapp-stable → myapp.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "myapp.example.com:80:${GATEWAY_IP}:80" "http://myapp.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing production/oauth2-proxy → oauth2.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "oauth2.example.com:80:${GATEWAY_IP}:80" "http://oauth2.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing production/protected-app → dashboard.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "dashboard.example.com:80:${GATEWAY_IP}:80" "http://dashboard.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing production/web-app → app.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "app.example.com:80:${GATEWAY_IP}:80" "http://app.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing security/payment-api → payments.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "payments.example.com:80:${GATEWAY_IP}:80" "http://payments.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing security/rate-limited-api → secure-api.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "secure-api.example.com:80:${GATEWAY_IP}:80" "http://secure-api.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing services/api-version-router → versioned-api.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "versioned-api.example.com:80:${GATEWAY_IP}:80" "http://versioned-api.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+echo "Testing services/microservices-gateway → gateway.example.com"
+GATEWAY_IP=$(kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}' 2>/dev/null || echo "")
+if [ -n "$GATEWAY_IP" ]; then
+  curl -s --connect-to "gateway.example.com:80:${GATEWAY_IP}:80" "http://gateway.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+else
+  echo "  Gateway IP not yet assigned"
+fi
+
+
+echo ""
+echo "If all tests pass, proceed to DNS migration:"
+echo "  Update DNS to point to Gateway's LoadBalancer IP"
+echo ""
+echo "Get Gateway LoadBalancer IP:"
+kubectl get gateway ing-switch-gateway -n default -o jsonpath='{.status.addresses[0].value}'
+echo ""
@@ -0,0 +1,28 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Install Traefik alongside existing NGINX Ingress Controller
+# Both will run in parallel — zero downtime migration
+set -e
+
+echo "Adding Traefik Helm repository..."
+helm repo add traefik https://traefik.github.io/charts
+helm repo update
+
+echo "Installing Traefik with Kubernetes Ingress NGINX provider..."
+helm upgrade --install traefik traefik/traefik \
+  --namespace traefik \
+  --create-namespace \
+  --values values.yaml \
+  --version ">=3.6.2"
+
+echo "Waiting for Traefik to be ready..."
+kubectl rollout status deployment/traefik -n traefik --timeout=120s
+
+echo ""
+echo "Traefik installed successfully!"
+echo ""
+echo "Get Traefik LoadBalancer IP:"
+kubectl get svc -n traefik traefik
+echo ""
+echo "Test that Traefik can serve your Ingress resources:"
+echo "  bash ../04-verify.sh"
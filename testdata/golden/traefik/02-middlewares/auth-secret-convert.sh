@@ -0,0 +1,83 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Convert ingress-nginx basic-auth secrets for the Traefik BasicAuth middlewares.
+# Traefik reads one htpasswd key from a secret in the middleware's namespace;
+# ingress-nginx secrets use the "auth" key (auth-file) or one key per user
+# (auth-map), possibly in another namespace. Entries hashed with bcrypt, apr1
+# or SHA1 are copied as-is; others are re-hashed with bcrypt (needs htpasswd
+# from apache2-utils / httpd-tools) after prompting for the password.
+# The ingress-nginx secrets are not modified.
+#
+# Usage: auth-secret-convert.sh [--rehash] [--output-dir DIR]
+#   --rehash          re-hash every entry with bcrypt, not only those Traefik cannot verify
+#   --output-dir DIR  write the Secret manifests to DIR instead of applying them
+#
+# Without a terminal to prompt on, secrets with entries to re-hash are skipped
+# and the htpasswd -nB commands to regenerate them are printed instead.
+set -euo pipefail
+
+REHASH=false
+OUTPUT_DIR=""
+while [ $# -gt 0 ]; do
+  case "$1" in
+    --rehash) REHASH=true ;;
+    --output-dir) OUTPUT_DIR=$2; shift ;;
+    *) echo "Unknown flag: $1" >&2; exit 1 ;;
+  esac
+  shift
+done
+[ -n "$OUTPUT_DIR" ] && mkdir -p "$OUTPUT_DIR"
+INTERACTIVE=false
+[ -t 0 ] && INTERACTIVE=true
+
+convert_secret() {
+  local src_ns=$1 src_name=$2 secret_type=$3 dst_ns=$4 dst_name=$5
+  local entries users skipped=false
+  entries=$(mktemp)
+  users=$(mktemp)
+
+  if [ "$secret_type" = "auth-map" ]; then
+    kubectl get secret -n "$src_ns" "$src_name" \
+      -o go-template='{{range $user, $hash := .data}}{{$user}}:{{$hash | base64decode}}{{"\n"}}{{end}}' > "$entries"
+  else
+    kubectl get secret -n "$src_ns" "$src_name" \
+      -o go-template='{{index .data "auth" | base64decode}}{{"\n"}}' > "$entries"
+  fi
+
+  while IFS=: read -r user hash; do
+    [ -z "$user" ] && continue
+    if ! $REHASH; then
+      case "$hash" in
+        '$2y$'*|'$2a$'*|'$2b$'*|'$apr1$'*|'{SHA}'*)
+          echo "$user:$hash" >> "$users"
+          continue
+          ;;
+      esac
+    fi
+    if ! $INTERACTIVE; then
+      echo "  $dst_ns/$dst_name: htpasswd -nB $user >> users" >&2
+      skipped=true
+      continue
+    fi
+    read -r -s -p "Password for $user ($src_ns/$src_name → bcrypt): " password < /dev/tty
+    echo
+    htpasswd -nbB "$user" "$password" | head -n 1 >> "$users"
+  done < "$entries"
+
+  if $skipped; then
+    echo "Skipped $dst_ns/$dst_name: regenerate the entries above, then run" >&2
+    echo "  kubectl create secret generic $dst_name -n $dst_ns --from-file=users" >&2
+  elif [ -n "$OUTPUT_DIR" ]; then
+    kubectl create secret generic "$dst_name" -n "$dst_ns" --from-file=users="$users" \
+      --dry-run=client -o yaml > "$OUTPUT_DIR/$dst_ns-$dst_name.yaml"
+    echo "Wrote $OUTPUT_DIR/$dst_ns-$dst_name.yaml from $src_ns/$src_name"
+  else
+    kubectl create secret generic "$dst_name" -n "$dst_ns" --from-file=users="$users" \
+      --dry-run=client -o yaml | kubectl apply -f -
+    echo "Created $dst_ns/$dst_name from $src_ns/$src_name"
+  fi
+  rm -f "$entries" "$users"
+}
+
+convert_secret ops ops-admin-htpasswd auth-file ops ops-admin-basicauth   # Ingress ops/ops-admin
+convert_secret monitoring metrics-users auth-map ops ops-metrics-basicauth   # Ingress ops/ops-metrics
@@ -0,0 +1,18 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Backend mTLS for fintech/secure-banking-app. Traefik reads the ServersTransport and the HTTPS
+# scheme from annotations on the backend Services, not the Ingress. Apply
+# fintech-secure-banking-app-serverstransport.yaml first, then run before cutover.
+set -e
+
+kubectl annotate service -n fintech banking-api --overwrite \
+  traefik.ingress.kubernetes.io/service.serversscheme=https \
+  traefik.ingress.kubernetes.io/service.serverstransport=fintech-secure-banking-app-backend-tls@kubernetescrd
+
+kubectl annotate service -n fintech banking-api-v2 --overwrite \
+  traefik.ingress.kubernetes.io/service.serversscheme=https \
+  traefik.ingress.kubernetes.io/service.serverstransport=fintech-secure-banking-app-backend-tls@kubernetescrd
+
+kubectl annotate service -n fintech banking-frontend --overwrite \
+  traefik.ingress.kubernetes.io/service.serversscheme=https \
+  traefik.ingress.kubernetes.io/service.serverstransport=fintech-secure-banking-app-backend-tls@kubernetescrd
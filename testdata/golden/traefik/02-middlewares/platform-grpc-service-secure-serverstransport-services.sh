@@ -0,0 +1,14 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Backend mTLS for platform/grpc-service-secure. Traefik reads the ServersTransport and the HTTPS
+# scheme from annotations on the backend Services, not the Ingress. Apply
+# platform-grpc-service-secure-serverstransport.yaml first, then run before cutover.
+set -e
+
+kubectl annotate service -n platform order-grpc-service --overwrite \
+  traefik.ingress.kubernetes.io/service.serversscheme=https \
+  traefik.ingress.kubernetes.io/service.serverstransport=platform-grpc-service-secure-backend-tls@kubernetescrd
+
+kubectl annotate service -n platform user-grpc-service --overwrite \
+  traefik.ingress.kubernetes.io/service.serversscheme=https \
+  traefik.ingress.kubernetes.io/service.serverstransport=platform-grpc-service-secure-backend-tls@kubernetescrd
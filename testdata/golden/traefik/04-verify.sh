@@ -0,0 +1,121 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Verify Traefik is handling your Ingress resources correctly
+# Run this BEFORE cutting over DNS to Traefik
+set -e
+
+echo "=== ing-switch Verification Script ==="
+echo ""
+
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}' 2>/dev/null || echo "")
+
+if [ -z "$TRAEFIK_IP" ]; then
+  echo "ERROR: Traefik LoadBalancer IP not assigned yet. Wait for it:"
+  kubectl get svc -n traefik traefik
+  exit 1
+fi
+
+echo "Traefik LoadBalancer: $TRAEFIK_IP"
+echo ""
+echo "Testing Ingress resources via Traefik..."
+echo ""
+
+echo "Testing ecommerce/ecommerce-shop → shop.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "shop.example.com:80:${TRAEFIK_IP}:80" "http://shop.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing enterprise/enterprise-app → api.enterprise.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "api.enterprise.com:80:${TRAEFIK_IP}:80" "http://api.enterprise.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing enterprise/enterprise-app → enterprise.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "enterprise.com:80:${TRAEFIK_IP}:80" "http://enterprise.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing enterprise/enterprise-app-canary → enterprise.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "enterprise.com:80:${TRAEFIK_IP}:80" "http://enterprise.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing fintech/secure-banking-app → api.banking.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "api.banking.example.com:80:${TRAEFIK_IP}:80" "http://api.banking.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing fintech/secure-banking-app → banking.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "banking.example.com:80:${TRAEFIK_IP}:80" "http://banking.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing fintech/secure-banking-app → www.banking.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "www.banking.example.com:80:${TRAEFIK_IP}:80" "http://www.banking.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing messaging/realtime-chat → chat.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "chat.example.com:80:${TRAEFIK_IP}:80" "http://chat.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing messaging/realtime-chat → notifications.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "notifications.example.com:80:${TRAEFIK_IP}:80" "http://notifications.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing ops/ops-admin → ops.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "ops.example.com:80:${TRAEFIK_IP}:80" "http://ops.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing ops/ops-metrics → ops.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "ops.example.com:80:${TRAEFIK_IP}:80" "http://ops.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing platform/grpc-service → grpc.platform.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "grpc.platform.example.com:80:${TRAEFIK_IP}:80" "http://grpc.platform.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing platform/grpc-service-secure → secure-grpc.platform.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "secure-grpc.platform.example.com:80:${TRAEFIK_IP}:80" "http://secure-grpc.platform.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing platform/public-api → api.platform.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "api.platform.example.com:80:${TRAEFIK_IP}:80" "http://api.platform.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing production/myapp-canary → myapp.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "myapp.example.com:80:${TRAEFIK_IP}:80" "http://myapp.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing production/myapp-stable → myapp.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "myapp.example.com:80:${TRAEFIK_IP}:80" "http://myapp.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing production/oauth2-proxy → oauth2.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "oauth2.example.com:80:${TRAEFIK_IP}:80" "http://oauth2.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing production/protected-app → dashboard.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "dashboard.example.com:80:${TRAEFIK_IP}:80" "http://dashboard.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing production/web-app → app.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "app.example.com:80:${TRAEFIK_IP}:80" "http://app.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing security/payment-api → payments.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "payments.example.com:80:${TRAEFIK_IP}:80" "http://payments.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing security/rate-limited-api → secure-api.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "secure-api.example.com:80:${TRAEFIK_IP}:80" "http://secure-api.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing services/api-version-router → versioned-api.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "versioned-api.example.com:80:${TRAEFIK_IP}:80" "http://versioned-api.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+echo "Testing services/microservices-gateway → gateway.example.com"
+TRAEFIK_IP=$(kubectl get svc -n traefik traefik -o go-template='{{ $ing := index .status.loadBalancer.ingress 0 }}{{ if $ing.ip }}{{ $ing.ip }}{{ else }}{{ $ing.hostname }}{{ end }}')
+curl -s --connect-to "gateway.example.com:80:${TRAEFIK_IP}:80" "http://gateway.example.com" -o /dev/null -w "HTTP %{http_code}\n" || true
+
+
+echo ""
+echo "=== Traefik Dashboard ==="
+echo "kubectl port-forward -n traefik svc/traefik 9000:9000"
+echo "Open http://localhost:9000/dashboard/"
+echo ""
+echo "If all tests pass, proceed to 05-dns-migration.md"
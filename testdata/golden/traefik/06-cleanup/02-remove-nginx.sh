@@ -0,0 +1,47 @@
+#!/bin/bash
+# Generated by ing-switch dev (commit none)
+# Remove Ingress NGINX Controller after migration is complete.
+#
+# PREREQUISITES:
+# 1. All traffic is routing through Traefik
+# 2. NGINX has been removed from DNS for 24-48 hours
+# 3. You have applied 01-preserve-ingressclass.yaml
+#
+set -e
+
+echo "=== Removing Ingress NGINX Controller ==="
+echo ""
+
+# Step 1: Preserve IngressClass
+echo "Step 1: Preserving nginx IngressClass..."
+kubectl apply -f 01-preserve-ingressclass.yaml
+kubectl annotate ingressclass nginx helm.sh/resource-policy=keep --overwrite
+
+# Step 2: Remove admission webhooks
+echo "Step 2: Removing NGINX admission webhooks..."
+kubectl delete validatingwebhookconfiguration ingress-nginx-admission --ignore-not-found
+kubectl delete mutatingwebhookconfiguration ingress-nginx-admission --ignore-not-found
+
+# Step 3: Uninstall NGINX Helm release
+echo "Step 3: Uninstalling NGINX Helm release..."
+if helm list -n ingress-nginx | grep -q ingress-nginx; then
+  helm uninstall ingress-nginx -n ingress-nginx
+else
+  echo "  NGINX Helm release not found — may have been installed differently"
+  echo "  Manual cleanup may be required"
+fi
+
+# Step 4: Verify IngressClass still exists
+echo ""
+echo "Step 4: Verifying IngressClass still exists..."
+kubectl get ingressclass nginx
+
+# Step 5: Clean up namespace
+echo "Step 5: Cleaning up ingress-nginx namespace..."
+kubectl delete namespace ingress-nginx --ignore-not-found
+
+echo ""
+echo "=== Migration Complete ==="
+echo ""
+echo "Verify Traefik is still serving your Ingresses:"
+kubectl get ingress --all-namespaces
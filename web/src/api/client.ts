@@ -2,6 +2,7 @@ import type {
   ScanResult,
   AnalysisReport,
   MigrateResponse,
+  PasteAnalysisResponse,
   ValidationResult,
   ApplyRequest,
   ApplyResponse,
@@ -10,11 +11,23 @@ import type {
 
 const BASE = import.meta.env.DEV ? 'http://localhost:8080' : '';
 
+// ApiError carries the HTTP status, which tells scan failures apart:
+// 400 no usable kubeconfig, 403 access denied, 404 nothing to migrate,
+// 503 cluster unreachable.
+export class ApiError extends Error {
+  readonly status: number;
+
+  constructor(message: string, status: number) {
+    super(message);
+    this.status = status;
+  }
+}
+
 async function get<T>(path: string): Promise<T> {
   const res = await fetch(`${BASE}${path}`);
   if (!res.ok) {
     const err = await res.json().catch(() => ({ error: res.statusText }));
-    throw new Error(err.error || `HTTP ${res.status}`);
+    throw new ApiError(err.error || `HTTP ${res.status}`, res.status);
   }
   return res.json();
 }
@@ -27,7 +40,7 @@ async function post<T>(path: string, body: unknown): Promise<T> {
   });
   if (!res.ok) {
     const err = await res.json().catch(() => ({ error: res.statusText }));
-    throw new Error(err.error || `HTTP ${res.status}`);
+    throw new ApiError(err.error || `HTTP ${res.status}`, res.status);
   }
   return res.json();
 }
@@ -46,8 +59,23 @@ export const api = {
     return get<AnalysisReport>(`/api/analyze?${params}`);
   },
 
-  migrate: (target: Target, outputDir?: string, namespace?: string): Promise<MigrateResponse> => {
-    return post<MigrateResponse>('/api/migrate', { target, outputDir, namespace });
+  analyzePaste: async (target: Target, yaml: string, namespace?: string): Promise<PasteAnalysisResponse> => {
+    const params = new URLSearchParams({ target });
+    if (namespace) params.set('namespace', namespace);
+    const res = await fetch(`${BASE}/api/analyze/paste?${params}`, {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/yaml' },
+      body: yaml,
+    });
+    if (!res.ok) {
+      const err = await res.json().catch(() => ({ error: res.statusText }));
+      throw new ApiError(err.error || `HTTP ${res.status}`, res.status);
+    }
+    return res.json();
+  },
+
+  migrate: (target: Target, outputDir?: string, namespace?: string, includeSupported?: boolean): Promise<MigrateResponse> => {
+    return post<MigrateResponse>('/api/migrate', { target, outputDir, namespace, includeSupported });
   },
 
   apply: (req: ApplyRequest): Promise<ApplyResponse> => {
@@ -60,7 +88,7 @@ export const api = {
     return get<ValidationResult>(`/api/validate?${params}`);
   },
 
-  downloadUrl: (target: Target): string => {
-    return `${BASE}/api/download?target=${target}`;
+  downloadUrl: (target: Target, includeSupported?: boolean): string => {
+    return `${BASE}/api/download?target=${target}` + (includeSupported ? '&includeSupported=true' : '');
   },
 };
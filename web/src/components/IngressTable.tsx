@@ -28,6 +28,7 @@ const IngressTable: FC<Props> = ({ ingresses, onSelect, selected }) => {
             <th className="px-4 py-3 text-left text-xs font-semibold text-slate-400 uppercase tracking-wider">Name</th>
             <th className="px-4 py-3 text-left text-xs font-semibold text-slate-400 uppercase tracking-wider">Hosts</th>
             <th className="px-4 py-3 text-left text-xs font-semibold text-slate-400 uppercase tracking-wider">Class</th>
+            <th className="px-4 py-3 text-left text-xs font-semibold text-slate-400 uppercase tracking-wider">Address</th>
             <th className="px-4 py-3 text-center text-xs font-semibold text-slate-400 uppercase tracking-wider">TLS</th>
             <th className="px-4 py-3 text-center text-xs font-semibold text-slate-400 uppercase tracking-wider">Annotations</th>
             <th className="px-4 py-3 text-left text-xs font-semibold text-slate-400 uppercase tracking-wider">Complexity</th>
@@ -70,10 +71,26 @@ const IngressTable: FC<Props> = ({ ingresses, onSelect, selected }) => {
                 <td className="px-4 py-3">
                   <span className="text-xs font-mono text-slate-400">{ing.ingressClass || '—'}</span>
                 </td>
+                <td className="px-4 py-3">
+                  {ing.addresses?.length ? (
+                    <div className="text-xs font-mono text-slate-300">
+                      {ing.addresses[0]}
+                      {ing.addresses.length > 1 && <span className="text-slate-500"> +{ing.addresses.length - 1}</span>}
+                      {ing.addressController && <div className="text-slate-500">{ing.addressController}</div>}
+                    </div>
+                  ) : (
+                    <span className="text-xs text-slate-500">—</span>
+                  )}
+                </td>
                 <td className="px-4 py-3 text-center">
-                  {ing.tlsEnabled
-                    ? <span className="text-emerald-400 text-sm">🔒</span>
-                    : <span className="text-slate-600 text-sm">—</span>
+                  {ing.tlsSecretIssues?.length
+                    ? <span
+                        className="text-amber-400 text-sm"
+                        title={ing.tlsSecretIssues.map(i => `${i.name}: ${i.type ? `type ${i.type}` : 'missing'}`).join(', ')}
+                      >⚠</span>
+                    : ing.tlsEnabled
+                      ? <span className="text-emerald-400 text-sm">🔒</span>
+                      : <span className="text-slate-600 text-sm">—</span>
                   }
                 </td>
                 <td className="px-4 py-3 text-center">
@@ -1,5 +1,5 @@
 import { useState } from 'react';
-import { api } from '../api/client';
+import { api, ApiError } from '../api/client';
 import type { ScanResult } from '../types';
 import IngressTable from '../components/IngressTable';
 
@@ -8,19 +8,34 @@ interface Props {
   scanResult: ScanResult | null;
 }
 
+// scanErrorHint is the guidance for a failed scan, by the API status.
+const scanErrorHint = (status: number | null): string => {
+  switch (status) {
+    case 403:
+      return 'The current credentials may not list Ingresses. Grant list on ingresses (networking.k8s.io) or switch context.';
+    case 503:
+      return 'The API server did not answer. Check the cluster is running and reachable from where ing-switch runs.';
+    default:
+      return 'Make sure ing-switch has cluster access via --kubeconfig flag or KUBECONFIG env var.';
+  }
+};
+
 const Detect = ({ onScanComplete, scanResult }: Props) => {
   const [loading, setLoading] = useState(false);
   const [error, setError] = useState<string | null>(null);
+  const [errorStatus, setErrorStatus] = useState<number | null>(null);
   const [namespace, setNamespace] = useState('');
 
   const handleScan = async () => {
     setLoading(true);
     setError(null);
+    setErrorStatus(null);
     try {
       const result = await api.scan(namespace || undefined);
       onScanComplete(result);
     } catch (e) {
       setError(e instanceof Error ? e.message : 'Scan failed');
+      setErrorStatus(e instanceof ApiError ? e.status : null);
     } finally {
       setLoading(false);
     }
@@ -84,7 +99,7 @@ const Detect = ({ onScanComplete, scanResult }: Props) => {
           <div className="mt-4 p-3 rounded-lg bg-red-500/10 border border-red-500/30 text-sm text-red-300">
             <div className="font-medium">Error: {error}</div>
             <div className="mt-1 text-xs text-red-400/70">
-              Make sure ing-switch has cluster access via --kubeconfig flag or KUBECONFIG env var.
+              {scanErrorHint(errorStatus)}
             </div>
           </div>
         )}
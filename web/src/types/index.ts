@@ -20,6 +20,11 @@ export interface ServiceRef {
   port: number;
 }
 
+export interface SecretIssue {
+  name: string;
+  type?: string; // absent when the secret does not exist
+}
+
 export interface IngressInfo {
   namespace: string;
   name: string;
@@ -31,10 +36,14 @@ export interface IngressInfo {
   tlsSecrets: string[];
   annotations: Record<string, string>;
   nginxAnnotations: Record<string, string>;
+  traefikAnnotations?: Record<string, string>;
   middlewares?: string[];
   plugins?: string[];
   services: ServiceRef[];
   complexity: 'simple' | 'complex' | 'unsupported';
+  addresses?: string[];
+  addressController?: string;
+  tlsSecretIssues?: SecretIssue[];
 }
 
 export interface ScanResult {
@@ -84,7 +93,7 @@ export interface GeneratedFile {
 export interface AnnotationIssue {
   key: string;              // e.g. "proxy-body-size"
   value: string;            // value set on the ingress
-  status: 'partial' | 'unsupported';
+  status: 'supported' | 'partial' | 'unsupported';
   targetResource: string;   // e.g. "Middleware (RateLimit)"
   note: string;             // one-line description
   what: string;             // what the annotation does
@@ -102,6 +111,7 @@ export interface IngressMigrationSummary {
   name: string;
   overallStatus: 'ready' | 'workaround' | 'breaking';
   issues: AnnotationIssue[];
+  supported?: AnnotationIssue[];  // cleanly mapped annotations, with includeSupported
 }
 
 export interface MigrateResponse {
@@ -111,6 +121,12 @@ export interface MigrateResponse {
   perIngress: IngressMigrationSummary[];
 }
 
+/** Analysis of pasted Ingress YAML returned by /api/analyze/paste */
+export interface PasteAnalysisResponse {
+  report: AnalysisReport;
+  perIngress: IngressMigrationSummary[];
+}
+
 export interface ValidationCheck {
   name: string;
   status: 'pass' | 'warn' | 'fail';